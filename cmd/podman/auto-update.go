@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/containers/common/pkg/auth"
 	"github.com/containers/common/pkg/completion"
@@ -38,7 +39,8 @@ var (
 		RunE:              autoUpdate,
 		ValidArgsFunction: completion.AutocompleteNone,
 		Example: `podman auto-update
-  podman auto-update --authfile ~/authfile.json`,
+  podman auto-update --authfile ~/authfile.json
+  podman auto-update --rollout=canary --soak-time=1m`,
 	}
 )
 
@@ -56,6 +58,14 @@ func init() {
 	flags.BoolVar(&autoUpdateOptions.DryRun, "dry-run", false, "Check for pending updates")
 	flags.BoolVar(&autoUpdateOptions.Rollback, "rollback", true, "Rollback to previous image if update fails")
 
+	rolloutFlagName := "rollout"
+	flags.StringVar(&autoUpdateOptions.Rollout, rolloutFlagName, entities.AutoUpdateRolloutAll, `Rollout mode ("all"|"canary")`)
+	_ = autoUpdateCommand.RegisterFlagCompletionFunc(rolloutFlagName, common.AutocompleteAutoUpdateRollout)
+
+	soakTimeFlagName := "soak-time"
+	flags.DurationVar(&autoUpdateOptions.SoakTime, soakTimeFlagName, 30*time.Second, "Time to wait for updated containers to become healthy in the canary rollout mode")
+	_ = autoUpdateCommand.RegisterFlagCompletionFunc(soakTimeFlagName, completion.AutocompleteNone)
+
 	flags.StringVar(&autoUpdateOptions.format, "format", "", "Change the output format to JSON or a Go template")
 	_ = autoUpdateCommand.RegisterFlagCompletionFunc("format", common.AutocompleteFormat(&autoUpdateOutput{}))
 
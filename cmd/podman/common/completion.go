@@ -583,6 +583,58 @@ func AutocompleteVolumes(cmd *cobra.Command, args []string, toComplete string) (
 	return getVolumes(cmd, toComplete)
 }
 
+// AutocompleteVolumeSnapshots - Autocomplete volume snapshots.
+// -> volumes for the first argument, snapshots of the volume for the others
+func AutocompleteVolumeSnapshots(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if !validCurrentCmdLine(cmd, args, toComplete) {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	if len(args) == 0 {
+		return getVolumes(cmd, toComplete)
+	}
+	suggestions := []string{}
+	engine, err := setupContainerEngine(cmd)
+	if err != nil {
+		cobra.CompErrorln(err.Error())
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	snapshots, err := engine.VolumeSnapshotList(registry.GetContext(), args[:1])
+	if err != nil {
+		cobra.CompErrorln(err.Error())
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	for _, s := range snapshots {
+		if strings.HasPrefix(s.Name, toComplete) {
+			suggestions = append(suggestions, s.Name)
+		}
+	}
+	return suggestions, cobra.ShellCompDirectiveNoFileComp
+}
+
+// AutocompleteQuadlets - Autocomplete installed quadlet files.
+func AutocompleteQuadlets(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if !validCurrentCmdLine(cmd, args, toComplete) {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	suggestions := []string{}
+	engine, err := setupContainerEngine(cmd)
+	if err != nil {
+		cobra.CompErrorln(err.Error())
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	quadlets, err := engine.QuadletList(registry.GetContext(), entities.QuadletListOptions{})
+	if err != nil {
+		cobra.CompErrorln(err.Error())
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	for _, q := range quadlets {
+		if strings.HasPrefix(q.Name, toComplete) {
+			suggestions = append(suggestions, q.Name)
+		}
+	}
+	return suggestions, cobra.ShellCompDirectiveNoFileComp
+}
+
 // AutocompleteSecrets - Autocomplete secrets.
 func AutocompleteSecrets(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 	if !validCurrentCmdLine(cmd, args, toComplete) {
@@ -598,6 +650,24 @@ func AutocompleteSecretCreate(cmd *cobra.Command, args []string, toComplete stri
 	return nil, cobra.ShellCompDirectiveNoFileComp
 }
 
+// AutocompleteSecretUpdate - Autocomplete secret update command.
+// -> secrets for the first argument, files for the second
+func AutocompleteSecretUpdate(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	switch len(args) {
+	case 0:
+		return getSecrets(cmd, toComplete, completeDefault)
+	case 1:
+		return nil, cobra.ShellCompDirectiveDefault
+	}
+	return nil, cobra.ShellCompDirectiveNoFileComp
+}
+
+// AutocompleteSecretPropagate - Autocomplete secret update --propagate options.
+// -> "none", "reinject", "restart"
+func AutocompleteSecretPropagate(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	return []string{entities.SecretPropagateNone, entities.SecretPropagateReinject, entities.SecretPropagateRestart}, cobra.ShellCompDirectiveNoFileComp
+}
+
 // AutocompleteImages - Autocomplete images.
 func AutocompleteImages(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 	if !validCurrentCmdLine(cmd, args, toComplete) {
@@ -992,7 +1062,7 @@ func AutocompleteLogDriver(cmd *cobra.Command, args []string, toComplete string)
 // AutocompleteLogOpt - Autocomplete log-opt options.
 // -> "path=", "tag="
 func AutocompleteLogOpt(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
-	logOptions := []string{"path=", "tag=", "max-size="}
+	logOptions := []string{"path=", "tag=", "max-size=", "max-file=", "compress="}
 	if strings.HasPrefix(toComplete, "path=") {
 		return nil, cobra.ShellCompDirectiveDefault
 	}
@@ -1006,6 +1076,13 @@ func AutocompletePullOption(cmd *cobra.Command, args []string, toComplete string
 	return pullOptions, cobra.ShellCompDirectiveNoFileComp
 }
 
+// AutocompleteAutoUpdateRollout - Autocomplete rollout modes for the auto-update command.
+// -> "all", "canary"
+func AutocompleteAutoUpdateRollout(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	rollouts := []string{entities.AutoUpdateRolloutAll, entities.AutoUpdateRolloutCanary}
+	return rollouts, cobra.ShellCompDirectiveNoFileComp
+}
+
 // AutocompleteRestartOption - Autocomplete restart options for create and run command.
 // -> "always", "no", "on-failure", "unless-stopped"
 func AutocompleteRestartOption(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
@@ -1411,7 +1488,7 @@ func getMethodNames(f reflect.Value, prefix string) []formatSuggestion {
 }
 
 // AutocompleteEventFilter - Autocomplete event filter flag options.
-// -> "container=", "event=", "image=", "pod=", "volume=", "type="
+// -> "container=", "event=", "health_status=", "image=", "pod=", "secret=", "volume=", "type="
 func AutocompleteEventFilter(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 	event := func(_ string) ([]string, cobra.ShellCompDirective) {
 		return []string{events.Attach.String(), events.AutoUpdate.String(), events.Checkpoint.String(), events.Cleanup.String(),
@@ -1422,22 +1499,27 @@ func AutocompleteEventFilter(cmd *cobra.Command, args []string, toComplete strin
 			events.Push.String(), events.Refresh.String(), events.Remove.String(), events.Rename.String(),
 			events.Renumber.String(), events.Restart.String(), events.Restore.String(), events.Save.String(),
 			events.Start.String(), events.Stop.String(), events.Sync.String(), events.Tag.String(), events.Unmount.String(),
-			events.Unpause.String(), events.Untag.String(),
+			events.Unpause.String(), events.Untag.String(), events.Update.String(),
 		}, cobra.ShellCompDirectiveNoFileComp
 	}
 	eventTypes := func(_ string) ([]string, cobra.ShellCompDirective) {
 		return []string{events.Container.String(), events.Image.String(), events.Network.String(),
-			events.Pod.String(), events.System.String(), events.Volume.String(),
+			events.Pod.String(), events.Secret.String(), events.System.String(), events.Volume.String(),
 		}, cobra.ShellCompDirectiveNoFileComp
 	}
 	kv := keyValueCompletion{
 		"container=": func(s string) ([]string, cobra.ShellCompDirective) { return getContainers(cmd, s, completeDefault) },
-		"image=":     func(s string) ([]string, cobra.ShellCompDirective) { return getImages(cmd, s) },
-		"pod=":       func(s string) ([]string, cobra.ShellCompDirective) { return getPods(cmd, s, completeDefault) },
-		"volume=":    func(s string) ([]string, cobra.ShellCompDirective) { return getVolumes(cmd, s) },
-		"event=":     event,
-		"label=":     nil,
-		"type=":      eventTypes,
+		"health_status=": func(_ string) ([]string, cobra.ShellCompDirective) {
+			return []string{define.HealthCheckHealthy, define.HealthCheckStarting,
+				define.HealthCheckUnhealthy}, cobra.ShellCompDirectiveNoFileComp
+		},
+		"image=":  func(s string) ([]string, cobra.ShellCompDirective) { return getImages(cmd, s) },
+		"pod=":    func(s string) ([]string, cobra.ShellCompDirective) { return getPods(cmd, s, completeDefault) },
+		"secret=": func(s string) ([]string, cobra.ShellCompDirective) { return getSecrets(cmd, s, completeDefault) },
+		"volume=": func(s string) ([]string, cobra.ShellCompDirective) { return getVolumes(cmd, s) },
+		"event=":  event,
+		"label=":  nil,
+		"type=":   eventTypes,
 	}
 	return completeKeyValues(toComplete, kv)
 }
@@ -1731,6 +1813,13 @@ func AutocompleteCheckpointCompressType(cmd *cobra.Command, args []string, toCom
 	return types, cobra.ShellCompDirectiveNoFileComp
 }
 
+// AutocompleteDatabaseBackend - Autocomplete database backend options.
+// -> "boltdb", "sqlite"
+func AutocompleteDatabaseBackend(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	backends := []string{"boltdb", "sqlite"}
+	return backends, cobra.ShellCompDirectiveNoFileComp
+}
+
 // AutocompleteCompressionFormat - Autocomplete compression-format type options.
 func AutocompleteCompressionFormat(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 	types := []string{"gzip", "zstd", "zstd:chunked"}
@@ -178,6 +178,46 @@ func DefineCreateFlags(cmd *cobra.Command, cf *entities.ContainerCreateOptions,
 		)
 		_ = cmd.RegisterFlagCompletionFunc(healthCmdFlagName, completion.AutocompleteNone)
 
+		healthGRPCFlagName := "health-grpc"
+		createFlags.StringVar(
+			&cf.HealthGRPC,
+			healthGRPCFlagName, "",
+			"set a gRPC healthcheck probing `[HOST:]PORT[/SERVICE]` from the host",
+		)
+		_ = cmd.RegisterFlagCompletionFunc(healthGRPCFlagName, completion.AutocompleteNone)
+
+		healthHTTPGetFlagName := "health-http-get"
+		createFlags.StringVar(
+			&cf.HealthHTTPGet,
+			healthHTTPGetFlagName, "",
+			"set an HTTP GET healthcheck probing `URL` from the host",
+		)
+		_ = cmd.RegisterFlagCompletionFunc(healthHTTPGetFlagName, completion.AutocompleteNone)
+
+		healthHTTPHeaderFlagName := "health-http-header"
+		createFlags.StringArrayVar(
+			&cf.HealthHTTPHeader,
+			healthHTTPHeaderFlagName, []string{},
+			"add a `NAME: VALUE` header to the HTTP GET healthcheck request",
+		)
+		_ = cmd.RegisterFlagCompletionFunc(healthHTTPHeaderFlagName, completion.AutocompleteNone)
+
+		healthHTTPStatusFlagName := "health-http-status"
+		createFlags.StringVar(
+			&cf.HealthHTTPStatus,
+			healthHTTPStatusFlagName, "",
+			"HTTP status `CODES` considered healthy by the HTTP GET healthcheck (default "+define.DefaultHealthProbeHTTPStatus+")",
+		)
+		_ = cmd.RegisterFlagCompletionFunc(healthHTTPStatusFlagName, completion.AutocompleteNone)
+
+		healthTCPFlagName := "health-tcp"
+		createFlags.StringVar(
+			&cf.HealthTCP,
+			healthTCPFlagName, "",
+			"set a TCP healthcheck connecting to `[HOST:]PORT` from the host",
+		)
+		_ = cmd.RegisterFlagCompletionFunc(healthTCPFlagName, completion.AutocompleteNone)
+
 		healthIntervalFlagName := "health-interval"
 		createFlags.StringVar(
 			&cf.HealthInterval,
@@ -218,6 +258,22 @@ func DefineCreateFlags(cmd *cobra.Command, cf *entities.ContainerCreateOptions,
 		)
 		_ = cmd.RegisterFlagCompletionFunc(healthOnFailureFlagName, AutocompleteHealthOnFailure)
 
+		hookPostStartFlagName := "hook-poststart"
+		createFlags.StringVar(
+			&cf.HookPostStart,
+			hookPostStartFlagName, "",
+			"command or http(s) URL to run after the container started",
+		)
+		_ = cmd.RegisterFlagCompletionFunc(hookPostStartFlagName, completion.AutocompleteNone)
+
+		hookPreStopFlagName := "hook-prestop"
+		createFlags.StringVar(
+			&cf.HookPreStop,
+			hookPreStopFlagName, "",
+			"command or http(s) URL to run before the container is stopped",
+		)
+		_ = cmd.RegisterFlagCompletionFunc(hookPreStopFlagName, completion.AutocompleteNone)
+
 		createFlags.BoolVar(
 			&cf.HTTPProxy,
 			"http-proxy", podmanConfig.ContainersConfDefaultsRO.Containers.HTTPProxy,
@@ -392,10 +448,34 @@ func DefineCreateFlags(cmd *cobra.Command, cf *entities.ContainerCreateOptions,
 		createFlags.StringSliceVar(
 			&cf.Requires,
 			requiresFlagName, []string{},
-			"Add one or more requirement containers that must be started before this container will start",
+			"Add one or more requirement containers that must be started before this container will start, optionally with a condition (started, healthy, completed-successfully) as `CONTAINER[:CONDITION]`",
 		)
 		_ = cmd.RegisterFlagCompletionFunc(requiresFlagName, AutocompleteContainers)
 
+		requiresTimeoutFlagName := "requires-timeout"
+		createFlags.UintVar(
+			&cf.RequiresTimeout,
+			requiresTimeoutFlagName, define.DefaultDependencyTimeout,
+			"Seconds to wait for the requirement containers to meet their conditions",
+		)
+		_ = cmd.RegisterFlagCompletionFunc(requiresTimeoutFlagName, completion.AutocompleteNone)
+
+		restartDelayFlagName := "restart-delay"
+		createFlags.StringVar(
+			&cf.RestartDelay,
+			restartDelayFlagName, define.DefaultRestartDelay.String(),
+			"Delay before restarting a container which keeps crashing, doubled with every restart (0 restarts immediately)",
+		)
+		_ = cmd.RegisterFlagCompletionFunc(restartDelayFlagName, completion.AutocompleteNone)
+
+		restartMaxDelayFlagName := "restart-max-delay"
+		createFlags.StringVar(
+			&cf.RestartMaxDelay,
+			restartMaxDelayFlagName, define.DefaultRestartMaxDelay.String(),
+			"Maximum delay between restarts of a container which keeps crashing",
+		)
+		_ = cmd.RegisterFlagCompletionFunc(restartMaxDelayFlagName, completion.AutocompleteNone)
+
 		createFlags.BoolVar(
 			&cf.Rm,
 			"rm", false,
@@ -407,6 +487,14 @@ func DefineCreateFlags(cmd *cobra.Command, cf *entities.ContainerCreateOptions,
 			"The first argument is not an image but the rootfs to the exploded container",
 		)
 
+		scheduleFlagName := "schedule"
+		createFlags.StringVar(
+			&cf.Schedule,
+			scheduleFlagName, "",
+			"Start the container on a schedule given as systemd calendar expression",
+		)
+		_ = cmd.RegisterFlagCompletionFunc(scheduleFlagName, completion.AutocompleteNone)
+
 		sdnotifyFlagName := "sdnotify"
 		createFlags.StringVar(
 			&cf.SdNotifyMode,
@@ -386,6 +386,9 @@ func (l psReporter) Status() string {
 	if hc != "" {
 		state += " (" + hc + ")"
 	}
+	if l.CrashLoop {
+		state += " (crashloop)"
+	}
 	return state
 }
 
@@ -0,0 +1,36 @@
+package containers
+
+import (
+	"github.com/containers/podman/v4/cmd/podman/common"
+	"github.com/containers/podman/v4/cmd/podman/registry"
+	"github.com/spf13/cobra"
+)
+
+var (
+	rotateLogDescription = `
+   podman container rotate-log
+
+   Rotates the log file of a container if it reached its maximum size. This command is used internally by the systemd timer rotating the log.
+`
+	rotateLogCmd = &cobra.Command{
+		Annotations:       map[string]string{registry.EngineMode: registry.ABIMode},
+		Use:               "rotate-log CONTAINER",
+		Short:             "Rotate the log file of a container",
+		Long:              rotateLogDescription,
+		RunE:              rotateLog,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: common.AutocompleteContainers,
+		Hidden:            true,
+	}
+)
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: rotateLogCmd,
+		Parent:  containerCmd,
+	})
+}
+
+func rotateLog(cmd *cobra.Command, args []string) error {
+	return registry.ContainerEngine().ContainerRotateLog(registry.GetContext(), args[0])
+}
@@ -0,0 +1,24 @@
+package containers
+
+import (
+	"github.com/containers/podman/v4/cmd/podman/registry"
+	"github.com/containers/podman/v4/cmd/podman/validate"
+	"github.com/spf13/cobra"
+)
+
+var (
+	// Command: podman container _schedule_
+	scheduleCmd = &cobra.Command{
+		Use:   "schedule",
+		Short: "Manage scheduled containers",
+		Long:  "Manage containers started on a schedule by systemd timers",
+		RunE:  validate.SubCommandExists,
+	}
+)
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: scheduleCmd,
+		Parent:  containerCmd,
+	})
+}
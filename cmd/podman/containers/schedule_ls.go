@@ -0,0 +1,133 @@
+package containers
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/containers/common/pkg/completion"
+	"github.com/containers/common/pkg/report"
+	"github.com/containers/podman/v4/cmd/podman/common"
+	"github.com/containers/podman/v4/cmd/podman/registry"
+	"github.com/containers/podman/v4/cmd/podman/validate"
+	"github.com/containers/storage/pkg/stringid"
+	"github.com/docker/go-units"
+	"github.com/spf13/cobra"
+)
+
+var (
+	scheduleLsCmd = &cobra.Command{
+		Use:               "ls [options]",
+		Aliases:           []string{"list"},
+		Short:             "List scheduled containers",
+		Long:              "List containers started on a schedule along with their last and next runs",
+		RunE:              scheduleLs,
+		Example:           "podman container schedule ls",
+		Args:              validate.NoArgs,
+		ValidArgsFunction: completion.AutocompleteNone,
+	}
+	scheduleLsOptions = scheduleLsFlagType{}
+)
+
+type scheduleLsFlagType struct {
+	format    string
+	noHeading bool
+	noTrunc   bool
+	quiet     bool
+}
+
+// scheduleLsReport is a scheduled container as displayed by schedule ls
+type scheduleLsReport struct {
+	ID       string
+	Name     string
+	Schedule string
+	State    string
+	LastRun  string
+	LastExit string
+	NextRun  string
+}
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: scheduleLsCmd,
+		Parent:  scheduleCmd,
+	})
+
+	flags := scheduleLsCmd.Flags()
+
+	formatFlagName := "format"
+	flags.StringVar(&scheduleLsOptions.format, formatFlagName, "{{range .}}{{.ID}}\t{{.Name}}\t{{.Schedule}}\t{{.State}}\t{{.LastRun}}\t{{.LastExit}}\t{{.NextRun}}\n{{end -}}", "Format scheduled container output using Go template")
+	_ = scheduleLsCmd.RegisterFlagCompletionFunc(formatFlagName, common.AutocompleteFormat(&scheduleLsReport{}))
+
+	flags.BoolVarP(&scheduleLsOptions.noHeading, "noheading", "n", false, "Do not print headers")
+	flags.BoolVar(&scheduleLsOptions.noTrunc, "no-trunc", false, "Do not truncate the output")
+	flags.BoolVarP(&scheduleLsOptions.quiet, "quiet", "q", false, "Print container IDs only")
+}
+
+func scheduleLs(cmd *cobra.Command, args []string) error {
+	responses, err := registry.ContainerEngine().ContainerScheduleList(registry.GetContext())
+	if err != nil {
+		return err
+	}
+
+	listed := make([]*scheduleLsReport, 0, len(responses))
+	for _, response := range responses {
+		r := &scheduleLsReport{
+			ID:       response.ID,
+			Name:     response.Name,
+			Schedule: response.Schedule,
+			State:    response.State,
+			LastRun:  "never",
+			LastExit: "-",
+			NextRun:  "-",
+		}
+		if !scheduleLsOptions.noTrunc {
+			r.ID = stringid.TruncateID(r.ID)
+		}
+		if !response.LastRun.IsZero() {
+			r.LastRun = units.HumanDuration(time.Since(response.LastRun)) + " ago"
+		}
+		if response.LastExitCode != nil {
+			r.LastExit = strconv.Itoa(int(*response.LastExitCode))
+		}
+		if !response.NextRun.IsZero() {
+			r.NextRun = "in " + units.HumanDuration(time.Until(response.NextRun))
+		}
+		listed = append(listed, r)
+	}
+
+	if scheduleLsOptions.quiet && !cmd.Flags().Changed("format") {
+		for _, r := range listed {
+			fmt.Println(r.ID)
+		}
+		return nil
+	}
+
+	headers := report.Headers(scheduleLsReport{}, map[string]string{
+		"ID":       "CONTAINER ID",
+		"LastRun":  "LAST RUN",
+		"LastExit": "LAST EXIT",
+		"NextRun":  "NEXT RUN",
+	})
+
+	rpt := report.New(os.Stdout, cmd.Name())
+	defer rpt.Flush()
+
+	switch {
+	case cmd.Flag("format").Changed:
+		rpt, err = rpt.Parse(report.OriginUser, scheduleLsOptions.format)
+	default:
+		rpt, err = rpt.Parse(report.OriginPodman, scheduleLsOptions.format)
+	}
+	if err != nil {
+		return err
+	}
+
+	if rpt.RenderHeaders && !scheduleLsOptions.noHeading {
+		if err := rpt.Execute(headers); err != nil {
+			return fmt.Errorf("failed to write report column headers: %w", err)
+		}
+	}
+	return rpt.Execute(listed)
+}
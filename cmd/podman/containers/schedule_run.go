@@ -0,0 +1,36 @@
+package containers
+
+import (
+	"github.com/containers/podman/v4/cmd/podman/common"
+	"github.com/containers/podman/v4/cmd/podman/registry"
+	"github.com/spf13/cobra"
+)
+
+var (
+	scheduleRunDescription = `
+   podman container schedule run
+
+   Starts a scheduled container and records the run. This command is used internally by the systemd timer of the schedule.
+`
+	scheduleRunCmd = &cobra.Command{
+		Annotations:       map[string]string{registry.EngineMode: registry.ABIMode},
+		Use:               "run CONTAINER",
+		Short:             "Start a scheduled container",
+		Long:              scheduleRunDescription,
+		RunE:              scheduleRun,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: common.AutocompleteContainers,
+		Hidden:            true,
+	}
+)
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: scheduleRunCmd,
+		Parent:  scheduleCmd,
+	})
+}
+
+func scheduleRun(cmd *cobra.Command, args []string) error {
+	return registry.ContainerEngine().ContainerScheduleRun(registry.GetContext(), args[0])
+}
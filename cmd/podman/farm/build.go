@@ -8,9 +8,12 @@ import (
 
 	"github.com/containers/common/pkg/completion"
 	"github.com/containers/common/pkg/config"
+	"github.com/containers/common/pkg/report"
+	"github.com/containers/image/v5/docker/reference"
 	"github.com/containers/podman/v4/cmd/podman/common"
 	"github.com/containers/podman/v4/cmd/podman/registry"
 	"github.com/containers/podman/v4/cmd/podman/utils"
+	"github.com/containers/podman/v4/pkg/domain/entities"
 	"github.com/containers/podman/v4/pkg/farm"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
@@ -21,6 +24,10 @@ type buildOptions struct {
 	local        bool
 	platforms    []string
 	farm         string
+	nodeWeights  map[string]int
+	excludeNodes []string
+	dryRun       bool
+	reuseCache   bool
 }
 
 var (
@@ -68,6 +75,17 @@ func init() {
 	buildCommand.PersistentFlags().StringSliceVar(&buildOpts.platforms, platformsFlag, nil, "Build only on farm nodes that match the given platforms")
 	_ = buildCommand.RegisterFlagCompletionFunc(platformsFlag, completion.AutocompletePlatform)
 
+	nodeWeightFlag := "node-weight"
+	flags.StringToIntVar(&buildOpts.nodeWeights, nodeWeightFlag, nil, "Weight of a farm node when scheduling builds, as `NODE=WEIGHT`")
+	_ = buildCommand.RegisterFlagCompletionFunc(nodeWeightFlag, completion.AutocompleteNone)
+
+	excludeNodeFlag := "exclude-node"
+	flags.StringSliceVar(&buildOpts.excludeNodes, excludeNodeFlag, nil, "Do not build on the given farm nodes")
+	_ = buildCommand.RegisterFlagCompletionFunc(excludeNodeFlag, common.AutocompleteSystemConnections)
+
+	flags.BoolVar(&buildOpts.dryRun, "dry-run", false, "Print the schedule of the builds without building")
+	flags.BoolVar(&buildOpts.reuseCache, "reuse-cache", false, "Reuse the build cache of previous builds, stored in the REPOSITORY-cache repository")
+
 	common.DefineBuildFlags(buildCommand, &buildOpts.buildOptions, true)
 }
 
@@ -136,6 +154,10 @@ func build(cmd *cobra.Command, args []string) error {
 		defaultFarm = f
 	}
 
+	scheduleOptions := farm.ScheduleOptions{
+		Weights: buildOpts.nodeWeights,
+		Exclude: buildOpts.excludeNodes,
+	}
 	localEngine := registry.ImageEngine()
 	ctx := registry.Context()
 	farm, err := farm.NewFarm(ctx, defaultFarm, localEngine, buildOpts.local)
@@ -143,11 +165,20 @@ func build(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("initializing: %w", err)
 	}
 
-	schedule, err := farm.Schedule(ctx, buildOpts.platforms)
+	schedule, err := farm.Schedule(ctx, buildOpts.platforms, scheduleOptions)
 	if err != nil {
 		return fmt.Errorf("scheduling builds: %w", err)
 	}
 	logrus.Infof("schedule: %v", schedule)
+	if buildOpts.dryRun {
+		return printSchedule(cmd, schedule)
+	}
+
+	if buildOpts.reuseCache {
+		if err := setCacheRepository(cmd, tag[0], opts); err != nil {
+			return err
+		}
+	}
 
 	manifestName := opts.Output
 	// Set Output to "" so that the images built on the farm nodes have no name
@@ -159,3 +190,65 @@ func build(cmd *cobra.Command, args []string) error {
 
 	return nil
 }
+
+// printSchedule prints where each platform would be built.
+func printSchedule(cmd *cobra.Command, schedule farm.Schedule) error {
+	type scheduleRow struct {
+		Platform  string
+		Builder   string
+		Native    bool
+		Fallbacks string
+	}
+	entries := schedule.Entries()
+	rows := make([]scheduleRow, 0, len(entries))
+	for _, entry := range entries {
+		rows = append(rows, scheduleRow{
+			Platform:  entry.Platform,
+			Builder:   entry.Builder,
+			Native:    entry.Native,
+			Fallbacks: strings.Join(entry.Fallbacks, ","),
+		})
+	}
+
+	rpt := report.New(os.Stdout, cmd.Name())
+	defer rpt.Flush()
+	rpt, err := rpt.Parse(report.OriginPodman,
+		"{{range .}}{{.Platform}}\t{{.Builder}}\t{{.Native}}\t{{.Fallbacks}}\n{{end -}}")
+	if err != nil {
+		return err
+	}
+	if rpt.RenderHeaders {
+		err = rpt.Execute([]map[string]string{{
+			"Platform":  "Platform",
+			"Builder":   "Builder",
+			"Native":    "Native",
+			"Fallbacks": "Fallbacks",
+		}})
+		if err != nil {
+			return err
+		}
+	}
+	return rpt.Execute(rows)
+}
+
+// setCacheRepository makes the builds on all nodes pull the build cache from,
+// and push it to, the REPOSITORY-cache repository next to the repository of
+// the manifest list, unless --cache-from or --cache-to were given.
+func setCacheRepository(cmd *cobra.Command, tag string, opts *entities.BuildOptions) error {
+	named, err := reference.ParseNormalizedNamed(tag)
+	if err != nil {
+		return fmt.Errorf("parsing %q: %w", tag, err)
+	}
+	cacheRepo, err := reference.ParseNormalizedNamed(named.Name() + "-cache")
+	if err != nil {
+		return fmt.Errorf("deriving cache repository from %q: %w", tag, err)
+	}
+	if !cmd.Flags().Changed("cache-from") {
+		opts.CacheFrom = []reference.Named{cacheRepo}
+	}
+	if !cmd.Flags().Changed("cache-to") {
+		opts.CacheTo = []reference.Named{cacheRepo}
+	}
+	logrus.Debugf("Using build cache repository %s", cacheRepo)
+	return nil
+}
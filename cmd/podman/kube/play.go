@@ -162,6 +162,9 @@ func playFlags(cmd *cobra.Command) {
 	publishAllPortsFlagName := "publish-all"
 	flags.BoolVar(&playOptions.PublishAllPorts, publishAllPortsFlagName, false, "Whether to publish all ports defined in the K8S YAML file (containerPort, hostPort), if false only hostPort will be published")
 
+	readinessGateFlagName := "readiness-gate"
+	flags.BoolVar(&playOptions.ReadinessGate, readinessGateFlagName, false, "Withhold the ports of containers with a readinessProbe and wait until they are ready")
+
 	waitFlagName := "wait"
 	flags.BoolVarP(&playOptions.Wait, waitFlagName, "w", false, "Clean up all objects created when a SIGTERM is received or pods exit")
 
@@ -536,8 +539,28 @@ func printPlayReport(report *entities.PlayKubeReport) error {
 		fmt.Println()
 	}
 
+	jobsFailed := 0
+	for _, job := range report.Jobs {
+		fmt.Println("Job:")
+		fmt.Println(job.Name)
+		// Jobs are only run to completion with --wait.
+		if playOptions.Wait {
+			fmt.Printf("Succeeded: %d\n", job.Succeeded)
+			fmt.Printf("Failed: %d\n", job.Failed)
+		}
+		if job.Reason != "" {
+			jobsFailed++
+			fmt.Fprintf(os.Stderr, "job %s failed: %s\n", job.Name, job.Reason)
+		}
+		// Empty line for space for next block
+		fmt.Println()
+	}
+
 	if ctrsFailed > 0 {
 		return fmt.Errorf("failed to start %d containers", ctrsFailed)
 	}
+	if jobsFailed > 0 {
+		return fmt.Errorf("%d jobs failed", jobsFailed)
+	}
 	return nil
 }
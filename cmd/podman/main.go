@@ -18,6 +18,7 @@ import (
 	_ "github.com/containers/podman/v4/cmd/podman/manifest"
 	_ "github.com/containers/podman/v4/cmd/podman/networks"
 	_ "github.com/containers/podman/v4/cmd/podman/pods"
+	_ "github.com/containers/podman/v4/cmd/podman/quadlet"
 	"github.com/containers/podman/v4/cmd/podman/registry"
 	_ "github.com/containers/podman/v4/cmd/podman/secrets"
 	_ "github.com/containers/podman/v4/cmd/podman/system"
@@ -1,10 +1,14 @@
 package network
 
 import (
+	"errors"
 	"fmt"
+	"net"
 
+	"github.com/containers/common/libnetwork/types"
 	"github.com/containers/common/pkg/completion"
 	"github.com/containers/podman/v4/cmd/podman/common"
+	"github.com/containers/podman/v4/cmd/podman/parse"
 	"github.com/containers/podman/v4/cmd/podman/registry"
 	"github.com/containers/podman/v4/pkg/domain/entities"
 	"github.com/spf13/cobra"
@@ -19,12 +23,22 @@ var (
 		RunE:              networkUpdate,
 		Args:              cobra.ExactArgs(1),
 		ValidArgsFunction: common.AutocompleteNetworks,
-		Example:           `podman network update podman1`,
+		Example: `podman network update podman1
+  podman network update --label-add env=prod --route-add 10.10.0.0/16,10.89.0.2 podman1
+  podman network update --subnet-add 10.90.0.0/24 --internal podman1`,
 	}
 )
 
 var (
 	networkUpdateOptions entities.NetworkUpdateOptions
+	updateLabels         []string
+	updateOpts           []string
+	updateRoutes         []string
+	updateSubnets        []string
+	updateGateways       []net.IP
+	updateRanges         []string
+	updateInternal       bool
+	updateDisableDNS     bool
 )
 
 func networkUpdateFlags(cmd *cobra.Command) {
@@ -36,6 +50,40 @@ func networkUpdateFlags(cmd *cobra.Command) {
 	flags.StringSliceVar(&networkUpdateOptions.RemoveDNSServers, removeDNSServerFlagName, nil, "remove network level nameservers")
 	_ = cmd.RegisterFlagCompletionFunc(addDNSServerFlagName, completion.AutocompleteNone)
 	_ = cmd.RegisterFlagCompletionFunc(removeDNSServerFlagName, completion.AutocompleteNone)
+
+	addLabelFlagName := "label-add"
+	flags.StringArrayVar(&updateLabels, addLabelFlagName, nil, "add or replace network labels")
+	_ = cmd.RegisterFlagCompletionFunc(addLabelFlagName, completion.AutocompleteNone)
+	removeLabelFlagName := "label-rm"
+	flags.StringArrayVar(&networkUpdateOptions.RemoveLabels, removeLabelFlagName, nil, "remove network labels")
+	_ = cmd.RegisterFlagCompletionFunc(removeLabelFlagName, completion.AutocompleteNone)
+
+	addOptFlagName := "opt-add"
+	flags.StringArrayVar(&updateOpts, addOptFlagName, nil, "add or replace driver specific options")
+	_ = cmd.RegisterFlagCompletionFunc(addOptFlagName, completion.AutocompleteNone)
+	removeOptFlagName := "opt-rm"
+	flags.StringArrayVar(&networkUpdateOptions.RemoveOptions, removeOptFlagName, nil, "remove driver specific options")
+	_ = cmd.RegisterFlagCompletionFunc(removeOptFlagName, completion.AutocompleteNone)
+
+	addRouteFlagName := "route-add"
+	flags.StringArrayVar(&updateRoutes, addRouteFlagName, nil, "add or replace static routes")
+	_ = cmd.RegisterFlagCompletionFunc(addRouteFlagName, completion.AutocompleteNone)
+	removeRouteFlagName := "route-rm"
+	flags.StringArrayVar(&networkUpdateOptions.RemoveRoutes, removeRouteFlagName, nil, "remove static routes by destination")
+	_ = cmd.RegisterFlagCompletionFunc(removeRouteFlagName, completion.AutocompleteNone)
+
+	addSubnetFlagName := "subnet-add"
+	flags.StringArrayVar(&updateSubnets, addSubnetFlagName, nil, "add subnets in CIDR format, or update existing ones")
+	_ = cmd.RegisterFlagCompletionFunc(addSubnetFlagName, completion.AutocompleteNone)
+	gatewayFlagName := "gateway"
+	flags.IPSliceVar(&updateGateways, gatewayFlagName, nil, "IPv4 or IPv6 gateway for the added subnet")
+	_ = cmd.RegisterFlagCompletionFunc(gatewayFlagName, completion.AutocompleteNone)
+	ipRangeFlagName := "ip-range"
+	flags.StringArrayVar(&updateRanges, ipRangeFlagName, nil, "allocate container IP from range of the added subnet")
+	_ = cmd.RegisterFlagCompletionFunc(ipRangeFlagName, completion.AutocompleteNone)
+
+	flags.BoolVar(&updateInternal, "internal", false, "restrict external access from this network")
+	flags.BoolVar(&updateDisableDNS, "disable-dns", false, "disable dns plugin")
 }
 func init() {
 	registry.Commands = append(registry.Commands, registry.CliCommand{
@@ -48,7 +96,59 @@ func init() {
 func networkUpdate(cmd *cobra.Command, args []string) error {
 	name := args[0]
 
-	err := registry.ContainerEngine().NetworkUpdate(registry.Context(), name, networkUpdateOptions)
+	var err error
+	networkUpdateOptions.AddLabels, err = parse.GetAllLabels([]string{}, updateLabels)
+	if err != nil {
+		return fmt.Errorf("failed to parse labels: %w", err)
+	}
+	networkUpdateOptions.AddOptions, err = parse.GetAllLabels([]string{}, updateOpts)
+	if err != nil {
+		return fmt.Errorf("unable to parse options: %w", err)
+	}
+
+	for _, r := range updateRoutes {
+		route, err := parseRoute(r)
+		if err != nil {
+			return err
+		}
+		networkUpdateOptions.AddRoutes = append(networkUpdateOptions.AddRoutes, *route)
+	}
+
+	if len(updateGateways) > len(updateSubnets) {
+		return errors.New("cannot set more gateways than subnets")
+	}
+	if len(updateRanges) > len(updateSubnets) {
+		return errors.New("cannot set more ranges than subnets")
+	}
+	for i := range updateSubnets {
+		subnet, err := types.ParseCIDR(updateSubnets[i])
+		if err != nil {
+			return err
+		}
+		s := types.Subnet{
+			Subnet: subnet,
+		}
+		if len(updateRanges) > i {
+			leaseRange, err := parseRange(updateRanges[i])
+			if err != nil {
+				return err
+			}
+			s.LeaseRange = leaseRange
+		}
+		if len(updateGateways) > i {
+			s.Gateway = updateGateways[i]
+		}
+		networkUpdateOptions.AddSubnets = append(networkUpdateOptions.AddSubnets, s)
+	}
+
+	if cmd.Flags().Changed("internal") {
+		networkUpdateOptions.Internal = &updateInternal
+	}
+	if cmd.Flags().Changed("disable-dns") {
+		networkUpdateOptions.DisableDNS = &updateDisableDNS
+	}
+
+	err = registry.ContainerEngine().NetworkUpdate(registry.Context(), name, networkUpdateOptions)
 	if err != nil {
 		return err
 	}
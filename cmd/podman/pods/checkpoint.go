@@ -0,0 +1,139 @@
+package pods
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/containers/common/pkg/completion"
+	"github.com/containers/podman/v4/cmd/podman/common"
+	"github.com/containers/podman/v4/cmd/podman/registry"
+	"github.com/containers/podman/v4/cmd/podman/utils"
+	"github.com/containers/podman/v4/cmd/podman/validate"
+	"github.com/containers/podman/v4/pkg/domain/entities"
+	"github.com/containers/podman/v4/pkg/rootless"
+	"github.com/containers/storage/pkg/archive"
+	"github.com/spf13/cobra"
+)
+
+var (
+	podCheckpointDescription = `The pod name or ID can be used.
+
+  All running containers of each specified pod are frozen and then checkpointed, so their state is consistent.`
+	checkpointCommand = &cobra.Command{
+		Use:   "checkpoint [options] POD [POD...]",
+		Short: "Checkpoint one or more pods",
+		Long:  podCheckpointDescription,
+		RunE:  checkpoint,
+		Args: func(cmd *cobra.Command, args []string) error {
+			return validate.CheckAllLatestAndIDFile(cmd, args, false, "")
+		},
+		ValidArgsFunction: common.AutocompletePodsRunning,
+		Example: `podman pod checkpoint podID
+  podman pod checkpoint --export /tmp/pod.tar.zst podID
+  podman pod checkpoint --create-image pod-checkpoint --leave-running podID
+  podman pod checkpoint --all`,
+	}
+)
+
+var checkpointOptions entities.PodCheckpointOptions
+
+type podCheckpointStatistics struct {
+	PodmanDuration int64                           `json:"podman_checkpoint_duration"`
+	PodStatistics  []*entities.PodCheckpointReport `json:"pod_statistics"`
+}
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: checkpointCommand,
+		Parent:  podCmd,
+	})
+	flags := checkpointCommand.Flags()
+	flags.BoolVarP(&checkpointOptions.Keep, "keep", "k", false, "Keep all temporary checkpoint files")
+	flags.BoolVarP(&checkpointOptions.LeaveRunning, "leave-running", "R", false, "Leave the containers of the pod running after writing the checkpoint to disk")
+	flags.BoolVar(&checkpointOptions.TCPEstablished, "tcp-established", false, "Checkpoint containers with established TCP connections")
+	flags.BoolVar(&checkpointOptions.FileLocks, "file-locks", false, "Checkpoint containers with file locks")
+	flags.BoolVarP(&checkpointOptions.All, "all", "a", false, "Checkpoint all running pods")
+
+	exportFlagName := "export"
+	flags.StringVarP(&checkpointOptions.Export, exportFlagName, "e", "", "Export the checkpoint of the pod to a single archive")
+	_ = checkpointCommand.RegisterFlagCompletionFunc(exportFlagName, completion.AutocompleteDefault)
+
+	flags.BoolVar(&checkpointOptions.IgnoreRootFS, "ignore-rootfs", false, "Do not include root file-system changes when exporting")
+	flags.BoolVar(&checkpointOptions.IgnoreVolumes, "ignore-volumes", false, "Do not export volumes associated with the containers of the pod")
+
+	createImageFlagName := "create-image"
+	flags.StringVarP(&checkpointOptions.CreateImage, createImageFlagName, "", "", "Create checkpoint image of the pod with specified name")
+	_ = checkpointCommand.RegisterFlagCompletionFunc(createImageFlagName, completion.AutocompleteNone)
+
+	flags.StringP("compress", "c", "zstd", "Select compression algorithm (gzip, none, zstd) for checkpoint archive.")
+	_ = checkpointCommand.RegisterFlagCompletionFunc("compress", common.AutocompleteCheckpointCompressType)
+
+	flags.BoolVar(&checkpointOptions.PrintStats, "print-stats", false, "Display checkpoint statistics")
+
+	validate.AddLatestFlag(checkpointCommand, &checkpointOptions.Latest)
+}
+
+func checkpoint(cmd *cobra.Command, args []string) error {
+	var errs utils.OutputErrors
+	podmanStart := time.Now()
+	if cmd.Flags().Changed("compress") {
+		if checkpointOptions.Export == "" {
+			return errors.New("--compress can only be used with --export")
+		}
+		compress, _ := cmd.Flags().GetString("compress")
+		switch strings.ToLower(compress) {
+		case "none":
+			checkpointOptions.Compression = archive.Uncompressed
+		case "gzip":
+			checkpointOptions.Compression = archive.Gzip
+		case "zstd":
+			checkpointOptions.Compression = archive.Zstd
+		default:
+			return fmt.Errorf("selected compression algorithm (%q) not supported. Please select one from: gzip, none, zstd", compress)
+		}
+	} else {
+		checkpointOptions.Compression = archive.Zstd
+	}
+	if rootless.IsRootless() {
+		return errors.New("checkpointing a pod requires root")
+	}
+	export := checkpointOptions.Export != "" || checkpointOptions.CreateImage != ""
+	if !export && checkpointOptions.IgnoreRootFS {
+		return errors.New("--ignore-rootfs can only be used with --export or --create-image")
+	}
+	if !export && checkpointOptions.IgnoreVolumes {
+		return errors.New("--ignore-volumes can only be used with --export or --create-image")
+	}
+	responses, err := registry.ContainerEngine().PodCheckpoint(context.Background(), args, checkpointOptions)
+	if err != nil {
+		return err
+	}
+	podmanFinished := time.Now()
+
+	var statistics podCheckpointStatistics
+
+	for _, r := range responses {
+		switch {
+		case r.Err != nil:
+			errs = append(errs, r.Err)
+		case checkpointOptions.PrintStats:
+			statistics.PodStatistics = append(statistics.PodStatistics, r)
+		default:
+			fmt.Println(r.Id)
+		}
+	}
+
+	if checkpointOptions.PrintStats {
+		statistics.PodmanDuration = podmanFinished.Sub(podmanStart).Microseconds()
+		j, err := json.MarshalIndent(statistics, "", "    ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(j))
+	}
+
+	return errs.PrintErrors()
+}
@@ -0,0 +1,137 @@
+package pods
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/containers/common/pkg/completion"
+	"github.com/containers/podman/v4/cmd/podman/common"
+	"github.com/containers/podman/v4/cmd/podman/registry"
+	"github.com/containers/podman/v4/cmd/podman/utils"
+	"github.com/containers/podman/v4/cmd/podman/validate"
+	"github.com/containers/podman/v4/pkg/domain/entities"
+	"github.com/containers/podman/v4/pkg/rootless"
+	"github.com/spf13/cobra"
+)
+
+var (
+	podRestoreDescription = `The pod name or ID can be used.
+
+  Restores the checkpointed containers of each specified pod, or creates a pod from a pod checkpoint archive or image and restores all of its containers into it.`
+	restoreCommand = &cobra.Command{
+		Use:   "restore [options] POD|IMAGE [POD|IMAGE...]",
+		Short: "Restore one or more pods from a checkpoint",
+		Long:  podRestoreDescription,
+		RunE:  restore,
+		Args: func(cmd *cobra.Command, args []string) error {
+			return validate.CheckAllLatestAndIDFile(cmd, args, true, "")
+		},
+		ValidArgsFunction: common.AutocompletePods,
+		Example: `podman pod restore podID
+  podman pod restore --import /tmp/pod.tar.zst --name newpod
+  podman pod restore pod-checkpoint
+  podman pod restore --all`,
+	}
+)
+
+var restoreOptions entities.PodRestoreOptions
+
+type podRestoreStatistics struct {
+	PodmanDuration int64                        `json:"podman_restore_duration"`
+	PodStatistics  []*entities.PodRestoreReport `json:"pod_statistics"`
+}
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: restoreCommand,
+		Parent:  podCmd,
+	})
+	flags := restoreCommand.Flags()
+	flags.BoolVarP(&restoreOptions.All, "all", "a", false, "Restore all pods with checkpointed containers")
+	flags.BoolVarP(&restoreOptions.Keep, "keep", "k", false, "Keep all temporary checkpoint files")
+	flags.BoolVar(&restoreOptions.TCPEstablished, "tcp-established", false, "Restore containers with established TCP connections")
+	flags.BoolVar(&restoreOptions.FileLocks, "file-locks", false, "Restore containers with file locks")
+
+	importFlagName := "import"
+	flags.StringVarP(&restoreOptions.Import, importFlagName, "i", "", "Restore from exported pod checkpoint archive")
+	_ = restoreCommand.RegisterFlagCompletionFunc(importFlagName, completion.AutocompleteDefault)
+
+	nameFlagName := "name"
+	flags.StringVarP(&restoreOptions.Name, nameFlagName, "n", "", "Specify new name for pod restored from exported checkpoint (only works with image or --import)")
+	_ = restoreCommand.RegisterFlagCompletionFunc(nameFlagName, completion.AutocompleteNone)
+
+	flags.BoolVar(&restoreOptions.IgnoreRootFS, "ignore-rootfs", false, "Do not apply root file-system changes when importing from exported checkpoint")
+	flags.BoolVar(&restoreOptions.IgnoreStaticIP, "ignore-static-ip", false, "Ignore IP address set via --ip of the pod")
+	flags.BoolVar(&restoreOptions.IgnoreStaticMAC, "ignore-static-mac", false, "Ignore MAC address set via --mac-address of the pod")
+	flags.BoolVar(&restoreOptions.IgnoreVolumes, "ignore-volumes", false, "Do not restore volumes associated with the containers of the pod")
+
+	flags.BoolVar(&restoreOptions.PrintStats, "print-stats", false, "Display restore statistics")
+
+	validate.AddLatestFlag(restoreCommand, &restoreOptions.Latest)
+}
+
+func restore(_ *cobra.Command, args []string) error {
+	var errs utils.OutputErrors
+
+	podmanStart := time.Now()
+	if rootless.IsRootless() {
+		return errors.New("restoring a pod requires root")
+	}
+
+	argLen := len(args)
+	if restoreOptions.Import != "" {
+		if restoreOptions.All || restoreOptions.Latest {
+			return errors.New("cannot use --import with --all or --latest")
+		}
+		if argLen > 0 {
+			return errors.New("cannot use --import with positional arguments")
+		}
+	}
+	if (restoreOptions.All || restoreOptions.Latest) && argLen > 0 {
+		return errors.New("--all or --latest and pods cannot be used together")
+	}
+	if argLen < 1 && !restoreOptions.All && !restoreOptions.Latest && restoreOptions.Import == "" {
+		return errors.New("you must provide at least one name or id")
+	}
+	if (restoreOptions.All || restoreOptions.Latest) && restoreOptions.Name != "" {
+		return errors.New("--name can only be used with image or --import")
+	}
+	if argLen > 1 && restoreOptions.Name != "" {
+		return errors.New("--name can only be used with one checkpoint image")
+	}
+	if restoreOptions.Name != "" && restoreOptions.TCPEstablished {
+		return errors.New("--tcp-established cannot be used with --name")
+	}
+
+	responses, err := registry.ContainerEngine().PodRestore(context.Background(), args, restoreOptions)
+	if err != nil {
+		return err
+	}
+	podmanFinished := time.Now()
+
+	var statistics podRestoreStatistics
+
+	for _, r := range responses {
+		switch {
+		case r.Err != nil:
+			errs = append(errs, r.Err)
+		case restoreOptions.PrintStats:
+			statistics.PodStatistics = append(statistics.PodStatistics, r)
+		default:
+			fmt.Println(r.Id)
+		}
+	}
+
+	if restoreOptions.PrintStats {
+		statistics.PodmanDuration = podmanFinished.Sub(podmanStart).Microseconds()
+		j, err := json.MarshalIndent(statistics, "", "    ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(j))
+	}
+
+	return errs.PrintErrors()
+}
@@ -0,0 +1,123 @@
+package pods
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/containers/podman/v4/cmd/podman/common"
+	"github.com/containers/podman/v4/cmd/podman/registry"
+	"github.com/containers/podman/v4/cmd/podman/utils"
+	"github.com/containers/podman/v4/pkg/domain/entities"
+	"github.com/containers/podman/v4/pkg/specgen"
+	"github.com/containers/podman/v4/pkg/specgenutil"
+	"github.com/containers/podman/v4/pkg/util"
+	"github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/spf13/cobra"
+)
+
+var (
+	podUpdateDescription = `Updates the cgroup configuration, the restart policy and the exit policy of a given pod.
+
+  Resource limits are applied to the cgroup of the pod and to all of its containers.`
+
+	podUpdateCommand = &cobra.Command{
+		Use:               "update [options] POD",
+		Short:             "Update an existing pod",
+		Long:              podUpdateDescription,
+		RunE:              update,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: common.AutocompletePods,
+		Example: `podman pod update --cpus=2 --memory=1g mypod
+  podman pod update --restart=on-failure:3 --exit-policy=stop mypod`,
+	}
+)
+
+var (
+	podUpdateOpts    entities.ContainerCreateOptions
+	podUpdateRestart string
+	podUpdateExit    string
+)
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: podUpdateCommand,
+		Parent:  podCmd,
+	})
+	flags := podUpdateCommand.Flags()
+
+	common.DefineCreateDefaults(&podUpdateOpts)
+	common.DefineCreateFlags(podUpdateCommand, &podUpdateOpts, entities.UpdateMode)
+
+	restartFlagName := "restart"
+	flags.StringVar(&podUpdateRestart, restartFlagName, "", `Restart policy to apply to the pod and its containers ("always"|"no"|"never"|"on-failure"|"unless-stopped")`)
+	_ = podUpdateCommand.RegisterFlagCompletionFunc(restartFlagName, common.AutocompleteRestartOption)
+
+	exitPolicyFlagName := "exit-policy"
+	flags.StringVar(&podUpdateExit, exitPolicyFlagName, "", "Behaviour when the last container exits")
+	_ = podUpdateCommand.RegisterFlagCompletionFunc(exitPolicyFlagName, common.AutocompletePodExitPolicy)
+}
+
+func update(cmd *cobra.Command, args []string) error {
+	var (
+		errs utils.OutputErrors
+		err  error
+	)
+
+	opts := &entities.PodUpdateOptions{
+		NameOrID: args[0],
+	}
+
+	if cmd.Flags().Changed("pids-limit") {
+		val := cmd.Flag("pids-limit").Value.String()
+		// Convert -1 to 0, so that -1 maps to unlimited pids limit
+		if val == "-1" {
+			val = "0"
+		}
+		pidsLimit, err := strconv.ParseInt(val, 10, 32)
+		if err != nil {
+			return err
+		}
+		podUpdateOpts.PIDsLimit = &pidsLimit
+	}
+
+	// use a specgen since this is the easiest way to hold resource info
+	s := &specgen.SpecGenerator{}
+	s.ResourceLimits = &specs.LinuxResources{}
+	// we need to pass the whole specgen since throttle devices are parsed later due to cross compat.
+	s.ResourceLimits, err = specgenutil.GetResources(s, &podUpdateOpts)
+	if err != nil {
+		return err
+	}
+	if s.ResourceLimits != nil {
+		opts.Specgen = s
+	}
+
+	if cmd.Flags().Changed("restart") {
+		policy, retries, err := util.ParseRestartPolicy(podUpdateRestart)
+		if err != nil {
+			return err
+		}
+		opts.RestartPolicy = &policy
+		opts.RestartRetries = &retries
+	}
+	if cmd.Flags().Changed("exit-policy") {
+		opts.ExitPolicy = &podUpdateExit
+	}
+
+	if opts.Specgen == nil && opts.RestartPolicy == nil && opts.ExitPolicy == nil {
+		return errors.New("no changes specified for the pod")
+	}
+
+	report, err := registry.ContainerEngine().PodUpdate(context.Background(), opts)
+	if err != nil {
+		return err
+	}
+	if len(report.Errs) > 0 {
+		errs = append(errs, report.Errs...)
+		return errs.PrintErrors()
+	}
+	fmt.Println(report.Id)
+	return nil
+}
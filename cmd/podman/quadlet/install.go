@@ -0,0 +1,68 @@
+package quadlet
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/containers/common/pkg/completion"
+	"github.com/containers/podman/v4/cmd/podman/registry"
+	"github.com/containers/podman/v4/cmd/podman/utils"
+	"github.com/containers/podman/v4/pkg/domain/entities"
+	"github.com/spf13/cobra"
+)
+
+var (
+	installDescription = `Install one or more Quadlet files for the current user.
+
+  A directory is installed with all of its files, so Quadlet files can refer to other files in it, e.g. a YAML or a Containerfile.
+  Files are installed to $XDG_CONFIG_HOME/containers/systemd for rootless users and to /etc/containers/systemd for root.`
+
+	installCmd = &cobra.Command{
+		Annotations:       map[string]string{registry.EngineMode: registry.ABIMode},
+		Use:               "install [options] PATH-OR-URL [PATH-OR-URL...]",
+		Short:             "Install one or more Quadlet files",
+		Long:              installDescription,
+		RunE:              install,
+		Args:              cobra.MinimumNArgs(1),
+		ValidArgsFunction: completion.AutocompleteDefault,
+		Example: `podman quadlet install myapp.container
+  podman quadlet install ./myapp/
+  podman quadlet install https://example.com/myapp.container`,
+	}
+)
+
+var installOptions entities.QuadletInstallOptions
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: installCmd,
+		Parent:  quadletCmd,
+	})
+	flags := installCmd.Flags()
+	flags.BoolVar(&installOptions.Replace, "replace", false, "Replace Quadlet files which are already installed")
+	flags.BoolVar(&installOptions.ReloadSystemd, "reload-systemd", true, "Reload systemd after installing the Quadlet files")
+}
+
+func install(cmd *cobra.Command, args []string) error {
+	var errs utils.OutputErrors
+	report, err := registry.ContainerEngine().QuadletInstall(registry.Context(), args, installOptions)
+	if report != nil {
+		for _, source := range args {
+			if dest, ok := report.InstalledQuadlets[source]; ok {
+				fmt.Println(dest)
+			}
+		}
+		sources := make([]string, 0, len(report.QuadletErrors))
+		for source := range report.QuadletErrors {
+			sources = append(sources, source)
+		}
+		sort.Strings(sources)
+		for _, source := range sources {
+			errs = append(errs, fmt.Errorf("installing %s: %w", source, report.QuadletErrors[source]))
+		}
+	}
+	if err != nil {
+		errs = append(errs, err)
+	}
+	return errs.PrintErrors()
+}
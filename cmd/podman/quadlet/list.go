@@ -0,0 +1,94 @@
+package quadlet
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/containers/common/pkg/completion"
+	"github.com/containers/common/pkg/report"
+	"github.com/containers/podman/v4/cmd/podman/common"
+	"github.com/containers/podman/v4/cmd/podman/registry"
+	"github.com/containers/podman/v4/cmd/podman/validate"
+	"github.com/containers/podman/v4/pkg/domain/entities"
+	"github.com/spf13/cobra"
+)
+
+var (
+	listDescription = `List the Quadlet files of the current user, the systemd units generated for them and the state of the units.`
+
+	listCmd = &cobra.Command{
+		Annotations:       map[string]string{registry.EngineMode: registry.ABIMode},
+		Use:               "list [options]",
+		Aliases:           []string{"ls"},
+		Short:             "List Quadlet files",
+		Long:              listDescription,
+		RunE:              list,
+		Args:              validate.NoArgs,
+		ValidArgsFunction: completion.AutocompleteNone,
+		Example: `podman quadlet list
+  podman quadlet list --format "{{.Name}} {{.Status}}"`,
+	}
+)
+
+var (
+	listOptions   entities.QuadletListOptions
+	listFormat    string
+	listNoHeading bool
+)
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: listCmd,
+		Parent:  quadletCmd,
+	})
+	flags := listCmd.Flags()
+	flags.BoolVarP(&listOptions.All, "all", "a", false, "Also list Quadlet files shadowed by files of the same name")
+
+	formatFlagName := "format"
+	flags.StringVar(&listFormat, formatFlagName, "{{range .}}{{.Name}}\t{{.UnitName}}\t{{.Path}}\t{{.Status}}\n{{end -}}", "Pretty-print output to JSON or using a Go template")
+	_ = listCmd.RegisterFlagCompletionFunc(formatFlagName, common.AutocompleteFormat(&entities.ListQuadlet{}))
+
+	flags.BoolVarP(&listNoHeading, "noheading", "n", false, "Do not print headers")
+}
+
+func list(cmd *cobra.Command, args []string) error {
+	quadlets, err := registry.ContainerEngine().QuadletList(registry.Context(), listOptions)
+	if err != nil {
+		return err
+	}
+
+	if report.IsJSON(listFormat) {
+		b, err := json.MarshalIndent(quadlets, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(b))
+		return nil
+	}
+
+	headers := report.Headers(entities.ListQuadlet{}, map[string]string{
+		"UnitName": "UNIT NAME",
+		"Path":     "PATH ON DISK",
+	})
+
+	rpt := report.New(os.Stdout, cmd.Name())
+	defer rpt.Flush()
+
+	switch {
+	case cmd.Flag("format").Changed:
+		rpt, err = rpt.Parse(report.OriginUser, listFormat)
+	default:
+		rpt, err = rpt.Parse(report.OriginPodman, listFormat)
+	}
+	if err != nil {
+		return err
+	}
+
+	if rpt.RenderHeaders && !listNoHeading {
+		if err := rpt.Execute(headers); err != nil {
+			return fmt.Errorf("failed to write report column headers: %w", err)
+		}
+	}
+	return rpt.Execute(quadlets)
+}
@@ -0,0 +1,40 @@
+package quadlet
+
+import (
+	"fmt"
+
+	"github.com/containers/podman/v4/cmd/podman/common"
+	"github.com/containers/podman/v4/cmd/podman/registry"
+	"github.com/spf13/cobra"
+)
+
+var (
+	printDescription = `Print the contents of an installed Quadlet file.`
+
+	printCmd = &cobra.Command{
+		Annotations:       map[string]string{registry.EngineMode: registry.ABIMode},
+		Use:               "print QUADLET",
+		Short:             "Print the contents of a Quadlet file",
+		Long:              printDescription,
+		RunE:              printQuadlet,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: common.AutocompleteQuadlets,
+		Example:           `podman quadlet print myapp.container`,
+	}
+)
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: printCmd,
+		Parent:  quadletCmd,
+	})
+}
+
+func printQuadlet(cmd *cobra.Command, args []string) error {
+	content, err := registry.ContainerEngine().QuadletPrint(registry.Context(), args[0])
+	if err != nil {
+		return err
+	}
+	fmt.Print(content)
+	return nil
+}
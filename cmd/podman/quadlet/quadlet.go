@@ -0,0 +1,28 @@
+package quadlet
+
+import (
+	"github.com/containers/podman/v4/cmd/podman/registry"
+	"github.com/containers/podman/v4/cmd/podman/validate"
+	"github.com/spf13/cobra"
+)
+
+var (
+	quadletDescription = `Manage Quadlet files.
+
+  Quadlet files describe containers, pods, volumes, networks, images and builds which are run as systemd units.`
+
+	// Command: podman _quadlet_
+	quadletCmd = &cobra.Command{
+		Annotations: map[string]string{registry.EngineMode: registry.ABIMode},
+		Use:         "quadlet",
+		Short:       "Manage Quadlet files",
+		Long:        quadletDescription,
+		RunE:        validate.SubCommandExists,
+	}
+)
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: quadletCmd,
+	})
+}
@@ -0,0 +1,62 @@
+package quadlet
+
+import (
+	"fmt"
+
+	"github.com/containers/podman/v4/cmd/podman/common"
+	"github.com/containers/podman/v4/cmd/podman/registry"
+	"github.com/containers/podman/v4/cmd/podman/utils"
+	"github.com/containers/podman/v4/pkg/domain/entities"
+	"github.com/spf13/cobra"
+)
+
+var (
+	removeDescription = `Remove one or more installed Quadlet files.
+
+  The systemd unit generated for a Quadlet file must not be active, unless --force is used to stop it.`
+
+	removeCmd = &cobra.Command{
+		Annotations:       map[string]string{registry.EngineMode: registry.ABIMode},
+		Use:               "rm [options] QUADLET [QUADLET...]",
+		Aliases:           []string{"remove"},
+		Short:             "Remove one or more Quadlet files",
+		Long:              removeDescription,
+		RunE:              remove,
+		Args:              cobra.MinimumNArgs(1),
+		ValidArgsFunction: common.AutocompleteQuadlets,
+		Example: `podman quadlet rm myapp.container
+  podman quadlet rm --force myapp.container mydata.volume`,
+	}
+)
+
+var removeOptions entities.QuadletRemoveOptions
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: removeCmd,
+		Parent:  quadletCmd,
+	})
+	flags := removeCmd.Flags()
+	flags.BoolVarP(&removeOptions.Force, "force", "f", false, "Stop the systemd units of the Quadlet files if they are active")
+	flags.BoolVarP(&removeOptions.Ignore, "ignore", "i", false, "Do not error for Quadlet files which are not installed")
+	flags.BoolVar(&removeOptions.ReloadSystemd, "reload-systemd", true, "Reload systemd after removing the Quadlet files")
+}
+
+func remove(cmd *cobra.Command, args []string) error {
+	var errs utils.OutputErrors
+	report, err := registry.ContainerEngine().QuadletRemove(registry.Context(), args, removeOptions)
+	if report != nil {
+		for _, name := range report.Removed {
+			fmt.Println(name)
+		}
+		for _, name := range args {
+			if rmErr, ok := report.Errors[name]; ok {
+				errs = append(errs, rmErr)
+			}
+		}
+	}
+	if err != nil {
+		errs = append(errs, err)
+	}
+	return errs.PrintErrors()
+}
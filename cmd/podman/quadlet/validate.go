@@ -0,0 +1,59 @@
+package quadlet
+
+import (
+	"fmt"
+
+	"github.com/containers/podman/v4/cmd/podman/common"
+	"github.com/containers/podman/v4/cmd/podman/registry"
+	"github.com/spf13/cobra"
+)
+
+var (
+	validateDescription = `Validate Quadlet files without generating systemd units.
+
+  The Quadlet files are converted like the systemd generator does.  Unknown keys, invalid values and
+  references to .network, .volume, .pod, .image and .build files which do not exist are reported.
+  Quadlet files given by path are validated along with the installed ones, so they can be checked before installing them.
+  Without arguments, all installed Quadlet files are validated.`
+
+	validateCmd = &cobra.Command{
+		Annotations:       map[string]string{registry.EngineMode: registry.ABIMode},
+		Use:               "validate [QUADLET|PATH...]",
+		Short:             "Validate Quadlet files",
+		Long:              validateDescription,
+		RunE:              validateQuadlets,
+		ValidArgsFunction: common.AutocompleteQuadlets,
+		Example: `podman quadlet validate
+  podman quadlet validate myapp.container
+  podman quadlet validate ./myapp/`,
+	}
+)
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: validateCmd,
+		Parent:  quadletCmd,
+	})
+}
+
+func validateQuadlets(cmd *cobra.Command, args []string) error {
+	reports, err := registry.ContainerEngine().QuadletValidate(registry.Context(), args)
+	if err != nil {
+		return err
+	}
+	invalid := 0
+	for _, r := range reports {
+		if len(r.Errors) == 0 {
+			fmt.Printf("%s: OK\n", r.Name)
+			continue
+		}
+		invalid++
+		for _, e := range r.Errors {
+			fmt.Printf("%s: %s\n", r.Name, e)
+		}
+	}
+	if invalid > 0 {
+		return fmt.Errorf("%d of %d Quadlet files are invalid", invalid, len(reports))
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+package secrets
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/containers/podman/v4/cmd/podman/common"
+	"github.com/containers/podman/v4/cmd/podman/registry"
+	"github.com/containers/podman/v4/pkg/domain/entities"
+	"github.com/spf13/cobra"
+)
+
+var (
+	updateCmd = &cobra.Command{
+		Use:   "update [options] SECRET FILE|-",
+		Short: "Update the data of a secret",
+		Long:  "Replace the data of an existing secret while keeping its name and metadata. Input can be a path to a file or \"-\" (read from stdin).",
+		RunE:  update,
+		Args:  cobra.ExactArgs(2),
+		Example: `podman secret update mysecret /path/to/secret
+  printf "newdata" | podman secret update --propagate=restart mysecret -`,
+		ValidArgsFunction: common.AutocompleteSecretUpdate,
+	}
+)
+
+var (
+	updateOpts = entities.SecretUpdateOptions{}
+	updateEnv  = false
+)
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: updateCmd,
+		Parent:  secretCmd,
+	})
+
+	flags := updateCmd.Flags()
+
+	envFlagName := "env"
+	flags.BoolVar(&updateEnv, envFlagName, false, "Read secret data from environment variable")
+
+	propagateFlagName := "propagate"
+	flags.StringVar(&updateOpts.Propagate, propagateFlagName, entities.SecretPropagateNone, "Propagate the new data to containers using the secret (none, reinject, restart)")
+	_ = updateCmd.RegisterFlagCompletionFunc(propagateFlagName, common.AutocompleteSecretPropagate)
+}
+
+func update(cmd *cobra.Command, args []string) error {
+	name := args[0]
+	path := args[1]
+
+	var reader io.Reader
+	switch {
+	case updateEnv:
+		envValue := os.Getenv(path)
+		if envValue == "" {
+			return fmt.Errorf("cannot update secret data: environment variable %s is not set", path)
+		}
+		reader = strings.NewReader(envValue)
+	case path == "-" || path == "/dev/stdin":
+		stat, err := os.Stdin.Stat()
+		if err != nil {
+			return err
+		}
+		if (stat.Mode() & os.ModeNamedPipe) == 0 {
+			return errors.New("if `-` is used, data must be passed into stdin")
+		}
+		reader = os.Stdin
+	default:
+		file, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		reader = file
+	}
+
+	report, err := registry.ContainerEngine().SecretUpdate(context.Background(), name, reader, updateOpts)
+	if err != nil {
+		return err
+	}
+	fmt.Println(report.ID)
+	return nil
+}
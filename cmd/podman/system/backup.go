@@ -0,0 +1,100 @@
+//go:build !remote
+
+package system
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+
+	"github.com/containers/common/pkg/completion"
+	"github.com/containers/podman/v4/cmd/podman/registry"
+	"github.com/containers/podman/v4/pkg/domain/entities"
+	"github.com/spf13/cobra"
+)
+
+var (
+	backupDescription = `
+        podman system backup
+
+        Back up all containers, pods, networks, volumes including their contents and secrets to a single archive.
+        Use "-" to write the archive to stdout.
+`
+
+	backupCommand = &cobra.Command{
+		Annotations:       map[string]string{registry.EngineMode: registry.ABIMode},
+		Use:               "backup [options] FILE",
+		Args:              cobra.ExactArgs(1),
+		Short:             "Back up containers, pods, networks, volumes and secrets",
+		Long:              backupDescription,
+		RunE:              backup,
+		ValidArgsFunction: completion.AutocompleteDefault,
+		Example: `podman system backup backup.tar
+  podman system backup --include-images --passphrase-file ./passphrase backup.tar
+  podman system backup - | gzip > backup.tar.gz`,
+	}
+)
+
+var (
+	backupOptions        entities.SystemBackupOptions
+	backupPassphraseFile string
+)
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: backupCommand,
+		Parent:  systemCmd,
+	})
+
+	flags := backupCommand.Flags()
+	flags.BoolVar(&backupOptions.IncludeImages, "include-images", false, "Store the images used by the containers in the backup")
+
+	passphraseFileFlagName := "passphrase-file"
+	flags.StringVar(&backupPassphraseFile, passphraseFileFlagName, "", "Encrypt the data of secrets with the passphrase read from `file`")
+	_ = backupCommand.RegisterFlagCompletionFunc(passphraseFileFlagName, completion.AutocompleteDefault)
+}
+
+func backup(cmd *cobra.Command, args []string) error {
+	if backupPassphraseFile != "" {
+		passphrase, err := readPassphraseFile(backupPassphraseFile)
+		if err != nil {
+			return err
+		}
+		backupOptions.Passphrase = passphrase
+	}
+
+	if args[0] == "-" {
+		_, err := registry.ContainerEngine().SystemBackup(registry.Context(), os.Stdout, backupOptions)
+		return err
+	}
+
+	f, err := os.OpenFile(args[0], os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o600)
+	if err != nil {
+		return err
+	}
+	report, err := registry.ContainerEngine().SystemBackup(registry.Context(), f, backupOptions)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(args[0])
+		return err
+	}
+	fmt.Printf("Backed up %d containers, %d pods, %d networks, %d volumes, %d secrets and %d images to %s\n",
+		len(report.Containers), len(report.Pods), len(report.Networks), len(report.Volumes), len(report.Secrets), len(report.Images), args[0])
+	return nil
+}
+
+// readPassphraseFile reads a passphrase from a file, without a trailing
+// newline.
+func readPassphraseFile(path string) ([]byte, error) {
+	passphrase, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	passphrase = bytes.TrimRight(passphrase, "\r\n")
+	if len(passphrase) == 0 {
+		return nil, fmt.Errorf("passphrase file %s is empty", path)
+	}
+	return passphrase, nil
+}
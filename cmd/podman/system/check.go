@@ -0,0 +1,113 @@
+//go:build !remote
+
+package system
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/containers/common/pkg/completion"
+	"github.com/containers/podman/v4/cmd/podman/registry"
+	"github.com/containers/podman/v4/cmd/podman/validate"
+	"github.com/containers/podman/v4/pkg/domain/entities"
+	"github.com/spf13/cobra"
+)
+
+var (
+	checkDescription = `
+        podman system check
+
+        Check the database and the storage for inconsistencies: containers without storage, storage containers, layers and images
+        Podman does not know, damaged layers and images, lock conflicts, missing volume mountpoints and stale exec sessions.
+        With --repair, fix the problems which can be fixed without losing data.
+`
+
+	checkCommand = &cobra.Command{
+		Annotations:       map[string]string{registry.EngineMode: registry.ABIMode},
+		Use:               "check [options]",
+		Args:              validate.NoArgs,
+		Short:             "Check the database and storage for inconsistencies",
+		Long:              checkDescription,
+		RunE:              check,
+		ValidArgsFunction: completion.AutocompleteNone,
+		Example: `podman system check
+  podman system check --quick --repair
+  podman system check --repair --force --max 1h`,
+	}
+)
+
+var (
+	checkOptions entities.SystemCheckOptions
+	checkMax     time.Duration
+)
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: checkCommand,
+		Parent:  systemCmd,
+	})
+
+	flags := checkCommand.Flags()
+	flags.BoolVarP(&checkOptions.Quick, "quick", "q", false, "Skip the slow checks of the contents of layers")
+	flags.BoolVarP(&checkOptions.Repair, "repair", "r", false, "Repair the problems which can be repaired without losing data")
+	flags.BoolVarP(&checkOptions.RepairLossy, "force", "f", false, "With --repair, also remove damaged containers and storage containers Podman does not know")
+
+	maxFlagName := "max"
+	flags.DurationVarP(&checkMax, maxFlagName, "m", 24*time.Hour, "Report layers no image or container uses once they are older than `duration`")
+	_ = checkCommand.RegisterFlagCompletionFunc(maxFlagName, completion.AutocompleteNone)
+}
+
+func check(cmd *cobra.Command, args []string) error {
+	if checkOptions.RepairLossy && !checkOptions.Repair {
+		return errors.New("--force requires --repair")
+	}
+	checkOptions.UnreferencedLayerMaximumAge = &checkMax
+
+	report, err := registry.ContainerEngine().SystemCheck(registry.Context(), checkOptions)
+	if err != nil {
+		return err
+	}
+	if len(report.Problems) == 0 {
+		fmt.Println("No problems found")
+		return nil
+	}
+
+	// Print the problems grouped by category, in the order they were
+	// found in.
+	var categories []string
+	byCategory := make(map[string][]entities.SystemCheckProblem)
+	for _, problem := range report.Problems {
+		if _, ok := byCategory[problem.Category]; !ok {
+			categories = append(categories, problem.Category)
+		}
+		byCategory[problem.Category] = append(byCategory[problem.Category], problem)
+	}
+	remaining := 0
+	for _, category := range categories {
+		fmt.Printf("%s problems:\n", strings.ToUpper(category[:1])+category[1:])
+		for _, problem := range byCategory[category] {
+			status := ""
+			switch {
+			case problem.Repaired:
+				status = " (repaired)"
+			case problem.RepairError != "":
+				status = fmt.Sprintf(" (repair failed: %s)", problem.RepairError)
+				remaining++
+			default:
+				remaining++
+			}
+			fmt.Printf("  %s: %s%s\n", problem.ID, problem.Description, status)
+		}
+	}
+
+	fmt.Printf("\n%d problems found, %d repaired\n", len(report.Problems), len(report.Problems)-remaining)
+	if remaining > 0 {
+		if !checkOptions.Repair {
+			return fmt.Errorf("%d problems found, use --repair to repair them", remaining)
+		}
+		return fmt.Errorf("%d problems were not repaired", remaining)
+	}
+	return nil
+}
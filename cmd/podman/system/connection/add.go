@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net/url"
 	"os"
+	"path/filepath"
 	"regexp"
 	"strings"
 
@@ -28,6 +29,7 @@ var (
     [user@]hostname (will default to ssh)
     ssh://[user@]hostname[:port][/path] (will obtain socket path from service, if not given.)
     tcp://hostname:port (not secured)
+    tcp+tls://hostname:port (secured with TLS, see --tls-ca, --tls-cert and --tls-key)
     unix://path (absolute path required)
 `,
 		RunE:              add,
@@ -36,6 +38,7 @@ var (
   podman system connection add --identity ~/.ssh/dev_rsa testing ssh://root@server.fubar.com:2222
   podman system connection add --identity ~/.ssh/dev_rsa --port 22 production root@server.fubar.com
   podman system connection add debug tcp://localhost:8080
+  podman system connection add --tls-ca ca.pem --tls-cert cert.pem --tls-key key.pem ci tcp+tls://server.fubar.com:8443
   `,
 	}
 
@@ -56,6 +59,9 @@ var (
 		UDSPath  string
 		Default  bool
 		Farm     string
+		TLSCA    string
+		TLSCert  string
+		TLSKey   string
 	}{}
 )
 
@@ -85,6 +91,18 @@ func init() {
 
 	flags.BoolVarP(&cOpts.Default, "default", "d", false, "Set connection to be default")
 
+	tlsCAFlagName := "tls-ca"
+	flags.StringVar(&cOpts.TLSCA, tlsCAFlagName, "", "path to PEM file with the CA(s) used to verify the server certificate (tcp+tls only)")
+	_ = addCmd.RegisterFlagCompletionFunc(tlsCAFlagName, completion.AutocompleteDefault)
+
+	tlsCertFlagName := "tls-cert"
+	flags.StringVar(&cOpts.TLSCert, tlsCertFlagName, "", "path to PEM file with the client certificate (tcp+tls only)")
+	_ = addCmd.RegisterFlagCompletionFunc(tlsCertFlagName, completion.AutocompleteDefault)
+
+	tlsKeyFlagName := "tls-key"
+	flags.StringVar(&cOpts.TLSKey, tlsKeyFlagName, "", "path to PEM file with the client private key (tcp+tls only)")
+	_ = addCmd.RegisterFlagCompletionFunc(tlsKeyFlagName, completion.AutocompleteDefault)
+
 	registry.Commands = append(registry.Commands, registry.CliCommand{
 		Command: createCmd,
 		Parent:  system.ContextCmd,
@@ -139,6 +157,14 @@ func add(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("invalid ssh mode")
 	}
 
+	if uri.Scheme != "tcp+tls" {
+		for _, name := range []string{"tls-ca", "tls-cert", "tls-key"} {
+			if cmd.Flags().Changed(name) {
+				return fmt.Errorf("--%s option only supported for tcp+tls scheme", name)
+			}
+		}
+	}
+
 	switch uri.Scheme {
 	case "ssh":
 		return ssh.Create(entities, sshMode)
@@ -172,6 +198,19 @@ func add(cmd *cobra.Command, args []string) error {
 		if uri.Port() == "" {
 			return errors.New("tcp scheme requires a port either via --port or in destination URL")
 		}
+	case "tcp+tls":
+		if cmd.Flags().Changed("socket-path") {
+			return errors.New("--socket-path option not supported for tcp+tls scheme")
+		}
+		if cmd.Flags().Changed("identity") {
+			return errors.New("--identity option not supported for tcp+tls scheme")
+		}
+		if uri.Port() == "" {
+			return errors.New("tcp+tls scheme requires a port in destination URL")
+		}
+		if err := setTLSQuery(uri, cOpts.TLSCA, cOpts.TLSCert, cOpts.TLSKey); err != nil {
+			return err
+		}
 	default:
 		logrus.Warnf("%q unknown scheme, no validation provided", uri.Scheme)
 	}
@@ -257,6 +296,31 @@ func create(cmd *cobra.Command, args []string) error {
 	return cfg.Write()
 }
 
+// setTLSQuery records the TLS files in the query of a tcp+tls URI, which is
+// where the bindings read them from. Relative paths are made absolute so the
+// connection works independent of the current directory.
+func setTLSQuery(uri *url.URL, ca, cert, key string) error {
+	if (cert == "") != (key == "") {
+		return errors.New("--tls-cert and --tls-key must be used together")
+	}
+	query := uri.Query()
+	for name, path := range map[string]string{"tls-ca": ca, "tls-cert": cert, "tls-key": key} {
+		if path == "" {
+			continue
+		}
+		abs, err := filepath.Abs(path)
+		if err != nil {
+			return err
+		}
+		if _, err := os.Stat(abs); err != nil {
+			return err
+		}
+		query.Set(name, abs)
+	}
+	uri.RawQuery = query.Encode()
+	return nil
+}
+
 func translateDest(path string) (string, error) {
 	if path == "" {
 		return "", nil
@@ -270,9 +334,29 @@ func translateDest(path string) (string, error) {
 	}
 	// "host=tcp://myserver:2376,ca=~/ca-file,cert=~/cert-file,key=~/key-file"
 	vals := strings.Split(val, ",")
-	if len(vals) > 1 {
-		return "", fmt.Errorf("--docker additional options %q not supported", strings.Join(vals[1:], ","))
+	if len(vals) == 1 {
+		return vals[0], nil
+	}
+	tlsFiles := map[string]string{}
+	for _, opt := range vals[1:] {
+		name, file, _ := strings.Cut(opt, "=")
+		switch name {
+		case "ca", "cert", "key":
+			tlsFiles[name] = file
+		default:
+			return "", fmt.Errorf("--docker additional option %q not supported", opt)
+		}
+	}
+	uri, err := url.Parse(vals[0])
+	if err != nil {
+		return "", err
+	}
+	if uri.Scheme != "tcp" {
+		return "", fmt.Errorf("--docker TLS options require a tcp host, not %q", uri.Scheme)
+	}
+	uri.Scheme = "tcp+tls"
+	if err := setTLSQuery(uri, tlsFiles["ca"], tlsFiles["cert"], tlsFiles["key"]); err != nil {
+		return "", err
 	}
-	// for now we ignore other fields specified on command line
-	return vals[0], nil
+	return uri.String(), nil
 }
@@ -125,8 +125,10 @@ func printSummary(cmd *cobra.Command, reports *entities.SystemDfReport) error {
 
 	for _, v := range reports.Volumes {
 		activeVolumes += v.Links
-		volumesSize += v.Size
-		volumesReclaimable += v.ReclaimableSize
+		// Snapshots are not used by containers and can always be
+		// reclaimed.
+		volumesSize += v.Size + v.SnapshotsSize
+		volumesReclaimable += v.ReclaimableSize + v.SnapshotsSize
 	}
 	volumeSummary := dfSummary{
 		Type:           "Local Volumes",
@@ -223,9 +225,10 @@ func printVerbose(cmd *cobra.Command, reports *entities.SystemDfReport) error {
 		dfVolumes = append(dfVolumes, &dfVolume{SystemDfVolumeReport: d})
 	}
 	hdrs = report.Headers(entities.SystemDfVolumeReport{}, map[string]string{
-		"VolumeName": "VOLUME NAME",
+		"VolumeName":    "VOLUME NAME",
+		"SnapshotsSize": "SNAPSHOTS SIZE",
 	})
-	volumeRow := "{{range .}}{{.VolumeName}}\t{{.Links}}\t{{.Size}}\n{{end -}}"
+	volumeRow := "{{range .}}{{.VolumeName}}\t{{.Links}}\t{{.Size}}\t{{.Snapshots}}\t{{.SnapshotsSize}}\n{{end -}}"
 	rpt, err = rpt.Parse(report.OriginPodman, volumeRow)
 	if err != nil {
 		return err
@@ -298,6 +301,10 @@ func (d *dfVolume) Size() string {
 	return units.HumanSize(float64(d.SystemDfVolumeReport.Size))
 }
 
+func (d *dfVolume) SnapshotsSize() string {
+	return units.HumanSize(float64(d.SystemDfVolumeReport.SnapshotsSize))
+}
+
 type dfSummary struct {
 	Type           string
 	Total          int
@@ -7,6 +7,7 @@ import (
 	"os"
 
 	"github.com/containers/common/pkg/completion"
+	"github.com/containers/podman/v4/cmd/podman/common"
 	"github.com/containers/podman/v4/cmd/podman/registry"
 	"github.com/containers/podman/v4/cmd/podman/validate"
 	"github.com/containers/podman/v4/libpod/define"
@@ -50,10 +51,22 @@ func init() {
 	newRuntimeFlagName := "new-runtime"
 	flags.StringVar(&migrateOptions.NewRuntime, newRuntimeFlagName, "", "Specify a new runtime for all containers")
 	_ = migrateCommand.RegisterFlagCompletionFunc(newRuntimeFlagName, completion.AutocompleteNone)
+
+	databaseBackendFlagName := "database-backend"
+	flags.StringVar(&migrateOptions.DatabaseBackend, databaseBackendFlagName, "", "Migrate the database to the `backend` (boltdb or sqlite)")
+	_ = migrateCommand.RegisterFlagCompletionFunc(databaseBackendFlagName, common.AutocompleteDatabaseBackend)
+
+	flags.BoolVar(&migrateOptions.DryRun, "dry-run", false, "Only report what the database migration would copy")
 }
 
 func migrate(cmd *cobra.Command, args []string) {
-	if err := registry.ContainerEngine().Migrate(registry.Context(), migrateOptions); err != nil {
+	if migrateOptions.DryRun && migrateOptions.DatabaseBackend == "" {
+		fmt.Println("--dry-run requires --database-backend")
+		os.Exit(define.ExecErrorCodeGeneric)
+	}
+
+	report, err := registry.ContainerEngine().Migrate(registry.Context(), migrateOptions)
+	if err != nil {
 		fmt.Println(err)
 
 		// FIXME change this to return the error like other commands
@@ -61,5 +74,20 @@ func migrate(cmd *cobra.Command, args []string) {
 		//nolint:gocritic
 		os.Exit(define.ExecErrorCodeGeneric)
 	}
+	if report != nil {
+		printMigrateReport(report)
+	}
 	os.Exit(0)
 }
+
+func printMigrateReport(report *entities.SystemMigrateReport) {
+	verb, moved := "Migrated", "was moved"
+	if report.DryRun {
+		verb, moved = "Would migrate", "would be moved"
+	}
+	fmt.Printf("%s %d containers, %d pods, %d volumes, %d exec sessions, %d network attachments and %d exit codes from %s to %s database %s\n",
+		verb, report.Containers, report.Pods, report.Volumes, report.ExecSessions, report.Networks, report.ExitCodes, report.From, report.To, report.Path)
+	if report.Backup != "" {
+		fmt.Printf("The existing %s database %s to %s\n", report.To, moved, report.Backup)
+	}
+}
@@ -0,0 +1,152 @@
+//go:build !remote
+
+package system
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/containers/common/pkg/completion"
+	"github.com/containers/podman/v4/cmd/podman/registry"
+	"github.com/containers/podman/v4/pkg/domain/entities"
+	"github.com/spf13/cobra"
+)
+
+var (
+	restoreDescription = `
+        podman system restore
+
+        Restore the containers, pods, networks, volumes and secrets of a backup created by podman system backup.
+        Use "-" to read the archive from stdin.
+`
+
+	restoreCommand = &cobra.Command{
+		Annotations:       map[string]string{registry.EngineMode: registry.ABIMode},
+		Use:               "restore [options] FILE",
+		Args:              cobra.ExactArgs(1),
+		Short:             "Restore a backup of containers, pods, networks, volumes and secrets",
+		Long:              restoreDescription,
+		RunE:              restore,
+		ValidArgsFunction: completion.AutocompleteDefault,
+		Example: `podman system restore backup.tar
+  podman system restore --map-name container:web=web-old --map-path /home/alice=/home/bob backup.tar
+  gunzip -c backup.tar.gz | podman system restore -`,
+	}
+)
+
+var (
+	restoreOptions        entities.SystemRestoreOptions
+	restoreMapName        []string
+	restoreMapPath        []string
+	restoreMapUID         []string
+	restoreMapGID         []string
+	restorePassphraseFile string
+)
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: restoreCommand,
+		Parent:  systemCmd,
+	})
+
+	flags := restoreCommand.Flags()
+
+	mapNameFlagName := "map-name"
+	flags.StringArrayVar(&restoreMapName, mapNameFlagName, nil, "Restore `[KIND:]OLD=NEW` under another name, KIND is one of container, pod, network, volume or secret")
+	_ = restoreCommand.RegisterFlagCompletionFunc(mapNameFlagName, completion.AutocompleteNone)
+
+	mapPathFlagName := "map-path"
+	flags.StringArrayVar(&restoreMapPath, mapPathFlagName, nil, "Replace the host path prefix `OLD=NEW` of mounts and volumes")
+	_ = restoreCommand.RegisterFlagCompletionFunc(mapPathFlagName, completion.AutocompleteNone)
+
+	mapUIDFlagName := "map-uid"
+	flags.StringArrayVar(&restoreMapUID, mapUIDFlagName, nil, "Replace the owner `OLD=NEW` of the files in volumes")
+	_ = restoreCommand.RegisterFlagCompletionFunc(mapUIDFlagName, completion.AutocompleteNone)
+
+	mapGIDFlagName := "map-gid"
+	flags.StringArrayVar(&restoreMapGID, mapGIDFlagName, nil, "Replace the group `OLD=NEW` of the files in volumes")
+	_ = restoreCommand.RegisterFlagCompletionFunc(mapGIDFlagName, completion.AutocompleteNone)
+
+	passphraseFileFlagName := "passphrase-file"
+	flags.StringVar(&restorePassphraseFile, passphraseFileFlagName, "", "Decrypt the data of secrets with the passphrase read from `file`")
+	_ = restoreCommand.RegisterFlagCompletionFunc(passphraseFileFlagName, completion.AutocompleteDefault)
+}
+
+func restore(cmd *cobra.Command, args []string) error {
+	var err error
+	if restoreOptions.NameMap, err = parseRestoreMapping("--map-name", restoreMapName); err != nil {
+		return err
+	}
+	if restoreOptions.PathMap, err = parseRestoreMapping("--map-path", restoreMapPath); err != nil {
+		return err
+	}
+	if restoreOptions.UIDMap, err = parseRestoreIDMapping("--map-uid", restoreMapUID); err != nil {
+		return err
+	}
+	if restoreOptions.GIDMap, err = parseRestoreIDMapping("--map-gid", restoreMapGID); err != nil {
+		return err
+	}
+	if restorePassphraseFile != "" {
+		if restoreOptions.Passphrase, err = readPassphraseFile(restorePassphraseFile); err != nil {
+			return err
+		}
+	}
+
+	var r io.Reader = os.Stdin
+	if args[0] != "-" {
+		f, err := os.Open(args[0])
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		r = f
+	}
+
+	report, err := registry.ContainerEngine().SystemRestore(registry.Context(), r, restoreOptions)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Restored %d containers, %d pods, %d networks, %d volumes, %d secrets and %d images\n",
+		len(report.Containers), len(report.Pods), len(report.Networks), len(report.Volumes), len(report.Secrets), len(report.Images))
+	return nil
+}
+
+// parseRestoreMapping parses OLD=NEW values of the given flag.
+func parseRestoreMapping(flag string, values []string) (map[string]string, error) {
+	if len(values) == 0 {
+		return nil, nil
+	}
+	mapping := make(map[string]string, len(values))
+	for _, value := range values {
+		oldValue, newValue, ok := strings.Cut(value, "=")
+		if !ok || oldValue == "" || newValue == "" {
+			return nil, fmt.Errorf("invalid %s %q: must be OLD=NEW", flag, value)
+		}
+		mapping[oldValue] = newValue
+	}
+	return mapping, nil
+}
+
+// parseRestoreIDMapping parses numeric OLD=NEW values of the given flag.
+func parseRestoreIDMapping(flag string, values []string) (map[int]int, error) {
+	mapping, err := parseRestoreMapping(flag, values)
+	if err != nil || mapping == nil {
+		return nil, err
+	}
+	ids := make(map[int]int, len(mapping))
+	for oldValue, newValue := range mapping {
+		oldID, err := strconv.ParseUint(oldValue, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s %s=%s: %w", flag, oldValue, newValue, err)
+		}
+		newID, err := strconv.ParseUint(newValue, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s %s=%s: %w", flag, oldValue, newValue, err)
+		}
+		ids[int(oldID)] = int(newID)
+	}
+	return ids, nil
+}
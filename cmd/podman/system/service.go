@@ -3,6 +3,8 @@
 package system
 
 import (
+	"errors"
+	"fmt"
 	"net/url"
 	"os"
 	"path/filepath"
@@ -36,13 +38,18 @@ Enable a listening service for API access to Podman commands.
 		RunE:              service,
 		ValidArgsFunction: common.AutocompleteDefaultOneArg,
 		Example: `podman system service --time=0 unix:///tmp/podman.sock
-  podman system service --time=0 tcp://localhost:8888`,
+  podman system service --time=0 tcp://localhost:8888
+  podman system service --time=0 --tls-cert=server.pem --tls-key=server-key.pem --tls-client-ca=ca.pem tcp://0.0.0.0:8443`,
 	}
 
 	srvArgs = struct {
 		CorsHeaders string
 		PProfAddr   string
 		Timeout     uint
+		TLSCertFile string
+		TLSKeyFile  string
+		TLSClientCA string
+		Metrics     bool
 	}{}
 )
 
@@ -67,6 +74,20 @@ func init() {
 	flags.StringVarP(&srvArgs.PProfAddr, "pprof-address", "", "",
 		"Binding network address for pprof profile endpoints, default: do not expose endpoints")
 	_ = flags.MarkHidden("pprof-address")
+
+	tlsCertFlagName := "tls-cert"
+	flags.StringVar(&srvArgs.TLSCertFile, tlsCertFlagName, "", "PEM file containing the server certificate, enables TLS")
+	_ = srvCmd.RegisterFlagCompletionFunc(tlsCertFlagName, completion.AutocompleteDefault)
+
+	tlsKeyFlagName := "tls-key"
+	flags.StringVar(&srvArgs.TLSKeyFile, tlsKeyFlagName, "", "PEM file containing the private key for --tls-cert")
+	_ = srvCmd.RegisterFlagCompletionFunc(tlsKeyFlagName, completion.AutocompleteDefault)
+
+	tlsClientCAFlagName := "tls-client-ca"
+	flags.StringVar(&srvArgs.TLSClientCA, tlsClientCAFlagName, "", "PEM file containing the CA(s) used to verify client certificates, requires clients to present a certificate")
+	_ = srvCmd.RegisterFlagCompletionFunc(tlsClientCAFlagName, completion.AutocompleteDefault)
+
+	flags.BoolVar(&srvArgs.Metrics, "metrics", false, "Expose metrics in the Prometheus format on /metrics")
 }
 
 func aliasTimeoutFlag(_ *pflag.FlagSet, name string) pflag.NormalizedName {
@@ -82,6 +103,13 @@ func service(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	if (srvArgs.TLSCertFile == "") != (srvArgs.TLSKeyFile == "") {
+		return errors.New("--tls-cert and --tls-key must be used together")
+	}
+	if srvArgs.TLSClientCA != "" && srvArgs.TLSCertFile == "" {
+		return errors.New("--tls-client-ca requires --tls-cert and --tls-key")
+	}
+
 	// Clean up any old existing unix domain socket
 	if len(apiURI) > 0 {
 		uri, err := url.Parse(apiURI)
@@ -89,6 +117,10 @@ func service(cmd *cobra.Command, args []string) error {
 			return err
 		}
 
+		if srvArgs.TLSCertFile != "" && uri.Scheme != "tcp" {
+			return fmt.Errorf("TLS is only supported for tcp endpoints, not %q", uri.Scheme)
+		}
+
 		// socket activation uses a unix:// socket in the shipped unit files but apiURI is coded as "" at this layer.
 		if uri.Scheme == "unix" && !registry.IsRemote() {
 			if err := syscall.Unlink(uri.Path); err != nil && !os.IsNotExist(err) {
@@ -100,10 +132,14 @@ func service(cmd *cobra.Command, args []string) error {
 	}
 
 	return restService(cmd.Flags(), registry.PodmanConfig(), entities.ServiceOptions{
-		CorsHeaders: srvArgs.CorsHeaders,
-		PProfAddr:   srvArgs.PProfAddr,
-		Timeout:     time.Duration(srvArgs.Timeout) * time.Second,
-		URI:         apiURI,
+		CorsHeaders:     srvArgs.CorsHeaders,
+		PProfAddr:       srvArgs.PProfAddr,
+		Timeout:         time.Duration(srvArgs.Timeout) * time.Second,
+		URI:             apiURI,
+		TLSCertFile:     srvArgs.TLSCertFile,
+		TLSKeyFile:      srvArgs.TLSKeyFile,
+		TLSClientCAFile: srvArgs.TLSClientCA,
+		Metrics:         srvArgs.Metrics,
 	})
 }
 
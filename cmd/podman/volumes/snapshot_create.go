@@ -0,0 +1,55 @@
+package volumes
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/containers/common/pkg/completion"
+	"github.com/containers/podman/v4/cmd/podman/common"
+	"github.com/containers/podman/v4/cmd/podman/registry"
+	"github.com/containers/podman/v4/pkg/domain/entities"
+	"github.com/spf13/cobra"
+)
+
+var (
+	snapshotCreateDescription = `Create a snapshot of the contents of a volume.
+
+  The files are copied using reflinks if the filesystem supports them. Only volumes of the local driver are supported.`
+	snapshotCreateCommand = &cobra.Command{
+		Use:               "create [options] VOLUME",
+		Short:             "Create a snapshot of a volume",
+		Long:              snapshotCreateDescription,
+		RunE:              snapshotCreate,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: common.AutocompleteVolumes,
+		Example: `podman volume snapshot create myvol
+  podman volume snapshot create --name before-upgrade --pause myvol`,
+	}
+)
+
+var (
+	snapshotCreateOpts = entities.VolumeSnapshotCreateOptions{}
+)
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: snapshotCreateCommand,
+		Parent:  snapshotCmd,
+	})
+	flags := snapshotCreateCommand.Flags()
+
+	nameFlagName := "name"
+	flags.StringVar(&snapshotCreateOpts.Name, nameFlagName, "", "Name of the snapshot (default is the current time)")
+	_ = snapshotCreateCommand.RegisterFlagCompletionFunc(nameFlagName, completion.AutocompleteNone)
+
+	flags.BoolVar(&snapshotCreateOpts.Pause, "pause", false, "Pause the running containers using the volume while creating the snapshot")
+}
+
+func snapshotCreate(cmd *cobra.Command, args []string) error {
+	response, err := registry.ContainerEngine().VolumeSnapshotCreate(context.Background(), args[0], snapshotCreateOpts)
+	if err != nil {
+		return err
+	}
+	fmt.Println(response.Name)
+	return nil
+}
@@ -0,0 +1,117 @@
+package volumes
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/containers/common/pkg/report"
+	"github.com/containers/podman/v4/cmd/podman/common"
+	"github.com/containers/podman/v4/cmd/podman/registry"
+	"github.com/containers/podman/v4/pkg/domain/entities"
+	"github.com/docker/go-units"
+	"github.com/spf13/cobra"
+)
+
+var (
+	snapshotLsDescription = `List the snapshots of the given volumes, or of all volumes if none are given.`
+	snapshotLsCommand     = &cobra.Command{
+		Use:               "ls [options] [VOLUME...]",
+		Aliases:           []string{"list"},
+		Short:             "List volume snapshots",
+		Long:              snapshotLsDescription,
+		RunE:              snapshotList,
+		ValidArgsFunction: common.AutocompleteVolumes,
+		Example: `podman volume snapshot ls
+  podman volume snapshot ls --format json myvol`,
+	}
+)
+
+var (
+	snapshotLsFormat string
+	snapshotLsQuiet  bool
+)
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: snapshotLsCommand,
+		Parent:  snapshotCmd,
+	})
+	flags := snapshotLsCommand.Flags()
+
+	formatFlagName := "format"
+	flags.StringVar(&snapshotLsFormat, formatFlagName, "{{range .}}{{.Volume}}\t{{.Name}}\t{{.Created}}\t{{.Size}}\n{{end -}}", "Format snapshot output using Go template")
+	_ = snapshotLsCommand.RegisterFlagCompletionFunc(formatFlagName, common.AutocompleteFormat(&snapshotReporter{}))
+
+	flags.BoolP("noheading", "n", false, "Do not print headers")
+	flags.BoolVarP(&snapshotLsQuiet, "quiet", "q", false, "Print only the snapshot names")
+}
+
+func snapshotList(cmd *cobra.Command, args []string) error {
+	if snapshotLsQuiet && cmd.Flag("format").Changed {
+		return errors.New("quiet and format flags cannot be used together")
+	}
+	responses, err := registry.ContainerEngine().VolumeSnapshotList(context.Background(), args)
+	if err != nil {
+		setExitCode(err)
+		return err
+	}
+
+	if report.IsJSON(snapshotLsFormat) {
+		b, err := json.MarshalIndent(responses, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(b))
+		return nil
+	}
+	if len(responses) < 1 {
+		return nil
+	}
+
+	snapshots := make([]snapshotReporter, 0, len(responses))
+	for _, r := range responses {
+		snapshots = append(snapshots, snapshotReporter{r})
+	}
+
+	noHeading, _ := cmd.Flags().GetBool("noheading")
+	headers := report.Headers(entities.VolumeSnapshotReport{}, map[string]string{
+		"Created": "CREATED",
+	})
+
+	rpt := report.New(os.Stdout, cmd.Name())
+	defer rpt.Flush()
+
+	switch {
+	case cmd.Flag("format").Changed:
+		rpt, err = rpt.Parse(report.OriginUser, snapshotLsFormat)
+	case snapshotLsQuiet:
+		rpt, err = rpt.Parse(report.OriginUser, "{{.Name}}\n")
+	default:
+		rpt, err = rpt.Parse(report.OriginPodman, snapshotLsFormat)
+	}
+	if err != nil {
+		return err
+	}
+
+	if rpt.RenderHeaders && !noHeading {
+		if err := rpt.Execute(headers); err != nil {
+			return fmt.Errorf("failed to write report column headers: %w", err)
+		}
+	}
+	return rpt.Execute(snapshots)
+}
+
+type snapshotReporter struct {
+	*entities.VolumeSnapshotReport
+}
+
+func (s snapshotReporter) Created() string {
+	return units.HumanDuration(time.Since(s.CreatedAt)) + " ago"
+}
+
+func (s snapshotReporter) Size() string {
+	return units.HumanSize(float64(s.VolumeSnapshotReport.Size))
+}
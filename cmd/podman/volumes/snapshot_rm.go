@@ -0,0 +1,52 @@
+package volumes
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/containers/podman/v4/cmd/podman/common"
+	"github.com/containers/podman/v4/cmd/podman/registry"
+	"github.com/containers/podman/v4/cmd/podman/utils"
+	"github.com/spf13/cobra"
+)
+
+var (
+	snapshotRmDescription = `Remove one or more snapshots of a volume.`
+	snapshotRmCommand     = &cobra.Command{
+		Use:               "rm VOLUME SNAPSHOT [SNAPSHOT...]",
+		Aliases:           []string{"remove"},
+		Short:             "Remove one or more snapshots of a volume",
+		Long:              snapshotRmDescription,
+		RunE:              snapshotRm,
+		Args:              cobra.MinimumNArgs(2),
+		ValidArgsFunction: common.AutocompleteVolumeSnapshots,
+		Example: `podman volume snapshot rm myvol before-upgrade
+  podman volume snapshot rm myvol snap1 snap2`,
+	}
+)
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: snapshotRmCommand,
+		Parent:  snapshotCmd,
+	})
+}
+
+func snapshotRm(cmd *cobra.Command, args []string) error {
+	var (
+		errs utils.OutputErrors
+	)
+	responses, err := registry.ContainerEngine().VolumeSnapshotRm(context.Background(), args[0], args[1:])
+	if err != nil {
+		setExitCode(err)
+		return err
+	}
+	for _, r := range responses {
+		if r.Err == nil {
+			fmt.Println(r.Name)
+		} else {
+			errs = append(errs, r.Err)
+		}
+	}
+	return errs.PrintErrors()
+}
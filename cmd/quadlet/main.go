@@ -5,11 +5,8 @@ import (
 	"flag"
 	"fmt"
 	"os"
-	"os/user"
 	"path"
 	"path/filepath"
-	"regexp"
-	"sort"
 	"strings"
 	"unicode"
 
@@ -34,29 +31,13 @@ var (
 	versionFlag bool // True if -version is used
 )
 
-const (
-	SystemUserDirLevel = 5
-)
-
 var (
 	// data saved between logToKmsg calls
 	noKmsg   = false
 	kmsgFile *os.File
 )
 
-var (
-	void struct{}
-	// Key: Extension
-	// Value: Processing order for resource naming dependencies
-	supportedExtensions = map[string]int{
-		".container": 3,
-		".volume":    2,
-		".kube":      3,
-		".network":   2,
-		".image":     1,
-		".pod":       4,
-	}
-)
+var void struct{}
 
 // We log directly to /dev/kmsg, because that is the only way to get information out
 // of the generator into the system logs.
@@ -105,108 +86,6 @@ func Debugf(format string, a ...interface{}) {
 	}
 }
 
-// This returns the directories where we read quadlet .container and .volumes from
-// For system generators these are in /usr/share/containers/systemd (for distro files)
-// and /etc/containers/systemd (for sysadmin files).
-// For user generators these can live in /etc/containers/systemd/users, /etc/containers/systemd/users/$UID, and $XDG_CONFIG_HOME/containers/systemd
-func getUnitDirs(rootless bool) []string {
-	// Allow overriding source dir, this is mainly for the CI tests
-	unitDirsEnv := os.Getenv("QUADLET_UNIT_DIRS")
-	dirs := make([]string, 0)
-
-	if len(unitDirsEnv) > 0 {
-		for _, eachUnitDir := range strings.Split(unitDirsEnv, ":") {
-			if !filepath.IsAbs(eachUnitDir) {
-				Logf("%s not a valid file path", eachUnitDir)
-				return nil
-			}
-			dirs = appendSubPaths(dirs, eachUnitDir, false, nil)
-		}
-		return dirs
-	}
-
-	if rootless {
-		configDir, err := os.UserConfigDir()
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "Warning: %v", err)
-			return nil
-		}
-		dirs = appendSubPaths(dirs, path.Join(configDir, "containers/systemd"), false, nil)
-		u, err := user.Current()
-		if err == nil {
-			dirs = appendSubPaths(dirs, filepath.Join(quadlet.UnitDirAdmin, "users"), true, nonNumericFilter)
-			dirs = appendSubPaths(dirs, filepath.Join(quadlet.UnitDirAdmin, "users", u.Uid), true, userLevelFilter)
-		} else {
-			fmt.Fprintf(os.Stderr, "Warning: %v", err)
-		}
-		return append(dirs, filepath.Join(quadlet.UnitDirAdmin, "users"))
-	}
-
-	dirs = appendSubPaths(dirs, quadlet.UnitDirAdmin, false, userLevelFilter)
-	return appendSubPaths(dirs, quadlet.UnitDirDistro, false, nil)
-}
-
-func appendSubPaths(dirs []string, path string, isUserFlag bool, filterPtr func(string, bool) bool) []string {
-	resolvedPath, err := filepath.EvalSymlinks(path)
-	if err != nil {
-		Debugf("Error occurred resolving path %q: %s", path, err)
-		// Despite the failure add the path to the list for logging purposes
-		// This is the equivalent of adding the path when info==nil below
-		dirs = append(dirs, path)
-		return dirs
-	}
-
-	err = filepath.WalkDir(resolvedPath, func(_path string, info os.DirEntry, err error) error {
-		if info == nil || info.IsDir() {
-			if filterPtr == nil || filterPtr(_path, isUserFlag) {
-				dirs = append(dirs, _path)
-			}
-		}
-		return err
-	})
-	if err != nil {
-		if !errors.Is(err, os.ErrNotExist) {
-			Debugf("Error occurred walking sub directories %q: %s", path, err)
-		}
-	}
-	return dirs
-}
-
-func nonNumericFilter(_path string, isUserFlag bool) bool {
-	// when running in rootless, recursive walk directories that are non numeric
-	// ignore sub dirs under the `users` directory which correspond to a user id
-	if strings.Contains(_path, filepath.Join(quadlet.UnitDirAdmin, "users")) {
-		listDirUserPathLevels := strings.Split(_path, string(os.PathSeparator))
-		if len(listDirUserPathLevels) > SystemUserDirLevel {
-			if !(regexp.MustCompile(`^[0-9]*$`).MatchString(listDirUserPathLevels[SystemUserDirLevel])) {
-				return true
-			}
-		}
-	} else {
-		return true
-	}
-	return false
-}
-
-func userLevelFilter(_path string, isUserFlag bool) bool {
-	// if quadlet generator is run rootless, do not recurse other user sub dirs
-	// if quadlet generator is run as root, ignore users sub dirs
-	if strings.Contains(_path, filepath.Join(quadlet.UnitDirAdmin, "users")) {
-		if isUserFlag {
-			return true
-		}
-	} else {
-		return true
-	}
-	return false
-}
-
-func isExtSupported(filename string) bool {
-	ext := filepath.Ext(filename)
-	_, ok := supportedExtensions[ext]
-	return ok
-}
-
 var seen = make(map[string]struct{})
 
 func loadUnitsFromDir(sourcePath string) ([]*parser.UnitFile, error) {
@@ -223,7 +102,7 @@ func loadUnitsFromDir(sourcePath string) ([]*parser.UnitFile, error) {
 
 	for _, file := range files {
 		name := file.Name()
-		if _, ok := seen[name]; !ok && isExtSupported(name) {
+		if _, ok := seen[name]; !ok && quadlet.IsExtSupported(name) {
 			path := path.Join(sourcePath, name)
 
 			Debugf("Loading source unit file %s", path)
@@ -245,67 +124,6 @@ func loadUnitsFromDir(sourcePath string) ([]*parser.UnitFile, error) {
 	return units, prevError
 }
 
-func loadUnitDropins(unit *parser.UnitFile, sourcePaths []string) error {
-	var prevError error
-	reportError := func(err error) {
-		if prevError != nil {
-			err = fmt.Errorf("%s\n%s", prevError, err)
-		}
-		prevError = err
-	}
-
-	var dropinPaths = make(map[string]string)
-	for _, sourcePath := range sourcePaths {
-		dropinDir := path.Join(sourcePath, unit.Filename+".d")
-
-		dropinFiles, err := os.ReadDir(dropinDir)
-		if err != nil {
-			if !errors.Is(err, os.ErrNotExist) {
-				reportError(fmt.Errorf("error reading directory %q, %w", dropinDir, err))
-			}
-
-			continue
-		}
-
-		for _, dropinFile := range dropinFiles {
-			dropinName := dropinFile.Name()
-			if filepath.Ext(dropinName) != ".conf" {
-				continue // Only *.conf supported
-			}
-
-			if _, ok := dropinPaths[dropinName]; ok {
-				continue // We already saw this name
-			}
-
-			dropinPaths[dropinName] = path.Join(dropinDir, dropinName)
-		}
-	}
-
-	dropinFiles := make([]string, len(dropinPaths))
-	i := 0
-	for k := range dropinPaths {
-		dropinFiles[i] = k
-		i++
-	}
-
-	// Merge in alpha-numerical order
-	sort.Strings(dropinFiles)
-
-	for _, dropinFile := range dropinFiles {
-		dropinPath := dropinPaths[dropinFile]
-
-		Debugf("Loading source drop-in file %s", dropinPath)
-
-		if f, err := parser.ParseUnitFile(dropinPath); err != nil {
-			reportError(fmt.Errorf("error loading %q, %w", dropinPath, err))
-		} else {
-			unit.Merge(f)
-		}
-	}
-
-	return prevError
-}
-
 func generateServiceFile(service *parser.UnitFile) error {
 	Debugf("writing %q", service.Path)
 
@@ -445,7 +263,7 @@ func warnIfAmbiguousName(unit *parser.UnitFile, group string) {
 	if !ok {
 		return
 	}
-	if strings.HasSuffix(imageName, ".image") {
+	if strings.HasSuffix(imageName, ".image") || strings.HasSuffix(imageName, ".build") {
 		return
 	}
 	if !isUnambiguousName(imageName) {
@@ -453,23 +271,6 @@ func warnIfAmbiguousName(unit *parser.UnitFile, group string) {
 	}
 }
 
-func generatePodsInfoMap(units []*parser.UnitFile) map[string]*quadlet.PodInfo {
-	podsInfoMap := make(map[string]*quadlet.PodInfo)
-	for _, unit := range units {
-		if !strings.HasSuffix(unit.Filename, ".pod") {
-			continue
-		}
-
-		serviceName := quadlet.GetPodServiceName(unit)
-		podsInfoMap[unit.Filename] = &quadlet.PodInfo{
-			ServiceName: serviceName,
-			Containers:  make([]string, 0),
-		}
-	}
-
-	return podsInfoMap
-}
-
 func main() {
 	if err := process(); err != nil {
 		Logf("%s", err.Error())
@@ -519,7 +320,10 @@ func process() error {
 		Debugf("Starting quadlet-generator, output to: %s", outputPath)
 	}
 
-	sourcePaths := getUnitDirs(isUserFlag)
+	sourcePaths, err := quadlet.GetUnitDirs(isUserFlag)
+	if err != nil {
+		Logf("%s", err)
+	}
 
 	var units []*parser.UnitFile
 	for _, d := range sourcePaths {
@@ -538,7 +342,7 @@ func process() error {
 	}
 
 	for _, unit := range units {
-		if err := loadUnitDropins(unit, sourcePaths); err != nil {
+		if err := quadlet.LoadUnitDropins(unit, sourcePaths); err != nil {
 			reportError(err)
 		}
 	}
@@ -551,75 +355,48 @@ func process() error {
 		}
 	}
 
-	// Sort unit files according to potential inter-dependencies, with Volume and Network units
-	// taking precedence over all others.
-	sort.Slice(units, func(i, j int) bool {
-		getOrder := func(i int) int {
-			ext := filepath.Ext(units[i].Filename)
-			order, ok := supportedExtensions[ext]
-			if !ok {
-				return 0
-			}
-			return order
-		}
-		return getOrder(i) < getOrder(j)
-	})
+	quadlet.SortUnits(units)
 
 	// Generate the PodsInfoMap to allow containers to link to their pods and add themselves to the pod's containers list
-	podsInfoMap := generatePodsInfoMap(units)
+	podsInfoMap := quadlet.GeneratePodsInfoMap(units)
 
-	// A map of network/volume unit file-names, against their calculated names, as needed by Podman.
+	// A map of network/volume/container unit file-names, against their calculated names, as needed by Podman.
 	var resourceNames = make(map[string]string)
+	quadlet.AddContainerNames(units, resourceNames)
 
 	for _, unit := range units {
-		var service *parser.UnitFile
-		var name string
-		var err error
-
-		switch {
-		case strings.HasSuffix(unit.Filename, ".container"):
+		switch filepath.Ext(unit.Filename) {
+		case ".container":
 			warnIfAmbiguousName(unit, quadlet.ContainerGroup)
-			service, err = quadlet.ConvertContainer(unit, resourceNames, isUserFlag, podsInfoMap)
-		case strings.HasSuffix(unit.Filename, ".volume"):
+		case ".volume":
 			warnIfAmbiguousName(unit, quadlet.VolumeGroup)
-			service, name, err = quadlet.ConvertVolume(unit, unit.Filename, resourceNames)
-		case strings.HasSuffix(unit.Filename, ".kube"):
-			service, err = quadlet.ConvertKube(unit, resourceNames, isUserFlag)
-		case strings.HasSuffix(unit.Filename, ".network"):
-			service, name, err = quadlet.ConvertNetwork(unit, unit.Filename)
-		case strings.HasSuffix(unit.Filename, ".image"):
+		case ".image":
 			warnIfAmbiguousName(unit, quadlet.ImageGroup)
-			service, name, err = quadlet.ConvertImage(unit)
-		case strings.HasSuffix(unit.Filename, ".pod"):
-			service, err = quadlet.ConvertPod(unit, unit.Filename, podsInfoMap, resourceNames)
-		default:
-			Logf("Unsupported file type %q", unit.Filename)
-			continue
 		}
 
+		generated, err := quadlet.ConvertUnit(unit, resourceNames, isUserFlag, podsInfoMap)
 		if err != nil {
 			reportError(fmt.Errorf("converting %q: %w", unit.Filename, err))
 			continue
 		}
 
-		if name != "" {
-			resourceNames[unit.Filename] = name
-		}
-		service.Path = path.Join(outputPath, service.Filename)
+		for _, file := range generated {
+			file.Path = path.Join(outputPath, file.Filename)
 
-		if dryRunFlag {
-			data, err := service.ToString()
-			if err != nil {
-				reportError(fmt.Errorf("parsing %s: %w", service.Path, err))
+			if dryRunFlag {
+				data, err := file.ToString()
+				if err != nil {
+					reportError(fmt.Errorf("parsing %s: %w", file.Path, err))
+					continue
+				}
+				fmt.Printf("---%s---\n%s\n", file.Path, data)
 				continue
 			}
-			fmt.Printf("---%s---\n%s\n", service.Path, data)
-			continue
-		}
-		if err := generateServiceFile(service); err != nil {
-			reportError(fmt.Errorf("generating service file %s: %w", service.Path, err))
+			if err := generateServiceFile(file); err != nil {
+				reportError(fmt.Errorf("generating service file %s: %w", file.Path, err))
+			}
+			enableServiceFile(outputPath, file)
 		}
-		enableServiceFile(outputPath, service)
 	}
 	return prevError
 }
@@ -1,13 +1,8 @@
 package main
 
 import (
-	"os"
-	"os/user"
-	"path"
-	"path/filepath"
 	"testing"
 
-	"github.com/containers/podman/v4/pkg/systemd/quadlet"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -45,56 +40,3 @@ func TestIsUnambiguousName(t *testing.T) {
 		assert.Equal(t, res, test.res, "%q", test.input)
 	}
 }
-
-func TestUnitDirs(t *testing.T) {
-	rootDirs := []string{}
-	rootDirs = appendSubPaths(rootDirs, quadlet.UnitDirAdmin, false, userLevelFilter)
-	rootDirs = appendSubPaths(rootDirs, quadlet.UnitDirDistro, false, userLevelFilter)
-	unitDirs := getUnitDirs(false)
-	assert.Equal(t, unitDirs, rootDirs, "rootful unit dirs should match")
-
-	configDir, err := os.UserConfigDir()
-	assert.Nil(t, err)
-	u, err := user.Current()
-	assert.Nil(t, err)
-
-	rootlessDirs := []string{}
-
-	rootlessDirs = appendSubPaths(rootlessDirs, path.Join(configDir, "containers/systemd"), false, nil)
-	rootlessDirs = appendSubPaths(rootlessDirs, filepath.Join(quadlet.UnitDirAdmin, "users"), true, nonNumericFilter)
-	rootlessDirs = appendSubPaths(rootlessDirs, filepath.Join(quadlet.UnitDirAdmin, "users", u.Uid), true, userLevelFilter)
-	rootlessDirs = append(rootlessDirs, filepath.Join(quadlet.UnitDirAdmin, "users"))
-
-	unitDirs = getUnitDirs(true)
-	assert.Equal(t, unitDirs, rootlessDirs, "rootless unit dirs should match")
-
-	name, err := os.MkdirTemp("", "dir")
-	assert.Nil(t, err)
-	// remove the temporary directory at the end of the program
-	defer os.RemoveAll(name)
-
-	t.Setenv("QUADLET_UNIT_DIRS", name)
-	unitDirs = getUnitDirs(false)
-	assert.Equal(t, unitDirs, []string{name}, "rootful should use environment variable")
-
-	unitDirs = getUnitDirs(true)
-	assert.Equal(t, unitDirs, []string{name}, "rootless should use environment variable")
-
-	symLinkTestBaseDir, err := os.MkdirTemp("", "podman-symlinktest")
-	assert.Nil(t, err)
-	// remove the temporary directory at the end of the program
-	defer os.RemoveAll(symLinkTestBaseDir)
-
-	actualDir := filepath.Join(symLinkTestBaseDir, "actual")
-	err = os.Mkdir(actualDir, 0755)
-	assert.Nil(t, err)
-	innerDir := filepath.Join(actualDir, "inner")
-	err = os.Mkdir(innerDir, 0755)
-	assert.Nil(t, err)
-	symlink := filepath.Join(symLinkTestBaseDir, "symlink")
-	err = os.Symlink(actualDir, symlink)
-	assert.Nil(t, err)
-	t.Setenv("QUADLET_UNIT_DIRS", actualDir)
-	unitDirs = getUnitDirs(true)
-	assert.Equal(t, unitDirs, []string{actualDir, innerDir}, "directory resolution should follow symlink")
-}
@@ -28,6 +28,10 @@ const (
 	// ReexecChildKey is used internally for the second reexec
 	ReexecChildKey       = "rootlessport-child"
 	reexecChildEnvOpaque = "_CONTAINERS_ROOTLESSPORT_CHILD_OPAQUE"
+	// withheldSuffix is the suffix of the file podman creates next to the
+	// socket to withhold the ports from the start, must match
+	// rootlessPortsWithheldSuffix in libpod
+	withheldSuffix = ".withheld"
 )
 
 func main() {
@@ -188,37 +192,49 @@ outer:
 		}
 	}()
 
-	// let parent expose ports
-	logrus.Infof("Exposing ports %v", cfg.Mappings)
-	if err := exposePorts(driver, cfg.Mappings, cfg.ChildIP); err != nil {
-		return err
-	}
-
-	// we only need to have a socket to reload ports when we run under rootless cni
-	if cfg.RootlessCNI {
-		socketfile := filepath.Join(socketDir, cfg.ContainerID)
-		// make sure to remove the file if it exists to prevent EADDRINUSE
-		_ = os.Remove(socketfile)
-		// workaround to bypass the 108 char socket path limit
-		// open the fd and use the path to the fd as bind argument
-		fd, err := unix.Open(socketDir, unix.O_PATH, 0)
+	// the ports of a container gated on its readiness are withheld from the
+	// start, until podman exposes them through the socket
+	var withheld []rkport.Spec
+	withheldFile := filepath.Join(socketDir, cfg.ContainerID+withheldSuffix)
+	if _, err := os.Stat(withheldFile); err == nil {
+		_ = os.Remove(withheldFile)
+		logrus.Infof("Withholding ports %v", cfg.Mappings)
+		withheld, err = portSpecs(cfg.Mappings, cfg.ChildIP)
 		if err != nil {
 			return err
 		}
-		socket, err := net.ListenUnix("unixpacket", &net.UnixAddr{Name: fmt.Sprintf("/proc/self/fd/%d/%s", fd, cfg.ContainerID), Net: "unixpacket"})
-		if err != nil {
+	} else {
+		// let parent expose ports
+		logrus.Infof("Exposing ports %v", cfg.Mappings)
+		if err := exposePorts(driver, cfg.Mappings, cfg.ChildIP); err != nil {
 			return err
 		}
-		err = unix.Close(fd)
-		// remove the socket file on exit
-		defer os.Remove(socketfile)
-		if err != nil {
-			logrus.Warnf("Failed to close the socketDir fd: %v", err)
-		}
-		defer socket.Close()
-		go serve(socket, driver)
 	}
 
+	// the socket is used to reload the ports when we run under rootless cni
+	// and to withhold the ports until the container is ready
+	socketfile := filepath.Join(socketDir, cfg.ContainerID)
+	// make sure to remove the file if it exists to prevent EADDRINUSE
+	_ = os.Remove(socketfile)
+	// workaround to bypass the 108 char socket path limit
+	// open the fd and use the path to the fd as bind argument
+	fd, err := unix.Open(socketDir, unix.O_PATH, 0)
+	if err != nil {
+		return err
+	}
+	socket, err := net.ListenUnix("unixpacket", &net.UnixAddr{Name: fmt.Sprintf("/proc/self/fd/%d/%s", fd, cfg.ContainerID), Net: "unixpacket"})
+	if err != nil {
+		return err
+	}
+	err = unix.Close(fd)
+	// remove the socket file on exit
+	defer os.Remove(socketfile)
+	if err != nil {
+		logrus.Warnf("Failed to close the socketDir fd: %v", err)
+	}
+	defer socket.Close()
+	go serve(socket, driver, withheld)
+
 	logrus.Info("Ready")
 
 	// https://github.com/containers/podman/issues/11248
@@ -244,7 +260,9 @@ outer:
 	return nil
 }
 
-func serve(listener net.Listener, pm rkport.Manager) {
+// serve handles the requests of podman. withheld holds the specs of the ports
+// while they are withheld, it is nil while the ports are exposed.
+func serve(listener net.Listener, pm rkport.Manager, withheld []rkport.Spec) {
 	for {
 		conn, err := listener.Accept()
 		if err != nil {
@@ -252,7 +270,7 @@ func serve(listener net.Listener, pm rkport.Manager) {
 			continue
 		}
 		ctx := context.TODO()
-		err = handler(ctx, conn, pm)
+		err = handler(ctx, conn, pm, &withheld)
 		if err != nil {
 			_, _ = conn.Write([]byte(err.Error()))
 		} else {
@@ -262,13 +280,34 @@ func serve(listener net.Listener, pm rkport.Manager) {
 	}
 }
 
-func handler(ctx context.Context, conn io.Reader, pm rkport.Manager) error {
-	var childIP string
+// portGate is sent by podman to withhold or expose the ports, it is used to
+// gate the ports on the readiness of the container.
+type portGate struct {
+	Expose bool
+}
+
+func handler(ctx context.Context, conn io.Reader, pm rkport.Manager, withheld *[]rkport.Spec) error {
+	var msg json.RawMessage
 	dec := json.NewDecoder(conn)
-	err := dec.Decode(&childIP)
+	err := dec.Decode(&msg)
 	if err != nil {
 		return fmt.Errorf("rootless port failed to decode ports: %w", err)
 	}
+	var childIP string
+	if err := json.Unmarshal(msg, &childIP); err != nil {
+		var gate portGate
+		if err := json.Unmarshal(msg, &gate); err != nil {
+			return fmt.Errorf("rootless port failed to decode ports: %w", err)
+		}
+		return gatePorts(ctx, pm, gate.Expose, withheld)
+	}
+	if *withheld != nil {
+		// the ports are not exposed, only remember the new child IP
+		for i := range *withheld {
+			(*withheld)[i].ChildIP = childIP
+		}
+		return nil
+	}
 	portStatus, err := pm.ListPorts(ctx)
 	if err != nil {
 		return fmt.Errorf("rootless port failed to list ports: %w", err)
@@ -291,8 +330,51 @@ func handler(ctx context.Context, conn io.Reader, pm rkport.Manager) error {
 	return nil
 }
 
+func gatePorts(ctx context.Context, pm rkport.Manager, expose bool, withheld *[]rkport.Spec) error {
+	if expose {
+		for _, spec := range *withheld {
+			if _, err := pm.AddPort(ctx, spec); err != nil {
+				return fmt.Errorf("rootless port failed to add port: %w", err)
+			}
+		}
+		*withheld = nil
+		return nil
+	}
+	if *withheld != nil {
+		return nil
+	}
+	portStatus, err := pm.ListPorts(ctx)
+	if err != nil {
+		return fmt.Errorf("rootless port failed to list ports: %w", err)
+	}
+	specs := make([]rkport.Spec, 0, len(portStatus))
+	for _, status := range portStatus {
+		if err := pm.RemovePort(ctx, status.ID); err != nil {
+			return fmt.Errorf("rootless port failed to remove port: %w", err)
+		}
+		specs = append(specs, status.Spec)
+	}
+	*withheld = specs
+	return nil
+}
+
 func exposePorts(pm rkport.Manager, portMappings []types.PortMapping, childIP string) error {
+	specs, err := portSpecs(portMappings, childIP)
+	if err != nil {
+		return err
+	}
 	ctx := context.TODO()
+	for _, spec := range specs {
+		if _, err := pm.AddPort(ctx, spec); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// portSpecs returns the validated specs of the ports to expose.
+func portSpecs(portMappings []types.PortMapping, childIP string) ([]rkport.Spec, error) {
+	specs := []rkport.Spec{}
 	for _, port := range portMappings {
 		protocols := strings.Split(port.Protocol, ",")
 		for _, protocol := range protocols {
@@ -310,25 +392,15 @@ func exposePorts(pm rkport.Manager, portMappings []types.PortMapping, childIP st
 				}
 
 				for _, spec = range splitDualStackSpecIfWsl(spec) {
-					if err := validateAndAddPort(ctx, pm, spec); err != nil {
-						return err
+					if err := rkportutil.ValidatePortSpec(spec, nil); err != nil {
+						return nil, err
 					}
+					specs = append(specs, spec)
 				}
 			}
 		}
 	}
-	return nil
-}
-
-func validateAndAddPort(ctx context.Context, pm rkport.Manager, spec rkport.Spec) error {
-	if err := rkportutil.ValidatePortSpec(spec, nil); err != nil {
-		return err
-	}
-	if _, err := pm.AddPort(ctx, spec); err != nil {
-		return err
-	}
-
-	return nil
+	return specs, nil
 }
 
 func child() error {
@@ -73,6 +73,21 @@ type BoltState struct {
 
 // NewBoltState creates a new bolt-backed state database
 func NewBoltState(path string, runtime *Runtime) (State, error) {
+	// BoltDB is deprecated and, as of Podman 5.0, we no longer allow the
+	// creation of new Bolt states.
+	// If the DB does not already exist, error out.
+	// To continue testing in CI, allow creation iff an undocumented env
+	// var is set.
+	allowCreate := os.Getenv("CI_DESIRED_DATABASE") == "boltdb"
+	if allowCreate {
+		logrus.Debugf("Allowing deprecated database backend due to CI_DESIRED_DATABASE.")
+	}
+	return newBoltState(path, runtime, allowCreate)
+}
+
+// newBoltState creates a new bolt-backed state database.  Unless allowCreate
+// is set, the database must already exist.
+func newBoltState(path string, runtime *Runtime, allowCreate bool) (State, error) {
 	logrus.Info("Using boltdb as database backend")
 	state := new(BoltState)
 	state.dbPath = path
@@ -80,17 +95,10 @@ func NewBoltState(path string, runtime *Runtime) (State, error) {
 
 	logrus.Debugf("Initializing boltdb state at %s", path)
 
-	// BoltDB is deprecated and, as of Podman 5.0, we no longer allow the
-	// creation of new Bolt states.
-	// If the DB does not already exist, error out.
-	// To continue testing in CI, allow creation iff an undocumented env
-	// var is set.
-	if os.Getenv("CI_DESIRED_DATABASE") != "boltdb" {
+	if !allowCreate {
 		if _, err := os.Stat(path); err != nil && errors.Is(err, fs.ErrNotExist) {
 			return nil, fmt.Errorf("the BoltDB backend has been deprecated, no new BoltDB databases can be created: %w", define.ErrInvalidArg)
 		}
-	} else {
-		logrus.Debugf("Allowing deprecated database backend due to CI_DESIRED_DATABASE.")
 	}
 
 	db, err := bolt.Open(path, 0600, nil)
@@ -1431,6 +1439,42 @@ func (s *BoltState) GetContainerExitCode(id string) (int32, error) {
 	})
 }
 
+// AllContainerExitCodes returns the exit codes of all containers in the
+// database, indexed by container ID.
+func (s *BoltState) AllContainerExitCodes() (map[string]int32, error) {
+	if !s.valid {
+		return nil, define.ErrDBClosed
+	}
+
+	db, err := s.getDBCon()
+	if err != nil {
+		return nil, err
+	}
+	defer s.deferredCloseDBCon(db)
+
+	exitCodes := make(map[string]int32)
+	err = db.View(func(tx *bolt.Tx) error {
+		exitCodeBucket, err := getExitCodeBucket(tx)
+		if err != nil {
+			return err
+		}
+
+		return exitCodeBucket.ForEach(func(rawID, rawExitCode []byte) error {
+			exitCode, err := strconv.Atoi(string(rawExitCode))
+			if err != nil {
+				return fmt.Errorf("converting raw exit code %v of container %s: %w", rawExitCode, string(rawID), err)
+			}
+			exitCodes[string(rawID)] = int32(exitCode)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return exitCodes, nil
+}
+
 // GetContainerExitCodeTimeStamp returns the time stamp when the exit code of
 // the specified container was added to the database.
 func (s *BoltState) GetContainerExitCodeTimeStamp(id string) (*time.Time, error) {
@@ -196,6 +196,14 @@ type ContainerState struct {
 	// restart policy. This is NOT incremented by normal container restarts
 	// (only by restart policy).
 	RestartCount uint `json:"restartCount,omitempty"`
+	// RestartDelay is the delay before the next restart of the container
+	// by its restart policy.  It doubles with every restart while the
+	// container keeps crashing, and is reset once the container ran
+	// longer than define.RestartBackoffResetPeriod.
+	RestartDelay time.Duration `json:"restartDelay,omitempty"`
+	// CrashLoopUntil is set while the container waits for the restart
+	// delay to pass before it is restarted by its restart policy.
+	CrashLoopUntil time.Time `json:"crashLoopUntil,omitempty"`
 	// StartupHCPassed indicates that the startup healthcheck has
 	// succeeded and the main healthcheck can begin.
 	StartupHCPassed bool `json:"startupHCPassed,omitempty"`
@@ -207,6 +215,18 @@ type ContainerState struct {
 	// healthcheck. The container will be restarted if this exceed a set
 	// number in the startup HC config.
 	StartupHCFailureCount int `json:"startupHCFailureCount,omitempty"`
+	// ReadinessHCPassed indicates that the readiness healthcheck has
+	// succeeded and the container is ready to receive traffic.
+	ReadinessHCPassed bool `json:"readinessHCPassed,omitempty"`
+	// ReadinessHCSuccessCount indicates the number of consecutive successes
+	// of the readiness healthcheck while the container is not ready.
+	ReadinessHCSuccessCount int `json:"readinessHCSuccessCount,omitempty"`
+	// ReadinessHCFailureCount indicates the number of consecutive failures
+	// of the readiness healthcheck while the container is ready.
+	ReadinessHCFailureCount int `json:"readinessHCFailureCount,omitempty"`
+	// ScheduleLastRun is the time the schedule of the container last
+	// started it.
+	ScheduleLastRun time.Time `json:"scheduleLastRun,omitempty"`
 
 	// ExtensionStageHooks holds hooks which will be executed by libpod
 	// and not delegated to the OCI runtime.
@@ -637,6 +657,18 @@ func (c *Container) LogTag() string {
 	return c.config.LogTag
 }
 
+// LogMaxFile returns the number of log files kept when rotating the log of
+// the container
+func (c *Container) LogMaxFile() uint {
+	return c.config.LogMaxFile
+}
+
+// LogCompress returns whether rotated log files of the container are
+// compressed
+func (c *Container) LogCompress() bool {
+	return c.config.LogCompress
+}
+
 // RestartPolicy returns the container's restart policy.
 func (c *Container) RestartPolicy() string {
 	return c.config.RestartPolicy
@@ -991,6 +1023,57 @@ func (c *Container) StartupHCPassed() (bool, error) {
 	return c.state.StartupHCPassed, nil
 }
 
+// Ready returns whether the container is ready to receive traffic.
+// Containers without a readiness healthcheck are ready while they are
+// running.
+func (c *Container) Ready() (bool, error) {
+	if !c.batched {
+		c.lock.Lock()
+		defer c.lock.Unlock()
+
+		if err := c.syncContainer(); err != nil {
+			return false, err
+		}
+	}
+
+	return c.ready(), nil
+}
+
+// ready is the internal version of Ready() that does not lock the container.
+func (c *Container) ready() bool {
+	if c.state.State != define.ContainerStateRunning {
+		return false
+	}
+	if c.config.ReadinessHealthCheckConfig == nil {
+		return true
+	}
+	return c.state.ReadinessHCPassed
+}
+
+// CrashLoop returns whether the container keeps crashing, and waits to be
+// restarted by its restart policy.
+func (c *Container) CrashLoop() (bool, error) {
+	if !c.batched {
+		c.lock.Lock()
+		defer c.lock.Unlock()
+
+		if err := c.syncContainer(); err != nil {
+			return false, err
+		}
+	}
+
+	return c.crashLoop(), nil
+}
+
+// crashLoop is the internal version of CrashLoop() that does not lock the
+// container.
+func (c *Container) crashLoop() bool {
+	if c.state.CrashLoopUntil.IsZero() || c.state.StoppedByUser || c.ensureState(define.ContainerStateRunning, define.ContainerStatePaused) {
+		return false
+	}
+	return time.Now().Before(c.state.CrashLoopUntil)
+}
+
 // Misc Accessors
 // Most will require locking
 
@@ -1256,6 +1339,12 @@ func (c *Container) Timezone() string {
 	return c.config.Timezone
 }
 
+// Schedule returns the systemd calendar expression of the schedule the
+// container is started on.  It is empty if the container is not scheduled.
+func (c *Container) Schedule() string {
+	return c.config.Schedule
+}
+
 // Umask returns the Umask bits configured inside the container.
 func (c *Container) Umask() string {
 	return c.config.Umask
@@ -102,6 +102,10 @@ func (c *Container) Start(ctx context.Context, recursive bool) (finalErr error)
 		}
 	}()
 
+	if err := c.startAndWaitForDependencies(ctx, recursive); err != nil {
+		return err
+	}
+
 	if !c.batched {
 		c.lock.Lock()
 		defer c.lock.Unlock()
@@ -110,7 +114,7 @@ func (c *Container) Start(ctx context.Context, recursive bool) (finalErr error)
 			return err
 		}
 	}
-	if err := c.prepareToStart(ctx, recursive); err != nil {
+	if err := c.prepareToStart(ctx); err != nil {
 		return err
 	}
 
@@ -127,6 +131,83 @@ func (c *Container) Update(res *spec.LinuxResources) error {
 	return c.update(res)
 }
 
+// ValidateRestartPolicy returns an error if the restart policy of the
+// container cannot be changed to the given policy and number of retries.
+func (c *Container) ValidateRestartPolicy(policy string, retries uint) error {
+	switch policy {
+	case define.RestartPolicyNone, define.RestartPolicyNo, define.RestartPolicyOnFailure, define.RestartPolicyAlways, define.RestartPolicyUnlessStopped:
+	default:
+		return fmt.Errorf("%q is not a valid restart policy: %w", policy, define.ErrInvalidArg)
+	}
+	if retries > 0 && policy != define.RestartPolicyOnFailure {
+		return fmt.Errorf("restart retries can only be set with the %q restart policy: %w", define.RestartPolicyOnFailure, define.ErrInvalidArg)
+	}
+	if c.IsInitCtr() {
+		return fmt.Errorf("cannot change the restart policy of init container %s: %w", c.ID(), define.ErrInvalidArg)
+	}
+	return nil
+}
+
+// UpdateRestartPolicy changes the restart policy of the container, and the
+// number of retries of the "on-failure" policy.  It takes effect the next
+// time the container exits.
+func (c *Container) UpdateRestartPolicy(policy string, retries uint) error {
+	if err := c.ValidateRestartPolicy(policy, retries); err != nil {
+		return err
+	}
+
+	if !c.batched {
+		c.lock.Lock()
+		defer c.lock.Unlock()
+
+		if err := c.syncContainer(); err != nil {
+			return err
+		}
+	}
+
+	return c.updateRestartPolicy(policy, retries)
+}
+
+// UsesSecret reports whether the container mounts the secret with the given
+// name and whether it exposes the secret as an environment variable.
+func (c *Container) UsesSecret(name string) (mounted bool, env bool) {
+	for _, secr := range c.config.Secrets {
+		if secr.Name == name {
+			mounted = true
+			break
+		}
+	}
+	for _, secr := range c.config.EnvSecrets {
+		if secr.Name == name {
+			env = true
+			break
+		}
+	}
+	return mounted, env
+}
+
+// ReinjectSecret rewrites the file of the mounted secret with the given name
+// with the current data of the secret.  Running containers see the new data
+// right away.  Secrets exposed as environment variables are not affected;
+// they are resolved every time the container starts.
+func (c *Container) ReinjectSecret(name string) error {
+	if !c.batched {
+		c.lock.Lock()
+		defer c.lock.Unlock()
+
+		if err := c.syncContainer(); err != nil {
+			return err
+		}
+	}
+
+	for _, secr := range c.config.Secrets {
+		if secr.Name == name {
+			return c.extractSecretToCtrStorage(secr)
+		}
+	}
+	return fmt.Errorf("container %s does not mount secret %s: %w", c.ID(), name, define.ErrInvalidArg)
+}
+
 // StartAndAttach starts a container and attaches to it.
 // This acts as a combination of the Start and Attach APIs, ensuring proper
 // ordering of the two such that no output from the container is lost (e.g. the
@@ -151,6 +232,10 @@ func (c *Container) StartAndAttach(ctx context.Context, streams *define.AttachSt
 		}
 	}()
 
+	if err := c.startAndWaitForDependencies(ctx, recursive); err != nil {
+		return nil, err
+	}
+
 	if !c.batched {
 		c.lock.Lock()
 		defer c.lock.Unlock()
@@ -160,7 +245,7 @@ func (c *Container) StartAndAttach(ctx context.Context, streams *define.AttachSt
 		}
 	}
 
-	if err := c.prepareToStart(ctx, recursive); err != nil {
+	if err := c.prepareToStart(ctx); err != nil {
 		return nil, err
 	}
 	attachChan := make(chan error)
@@ -588,6 +673,14 @@ func (c *Container) WaitForExit(ctx context.Context, pollInterval time.Duration)
 			containerRemoved = true
 		}
 
+		// A crash looping container is restarted once its restart
+		// delay passed.  Do not time out waiting for conmon meanwhile,
+		// unless the container is always restarted (see below).
+		if !containerRemoved && c.config.RestartPolicy != define.RestartPolicyAlways && c.crashLoop() {
+			conmonTimerSet = false
+			return false, -1, nil
+		}
+
 		// If conmon is not alive anymore set a timer to make sure
 		// we're returning even if conmon has forcefully been killed.
 		if !conmonTimerSet && !containerRemoved {
@@ -831,6 +924,12 @@ func (c *Container) Cleanup(ctx context.Context) error {
 	if didRestart {
 		return nil
 	}
+	// The container lock is released while a crash looping container
+	// waits to be restarted, it may have been removed, started or cleaned
+	// up in the meantime.
+	if !c.valid || !c.ensureState(define.ContainerStateCreated, define.ContainerStateStopped, define.ContainerStateStopping) {
+		return nil
+	}
 
 	// If we didn't restart, we perform a normal cleanup
 
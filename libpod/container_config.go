@@ -82,6 +82,14 @@ type ContainerConfig struct {
 	// These containers must be started before this container is started.
 	Dependencies []string
 
+	// DependencyConditions are the conditions the dependency containers,
+	// keyed by their IDs, must meet before this container is started.
+	// Dependencies without a condition must be running.
+	DependencyConditions map[string]define.DependencyCondition `json:"dependencyConditions,omitempty"`
+	// DependencyTimeout is the time in seconds to wait for the dependency
+	// conditions to be met.  If 0, define.DefaultDependencyTimeout is used.
+	DependencyTimeout uint `json:"dependencyTimeout,omitempty"`
+
 	// rewrite is an internal bool to indicate that the config was modified after
 	// a read from the db, e.g. to migrate config fields after an upgrade.
 	// This field should never be written to the db, the json tag ensures this.
@@ -376,6 +384,12 @@ type ContainerMiscConfig struct {
 	LogTag string `json:"logTag"`
 	// LogSize is the tag used for logging
 	LogSize int64 `json:"logSize"`
+	// LogMaxFile is the number of log files kept when rotating the log of
+	// the k8s-file and json-file log drivers.  The log is truncated
+	// instead of rotated if less than 2.
+	LogMaxFile uint `json:"logMaxFile,omitempty"`
+	// LogCompress indicates that rotated log files are compressed.
+	LogCompress bool `json:"logCompress,omitempty"`
 	// LogDriver driver for logs
 	LogDriver string `json:"logDriver"`
 	// File containing the conmon PID
@@ -391,6 +405,14 @@ type ContainerMiscConfig struct {
 	// restart the container. Used only if RestartPolicy is set to
 	// "on-failure".
 	RestartRetries uint `json:"restart_retries,omitempty"`
+	// RestartDelay is the delay before the second restart of the
+	// container by its restart policy, if it keeps crashing.  The delay
+	// doubles with every restart, up to RestartMaxDelay.  0 disables the
+	// backoff, and the container is restarted immediately.
+	RestartDelay time.Duration `json:"restart_delay,omitempty"`
+	// RestartMaxDelay is the maximum delay between restarts of the
+	// container by its restart policy.
+	RestartMaxDelay time.Duration `json:"restart_max_delay,omitempty"`
 	// PostConfigureNetNS needed when a user namespace is created by an OCI runtime
 	// if the network namespace is created before the user namespace it will be
 	// owned by the wrong user namespace.
@@ -417,6 +439,23 @@ type ContainerMiscConfig struct {
 	// healthcheck for the container. This will run before the regular HC
 	// runs, and when it passes the regular HC will be activated.
 	StartupHealthCheckConfig *define.StartupHealthCheck `json:"startupHealthCheck,omitempty"`
+	// ReadinessHealthCheckConfig is the configuration of the readiness
+	// healthcheck for the container. It runs independently of the regular
+	// HC and only determines whether the container is ready.
+	ReadinessHealthCheckConfig *define.ReadinessHealthCheck `json:"readinessHealthCheck,omitempty"`
+	// ReadinessGate withholds the container's published ports until the
+	// readiness healthcheck passed. Only honored by the rootlessport
+	// forwarder.
+	ReadinessGate bool `json:"readinessGate,omitempty"`
+	// PostStartHook is run once the container was started.  A failure is
+	// logged and recorded as an event but does not stop the container.
+	PostStartHook *define.LifecycleHook `json:"postStartHook,omitempty"`
+	// PreStopHook is run before the stop signal is sent to the container.
+	// It is bounded by the stop timeout of the container.
+	PreStopHook *define.LifecycleHook `json:"preStopHook,omitempty"`
+	// Schedule is a systemd calendar expression. A transient systemd timer
+	// starts the container whenever it elapses.
+	Schedule string `json:"schedule,omitempty"`
 	// PreserveFDs is a number of additional file descriptors (in addition
 	// to 0, 1, 2) that will be passed to the executed process. The total FDs
 	// passed will be 3 + PreserveFDs.
@@ -0,0 +1,163 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/containers/podman/v4/libpod/define"
+)
+
+// dependencyPollInterval is the interval at which the dependencies of a
+// container are checked for their conditions.
+const dependencyPollInterval = 250 * time.Millisecond
+
+// startAndWaitForDependencies starts the dependencies of the container if
+// recursive is set, and waits until those with a condition meet it.  Waiting
+// can take until the dependency timeout, so the container must not be locked
+// unless it is batched, to not block inspecting, stopping or removing it
+// meanwhile.  prepareToStart checks the conditions again once it is locked.
+func (c *Container) startAndWaitForDependencies(ctx context.Context, recursive bool) error {
+	if recursive {
+		if err := c.startDependencies(ctx); err != nil {
+			return err
+		}
+	}
+	return c.waitForDependencyConditions(ctx)
+}
+
+// waitForDependencyConditions waits until all dependencies of the container
+// with a condition meet it, or the dependency timeout of the container
+// expires.  The returned error names the dependency which blocked the start of
+// the container.  Does not require the container to be locked, only its
+// dependencies are locked.
+func (c *Container) waitForDependencyConditions(ctx context.Context) error {
+	if len(c.config.DependencyConditions) == 0 {
+		return nil
+	}
+
+	timeout := c.config.DependencyTimeout
+	if timeout == 0 {
+		timeout = define.DefaultDependencyTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, time.Duration(timeout)*time.Second)
+	defer cancel()
+
+	for _, dep := range c.conditionDependencies() {
+		condition := c.config.DependencyConditions[dep]
+		depCtr, err := c.runtime.state.Container(dep)
+		if err != nil {
+			return fmt.Errorf("retrieving dependency %s of container %s from state: %w", dep, c.ID(), err)
+		}
+		for {
+			met, err := depCtr.dependencyConditionMet(condition)
+			if err != nil {
+				return fmt.Errorf("dependency %s of container %s cannot meet condition %q: %w", depCtr.Name(), c.ID(), condition, err)
+			}
+			if met {
+				break
+			}
+			select {
+			case <-ctx.Done():
+				if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+					return fmt.Errorf("dependency %s of container %s did not meet condition %q within %d seconds: %w", depCtr.Name(), c.ID(), condition, timeout, define.ErrDependencyTimeout)
+				}
+				return fmt.Errorf("waiting for dependency %s of container %s: %w", depCtr.Name(), c.ID(), ctx.Err())
+			case <-time.After(dependencyPollInterval):
+			}
+		}
+	}
+	return nil
+}
+
+// checkDependencyConditions returns an error if a dependency of the container
+// with a condition does not meet it, without waiting for it.
+func (c *Container) checkDependencyConditions() error {
+	for _, dep := range c.conditionDependencies() {
+		condition := c.config.DependencyConditions[dep]
+		depCtr, err := c.runtime.state.Container(dep)
+		if err != nil {
+			return fmt.Errorf("retrieving dependency %s of container %s from state: %w", dep, c.ID(), err)
+		}
+		met, err := depCtr.dependencyConditionMet(condition)
+		if err != nil {
+			return fmt.Errorf("dependency %s of container %s cannot meet condition %q: %w", depCtr.Name(), c.ID(), condition, err)
+		}
+		if !met {
+			return fmt.Errorf("dependency %s of container %s does not meet condition %q: %w", depCtr.Name(), c.ID(), condition, define.ErrCtrStateInvalid)
+		}
+	}
+	return nil
+}
+
+// conditionDependencies returns the sorted IDs of the dependencies of the
+// container with a condition.
+func (c *Container) conditionDependencies() []string {
+	deps := make([]string, 0, len(c.config.DependencyConditions))
+	for dep := range c.config.DependencyConditions {
+		deps = append(deps, dep)
+	}
+	sort.Strings(deps)
+	return deps
+}
+
+// dependencyConditionMet returns whether the container meets the condition of
+// a dependency.  An error is returned if the container cannot meet the
+// condition anymore without being started again.
+func (c *Container) dependencyConditionMet(condition define.DependencyCondition) (bool, error) {
+	if !c.batched {
+		c.lock.Lock()
+		defer c.lock.Unlock()
+
+		if err := c.syncContainer(); err != nil {
+			return false, err
+		}
+	}
+
+	state := c.state.State
+	switch condition {
+	case define.DependencyConditionHealthy:
+		if !c.HasHealthCheck() {
+			return false, errors.New("container has no healthcheck")
+		}
+		switch state {
+		case define.ContainerStateRunning:
+		case define.ContainerStatePaused:
+			return false, nil
+		default:
+			return false, fmt.Errorf("container is %s: %w", state, define.ErrCtrStateInvalid)
+		}
+		results, err := c.getHealthCheckLog()
+		if err != nil {
+			return false, fmt.Errorf("unable to get healthcheck log: %w", err)
+		}
+		switch results.Status {
+		case define.HealthCheckHealthy:
+			return true, nil
+		case define.HealthCheckUnhealthy:
+			return false, fmt.Errorf("container is %s", results.Status)
+		}
+		return false, nil
+	case define.DependencyConditionCompletedSuccessfully:
+		switch state {
+		case define.ContainerStateRunning, define.ContainerStatePaused, define.ContainerStateStopping:
+			return false, nil
+		case define.ContainerStateStopped, define.ContainerStateExited:
+			if c.state.ExitCode != 0 {
+				return false, fmt.Errorf("container exited with code %d", c.state.ExitCode)
+			}
+			return true, nil
+		default:
+			return false, fmt.Errorf("container has not been started: %w", define.ErrCtrStateInvalid)
+		}
+	default:
+		if state != define.ContainerStateRunning && !c.config.IsInfra {
+			return false, fmt.Errorf("container is %s: %w", state, define.ErrCtrStateInvalid)
+		}
+		return true, nil
+	}
+}
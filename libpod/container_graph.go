@@ -249,6 +249,15 @@ func startNode(ctx context.Context, node *containerNode, setError bool, ctrError
 		ctrErrored = true
 	}
 
+	// Wait for dependencies with a condition, e.g. to become healthy.
+	// Does not require the container to be locked either.
+	if !ctrErrored {
+		if err := node.container.waitForDependencyConditions(ctx); err != nil {
+			ctrErrors[node.id] = err
+			ctrErrored = true
+		}
+	}
+
 	// Lock before we start
 	node.container.lock.Lock()
 
@@ -126,6 +126,7 @@ func (c *Container) getContainerInspectData(size bool, driverData *define.Driver
 			Status:         runtimeInfo.State.String(),
 			Running:        runtimeInfo.State == define.ContainerStateRunning,
 			Paused:         runtimeInfo.State == define.ContainerStatePaused,
+			Restarting:     c.crashLoop(),
 			OOMKilled:      runtimeInfo.OOMKilled,
 			Dead:           runtimeInfo.State.String() == "bad state",
 			Pid:            runtimeInfo.PID,
@@ -143,6 +144,9 @@ func (c *Container) getContainerInspectData(size bool, driverData *define.Driver
 			CheckpointLog:  runtimeInfo.CheckpointLog,
 			RestoreLog:     runtimeInfo.RestoreLog,
 			StoppedByUser:  c.state.StoppedByUser,
+			Ready:          c.ready(),
+			CrashLoop:      c.crashLoop(),
+			Schedule:       c.getScheduleInspect(),
 		},
 		Image:                   config.RootfsImageID,
 		ImageName:               config.RootfsImageName,
@@ -172,6 +176,13 @@ func (c *Container) getContainerInspectData(size bool, driverData *define.Driver
 		LockNumber:              c.lock.ID(),
 	}
 
+	if len(config.DependencyConditions) > 0 {
+		data.DependencyConditions = make(map[string]string, len(config.DependencyConditions))
+		for id, condition := range config.DependencyConditions {
+			data.DependencyConditions[id] = string(condition)
+		}
+	}
+
 	if config.RootfsImageID != "" { // May not be set if the container was created with --rootfs
 		image, _, err := c.runtime.libimageRuntime.LookupImage(config.RootfsImageID, nil)
 		if err != nil {
@@ -407,6 +418,13 @@ func (c *Container) generateInspectContainerConfig(spec *spec.Spec) *define.Insp
 
 	ctrConfig.HealthcheckOnFailureAction = c.config.HealthCheckOnFailureAction.String()
 
+	ctrConfig.ReadinessHealthcheck = c.config.ReadinessHealthCheckConfig
+
+	ctrConfig.Schedule = c.config.Schedule
+
+	ctrConfig.PostStartHook = c.config.PostStartHook
+	ctrConfig.PreStopHook = c.config.PreStopHook
+
 	ctrConfig.CreateCommand = c.config.CreateCommand
 
 	ctrConfig.Timezone = c.config.Timezone
@@ -457,12 +475,16 @@ func (c *Container) generateInspectContainerHostConfig(ctrSpec *spec.Spec, named
 	logConfig.Path = c.config.LogPath
 	logConfig.Size = units.HumanSize(float64(c.config.LogSize))
 	logConfig.Tag = c.config.LogTag
+	logConfig.MaxFile = c.config.LogMaxFile
+	logConfig.Compress = c.config.LogCompress
 
 	hostConfig.LogConfig = logConfig
 
 	restartPolicy := new(define.InspectRestartPolicy)
 	restartPolicy.Name = c.config.RestartPolicy
 	restartPolicy.MaximumRetryCount = c.config.RestartRetries
+	restartPolicy.Delay = c.config.RestartDelay
+	restartPolicy.MaxDelay = c.config.RestartMaxDelay
 	hostConfig.RestartPolicy = restartPolicy
 	if c.config.NoCgroups {
 		hostConfig.Cgroups = "disabled"
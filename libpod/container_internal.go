@@ -266,6 +266,16 @@ func (c *Container) handleRestartPolicy(ctx context.Context) (_ bool, retErr err
 			return false, err
 		}
 	}
+	if c.config.ReadinessHealthCheckConfig != nil {
+		if err := c.removeReadinessTransientFiles(ctx); err != nil {
+			return false, err
+		}
+	}
+	if c.rotatesLog() {
+		if err := c.removeLogRotateTimer(ctx); err != nil {
+			return false, err
+		}
+	}
 
 	// Is the container running again?
 	// If so, we don't have to do anything
@@ -275,6 +285,12 @@ func (c *Container) handleRestartPolicy(ctx context.Context) (_ bool, retErr err
 		return false, fmt.Errorf("invalid container state encountered in restart attempt: %w", define.ErrInternal)
 	}
 
+	// Wait before restarting a container which keeps crashing.
+	restart, err := c.restartBackoff(ctx)
+	if err != nil || !restart {
+		return false, err
+	}
+
 	c.newContainerEvent(events.Restart)
 
 	// Increment restart count
@@ -328,6 +344,73 @@ func (c *Container) handleRestartPolicy(ctx context.Context) (_ bool, retErr err
 	return true, nil
 }
 
+// restartBackoff waits for the restart delay of the container to pass, if it
+// keeps crashing, and doubles the delay for the next restart.  The container
+// lock is released while waiting, so the container can be stopped, started or
+// removed in the meantime.  Returns whether the container must still be
+// restarted.
+func (c *Container) restartBackoff(ctx context.Context) (bool, error) {
+	if c.config.RestartDelay == 0 {
+		return true, nil
+	}
+
+	// A container which ran long enough is not crashing, reset the delay.
+	if c.state.FinishedTime.Sub(c.state.StartedTime) >= define.RestartBackoffResetPeriod {
+		c.state.RestartDelay = 0
+	}
+	delay := c.state.RestartDelay
+	if delay == 0 {
+		c.state.RestartDelay = c.config.RestartDelay
+		return true, c.save()
+	}
+	c.state.RestartDelay = 2 * delay
+	if c.state.RestartDelay > c.config.RestartMaxDelay {
+		c.state.RestartDelay = c.config.RestartMaxDelay
+	}
+
+	logrus.Infof("Container %s keeps crashing, restarting it in %s", c.ID(), delay)
+	c.state.CrashLoopUntil = time.Now().Add(delay)
+	if err := c.save(); err != nil {
+		return false, err
+	}
+	c.newContainerEvent(events.CrashLoop)
+
+	if !c.batched {
+		c.lock.Unlock()
+	}
+	var waitErr error
+	select {
+	case <-ctx.Done():
+		waitErr = ctx.Err()
+	case <-time.After(delay):
+	}
+	if !c.batched {
+		c.lock.Lock()
+		if err := c.syncContainer(); err != nil {
+			if errors.Is(err, define.ErrNoSuchCtr) || errors.Is(err, define.ErrCtrRemoved) {
+				// Removed while waiting, nothing left to do.
+				return false, nil
+			}
+			return false, err
+		}
+	}
+
+	c.state.CrashLoopUntil = time.Time{}
+	if err := c.save(); err != nil {
+		return false, err
+	}
+	if waitErr != nil {
+		return false, fmt.Errorf("waiting to restart container %s: %w", c.ID(), waitErr)
+	}
+
+	// Check the container again, it may have been stopped or started
+	// while waiting.
+	if c.state.StoppedByUser || !c.ensureState(define.ContainerStateStopped, define.ContainerStateExited) {
+		return false, nil
+	}
+	return true, nil
+}
+
 // Ensure that the container is in a specific state or state.
 // Returns true if the container is in one of the given states,
 // or false otherwise.
@@ -631,6 +714,8 @@ func resetContainerState(state *ContainerState) {
 	state.StoppedByUser = false
 	state.RestartPolicyMatch = false
 	state.RestartCount = 0
+	state.RestartDelay = 0
+	state.CrashLoopUntil = time.Time{}
 	state.Checkpointed = false
 	state.Restored = false
 	state.CheckpointedTime = time.Time{}
@@ -641,6 +726,9 @@ func resetContainerState(state *ContainerState) {
 	state.StartupHCPassed = false
 	state.StartupHCSuccessCount = 0
 	state.StartupHCFailureCount = 0
+	state.ReadinessHCPassed = false
+	state.ReadinessHCSuccessCount = 0
+	state.ReadinessHCFailureCount = 0
 	state.NetNS = ""
 	state.NetworkStatus = nil
 }
@@ -716,6 +804,13 @@ func (c *Container) refresh() error {
 		return err
 	}
 
+	// The transient timer of the schedule was lost in the reboot
+	if c.config.Schedule != "" {
+		if err := c.createScheduleTimer(); err != nil {
+			logrus.Errorf("Recreating schedule timer of container %s: %v", c.ID(), err)
+		}
+	}
+
 	return nil
 }
 
@@ -797,22 +892,20 @@ func (c *Container) save() error {
 }
 
 // Checks the container is in the right state, then initializes the container in preparation to start the container.
-// If recursive is true, each of the container's dependencies will be started.
-// Otherwise, this function will return with error if there are dependencies of this container that aren't running.
-func (c *Container) prepareToStart(ctx context.Context, recursive bool) (retErr error) {
+// The dependencies of the container must have been started and their conditions waited for by
+// startAndWaitForDependencies before locking the container.  This function will return with error
+// if there are dependencies of this container that aren't running or do not meet their condition.
+func (c *Container) prepareToStart(ctx context.Context) (retErr error) {
 	// Container must be created or stopped to be started
 	if !c.ensureState(define.ContainerStateConfigured, define.ContainerStateCreated, define.ContainerStateStopped, define.ContainerStateExited) {
 		return fmt.Errorf("container %s must be in Created or Stopped state to be started: %w", c.ID(), define.ErrCtrStateInvalid)
 	}
 
-	if !recursive {
-		if err := c.checkDependenciesAndHandleError(); err != nil {
-			return err
-		}
-	} else {
-		if err := c.startDependencies(ctx); err != nil {
-			return err
-		}
+	if err := c.checkDependenciesAndHandleError(); err != nil {
+		return err
+	}
+	if err := c.checkDependencyConditions(); err != nil {
+		return err
 	}
 
 	defer func() {
@@ -967,7 +1060,10 @@ func (c *Container) checkDependenciesRunning() ([]string, error) {
 		if err != nil {
 			return nil, fmt.Errorf("retrieving state of dependency %s of container %s: %w", dep, c.ID(), err)
 		}
-		if state != define.ContainerStateRunning && !depCtr.config.IsInfra {
+		// Dependencies which must complete are not running anymore once
+		// they met their condition.
+		completes := c.config.DependencyConditions[dep] == define.DependencyConditionCompletedSuccessfully
+		if state != define.ContainerStateRunning && !depCtr.config.IsInfra && !completes {
 			notRunning = append(notRunning, dep)
 		}
 		depCtrs[dep] = depCtr
@@ -1088,9 +1184,13 @@ func (c *Container) init(ctx context.Context, retainRetries bool) error {
 	c.state.StartupHCFailureCount = 0
 	c.state.StartupHCSuccessCount = 0
 	c.state.StartupHCPassed = false
+	c.state.ReadinessHCPassed = false
+	c.state.ReadinessHCSuccessCount = 0
+	c.state.ReadinessHCFailureCount = 0
 
 	if !retainRetries {
 		c.state.RestartCount = 0
+		c.state.RestartDelay = 0
 	}
 
 	// bugzilla.redhat.com/show_bug.cgi?id=2144754:
@@ -1116,6 +1216,20 @@ func (c *Container) init(ctx context.Context, retainRetries bool) error {
 		}
 	}
 
+	if c.config.ReadinessHealthCheckConfig != nil {
+		if err := c.createReadinessTimer(); err != nil {
+			logrus.Error(err)
+		}
+	}
+
+	if c.rotatesLog() {
+		if err := c.createLogRotateTimer(); err != nil {
+			logrus.Error(err)
+		}
+	} else if c.config.LogMaxFile > 1 {
+		logrus.Warnf("Log rotation requires systemd, the log file of container %s is truncated when reaching its maximum size instead", c.ID())
+	}
+
 	defer c.newContainerEvent(events.Init)
 	return c.completeNetworkSetup()
 }
@@ -1271,12 +1385,28 @@ func (c *Container) start(ctx context.Context) error {
 		}
 	}
 
+	if c.config.ReadinessHealthCheckConfig != nil {
+		if err := c.startReadinessTimer(); err != nil {
+			logrus.Error(err)
+		}
+	}
+
+	if c.rotatesLog() {
+		if err := c.startLogRotateTimer(); err != nil {
+			logrus.Error(err)
+		}
+	}
+
 	c.newContainerEvent(events.Start)
 
 	if err := c.save(); err != nil {
 		return err
 	}
 
+	if err := c.runPostStartHook(); err != nil {
+		return err
+	}
+
 	if c.config.SdNotifyMode != define.SdNotifyModeHealthy {
 		return nil
 	}
@@ -1328,6 +1458,15 @@ func (c *Container) stop(timeout uint) error {
 		}
 	}
 
+	// Run the pre-stop hook before the container is marked as stopping,
+	// exec sessions can only be created in running containers.  The lock
+	// is released while the hook runs, so the state must only be checked
+	// afterwards.
+	timeout, err := c.runPreStopHook(timeout)
+	if err != nil {
+		return err
+	}
+
 	// OK, the following code looks a bit weird but we have to make sure we can stop
 	// containers with the restart policy always, to do this we have to set
 	// StoppedByUser even when there is nothing to stop right now. This is due to the
@@ -1497,6 +1636,16 @@ func (c *Container) restartWithTimeout(ctx context.Context, timeout uint) (retEr
 				logrus.Error(err.Error())
 			}
 		}
+		if c.config.ReadinessHealthCheckConfig != nil {
+			if err := c.removeReadinessTransientFiles(context.Background()); err != nil {
+				logrus.Error(err.Error())
+			}
+		}
+		if c.rotatesLog() {
+			if err := c.removeLogRotateTimer(context.Background()); err != nil {
+				logrus.Error(err.Error())
+			}
+		}
 		// Old versions of conmon have a bug where they create the exit file before
 		// closing open file descriptors causing a race condition when restarting
 		// containers with open ports since we cannot bind the ports as they're not
@@ -1994,6 +2143,16 @@ func (c *Container) cleanup(ctx context.Context) error {
 			logrus.Errorf("Removing timer for container %s healthcheck: %v", c.ID(), err)
 		}
 	}
+	if c.config.ReadinessHealthCheckConfig != nil {
+		if err := c.removeReadinessTransientFiles(ctx); err != nil {
+			logrus.Errorf("Removing timer for container %s readiness healthcheck: %v", c.ID(), err)
+		}
+	}
+	if c.rotatesLog() {
+		if err := c.removeLogRotateTimer(ctx); err != nil {
+			logrus.Errorf("Removing timer for container %s log rotation: %v", c.ID(), err)
+		}
+	}
 
 	// Clean up network namespace, if present
 	if err := c.cleanupNetwork(); err != nil {
@@ -2526,3 +2685,41 @@ func (c *Container) update(resources *spec.LinuxResources) error {
 	logrus.Debugf("updated container %s", c.ID())
 	return nil
 }
+
+// podUpdate applies an update of its pod to the container.  The resource
+// limits are applied if the container has a cgroup, the restart policy is
+// persisted in the config of the container.
+func (c *Container) podUpdate(resources *spec.LinuxResources, restartPolicy *string, restartRetries uint) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if err := c.syncContainer(); err != nil {
+		return err
+	}
+
+	if resources != nil && !c.IsInfra() {
+		switch c.state.State {
+		case define.ContainerStateCreated, define.ContainerStateRunning, define.ContainerStatePaused:
+			if err := c.update(resources); err != nil {
+				return err
+			}
+		}
+	}
+
+	if restartPolicy != nil && !c.IsInitCtr() {
+		return c.updateRestartPolicy(*restartPolicy, restartRetries)
+	}
+	return nil
+}
+
+// updateRestartPolicy persists a new restart policy in the config of the
+// container.  The policy must be valid.
+func (c *Container) updateRestartPolicy(policy string, retries uint) error {
+	c.config.RestartPolicy = policy
+	c.config.RestartRetries = retries
+	// SafeRewriteContainerConfig must be used with care. Make sure to not change config fields by accident.
+	if err := c.runtime.state.SafeRewriteContainerConfig(c, "", "", c.config); err != nil {
+		return fmt.Errorf("rewriting config of container %s: %w", c.ID(), err)
+	}
+	return nil
+}
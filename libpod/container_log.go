@@ -21,6 +21,15 @@ import (
 // logDrivers stores the currently available log drivers, do not modify
 var logDrivers []string
 
+// logRotateInterval is how often the size of a log file is checked when it
+// is rotated by Podman
+const logRotateInterval = 30 * time.Second
+
+// logSizeHardCapFactor is the multiple of the maximum log size at which
+// conmon truncates a log file rotated by Podman, in case the log grows
+// faster than it is rotated
+const logSizeHardCapFactor = 2
+
 func init() {
 	logDrivers = append(logDrivers, define.KubernetesLogging, define.NoLogging, define.PassthroughLogging)
 }
@@ -164,3 +173,74 @@ func (c *Container) readFromLogFile(ctx context.Context, options *logs.LogOption
 	}
 	return nil
 }
+
+// logSizeMax returns the size at which the log file of the container is
+// rotated or truncated
+func (c *Container) logSizeMax() int64 {
+	if c.config.LogSize > 0 {
+		return c.config.LogSize
+	}
+	return c.runtime.config.Containers.LogSizeMax
+}
+
+// logRotateUnitName returns the name of the systemd timer rotating the log
+// file of the container
+func (c *Container) logRotateUnitName() string {
+	return c.ID() + "-logrotate"
+}
+
+// rotatesLog returns whether Podman rotates the log file of the container
+// rather than conmon truncating it when it reaches its maximum size
+func (c *Container) rotatesLog() bool {
+	if c.config.LogMaxFile < 2 || c.logSizeMax() <= 0 {
+		return false
+	}
+	switch c.LogDriver() {
+	case define.KubernetesLogging, define.JSONLogging, "":
+		return logRotationSupported()
+	default:
+		return false
+	}
+}
+
+// RotateLog rotates the log file of the container if it reached its maximum
+// size.  Rotated files beyond the max-file log option are removed.
+func (c *Container) RotateLog() error {
+	if !c.batched {
+		c.lock.Lock()
+		defer c.lock.Unlock()
+
+		if err := c.syncContainer(); err != nil {
+			return err
+		}
+	}
+
+	if !c.rotatesLog() {
+		return fmt.Errorf("log rotation is not enabled for container %s: %w", c.ID(), define.ErrInvalidArg)
+	}
+
+	// Only conmon can create the new log file, so the log of a stopped
+	// container is not rotated.
+	if !c.ensureState(define.ContainerStateRunning, define.ContainerStatePaused) {
+		return nil
+	}
+
+	info, err := os.Stat(c.LogPath())
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+	if info.Size() < c.logSizeMax() {
+		return nil
+	}
+
+	logrus.Debugf("Rotating log file %s of container %s", c.LogPath(), c.ID())
+	if err := logs.RotateLogFile(c.LogPath(), c.config.LogMaxFile, c.config.LogCompress); err != nil {
+		return fmt.Errorf("rotating log file of container %s: %w", c.ID(), err)
+	}
+	// Conmon keeps writing to the rotated file until it reopens the log,
+	// which happens asynchronously.
+	return c.ociRuntime.ReopenContainerLog(c)
+}
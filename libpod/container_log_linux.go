@@ -10,6 +10,7 @@ import (
 	"strings"
 	"time"
 
+	systemdCommon "github.com/containers/common/pkg/systemd"
 	"github.com/containers/podman/v4/libpod/define"
 	"github.com/containers/podman/v4/libpod/events"
 	"github.com/containers/podman/v4/libpod/logs"
@@ -30,6 +31,30 @@ func init() {
 	logDrivers = append(logDrivers, define.JournaldLogging)
 }
 
+// logRotationSupported returns whether log files can be rotated, which
+// requires a systemd timer checking their size periodically
+func logRotationSupported() bool {
+	return systemdCommon.RunsOnSystemd()
+}
+
+// createLogRotateTimer creates the systemd timer rotating the log file of
+// the container
+func (c *Container) createLogRotateTimer() error {
+	return c.createTransientTimer(c.logRotateUnitName(), "--on-unit-inactive="+logRotateInterval.String(), "container", "rotate-log", c.ID())
+}
+
+// startLogRotateTimer starts the systemd timer rotating the log file of the
+// container
+func (c *Container) startLogRotateTimer() error {
+	return startTransientUnit(c.logRotateUnitName())
+}
+
+// removeLogRotateTimer removes the systemd timer and unit rotating the log
+// file of the container
+func (c *Container) removeLogRotateTimer(ctx context.Context) error {
+	return removeTransientUnit(ctx, c.logRotateUnitName())
+}
+
 func (c *Container) readFromJournal(ctx context.Context, options *logs.LogOptions,
 	logChannel chan *logs.LogLine, colorID int64, passthroughUnit string) error {
 	// We need the container's events in the same journal to guarantee
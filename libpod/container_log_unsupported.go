@@ -13,3 +13,27 @@ import (
 func (c *Container) readFromJournal(_ context.Context, _ *logs.LogOptions, _ chan *logs.LogLine, _ int64, _ string) error {
 	return fmt.Errorf("journald logging only enabled with systemd on linux: %w", define.ErrOSNotSupported)
 }
+
+// logRotationSupported returns whether log files can be rotated, which
+// requires a systemd timer checking their size periodically
+func logRotationSupported() bool {
+	return false
+}
+
+// createLogRotateTimer creates the systemd timer rotating the log file of
+// the container
+func (c *Container) createLogRotateTimer() error {
+	return nil
+}
+
+// startLogRotateTimer starts the systemd timer rotating the log file of the
+// container
+func (c *Container) startLogRotateTimer() error {
+	return nil
+}
+
+// removeLogRotateTimer removes the systemd timer and unit rotating the log
+// file of the container
+func (c *Container) removeLogRotateTimer(_ context.Context) error {
+	return nil
+}
@@ -0,0 +1,107 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/containers/podman/v4/libpod/define"
+	"github.com/sirupsen/logrus"
+)
+
+// RunScheduled starts the container as its schedule does, and records the
+// time of the run.  A run is skipped if the previous one is still active.
+func (c *Container) RunScheduled(ctx context.Context) (finalErr error) {
+	defer func() {
+		if finalErr != nil {
+			if !c.batched {
+				c.lock.Lock()
+				defer c.lock.Unlock()
+			}
+
+			if err := saveContainerError(c, finalErr); err != nil {
+				logrus.Debug(err)
+			}
+		}
+	}()
+
+	if c.config.Schedule == "" {
+		return fmt.Errorf("container %s is not scheduled: %w", c.ID(), define.ErrInvalidArg)
+	}
+	// Check for an active run before starting the dependencies, which is
+	// done unlocked, and again once locked.
+	state, err := c.State()
+	if err != nil {
+		return err
+	}
+	if scheduledRunActive(state) {
+		logrus.Infof("Skipping scheduled run of container %s: previous run is still active", c.ID())
+		return nil
+	}
+	if err := c.startAndWaitForDependencies(ctx, true); err != nil {
+		return err
+	}
+
+	if !c.batched {
+		c.lock.Lock()
+		defer c.lock.Unlock()
+
+		if err := c.syncContainer(); err != nil {
+			return err
+		}
+	}
+
+	if scheduledRunActive(c.state.State) {
+		logrus.Infof("Skipping scheduled run of container %s: previous run is still active", c.ID())
+		return nil
+	}
+
+	c.state.ScheduleLastRun = time.Now()
+	if err := c.save(); err != nil {
+		return err
+	}
+
+	if err := c.prepareToStart(ctx); err != nil {
+		return err
+	}
+	return c.start(ctx)
+}
+
+// scheduledRunActive returns whether a run of a scheduled container in the
+// given state is still active.
+func scheduledRunActive(state define.ContainerStatus) bool {
+	return state == define.ContainerStateRunning || state == define.ContainerStatePaused || state == define.ContainerStateStopping
+}
+
+// scheduleUnitName returns the name of the systemd timer starting the
+// container
+func (c *Container) scheduleUnitName() string {
+	return c.ID() + "-schedule"
+}
+
+// getScheduleInspect returns the schedule of the container as shown by
+// inspect, or nil if the container is not scheduled.
+func (c *Container) getScheduleInspect() *define.InspectContainerSchedule {
+	if c.config.Schedule == "" {
+		return nil
+	}
+	schedule := &define.InspectContainerSchedule{
+		Unit:    c.scheduleUnitName() + ".timer",
+		LastRun: c.state.ScheduleLastRun,
+	}
+	// The exit code belongs to the last run only if the container
+	// finished after it was started by the schedule.
+	if !c.state.ScheduleLastRun.IsZero() && !c.ensureState(define.ContainerStateRunning, define.ContainerStatePaused, define.ContainerStateStopping) &&
+		c.state.FinishedTime.After(c.state.ScheduleLastRun) {
+		exitCode := c.state.ExitCode
+		schedule.LastExitCode = &exitCode
+	}
+	nextRun, err := c.scheduleNextRun()
+	if err != nil {
+		logrus.Debugf("Retrieving next scheduled run of container %s: %v", c.ID(), err)
+	}
+	schedule.NextRun = nextRun
+	return schedule
+}
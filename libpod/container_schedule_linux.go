@@ -0,0 +1,53 @@
+//go:build !remote && systemd
+
+package libpod
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	systemdCommon "github.com/containers/common/pkg/systemd"
+	"github.com/containers/podman/v4/pkg/systemd"
+)
+
+// createScheduleTimer creates the systemd timer starting the container on
+// its schedule
+func (c *Container) createScheduleTimer() error {
+	if !systemdCommon.RunsOnSystemd() {
+		return errors.New("scheduling containers requires systemd")
+	}
+	return c.createTransientTimer(c.scheduleUnitName(), "--on-calendar="+c.config.Schedule, "container", "schedule", "run", c.ID())
+}
+
+// removeScheduleTimer removes the systemd timer and unit of the schedule of
+// the container
+func (c *Container) removeScheduleTimer(ctx context.Context) error {
+	if !systemdCommon.RunsOnSystemd() {
+		return nil
+	}
+	return removeTransientUnit(ctx, c.scheduleUnitName())
+}
+
+// scheduleNextRun returns when the timer of the container elapses next
+func (c *Container) scheduleNextRun() (time.Time, error) {
+	if !systemdCommon.RunsOnSystemd() {
+		return time.Time{}, nil
+	}
+	conn, err := systemd.ConnectToDBUS()
+	if err != nil {
+		return time.Time{}, fmt.Errorf("unable to get systemd connection: %w", err)
+	}
+	defer conn.Close()
+
+	prop, err := conn.GetUnitTypePropertyContext(context.Background(), c.scheduleUnitName()+".timer", "Timer", "NextElapseUSecRealtime")
+	if err != nil {
+		return time.Time{}, err
+	}
+	usec, ok := prop.Value.Value().(uint64)
+	if !ok || usec == 0 {
+		return time.Time{}, nil
+	}
+	return time.UnixMicro(int64(usec)), nil
+}
@@ -0,0 +1,26 @@
+//go:build !remote && (!linux || !systemd)
+
+package libpod
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// createScheduleTimer creates the systemd timer starting the container on
+// its schedule
+func (c *Container) createScheduleTimer() error {
+	return errors.New("scheduling containers requires systemd")
+}
+
+// removeScheduleTimer removes the systemd timer and unit of the schedule of
+// the container
+func (c *Container) removeScheduleTimer(ctx context.Context) error {
+	return nil
+}
+
+// scheduleNextRun returns when the timer of the container elapses next
+func (c *Container) scheduleNextRun() (time.Time, error) {
+	return time.Time{}, nil
+}
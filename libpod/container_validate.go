@@ -5,6 +5,7 @@ package libpod
 import (
 	"fmt"
 
+	"github.com/blang/semver/v4"
 	"github.com/containers/image/v5/docker"
 	"github.com/containers/image/v5/pkg/shortnames"
 	"github.com/containers/image/v5/transports/alltransports"
@@ -151,11 +152,20 @@ func (c *Container) validate() error {
 		// TODO: we cannot reference pkg/autoupdate here due to
 		// circular dependencies.  It's worth considering moving the
 		// auto-update logic into the libpod package.
-		if value == "registry" || value == "image" {
+		if value == "registry" || value == "image" || value == "semver" {
 			if err := validateAutoUpdateImageReference(c.config.RawImageName); err != nil {
 				return err
 			}
 		}
+		if value == "semver" {
+			constraint, exists := c.config.Labels[define.AutoUpdateSemverLabel]
+			if !exists {
+				return fmt.Errorf("the semver auto-update policy requires the %s label", define.AutoUpdateSemverLabel)
+			}
+			if _, err := semver.ParseRange(constraint); err != nil {
+				return fmt.Errorf("invalid semver constraint %q: %w", constraint, err)
+			}
+		}
 	}
 
 	// Cannot set startup HC without a healthcheck
@@ -163,6 +173,31 @@ func (c *Container) validate() error {
 		return fmt.Errorf("cannot set a startup healthcheck when there is no regular healthcheck: %w", define.ErrInvalidArg)
 	}
 
+	// A scheduled container must survive its runs
+	if c.config.Schedule != "" && c.AutoRemove() {
+		return fmt.Errorf("cannot schedule a container that is removed when it exits: %w", define.ErrInvalidArg)
+	}
+
+	// Log rotation needs a log file with a size limit
+	if c.config.LogMaxFile > 1 {
+		switch c.config.LogDriver {
+		case define.KubernetesLogging, define.JSONLogging, "":
+		default:
+			return fmt.Errorf("max-file is only supported by the %s and %s log drivers: %w", define.KubernetesLogging, define.JSONLogging, define.ErrInvalidArg)
+		}
+		if c.logSizeMax() <= 0 {
+			return fmt.Errorf("max-file requires max-size to be set: %w", define.ErrInvalidArg)
+		}
+	}
+	if c.config.LogCompress && c.config.LogMaxFile < 3 {
+		return fmt.Errorf("compress requires max-file to be greater than 2: %w", define.ErrInvalidArg)
+	}
+
+	// Cannot gate on readiness without a readiness HC
+	if c.config.ReadinessGate && c.config.ReadinessHealthCheckConfig == nil {
+		return fmt.Errorf("cannot set a readiness gate when there is no readiness healthcheck: %w", define.ErrInvalidArg)
+	}
+
 	return nil
 }
 
@@ -141,6 +141,11 @@ const (
 	// which the checkpoint was created.
 	CheckpointAnnotationDistributionName = "io.podman.annotations.checkpoint.distribution.name"
 
+	// CheckpointAnnotationPodName is set as a label by Pod Checkpoint when
+	// creating a checkpoint image to specify the original human-readable
+	// name of the pod.  It marks an image as a pod checkpoint image.
+	CheckpointAnnotationPodName = "io.podman.annotations.checkpoint.pod.name"
+
 	// InitContainerType is used by play kube when playing a kube yaml to specify the type
 	// of the init container.
 	InitContainerType = "io.podman.annotations.init.container.type"
@@ -157,6 +162,13 @@ const (
 	// of the container
 	UlimitAnnotation = "io.podman.annotations.ulimit"
 
+	// RequiresAnnotation is used by kube play to specify the containers of
+	// the pod a container depends on, as a comma-separated list of
+	// container names, each optionally followed by a colon and the
+	// condition the dependency must meet before the container is started.
+	// It is set per container by appending "/<container name>" to the key.
+	RequiresAnnotation = "io.podman.annotations.requires"
+
 	// KubeHealthCheckAnnotation is used by kube play to tell podman that any health checks should follow
 	// the k8s behavior of waiting for the intialDelaySeconds to be over before updating the status
 	KubeHealthCheckAnnotation = "io.podman.annotations.kube.health.check"
@@ -7,3 +7,11 @@ const AutoUpdateLabel = "io.containers.autoupdate"
 // AutoUpdateAuthfileLabel denotes the container label key to specify authfile
 // in container labels.
 const AutoUpdateAuthfileLabel = "io.containers.autoupdate.authfile"
+
+// AutoUpdateSemverLabel denotes the container label key to specify the
+// semantic version constraint of the semver auto-update policy.
+const AutoUpdateSemverLabel = "io.containers.autoupdate.semver"
+
+// AutoUpdateWindowLabel denotes the container label key to specify the daily
+// maintenance windows in which a container may be updated.
+const AutoUpdateWindowLabel = "io.containers.autoupdate.window"
@@ -1,5 +1,7 @@
 package define
 
+import "time"
+
 // Valid restart policy types.
 const (
 	// RestartPolicyNone indicates that no restart policy has been requested
@@ -18,6 +20,21 @@ const (
 	RestartPolicyUnlessStopped = "unless-stopped"
 )
 
+// Defaults of the exponential backoff between restarts of a container by its
+// restart policy.
+const (
+	// DefaultRestartDelay is the delay before the second restart of a
+	// container which keeps crashing.  The first restart is immediate.
+	// The delay doubles with every restart.
+	DefaultRestartDelay = time.Second
+	// DefaultRestartMaxDelay is the maximum delay between restarts.
+	DefaultRestartMaxDelay = 5 * time.Minute
+	// RestartBackoffResetPeriod is the time a container must run before it
+	// is considered stable, and the delay before the next restart is
+	// reset.
+	RestartBackoffResetPeriod = 10 * time.Minute
+)
+
 // RestartPolicyMap maps between restart-policy valid values to restart policy types
 var RestartPolicyMap = map[string]string{
 	"none":                     RestartPolicyNone,
@@ -57,6 +57,16 @@ type InspectContainerConfig struct {
 	Healthcheck *manifest.Schema2HealthConfig `json:"Healthcheck,omitempty"`
 	// HealthcheckOnFailureAction defines an action to take once the container turns unhealthy.
 	HealthcheckOnFailureAction string `json:"HealthcheckOnFailureAction,omitempty"`
+	// Configured readiness healthcheck for the container
+	ReadinessHealthcheck *ReadinessHealthCheck `json:"ReadinessHealthcheck,omitempty"`
+	// Schedule is the systemd calendar expression the container is
+	// started on.
+	Schedule string `json:"Schedule,omitempty"`
+	// PostStartHook is the lifecycle hook run after the container started.
+	PostStartHook *LifecycleHook `json:"PostStartHook,omitempty"`
+	// PreStopHook is the lifecycle hook run before the container is
+	// stopped.
+	PreStopHook *LifecycleHook `json:"PreStopHook,omitempty"`
 	// CreateCommand is the full command plus arguments of the process the
 	// container has been created with.
 	CreateCommand []string `json:"CreateCommand,omitempty"`
@@ -101,6 +111,12 @@ type InspectRestartPolicy struct {
 	// "on-failure" restart policy is in use. Not used if "on-failure" is
 	// not set.
 	MaximumRetryCount uint `json:"MaximumRetryCount"`
+	// Delay is the delay before the second restart of a container which
+	// keeps crashing.  It doubles with every restart, up to MaxDelay.
+	// Not set if restarts are not delayed.
+	Delay time.Duration `json:"Delay,omitempty"`
+	// MaxDelay is the maximum delay between restarts.
+	MaxDelay time.Duration `json:"MaxDelay,omitempty"`
 }
 
 // InspectLogConfig holds information about a container's configured log driver
@@ -113,6 +129,10 @@ type InspectLogConfig struct {
 	Tag string `json:"Tag"`
 	// Size specifies a maximum size of the container log
 	Size string `json:"Size"`
+	// MaxFile is the number of log files kept when rotating the log
+	MaxFile uint `json:"MaxFile,omitempty"`
+	// Compress indicates that rotated log files are compressed
+	Compress bool `json:"Compress,omitempty"`
 }
 
 // InspectBlkioWeightDevice holds information about the relative weight
@@ -210,7 +230,7 @@ type InspectContainerState struct {
 	Status         string             `json:"Status"`
 	Running        bool               `json:"Running"`
 	Paused         bool               `json:"Paused"`
-	Restarting     bool               `json:"Restarting"` // TODO
+	Restarting     bool               `json:"Restarting"`
 	OOMKilled      bool               `json:"OOMKilled"`
 	Dead           bool               `json:"Dead"`
 	Pid            int                `json:"Pid"`
@@ -229,6 +249,29 @@ type InspectContainerState struct {
 	RestoreLog     string             `json:"RestoreLog,omitempty"`
 	Restored       bool               `json:"Restored,omitempty"`
 	StoppedByUser  bool               `json:"StoppedByUser,omitempty"`
+	// Ready is set if the container is running and its readiness
+	// healthcheck, if any, passed.
+	Ready bool `json:"Ready"`
+	// CrashLoop is set if the container keeps crashing, and waits to be
+	// restarted by its restart policy.
+	CrashLoop bool `json:"CrashLoop,omitempty"`
+	// Schedule describes the runs of a scheduled container.
+	Schedule *InspectContainerSchedule `json:"Schedule,omitempty"`
+}
+
+// InspectContainerSchedule describes the runs of a container started by a
+// systemd timer on a schedule.
+type InspectContainerSchedule struct {
+	// Unit is the name of the systemd timer.
+	Unit string `json:"Unit"`
+	// LastRun is the time the timer last started the container.
+	LastRun time.Time `json:"LastRun"`
+	// LastExitCode is the exit code of the last run.  It is not set if
+	// the container never ran or the last run is still active.
+	LastExitCode *int32 `json:"LastExitCode,omitempty"`
+	// NextRun is the time the timer starts the container next.  It is
+	// zero if unknown.
+	NextRun time.Time `json:"NextRun"`
 }
 
 // Healthcheck returns the HealthCheckResults. This is used for old podman compat
@@ -690,6 +733,7 @@ type InspectContainerData struct {
 	SizeRootFs              int64                       `json:"SizeRootFs,omitempty"`
 	Mounts                  []InspectMount              `json:"Mounts"`
 	Dependencies            []string                    `json:"Dependencies"`
+	DependencyConditions    map[string]string           `json:"DependencyConditions,omitempty"`
 	NetworkSettings         *InspectNetworkSettings     `json:"NetworkSettings"`
 	Namespace               string                      `json:"Namespace"`
 	IsInfra                 bool                        `json:"IsInfra"`
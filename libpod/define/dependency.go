@@ -0,0 +1,46 @@
+package define
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DependencyCondition is the condition a dependency container must meet
+// before the containers depending on it are started.
+type DependencyCondition string
+
+const (
+	// DependencyConditionStarted requires the dependency to be running.
+	// It is the default condition.
+	DependencyConditionStarted DependencyCondition = "started"
+	// DependencyConditionHealthy requires the healthcheck of the
+	// dependency to report it as healthy.
+	DependencyConditionHealthy DependencyCondition = "healthy"
+	// DependencyConditionCompletedSuccessfully requires the dependency to
+	// have exited with exit code 0.
+	DependencyConditionCompletedSuccessfully DependencyCondition = "completed-successfully"
+
+	// DefaultDependencyTimeout is the default time in seconds to wait for
+	// the dependencies of a container to meet their conditions.
+	DefaultDependencyTimeout = 300
+)
+
+// ParseDependency parses a dependency of the form NAME[:CONDITION] into the
+// name or ID of the dependency container and its condition.  The condition
+// defaults to DependencyConditionStarted.
+func ParseDependency(dep string) (string, DependencyCondition, error) {
+	name, condition, hasCondition := strings.Cut(dep, ":")
+	if name == "" {
+		return "", "", fmt.Errorf("invalid dependency %q: missing container name or ID: %w", dep, ErrInvalidArg)
+	}
+	if !hasCondition {
+		return name, DependencyConditionStarted, nil
+	}
+	switch c := DependencyCondition(condition); c {
+	case DependencyConditionStarted, DependencyConditionHealthy, DependencyConditionCompletedSuccessfully:
+		return name, c, nil
+	default:
+		return "", "", fmt.Errorf("invalid condition %q of dependency %s: must be one of %s, %s or %s: %w", condition, name,
+			DependencyConditionStarted, DependencyConditionHealthy, DependencyConditionCompletedSuccessfully, ErrInvalidArg)
+	}
+}
@@ -17,6 +17,9 @@ var (
 
 	// ErrNoSuchVolume indicates the requested volume does not exist
 	ErrNoSuchVolume = errors.New("no such volume")
+	// ErrNoSuchSnapshot indicates the requested volume snapshot does not
+	// exist
+	ErrNoSuchSnapshot = errors.New("no such snapshot")
 
 	// ErrNoSuchNetwork indicates the requested network does not exist
 	ErrNoSuchNetwork = types.ErrNoSuchNetwork
@@ -50,6 +53,9 @@ var (
 	ErrImageExists = errors.New("image already exists")
 	// ErrVolumeExists indicates a volume with the same name already exists
 	ErrVolumeExists = errors.New("volume already exists")
+	// ErrSnapshotExists indicates a snapshot of a volume with the same name
+	// already exists
+	ErrSnapshotExists = errors.New("snapshot already exists")
 	// ErrExecSessionExists indicates an exec session with the same ID
 	// already exists.
 	ErrExecSessionExists = errors.New("exec session already exists")
@@ -204,6 +210,10 @@ var (
 	// for the container
 	ErrSecurityAttribute = fmt.Errorf("%w: unable to process security attribute", ErrOCIRuntime)
 
+	// ErrDependencyTimeout indicates that a dependency of a container did
+	// not meet its condition in time for the container to be started.
+	ErrDependencyTimeout = errors.New("timed out waiting for dependency")
+
 	// ErrCanceled indicates that an operation has been cancelled by a user.
 	// Useful for potentially long running tasks.
 	ErrCanceled = errors.New("cancelled by user")
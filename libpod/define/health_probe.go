@@ -0,0 +1,242 @@
+package define
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// HealthConfig.Test options for probes which Podman runs from the host within
+// the network namespace of the container.  Unlike commands they do not
+// require any tooling inside the image.
+const (
+	// HealthConfigTestHTTPGet sends an HTTP GET request to a URL
+	HealthConfigTestHTTPGet = "HTTP-GET"
+	// HealthConfigTestTCP opens a TCP connection to an address
+	HealthConfigTestTCP = "TCP"
+	// HealthConfigTestGRPC calls the standard gRPC health checking service
+	HealthConfigTestGRPC = "GRPC"
+)
+
+const (
+	// DefaultHealthProbeHTTPStatus are the HTTP status codes considered
+	// healthy by default.  Matches the behavior of Kubernetes.
+	DefaultHealthProbeHTTPStatus = "200-399"
+
+	healthProbeStatusPrefix  = "status="
+	healthProbeHeaderPrefix  = "header="
+	healthProbeServicePrefix = "service="
+)
+
+// HealthProbe is a healthcheck that is run by Podman rather than executed as
+// a command inside the container.  It is stored in the Test of a healthcheck
+// config, e.g. ["HTTP-GET", "http://localhost:8080/healthz", "status=200"].
+type HealthProbe struct {
+	// Type is HealthConfigTestHTTPGet, HealthConfigTestTCP or
+	// HealthConfigTestGRPC.
+	Type string
+	// Target is the URL of HTTP probes and the host:port address of TCP
+	// and gRPC probes.
+	Target string
+	// Status lists the HTTP status codes and ranges considered healthy,
+	// e.g. "200-299,304".  Defaults to DefaultHealthProbeHTTPStatus.
+	Status string
+	// Headers are additional HTTP request headers in "Name: value" form.
+	Headers []string
+	// Service is the name of the gRPC service to check.  The server as a
+	// whole is checked if empty.
+	Service string
+}
+
+// NewHTTPGetHealthProbe returns a probe sending a GET request to the
+// specified http(s) URL.  status and headers are optional.
+func NewHTTPGetHealthProbe(rawURL, status string, headers []string) (*HealthProbe, error) {
+	probe := &HealthProbe{Type: HealthConfigTestHTTPGet, Target: rawURL, Status: status, Headers: headers}
+	if err := probe.Validate(); err != nil {
+		return nil, err
+	}
+	return probe, nil
+}
+
+// NewTCPHealthProbe returns a probe connecting to the specified
+// [HOST:]PORT address.  The host defaults to localhost.
+func NewTCPHealthProbe(address string) (*HealthProbe, error) {
+	target, err := parseHealthProbeAddress(address)
+	if err != nil {
+		return nil, err
+	}
+	return &HealthProbe{Type: HealthConfigTestTCP, Target: target}, nil
+}
+
+// NewGRPCHealthProbe returns a probe calling the gRPC health service at the
+// specified [HOST:]PORT[/SERVICE] address.  The host defaults to localhost.
+func NewGRPCHealthProbe(address string) (*HealthProbe, error) {
+	address, service, _ := strings.Cut(address, "/")
+	target, err := parseHealthProbeAddress(address)
+	if err != nil {
+		return nil, err
+	}
+	return &HealthProbe{Type: HealthConfigTestGRPC, Target: target, Service: service}, nil
+}
+
+// ParseHealthProbe parses the Test of a healthcheck config.  nil is returned
+// if the test is not a probe but, for instance, a command.
+func ParseHealthProbe(test []string) (*HealthProbe, error) {
+	if len(test) < 1 {
+		return nil, nil
+	}
+	switch test[0] {
+	case HealthConfigTestHTTPGet, HealthConfigTestTCP, HealthConfigTestGRPC:
+	default:
+		return nil, nil
+	}
+	if len(test) < 2 {
+		return nil, fmt.Errorf("%w: %s healthcheck requires a target", ErrInvalidArg, test[0])
+	}
+
+	probe := &HealthProbe{Type: test[0], Target: test[1]}
+	for _, opt := range test[2:] {
+		switch {
+		case strings.HasPrefix(opt, healthProbeStatusPrefix):
+			probe.Status = strings.TrimPrefix(opt, healthProbeStatusPrefix)
+		case strings.HasPrefix(opt, healthProbeHeaderPrefix):
+			probe.Headers = append(probe.Headers, strings.TrimPrefix(opt, healthProbeHeaderPrefix))
+		case strings.HasPrefix(opt, healthProbeServicePrefix):
+			probe.Service = strings.TrimPrefix(opt, healthProbeServicePrefix)
+		default:
+			return nil, fmt.Errorf("%w: unknown option %q for %s healthcheck", ErrInvalidArg, opt, test[0])
+		}
+	}
+	if err := probe.Validate(); err != nil {
+		return nil, err
+	}
+	return probe, nil
+}
+
+// Test returns the representation of the probe in the Test of a healthcheck
+// config.
+func (p *HealthProbe) Test() []string {
+	test := []string{p.Type, p.Target}
+	if p.Status != "" {
+		test = append(test, healthProbeStatusPrefix+p.Status)
+	}
+	for _, header := range p.Headers {
+		test = append(test, healthProbeHeaderPrefix+header)
+	}
+	if p.Service != "" {
+		test = append(test, healthProbeServicePrefix+p.Service)
+	}
+	return test
+}
+
+// String returns a human-readable representation of the probe.
+func (p *HealthProbe) String() string {
+	if p.Service != "" {
+		return fmt.Sprintf("%s %s/%s", p.Type, p.Target, p.Service)
+	}
+	return fmt.Sprintf("%s %s", p.Type, p.Target)
+}
+
+// Validate checks that the probe is well formed.
+func (p *HealthProbe) Validate() error {
+	switch p.Type {
+	case HealthConfigTestHTTPGet:
+		u, err := url.Parse(p.Target)
+		if err != nil {
+			return fmt.Errorf("%w: invalid healthcheck URL %q: %v", ErrInvalidArg, p.Target, err)
+		}
+		if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("%w: healthcheck URL %q must be an absolute http or https URL", ErrInvalidArg, p.Target)
+		}
+		if _, err := parseHTTPStatusRanges(p.Status); err != nil {
+			return err
+		}
+		for _, header := range p.Headers {
+			if _, _, err := ParseHealthProbeHeader(header); err != nil {
+				return err
+			}
+		}
+	case HealthConfigTestTCP, HealthConfigTestGRPC:
+		if _, _, err := net.SplitHostPort(p.Target); err != nil {
+			return fmt.Errorf("%w: invalid healthcheck address %q: %v", ErrInvalidArg, p.Target, err)
+		}
+	default:
+		return fmt.Errorf("%w: unknown healthcheck probe type %q", ErrInvalidArg, p.Type)
+	}
+	return nil
+}
+
+// StatusOK returns whether the specified HTTP status code is considered
+// healthy by the probe.
+func (p *HealthProbe) StatusOK(code int) bool {
+	ranges, err := parseHTTPStatusRanges(p.Status)
+	if err != nil {
+		return false
+	}
+	for _, r := range ranges {
+		if code >= r[0] && code <= r[1] {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseHealthProbeHeader splits a "Name: value" HTTP header.
+func ParseHealthProbeHeader(header string) (string, string, error) {
+	name, value, found := strings.Cut(header, ":")
+	name = strings.TrimSpace(name)
+	if !found || name == "" || strings.ContainsAny(name, " \t") {
+		return "", "", fmt.Errorf("%w: invalid healthcheck header %q, must be in the form \"Name: value\"", ErrInvalidArg, header)
+	}
+	return name, strings.TrimSpace(value), nil
+}
+
+// parseHTTPStatusRanges parses a comma-separated list of HTTP status codes
+// and ranges such as "200-299,304" into inclusive ranges.
+func parseHTTPStatusRanges(status string) ([][2]int, error) {
+	if status == "" {
+		status = DefaultHealthProbeHTTPStatus
+	}
+	var ranges [][2]int
+	for _, field := range strings.Split(status, ",") {
+		lowStr, highStr, isRange := strings.Cut(strings.TrimSpace(field), "-")
+		if !isRange {
+			highStr = lowStr
+		}
+		low, err := strconv.Atoi(lowStr)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid healthcheck HTTP status %q", ErrInvalidArg, field)
+		}
+		high, err := strconv.Atoi(highStr)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid healthcheck HTTP status %q", ErrInvalidArg, field)
+		}
+		if low < 100 || high > 599 || low > high {
+			return nil, fmt.Errorf("%w: invalid healthcheck HTTP status %q", ErrInvalidArg, field)
+		}
+		ranges = append(ranges, [2]int{low, high})
+	}
+	return ranges, nil
+}
+
+// parseHealthProbeAddress turns a [HOST:]PORT address into HOST:PORT.
+func parseHealthProbeAddress(address string) (string, error) {
+	host, port := "localhost", address
+	if strings.Contains(address, ":") {
+		var err error
+		host, port, err = net.SplitHostPort(address)
+		if err != nil {
+			return "", fmt.Errorf("%w: invalid healthcheck address %q: %v", ErrInvalidArg, address, err)
+		}
+		if host == "" {
+			host = "localhost"
+		}
+	}
+	portNum, err := strconv.ParseUint(port, 10, 16)
+	if err != nil || portNum == 0 {
+		return "", fmt.Errorf("%w: invalid port in healthcheck address %q", ErrInvalidArg, address)
+	}
+	return net.JoinHostPort(host, port), nil
+}
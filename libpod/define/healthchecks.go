@@ -16,6 +16,12 @@ const (
 	// and the start-period (time allowed for the container to start and application
 	// to be running) expires.
 	HealthCheckStarting string = "starting"
+	// HealthCheckReady describes a container whose readiness healthcheck
+	// passed, so it is ready to receive traffic
+	HealthCheckReady string = "ready"
+	// HealthCheckUnready describes a container whose readiness healthcheck
+	// has not passed (yet), so it should not receive traffic
+	HealthCheckUnready string = "unready"
 )
 
 // HealthCheckStatus represents the current state of a container
@@ -145,3 +151,14 @@ type StartupHealthCheck struct {
 	// If set to 0, a single success will mark the HC as passed.
 	Successes int `json:",omitempty"`
 }
+
+// ReadinessHealthCheck is the configuration of a readiness healthcheck.
+// Unlike the regular healthcheck it never acts on the container; it only
+// tracks whether the container is ready to receive traffic.
+type ReadinessHealthCheck struct {
+	manifest.Schema2HealthConfig
+	// Successes are the number of consecutive successes required to mark
+	// the container as ready.
+	// If set to 0, a single success will mark the container as ready.
+	Successes int `json:",omitempty"`
+}
@@ -0,0 +1,71 @@
+package define
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// Names of the supported container lifecycle hooks.
+const (
+	// LifecycleHookPostStart is run right after the container started.
+	LifecycleHookPostStart = "poststart"
+	// LifecycleHookPreStop is run before the stop signal is sent to the
+	// container.
+	LifecycleHookPreStop = "prestop"
+)
+
+// LifecycleHook is a handler that is run at a certain point in the
+// lifecycle of a container.  Exactly one of Exec or HTTPGet is set.
+// Not to be confused with OCI hooks which are run by the OCI runtime.
+type LifecycleHook struct {
+	// Exec is the command to execute inside the container.
+	Exec []string `json:"exec,omitempty"`
+	// HTTPGet is the URL to send a GET request to from within the
+	// network namespace of the container.
+	HTTPGet string `json:"httpGet,omitempty"`
+}
+
+// String returns a human-readable representation of the hook.
+func (h *LifecycleHook) String() string {
+	if h.HTTPGet != "" {
+		return h.HTTPGet
+	}
+	return strings.Join(h.Exec, " ")
+}
+
+// ParseLifecycleHook parses the specified string into a LifecycleHook.  An
+// http:// or https:// URL is turned into an HTTP GET handler.  Everything
+// else is treated as a command which is either a JSON array or a string
+// executed via `/bin/sh -c`.
+func ParseLifecycleHook(s string) (*LifecycleHook, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil, fmt.Errorf("%w: lifecycle hook must not be empty", ErrInvalidArg)
+	}
+
+	if strings.HasPrefix(s, "http://") || strings.HasPrefix(s, "https://") {
+		u, err := url.Parse(s)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid lifecycle hook URL %q: %v", ErrInvalidArg, s, err)
+		}
+		if u.Host == "" {
+			return nil, fmt.Errorf("%w: lifecycle hook URL %q has no host", ErrInvalidArg, s)
+		}
+		return &LifecycleHook{HTTPGet: u.String()}, nil
+	}
+
+	if strings.HasPrefix(s, "[") {
+		var cmd []string
+		if err := json.Unmarshal([]byte(s), &cmd); err != nil {
+			return nil, fmt.Errorf("%w: invalid lifecycle hook command %q: %v", ErrInvalidArg, s, err)
+		}
+		if len(cmd) == 0 {
+			return nil, fmt.Errorf("%w: lifecycle hook command must not be empty", ErrInvalidArg)
+		}
+		return &LifecycleHook{Exec: cmd}, nil
+	}
+
+	return &LifecycleHook{Exec: []string{"/bin/sh", "-c", s}}, nil
+}
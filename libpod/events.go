@@ -5,10 +5,15 @@ package libpod
 import (
 	"context"
 	"fmt"
+	"os"
 	"path/filepath"
+	"strings"
 	"sync"
 
+	"github.com/containers/common/pkg/config"
+	"github.com/containers/podman/v4/libpod/define"
 	"github.com/containers/podman/v4/libpod/events"
+	"github.com/containers/podman/v4/pkg/rootless"
 	"github.com/sirupsen/logrus"
 )
 
@@ -23,7 +28,46 @@ func (r *Runtime) newEventer() (events.Eventer, error) {
 		LogFilePath:    r.config.Engine.EventsLogFilePath,
 		LogFileMaxSize: r.config.Engine.EventsLogMaxSize(),
 	}
-	return events.NewEventer(options)
+	eventer, err := events.NewEventer(options)
+	if err != nil {
+		return nil, err
+	}
+
+	sinks, err := events.ReadSinkConfigs(r.eventSinkConfigFiles())
+	if err != nil {
+		return nil, err
+	}
+	return events.NewSinkEventer(eventer, sinks)
+}
+
+// eventSinkConfigFiles returns the containers.conf files in the order they
+// are merged by containers/common, which does not know about event sinks.
+func (r *Runtime) eventSinkConfigFiles() []string {
+	var files []string
+	if path := os.Getenv("CONTAINERS_CONF"); path != "" {
+		files = append(files, path)
+	} else {
+		files = append(files, config.DefaultContainersConfig, config.OverrideContainersConfig)
+		files = append(files, confDirFiles(config.OverrideContainersConfig+".d")...)
+		if rootless.IsRootless() {
+			if path := config.Path(); !strings.HasPrefix(path, "$HOME") {
+				files = append(files, path)
+				files = append(files, confDirFiles(path+".d")...)
+			}
+		}
+	}
+	files = append(files, r.config.LoadedModules()...)
+	if path := os.Getenv("CONTAINERS_CONF_OVERRIDE"); path != "" {
+		files = append(files, path)
+	}
+	return files
+}
+
+// confDirFiles returns the *.conf files in the specified directory in
+// lexical order.
+func confDirFiles(dir string) []string {
+	files, _ := filepath.Glob(filepath.Join(dir, "*.conf"))
+	return files
 }
 
 // newContainerEvent creates a new event based on a container
@@ -76,6 +120,13 @@ func (c *Container) newContainerEventWithInspectData(status events.Status, inspe
 		e.HealthStatus = containerHealthStatus
 	}
 
+	if status == events.ReadinessStatus {
+		e.ReadinessStatus = define.HealthCheckUnready
+		if c.ready() {
+			e.ReadinessStatus = define.HealthCheckReady
+		}
+	}
+
 	if status == events.Remove {
 		exitCode, err := c.runtime.state.GetContainerExitCode(c.ID())
 		if err == nil {
@@ -130,6 +181,32 @@ func (c *Container) newExecDiedEvent(sessionID string, exitCode int) {
 	}
 }
 
+// newLifecycleHookFailedEvent creates a new event for a failed lifecycle hook
+func (c *Container) newLifecycleHookFailedEvent(hook string, hookErr error) {
+	e := events.NewEvent(events.HookFailed)
+	e.ID = c.ID()
+	e.Name = c.Name()
+	e.Image = c.config.RootfsImageName
+	e.Type = events.Container
+
+	attributes := make(map[string]string)
+	for k, v := range c.Labels() {
+		attributes[k] = v
+	}
+	attributes["hook"] = hook
+	attributes["error"] = hookErr.Error()
+
+	e.Details = events.Details{
+		ID:         e.ID,
+		PodID:      c.PodID(),
+		Attributes: attributes,
+	}
+
+	if err := c.runtime.eventer.Write(e); err != nil {
+		logrus.Errorf("Unable to write hook failed event: %q", err)
+	}
+}
+
 // netNetworkEvent creates a new event based on a network connect/disconnect
 func (c *Container) newNetworkEvent(status events.Status, netName string) {
 	e := events.NewEvent(status)
@@ -163,6 +240,19 @@ func (r *Runtime) NewSystemEvent(status events.Status) {
 	}
 }
 
+// NewSecretEvent creates a new event for a secret.  Secrets are managed
+// outside of libpod, so the caller has to provide the ID and name.
+func (r *Runtime) NewSecretEvent(status events.Status, id, name string) {
+	e := events.NewEvent(status)
+	e.ID = id
+	e.Name = name
+	e.Type = events.Secret
+
+	if err := r.eventer.Write(e); err != nil {
+		logrus.Errorf("Unable to write secret event: %q", err)
+	}
+}
+
 // newVolumeEvent creates a new event for a libpod volume
 func (v *Volume) newVolumeEvent(status events.Status) {
 	e := events.NewEvent(status)
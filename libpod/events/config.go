@@ -41,6 +41,8 @@ type Event struct {
 	Type Type
 	// Health status of the current container
 	HealthStatus string `json:"health_status,omitempty"`
+	// Readiness status of the current container
+	ReadinessStatus string `json:"readiness_status,omitempty"`
 
 	Details
 }
@@ -116,6 +118,8 @@ const (
 	Network Type = "network"
 	// Pod - event is related to pods
 	Pod Type = "pod"
+	// Secret - event is related to secrets
+	Secret Type = "secret"
 	// System - event is related to Podman whole and not to any specific
 	// container/pod/image/volume
 	System Type = "system"
@@ -138,6 +142,9 @@ const (
 	Commit Status = "commit"
 	// Copy ...
 	Copy Status = "copy"
+	// CrashLoop indicates that a container keeps crashing, and waits before
+	// it is restarted by its restart policy.
+	CrashLoop Status = "crashloop"
 	// Create ...
 	Create Status = "create"
 	// Exec ...
@@ -152,6 +159,9 @@ const (
 	HealthStatus Status = "health_status"
 	// History ...
 	History Status = "history"
+	// HookFailed indicates that a container lifecycle hook (poststart or
+	// prestop) failed.
+	HookFailed Status = "hook_failed"
 	// Import ...
 	Import Status = "import"
 	// Init ...
@@ -174,6 +184,9 @@ const (
 	Pull Status = "pull"
 	// Push ...
 	Push Status = "push"
+	// ReadinessStatus indicates that the readiness healthcheck of a
+	// container ran.
+	ReadinessStatus Status = "readiness_status"
 	// Refresh indicates that the system refreshed the state after a
 	// reboot.
 	Refresh Status = "refresh"
@@ -206,6 +219,8 @@ const (
 	Unpause Status = "unpause"
 	// Untag ...
 	Untag Status = "untag"
+	// Update indicates that the target was updated in place.
+	Update Status = "update"
 )
 
 // EventFilter for filtering events
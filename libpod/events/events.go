@@ -79,6 +79,9 @@ func (e *Event) ToHumanReadable(truncate bool) string {
 		if e.HealthStatus != "" {
 			humanFormat += fmt.Sprintf(", health_status=%s", e.HealthStatus)
 		}
+		if e.ReadinessStatus != "" {
+			humanFormat += fmt.Sprintf(", readiness_status=%s", e.ReadinessStatus)
+		}
 		// check if the container has labels and add it to the output
 		if len(e.Attributes) > 0 {
 			for k, v := range e.Attributes {
@@ -88,7 +91,7 @@ func (e *Event) ToHumanReadable(truncate bool) string {
 		humanFormat += ")"
 	case Network:
 		humanFormat = fmt.Sprintf("%s %s %s %s (container=%s, name=%s)", e.Time, e.Type, e.Status, id, id, e.Network)
-	case Image:
+	case Image, Secret:
 		humanFormat = fmt.Sprintf("%s %s %s %s %s", e.Time, e.Type, e.Status, id, e.Name)
 	case System:
 		if e.Name != "" {
@@ -135,6 +138,8 @@ func StringToType(name string) (Type, error) {
 		return Network, nil
 	case Pod.String():
 		return Pod, nil
+	case Secret.String():
+		return Secret, nil
 	case System.String():
 		return System, nil
 	case Volume.String():
@@ -160,6 +165,8 @@ func StringToStatus(name string) (Status, error) {
 		return Cleanup, nil
 	case Commit.String():
 		return Commit, nil
+	case CrashLoop.String():
+		return CrashLoop, nil
 	case Create.String():
 		return Create, nil
 	case Exec.String():
@@ -174,6 +181,8 @@ func StringToStatus(name string) (Status, error) {
 		return HealthStatus, nil
 	case History.String():
 		return History, nil
+	case HookFailed.String():
+		return HookFailed, nil
 	case Import.String():
 		return Import, nil
 	case Init.String():
@@ -196,6 +205,8 @@ func StringToStatus(name string) (Status, error) {
 		return Pull, nil
 	case Push.String():
 		return Push, nil
+	case ReadinessStatus.String():
+		return ReadinessStatus, nil
 	case Refresh.String():
 		return Refresh, nil
 	case Remove.String():
@@ -226,6 +237,8 @@ func StringToStatus(name string) (Status, error) {
 		return Unpause, nil
 	case Untag.String():
 		return Untag, nil
+	case Update.String():
+		return Update, nil
 	}
 	return "", fmt.Errorf("unknown event status %q", name)
 }
@@ -27,6 +27,10 @@ func generateEventFilter(filter, filterValue string) (func(e *Event) bool, error
 		return func(e *Event) bool {
 			return string(e.Status) == filterValue
 		}, nil
+	case "HEALTH_STATUS":
+		return func(e *Event) bool {
+			return e.HealthStatus == filterValue
+		}, nil
 	case "IMAGE":
 		return func(e *Event) bool {
 			if e.Type != Image {
@@ -47,6 +51,16 @@ func generateEventFilter(filter, filterValue string) (func(e *Event) bool, error
 			}
 			return strings.HasPrefix(e.ID, filterValue)
 		}, nil
+	case "SECRET":
+		return func(e *Event) bool {
+			if e.Type != Secret {
+				return false
+			}
+			if e.Name == filterValue {
+				return true
+			}
+			return strings.HasPrefix(e.ID, filterValue)
+		}, nil
 	case "VOLUME":
 		return func(e *Event) bool {
 			if e.Type != Volume {
@@ -40,7 +40,7 @@ func (e EventJournalD) Write(ee Event) error {
 
 	// Add specialized information based on the podman type
 	switch ee.Type {
-	case Image:
+	case Image, Secret:
 		m["PODMAN_NAME"] = ee.Name
 		m["PODMAN_ID"] = ee.ID
 	case Container, Pod:
@@ -63,6 +63,9 @@ func (e EventJournalD) Write(ee Event) error {
 			m["PODMAN_LABELS"] = string(b)
 		}
 		m["PODMAN_HEALTH_STATUS"] = ee.HealthStatus
+		if ee.ReadinessStatus != "" {
+			m["PODMAN_READINESS_STATUS"] = ee.ReadinessStatus
+		}
 
 		if len(ee.Details.ContainerInspectData) > 0 {
 			m["PODMAN_CONTAINER_INSPECT_DATA"] = ee.Details.ContainerInspectData
@@ -81,7 +84,7 @@ func (e EventJournalD) Write(ee Event) error {
 	// level. To fix this we have to send the event on notice level.
 	// https://github.com/containers/podman/issues/20342
 	prio := journal.PriInfo
-	if len(ee.HealthStatus) > 0 {
+	if len(ee.HealthStatus) > 0 || len(ee.ReadinessStatus) > 0 {
 		prio = journal.PriNotice
 	}
 
@@ -222,11 +225,12 @@ func newEventFromJournalEntry(entry *sdjournal.JournalEntry) (*Event, error) {
 			}
 		}
 		newEvent.HealthStatus = entry.Fields["PODMAN_HEALTH_STATUS"]
+		newEvent.ReadinessStatus = entry.Fields["PODMAN_READINESS_STATUS"]
 		newEvent.Details.ContainerInspectData = entry.Fields["PODMAN_CONTAINER_INSPECT_DATA"]
 	case Network:
 		newEvent.ID = entry.Fields["PODMAN_ID"]
 		newEvent.Network = entry.Fields["PODMAN_NETWORK_NAME"]
-	case Image:
+	case Image, Secret:
 		newEvent.ID = entry.Fields["PODMAN_ID"]
 	}
 	return &newEvent, nil
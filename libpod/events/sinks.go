@@ -0,0 +1,300 @@
+package events
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// WebhookSink POSTs events as JSON to an HTTP endpoint
+	WebhookSink = "webhook"
+	// ExecSink runs a command with the event as JSON on its stdin
+	ExecSink = "exec"
+
+	// SinkEnv is set in the environment of commands run by exec sinks to
+	// the name of the sink.  Events written by processes with the variable
+	// set are not delivered to any sink in order to avoid loops.
+	SinkEnv = "PODMAN_EVENT_SINK"
+
+	defaultSinkRetries    = 3
+	defaultSinkRetryDelay = time.Second
+	defaultSinkTimeout    = 5 * time.Second
+
+	// sinkDrainTimeout limits how long Close waits for pending
+	// deliveries to all sinks.
+	sinkDrainTimeout = 10 * time.Second
+)
+
+// SinkConfig describes an event sink as configured in an
+// [engine.event_sinks.NAME] table of containers.conf.
+type SinkConfig struct {
+	// Type is either WebhookSink or ExecSink.
+	Type string `toml:"type"`
+	// URL is the endpoint of webhook sinks.
+	URL string `toml:"url"`
+	// Headers are additional HTTP headers of webhook sinks in
+	// "Name: value" form.
+	Headers []string `toml:"headers"`
+	// Command is the command and its arguments run by exec sinks.
+	Command []string `toml:"command"`
+	// Filters select the events delivered to the sink, using the syntax
+	// of `podman events --filter`.  All events are delivered if empty.
+	Filters []string `toml:"filters"`
+	// Retries is the number of times a failed delivery is retried.
+	// Defaults to 3.
+	Retries *uint `toml:"retries"`
+	// RetryDelay is the delay before the first retry, it doubles with each
+	// further retry.  Defaults to 1s.
+	RetryDelay string `toml:"retry_delay"`
+	// Timeout limits a single delivery attempt.  Defaults to 5s.
+	Timeout string `toml:"timeout"`
+}
+
+// sinkConfigFile is the part of containers.conf holding the sinks, which
+// containers/common does not know about.
+type sinkConfigFile struct {
+	Engine struct {
+		EventSinks map[string]SinkConfig `toml:"event_sinks"`
+	} `toml:"engine"`
+}
+
+// ReadSinkConfigs reads the event sinks, mapped by their names, from the
+// specified containers.conf files.  The files are read in order and sinks in
+// later files replace sinks of the same name in earlier ones.  Missing files
+// are skipped.
+func ReadSinkConfigs(paths []string) (map[string]SinkConfig, error) {
+	sinks := make(map[string]SinkConfig)
+	for _, path := range paths {
+		var file sinkConfigFile
+		if _, err := toml.DecodeFile(path, &file); err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				continue
+			}
+			return nil, fmt.Errorf("decode event sinks of %v: %w", path, err)
+		}
+		for name, sink := range file.Engine.EventSinks {
+			sinks[name] = sink
+		}
+	}
+	return sinks, nil
+}
+
+// eventSink delivers events to a webhook or a command.
+type eventSink struct {
+	name       string
+	config     SinkConfig
+	filterMap  map[string][]EventFilter
+	retries    uint
+	retryDelay time.Duration
+	timeout    time.Duration
+	headers    http.Header
+}
+
+func newEventSink(name string, config SinkConfig) (*eventSink, error) {
+	if name == "" {
+		return nil, errors.New("event sink without a name")
+	}
+	sink := &eventSink{
+		name:       name,
+		config:     config,
+		retries:    defaultSinkRetries,
+		retryDelay: defaultSinkRetryDelay,
+		timeout:    defaultSinkTimeout,
+		headers:    make(http.Header),
+	}
+
+	switch config.Type {
+	case WebhookSink:
+		u, err := url.Parse(config.URL)
+		if err != nil {
+			return nil, fmt.Errorf("event sink %q: invalid url: %w", name, err)
+		}
+		if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return nil, fmt.Errorf("event sink %q: url %q must be an absolute http or https URL", name, config.URL)
+		}
+		for _, header := range config.Headers {
+			key, value, found := strings.Cut(header, ":")
+			if !found || strings.TrimSpace(key) == "" {
+				return nil, fmt.Errorf("event sink %q: invalid header %q, must be in the form \"Name: value\"", name, header)
+			}
+			sink.headers.Add(strings.TrimSpace(key), strings.TrimSpace(value))
+		}
+	case ExecSink:
+		if len(config.Command) == 0 {
+			return nil, fmt.Errorf("event sink %q: command must be set", name)
+		}
+	default:
+		return nil, fmt.Errorf("event sink %q: unknown type %q, must be %q or %q", name, config.Type, WebhookSink, ExecSink)
+	}
+
+	filterMap, err := generateEventFilters(config.Filters, "", "")
+	if err != nil {
+		return nil, fmt.Errorf("event sink %q: %w", name, err)
+	}
+	sink.filterMap = filterMap
+
+	if config.Retries != nil {
+		sink.retries = *config.Retries
+	}
+	if config.RetryDelay != "" {
+		if sink.retryDelay, err = time.ParseDuration(config.RetryDelay); err != nil {
+			return nil, fmt.Errorf("event sink %q: invalid retry_delay: %w", name, err)
+		}
+	}
+	if config.Timeout != "" {
+		if sink.timeout, err = time.ParseDuration(config.Timeout); err != nil {
+			return nil, fmt.Errorf("event sink %q: invalid timeout: %w", name, err)
+		}
+	}
+	return sink, nil
+}
+
+// deliver sends the event to the sink, retrying with an exponential backoff
+// on failure.
+func (s *eventSink) deliver(event *Event, data []byte) {
+	delay := s.retryDelay
+	for attempt := uint(0); ; attempt++ {
+		err := s.send(data)
+		if err == nil {
+			return
+		}
+		if attempt >= s.retries {
+			logrus.Errorf("Delivering %s %s event to sink %q: %v", event.Type, event.Status, s.name, err)
+			return
+		}
+		logrus.Debugf("Delivering %s %s event to sink %q failed, retrying in %s: %v", event.Type, event.Status, s.name, delay, err)
+		time.Sleep(delay)
+		delay *= 2
+	}
+}
+
+func (s *eventSink) send(data []byte) error {
+	ctx := context.Background()
+	if s.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.timeout)
+		defer cancel()
+	}
+
+	if s.config.Type == ExecSink {
+		cmd := exec.CommandContext(ctx, s.config.Command[0], s.config.Command[1:]...)
+		cmd.Stdin = bytes.NewReader(data)
+		cmd.Env = append(os.Environ(), SinkEnv+"="+s.name)
+		if out, err := cmd.CombinedOutput(); err != nil {
+			if len(out) > 0 {
+				return fmt.Errorf("%w: %s", err, strings.TrimSpace(string(out)))
+			}
+			return err
+		}
+		return nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.config.URL, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header = s.headers.Clone()
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return nil
+}
+
+// SinkEventer is an eventer which, in addition to writing events to its
+// backend, delivers them to the configured event sinks.
+type SinkEventer struct {
+	Eventer
+	sinks   []*eventSink
+	pending sync.WaitGroup
+}
+
+// NewSinkEventer wraps the specified eventer to deliver written events to
+// the specified sinks, mapped by their names as in containers.conf.  The
+// eventer is returned as is if there are no sinks.
+func NewSinkEventer(eventer Eventer, configs map[string]SinkConfig) (Eventer, error) {
+	if len(configs) == 0 {
+		return eventer, nil
+	}
+	names := make([]string, 0, len(configs))
+	for name := range configs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	e := &SinkEventer{Eventer: eventer}
+	for _, name := range names {
+		sink, err := newEventSink(name, configs[name])
+		if err != nil {
+			return nil, err
+		}
+		e.sinks = append(e.sinks, sink)
+	}
+	return e, nil
+}
+
+// Write writes the event to the backend and delivers it in the background to
+// all sinks whose filters match the event.
+func (e *SinkEventer) Write(event Event) error {
+	err := e.Eventer.Write(event)
+
+	if _, isSink := os.LookupEnv(SinkEnv); isSink {
+		return err
+	}
+	var data []byte
+	for _, sink := range e.sinks {
+		if !applyFilters(&event, sink.filterMap) {
+			continue
+		}
+		if data == nil {
+			jsonStr, jsonErr := event.ToJSONString()
+			if jsonErr != nil {
+				logrus.Errorf("Marshalling event for sinks: %v", jsonErr)
+				return err
+			}
+			data = []byte(jsonStr + "\n")
+		}
+		e.pending.Add(1)
+		go func(sink *eventSink) {
+			defer e.pending.Done()
+			sink.deliver(&event, data)
+		}(sink)
+	}
+	return err
+}
+
+// Close waits for all pending deliveries to complete, but no longer than
+// sinkDrainTimeout in total.  Deliveries still pending by then are abandoned.
+func (e *SinkEventer) Close() error {
+	done := make(chan struct{})
+	go func() {
+		e.pending.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-time.After(sinkDrainTimeout):
+		return fmt.Errorf("not all events were delivered to the event sinks within %s", sinkDrainTimeout)
+	}
+}
@@ -0,0 +1,160 @@
+package events
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadSinkConfigs(t *testing.T) {
+	dir := t.TempDir()
+	first := filepath.Join(dir, "first.conf")
+	second := filepath.Join(dir, "second.conf")
+	require.NoError(t, os.WriteFile(first, []byte(`
+[engine]
+events_logger="file"
+
+[engine.event_sinks.alerts]
+type="webhook"
+url="http://localhost:8080/first"
+retries=5
+
+[engine.event_sinks.script]
+type="exec"
+command=["/usr/local/bin/on-event"]
+filters=["event=died"]
+`), 0644))
+	require.NoError(t, os.WriteFile(second, []byte(`
+[engine.event_sinks.alerts]
+type="webhook"
+url="http://localhost:8080/second"
+`), 0644))
+
+	sinks, err := ReadSinkConfigs([]string{first, filepath.Join(dir, "missing.conf"), second})
+	require.NoError(t, err)
+	require.Len(t, sinks, 2)
+	assert.Equal(t, "http://localhost:8080/second", sinks["alerts"].URL)
+	// The sink of the later file replaces the earlier one as a whole.
+	assert.Nil(t, sinks["alerts"].Retries)
+	assert.Equal(t, []string{"/usr/local/bin/on-event"}, sinks["script"].Command)
+	assert.Equal(t, []string{"event=died"}, sinks["script"].Filters)
+}
+
+func TestNewSinkEventerInvalid(t *testing.T) {
+	tests := []struct {
+		name     string
+		sinkName string
+		config   SinkConfig
+	}{
+		{"no name", "", SinkConfig{Type: ExecSink, Command: []string{"true"}}},
+		{"unknown type", "s", SinkConfig{Type: "mail"}},
+		{"relative url", "s", SinkConfig{Type: WebhookSink, URL: "/events"}},
+		{"invalid header", "s", SinkConfig{Type: WebhookSink, URL: "http://localhost", Headers: []string{"Authorization"}}},
+		{"no command", "s", SinkConfig{Type: ExecSink}},
+		{"invalid filter", "s", SinkConfig{Type: ExecSink, Command: []string{"true"}, Filters: []string{"foo=bar"}}},
+		{"invalid retry delay", "s", SinkConfig{Type: ExecSink, Command: []string{"true"}, RetryDelay: "soon"}},
+	}
+	for _, test := range tests {
+		_, err := NewSinkEventer(newNullEventer(), map[string]SinkConfig{test.sinkName: test.config})
+		assert.Error(t, err, test.name)
+	}
+}
+
+func TestSinkEventerWebhook(t *testing.T) {
+	var (
+		mutex    sync.Mutex
+		received []Event
+		requests int
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		requests++
+		// Fail the first request to exercise the retries.
+		if requests == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		var event Event
+		assert.NoError(t, json.Unmarshal(body, &event))
+		received = append(received, event)
+	}))
+	defer server.Close()
+
+	eventer, err := NewSinkEventer(newNullEventer(), map[string]SinkConfig{"webhook": {
+		Type:       WebhookSink,
+		URL:        server.URL,
+		Headers:    []string{"Authorization: Bearer secret"},
+		Filters:    []string{"event=died", "event=health_status", "health_status=unhealthy"},
+		RetryDelay: "1ms",
+	}})
+	require.NoError(t, err)
+
+	died := NewEvent(Exited)
+	died.Type = Container
+	died.Name = "died"
+	require.NoError(t, eventer.Write(died))
+
+	unhealthy := NewEvent(HealthStatus)
+	unhealthy.Type = Container
+	unhealthy.Name = "unhealthy"
+	unhealthy.HealthStatus = "unhealthy"
+	require.NoError(t, eventer.Write(unhealthy))
+
+	healthy := NewEvent(HealthStatus)
+	healthy.Type = Container
+	healthy.Name = "healthy"
+	healthy.HealthStatus = "healthy"
+	require.NoError(t, eventer.Write(healthy))
+
+	require.NoError(t, eventer.(io.Closer).Close())
+
+	mutex.Lock()
+	defer mutex.Unlock()
+	assert.Equal(t, 2, requests)
+	require.Len(t, received, 1)
+	assert.Equal(t, "unhealthy", received[0].Name)
+}
+
+func TestSinkEventerExec(t *testing.T) {
+	out := filepath.Join(t.TempDir(), "events")
+	eventer, err := NewSinkEventer(newNullEventer(), map[string]SinkConfig{"exec": {
+		Type:    ExecSink,
+		Command: []string{"sh", "-c", `cat >> "$1"; echo "$PODMAN_EVENT_SINK" >> "$1"`, "sh", out},
+		Filters: []string{"type=image"},
+	}})
+	require.NoError(t, err)
+
+	pull := NewEvent(Pull)
+	pull.Type = Image
+	pull.Name = "quay.io/libpod/alpine:latest"
+	require.NoError(t, eventer.Write(pull))
+
+	create := NewEvent(Create)
+	create.Type = Container
+	require.NoError(t, eventer.Write(create))
+
+	require.NoError(t, eventer.(io.Closer).Close())
+
+	data, err := os.ReadFile(out)
+	require.NoError(t, err)
+	var event Event
+	lines := string(data)
+	require.NoError(t, json.NewDecoder(strings.NewReader(lines)).Decode(&event))
+	assert.Equal(t, Pull, event.Status)
+	assert.Equal(t, "quay.io/libpod/alpine:latest", event.Name)
+	assert.Contains(t, lines, "\nexec\n")
+}
@@ -57,6 +57,132 @@ func (r *Runtime) HealthCheck(ctx context.Context, name string) (define.HealthCh
 	return hcStatus, err
 }
 
+// ReadinessCheck verifies that the readiness healthcheck of the container
+// can be run, executes it and updates the readiness of the container.
+// HealthCheckSuccess is returned if the container is ready.
+func (r *Runtime) ReadinessCheck(ctx context.Context, name string) (define.HealthCheckStatus, error) {
+	container, err := r.LookupContainer(name)
+	if err != nil {
+		return define.HealthCheckContainerNotFound, fmt.Errorf("unable to look up %s to perform a readiness check: %w", name, err)
+	}
+
+	cstate, err := container.State()
+	if err != nil {
+		return define.HealthCheckInternalError, err
+	}
+	if cstate != define.ContainerStateRunning {
+		return define.HealthCheckContainerStopped, fmt.Errorf("container %s is not running", container.ID())
+	}
+	if container.config.ReadinessHealthCheckConfig == nil {
+		return define.HealthCheckNotDefined, fmt.Errorf("container %s has no defined readiness healthcheck", container.ID())
+	}
+
+	return container.runReadinessCheck()
+}
+
+func (c *Container) runReadinessCheck() (define.HealthCheckStatus, error) {
+	config := c.config.ReadinessHealthCheckConfig
+	probe, err := define.ParseHealthProbe(config.Test)
+	if err != nil {
+		return define.HealthCheckInternalError, err
+	}
+	command := healthCheckCommand(config.Test)
+	if probe == nil && (len(command) < 1 || command[0] == "") {
+		return define.HealthCheckNotDefined, fmt.Errorf("container %s has no defined readiness healthcheck", c.ID())
+	}
+
+	timeStart := time.Now()
+	if config.StartPeriod > 0 && timeStart.Before(c.state.StartedTime.Add(config.StartPeriod)) {
+		// Like kube, do not probe the readiness before the initial delay.
+		logrus.Debugf("readiness healthcheck for %s skipped in start-period", c.ID())
+		return define.HealthCheckStartup, nil
+	}
+
+	var (
+		exitCode int
+		hcErr    error
+	)
+	if probe != nil {
+		exitCode, _ = c.runHealthProbe(probe, config.Timeout)
+	} else {
+		logrus.Debugf("executing readiness check command %s for %s", strings.Join(command, " "), c.ID())
+		exitCode, _, hcErr, err = c.execHealthCheck(command)
+		if err != nil {
+			return define.HealthCheckInternalError, err
+		}
+	}
+	passed := hcErr == nil && exitCode == 0
+	if hcErr != nil {
+		logrus.Debugf("readiness check command for %s failed: %v", c.ID(), hcErr)
+	}
+	if config.Timeout > 0 && time.Since(timeStart) > config.Timeout {
+		logrus.Debugf("readiness check command for %s exceeded timeout of %s", c.ID(), config.Timeout)
+		passed = false
+	}
+
+	ready, err := c.updateReadiness(passed)
+	if err != nil {
+		return define.HealthCheckInternalError, err
+	}
+	if !ready {
+		return define.HealthCheckFailure, nil
+	}
+	return define.HealthCheckSuccess, nil
+}
+
+// updateReadiness records the result of a readiness check and returns
+// whether the container is ready.  The container turns ready after the
+// configured number of consecutive successes and unready after the
+// configured number of consecutive failures.
+func (c *Container) updateReadiness(passed bool) (bool, error) {
+	if !c.batched {
+		c.lock.Lock()
+		defer c.lock.Unlock()
+
+		if err := c.syncContainer(); err != nil {
+			return false, err
+		}
+	}
+
+	config := c.config.ReadinessHealthCheckConfig
+	wasReady := c.state.ReadinessHCPassed
+	if passed {
+		c.state.ReadinessHCFailureCount = 0
+		if !wasReady {
+			c.state.ReadinessHCSuccessCount++
+			if config.Successes == 0 || c.state.ReadinessHCSuccessCount >= config.Successes {
+				c.state.ReadinessHCPassed = true
+				c.state.ReadinessHCSuccessCount = 0
+			}
+		}
+	} else {
+		c.state.ReadinessHCSuccessCount = 0
+		if wasReady {
+			c.state.ReadinessHCFailureCount++
+			if config.Retries == 0 || c.state.ReadinessHCFailureCount >= config.Retries {
+				c.state.ReadinessHCPassed = false
+				c.state.ReadinessHCFailureCount = 0
+			}
+		}
+	}
+
+	if err := c.save(); err != nil {
+		return false, err
+	}
+
+	if wasReady != c.state.ReadinessHCPassed {
+		logrus.Debugf("Container %s readiness changed, ready: %t", c.ID(), c.state.ReadinessHCPassed)
+		if c.config.ReadinessGate {
+			if err := c.exposeRootlessPorts(c.state.ReadinessHCPassed); err != nil {
+				logrus.Errorf("Gating ports of container %s on its readiness: %v", c.ID(), err)
+			}
+		}
+	}
+	c.newContainerEvent(events.ReadinessStatus)
+
+	return c.state.ReadinessHCPassed, nil
+}
+
 func (c *Container) runHealthCheck(ctx context.Context, isStartup bool) (define.HealthCheckStatus, string, error) {
 	var (
 		newCommand    []string
@@ -65,58 +191,38 @@ func (c *Container) runHealthCheck(ctx context.Context, isStartup bool) (define.
 	)
 
 	hcCommand := c.HealthCheckConfig().Test
+	hcTimeout := c.HealthCheckConfig().Timeout
 	if isStartup {
 		logrus.Debugf("Running startup healthcheck for container %s", c.ID())
 		hcCommand = c.config.StartupHealthCheckConfig.Test
+		hcTimeout = c.config.StartupHealthCheckConfig.Timeout
 	}
-	if len(hcCommand) < 1 {
-		return define.HealthCheckNotDefined, "", fmt.Errorf("container %s has no defined healthcheck", c.ID())
-	}
-	switch hcCommand[0] {
-	case "", define.HealthConfigTestNone:
-		return define.HealthCheckNotDefined, "", fmt.Errorf("container %s has no defined healthcheck", c.ID())
-	case define.HealthConfigTestCmd:
-		newCommand = hcCommand[1:]
-	case define.HealthConfigTestCmdShell:
-		// TODO: SHELL command from image not available in Container - use Docker default
-		newCommand = []string{"/bin/sh", "-c", strings.Join(hcCommand[1:], " ")}
-	default:
-		// command supplied on command line - pass as-is
-		newCommand = hcCommand
+	probe, err := define.ParseHealthProbe(hcCommand)
+	if err != nil {
+		return define.HealthCheckInternalError, "", err
 	}
-	if len(newCommand) < 1 || newCommand[0] == "" {
+	newCommand = healthCheckCommand(hcCommand)
+	if probe == nil && (len(newCommand) < 1 || newCommand[0] == "") {
 		return define.HealthCheckNotDefined, "", fmt.Errorf("container %s has no defined healthcheck", c.ID())
 	}
-	rPipe, wPipe, err := os.Pipe()
-	if err != nil {
-		return define.HealthCheckInternalError, "", fmt.Errorf("unable to create pipe for healthcheck session: %w", err)
-	}
-	defer wPipe.Close()
-	defer rPipe.Close()
-
-	streams := new(define.AttachStreams)
 
-	streams.InputStream = bufio.NewReader(os.Stdin)
-	streams.OutputStream = wPipe
-	streams.ErrorStream = wPipe
-	streams.AttachOutput = true
-	streams.AttachError = true
-	streams.AttachInput = true
-
-	stdout := []string{}
-	go func() {
-		scanner := bufio.NewScanner(rPipe)
-		for scanner.Scan() {
-			stdout = append(stdout, scanner.Text())
-		}
-	}()
-
-	logrus.Debugf("executing health check command %s for %s", strings.Join(newCommand, " "), c.ID())
+	var (
+		exitCode int
+		stdout   []string
+		hcErr    error
+	)
 	timeStart := time.Now()
 	hcResult := define.HealthCheckSuccess
-	config := new(ExecConfig)
-	config.Command = newCommand
-	exitCode, hcErr := c.exec(config, streams, nil, true)
+	if probe != nil {
+		// Probes are run by Podman, so they can be bounded by the timeout.
+		exitCode, stdout = c.runHealthProbe(probe, hcTimeout)
+	} else {
+		logrus.Debugf("executing health check command %s for %s", strings.Join(newCommand, " "), c.ID())
+		exitCode, stdout, hcErr, err = c.execHealthCheck(newCommand)
+		if err != nil {
+			return define.HealthCheckInternalError, "", err
+		}
+	}
 	if hcErr != nil {
 		hcResult = define.HealthCheckFailure
 		if errors.Is(hcErr, define.ErrOCIRuntimeNotFound) ||
@@ -181,6 +287,61 @@ func (c *Container) runHealthCheck(ctx context.Context, isStartup bool) (define.
 	return hcResult, logStatus, hcErr
 }
 
+// healthCheckCommand converts the test of a healthcheck config into the
+// command to execute in the container.  It returns nil if no command is
+// defined.
+func healthCheckCommand(test []string) []string {
+	if len(test) < 1 {
+		return nil
+	}
+	switch test[0] {
+	case "", define.HealthConfigTestNone:
+		return nil
+	case define.HealthConfigTestCmd:
+		return test[1:]
+	case define.HealthConfigTestCmdShell:
+		// TODO: SHELL command from image not available in Container - use Docker default
+		return []string{"/bin/sh", "-c", strings.Join(test[1:], " ")}
+	default:
+		// command supplied on command line - pass as-is
+		return test
+	}
+}
+
+// execHealthCheck executes a healthcheck command in the container and
+// returns its exit code and output.  hcErr is set if the command could not
+// be executed, err if the execution could not be set up.
+func (c *Container) execHealthCheck(command []string) (exitCode int, output []string, hcErr error, err error) {
+	rPipe, wPipe, err := os.Pipe()
+	if err != nil {
+		return 0, nil, nil, fmt.Errorf("unable to create pipe for healthcheck session: %w", err)
+	}
+	defer wPipe.Close()
+	defer rPipe.Close()
+
+	streams := new(define.AttachStreams)
+
+	streams.InputStream = bufio.NewReader(os.Stdin)
+	streams.OutputStream = wPipe
+	streams.ErrorStream = wPipe
+	streams.AttachOutput = true
+	streams.AttachError = true
+	streams.AttachInput = true
+
+	stdout := []string{}
+	go func() {
+		scanner := bufio.NewScanner(rPipe)
+		for scanner.Scan() {
+			stdout = append(stdout, scanner.Text())
+		}
+	}()
+
+	config := new(ExecConfig)
+	config.Command = command
+	exitCode, hcErr = c.exec(config, streams, nil, true)
+	return exitCode, stdout, hcErr, nil
+}
+
 func (c *Container) processHealthCheckStatus(status string) error {
 	if status != define.HealthCheckUnhealthy {
 		return nil
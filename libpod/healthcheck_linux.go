@@ -21,9 +21,24 @@ func (c *Container) createTimer(interval string, isStartup bool) error {
 	if c.disableHealthCheckSystemd(isStartup) {
 		return nil
 	}
+	return c.createTransientTimer(c.hcUnitName(isStartup), "--on-unit-inactive="+interval, "healthcheck", "run", c.ID())
+}
+
+// createReadinessTimer creates the systemd timer for the readiness
+// healthcheck of a container
+func (c *Container) createReadinessTimer() error {
+	if c.disableReadinessHealthCheckSystemd() {
+		return nil
+	}
+	return c.createTransientTimer(c.readinessUnitName(), "--on-unit-inactive="+c.config.ReadinessHealthCheckConfig.Interval.String(), "healthcheck", "run", "--readiness", c.ID())
+}
+
+// createTransientTimer creates a transient systemd timer running podman with
+// the specified arguments whenever the timer elapses
+func (c *Container) createTransientTimer(unitName, onTimer string, podmanArgs ...string) error {
 	podman, err := os.Executable()
 	if err != nil {
-		return fmt.Errorf("failed to get path for podman for a health check timer: %w", err)
+		return fmt.Errorf("failed to get path for podman for a systemd timer: %w", err)
 	}
 
 	var cmd = []string{"--property", "LogLevelMax=notice"}
@@ -35,17 +50,17 @@ func (c *Container) createTimer(interval string, isStartup bool) error {
 		cmd = append(cmd, "--setenv=PATH="+path)
 	}
 
-	cmd = append(cmd, "--unit", c.hcUnitName(isStartup), fmt.Sprintf("--on-unit-inactive=%s", interval), "--timer-property=AccuracySec=1s", podman)
+	cmd = append(cmd, "--unit", unitName, onTimer, "--timer-property=AccuracySec=1s", podman)
 
 	if logrus.IsLevelEnabled(logrus.DebugLevel) {
 		cmd = append(cmd, "--log-level=debug", "--syslog")
 	}
 
-	cmd = append(cmd, "healthcheck", "run", c.ID())
+	cmd = append(cmd, podmanArgs...)
 
 	conn, err := systemd.ConnectToDBUS()
 	if err != nil {
-		return fmt.Errorf("unable to get systemd connection to add healthchecks: %w", err)
+		return fmt.Errorf("unable to get systemd connection to add timer: %w", err)
 	}
 	conn.Close()
 	logrus.Debugf("creating systemd-transient files: %s %s", "systemd-run", cmd)
@@ -72,13 +87,25 @@ func (c *Container) startTimer(isStartup bool) error {
 	if c.disableHealthCheckSystemd(isStartup) {
 		return nil
 	}
+	return startTransientUnit(c.hcUnitName(isStartup))
+}
+
+// startReadinessTimer starts the systemd timer for the readiness healthcheck
+func (c *Container) startReadinessTimer() error {
+	if c.disableReadinessHealthCheckSystemd() {
+		return nil
+	}
+	return startTransientUnit(c.readinessUnitName())
+}
+
+func startTransientUnit(unitName string) error {
 	conn, err := systemd.ConnectToDBUS()
 	if err != nil {
 		return fmt.Errorf("unable to get systemd connection to start healthchecks: %w", err)
 	}
 	defer conn.Close()
 
-	startFile := fmt.Sprintf("%s.service", c.hcUnitName(isStartup))
+	startFile := fmt.Sprintf("%s.service", unitName)
 	startChan := make(chan string)
 	if _, err := conn.RestartUnitContext(context.Background(), startFile, "fail", startChan); err != nil {
 		return err
@@ -96,9 +123,22 @@ func (c *Container) removeTransientFiles(ctx context.Context, isStartup bool) er
 	if c.disableHealthCheckSystemd(isStartup) {
 		return nil
 	}
+	return removeTransientUnit(ctx, c.hcUnitName(isStartup))
+}
+
+// removeReadinessTransientFiles removes the systemd timer and unit files
+// of the readiness healthcheck for the container
+func (c *Container) removeReadinessTransientFiles(ctx context.Context) error {
+	if c.disableReadinessHealthCheckSystemd() {
+		return nil
+	}
+	return removeTransientUnit(ctx, c.readinessUnitName())
+}
+
+func removeTransientUnit(ctx context.Context, unitName string) error {
 	conn, err := systemd.ConnectToDBUS()
 	if err != nil {
-		return fmt.Errorf("unable to get systemd connection to remove healthchecks: %w", err)
+		return fmt.Errorf("unable to get systemd connection to remove timer: %w", err)
 	}
 	defer conn.Close()
 
@@ -109,28 +149,28 @@ func (c *Container) removeTransientFiles(ctx context.Context, isStartup bool) er
 	// Stop the timer before the service to make sure the timer does not
 	// fire after the service is stopped.
 	timerChan := make(chan string)
-	timerFile := fmt.Sprintf("%s.timer", c.hcUnitName(isStartup))
+	timerFile := fmt.Sprintf("%s.timer", unitName)
 	if _, err := conn.StopUnitContext(ctx, timerFile, "ignore-dependencies", timerChan); err != nil {
 		if !strings.HasSuffix(err.Error(), ".timer not loaded.") {
-			stopErrors = append(stopErrors, fmt.Errorf("removing health-check timer %q: %w", timerFile, err))
+			stopErrors = append(stopErrors, fmt.Errorf("removing systemd timer %q: %w", timerFile, err))
 		}
 	} else if err := systemdOpSuccessful(timerChan); err != nil {
-		stopErrors = append(stopErrors, fmt.Errorf("stopping systemd health-check timer %q: %w", timerFile, err))
+		stopErrors = append(stopErrors, fmt.Errorf("stopping systemd timer %q: %w", timerFile, err))
 	}
 
 	// Reset the service before stopping it to make sure it's being removed
 	// on stop.
 	serviceChan := make(chan string)
-	serviceFile := fmt.Sprintf("%s.service", c.hcUnitName(isStartup))
+	serviceFile := fmt.Sprintf("%s.service", unitName)
 	if err := conn.ResetFailedUnitContext(ctx, serviceFile); err != nil {
 		logrus.Debugf("Failed to reset unit file: %q", err)
 	}
 	if _, err := conn.StopUnitContext(ctx, serviceFile, "ignore-dependencies", serviceChan); err != nil {
 		if !strings.HasSuffix(err.Error(), ".service not loaded.") {
-			stopErrors = append(stopErrors, fmt.Errorf("removing health-check service %q: %w", serviceFile, err))
+			stopErrors = append(stopErrors, fmt.Errorf("removing systemd service %q: %w", serviceFile, err))
 		}
 	} else if err := systemdOpSuccessful(serviceChan); err != nil {
-		stopErrors = append(stopErrors, fmt.Errorf("stopping systemd health-check service %q: %w", serviceFile, err))
+		stopErrors = append(stopErrors, fmt.Errorf("stopping systemd service %q: %w", serviceFile, err))
 	}
 
 	return errorhandling.JoinErrors(stopErrors)
@@ -151,6 +191,13 @@ func (c *Container) disableHealthCheckSystemd(isStartup bool) bool {
 	return false
 }
 
+func (c *Container) disableReadinessHealthCheckSystemd() bool {
+	if !systemdCommon.RunsOnSystemd() || os.Getenv("DISABLE_HC_SYSTEMD") == "true" {
+		return true
+	}
+	return c.config.ReadinessHealthCheckConfig.Interval == 0
+}
+
 // Systemd unit name for the healthcheck systemd unit
 func (c *Container) hcUnitName(isStartup bool) string {
 	unitName := c.ID()
@@ -159,3 +206,8 @@ func (c *Container) hcUnitName(isStartup bool) string {
 	}
 	return unitName
 }
+
+// Systemd unit name for the readiness healthcheck systemd unit
+func (c *Container) readinessUnitName() string {
+	return c.ID() + "-readiness"
+}
@@ -0,0 +1,202 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/containers/podman/v4/libpod/define"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// grpcHealthCheckMethod is the method of the standard gRPC health checking
+// protocol, see https://github.com/grpc/grpc/blob/master/doc/health-checking.md
+const grpcHealthCheckMethod = "/grpc.health.v1.Health/Check"
+
+// grpcHealthServing is the SERVING value of the ServingStatus enum of the
+// gRPC health checking protocol.
+const grpcHealthServing = 1
+
+// runHealthProbe runs a healthcheck probe from the host within the network
+// namespace of the container.  It returns an exit code like a healthcheck
+// command would: 0 if the probe passed and 1 if not, along with a message
+// describing the result.
+func (c *Container) runHealthProbe(probe *define.HealthProbe, timeout time.Duration) (int, []string) {
+	ctx := context.Background()
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	logrus.Debugf("running health probe %s for %s", probe.String(), c.ID())
+
+	var (
+		msg string
+		err error
+	)
+	switch probe.Type {
+	case define.HealthConfigTestHTTPGet:
+		msg, err = c.healthProbeHTTPGet(ctx, probe)
+	case define.HealthConfigTestTCP:
+		msg, err = c.healthProbeTCP(ctx, probe)
+	case define.HealthConfigTestGRPC:
+		msg, err = c.healthProbeGRPC(ctx, probe)
+	default:
+		err = fmt.Errorf("unknown health probe type %q", probe.Type)
+	}
+	if err != nil {
+		return 1, []string{err.Error()}
+	}
+	return 0, []string{msg}
+}
+
+// healthProbeHTTPGet sends a GET request to the URL of the probe and checks
+// the status code of the response.
+func (c *Container) healthProbeHTTPGet(ctx context.Context, probe *define.HealthProbe) (string, error) {
+	transport := &http.Transport{
+		DialContext: func(ctx context.Context, network, addr string) (net.Conn, error) {
+			return c.dialInNetNS(ctx, network, addr)
+		},
+		// Kubernetes does not verify certificates of HTTP probes either.
+		TLSClientConfig:   &tls.Config{InsecureSkipVerify: true}, //nolint:gosec
+		DisableKeepAlives: true,
+	}
+	defer transport.CloseIdleConnections()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, probe.Target, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("User-Agent", "podman-healthcheck")
+	for _, header := range probe.Headers {
+		name, value, err := define.ParseHealthProbeHeader(header)
+		if err != nil {
+			return "", err
+		}
+		// The Host header must be set on the request itself.
+		if http.CanonicalHeaderKey(name) == "Host" {
+			req.Host = value
+			continue
+		}
+		req.Header.Add(name, value)
+	}
+
+	resp, err := (&http.Client{Transport: transport}).Do(req)
+	if err != nil {
+		return "", fmt.Errorf("GET %s: %w", probe.Target, err)
+	}
+	defer resp.Body.Close()
+
+	if !probe.StatusOK(resp.StatusCode) {
+		return "", fmt.Errorf("GET %s: unexpected status %s", probe.Target, resp.Status)
+	}
+	return fmt.Sprintf("GET %s: %s", probe.Target, resp.Status), nil
+}
+
+// healthProbeTCP checks that a TCP connection to the address of the probe
+// can be established.
+func (c *Container) healthProbeTCP(ctx context.Context, probe *define.HealthProbe) (string, error) {
+	conn, err := c.dialInNetNS(ctx, "tcp", probe.Target)
+	if err != nil {
+		return "", err
+	}
+	conn.Close()
+	return fmt.Sprintf("connected to %s", probe.Target), nil
+}
+
+// healthProbeGRPC calls the gRPC health checking service at the address of
+// the probe and checks that the service is serving.
+func (c *Container) healthProbeGRPC(ctx context.Context, probe *define.HealthProbe) (string, error) {
+	// The passthrough resolver hands the address as-is to the dialer, which
+	// resolves it within the network namespace of the container.
+	conn, err := grpc.DialContext(ctx, "passthrough:///"+probe.Target,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithContextDialer(func(ctx context.Context, addr string) (net.Conn, error) {
+			return c.dialInNetNS(ctx, "tcp", addr)
+		}),
+	)
+	if err != nil {
+		return "", err
+	}
+	defer conn.Close()
+
+	// Encode the HealthCheckRequest message by hand, it consists of the
+	// service name only.
+	var req []byte
+	if probe.Service != "" {
+		req = protowire.AppendTag(req, 1, protowire.BytesType)
+		req = protowire.AppendString(req, probe.Service)
+	}
+	var resp []byte
+	if err := conn.Invoke(ctx, grpcHealthCheckMethod, &req, &resp, grpc.ForceCodec(rawCodec{})); err != nil {
+		return "", fmt.Errorf("gRPC health check of %s: %w", probe.Target, err)
+	}
+
+	status, err := grpcHealthStatus(resp)
+	if err != nil {
+		return "", fmt.Errorf("gRPC health check of %s: %w", probe.Target, err)
+	}
+	if status != grpcHealthServing {
+		return "", fmt.Errorf("gRPC health check of %s: service is not serving (status %d)", probe.Target, status)
+	}
+	return fmt.Sprintf("gRPC health check of %s: serving", probe.Target), nil
+}
+
+// grpcHealthStatus decodes the status of a HealthCheckResponse message.
+func grpcHealthStatus(resp []byte) (uint64, error) {
+	var status uint64
+	for len(resp) > 0 {
+		num, typ, n := protowire.ConsumeTag(resp)
+		if n < 0 {
+			return 0, protowire.ParseError(n)
+		}
+		resp = resp[n:]
+		if num == 1 && typ == protowire.VarintType {
+			status, n = protowire.ConsumeVarint(resp)
+		} else {
+			n = protowire.ConsumeFieldValue(num, typ, resp)
+		}
+		if n < 0 {
+			return 0, protowire.ParseError(n)
+		}
+		resp = resp[n:]
+	}
+	return status, nil
+}
+
+// rawCodec is a gRPC codec passing through already encoded messages.  It
+// avoids depending on generated protobuf code for the health service.
+type rawCodec struct{}
+
+func (rawCodec) Marshal(v any) ([]byte, error) {
+	b, ok := v.(*[]byte)
+	if !ok {
+		return nil, errors.New("rawCodec: message must be a *[]byte")
+	}
+	return *b, nil
+}
+
+func (rawCodec) Unmarshal(data []byte, v any) error {
+	b, ok := v.(*[]byte)
+	if !ok {
+		return errors.New("rawCodec: message must be a *[]byte")
+	}
+	*b = append((*b)[:0], data...)
+	return nil
+}
+
+func (rawCodec) Name() string {
+	// Use the name of the proto codec so that the server accepts the
+	// content type of the request.
+	return "proto"
+}
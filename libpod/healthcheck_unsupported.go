@@ -21,3 +21,20 @@ func (c *Container) startTimer(isStartup bool) error {
 func (c *Container) removeTransientFiles(ctx context.Context, isStartup bool) error {
 	return nil
 }
+
+// createReadinessTimer creates the systemd timer for the readiness
+// healthcheck of a container
+func (c *Container) createReadinessTimer() error {
+	return nil
+}
+
+// startReadinessTimer starts the systemd timer for the readiness healthcheck
+func (c *Container) startReadinessTimer() error {
+	return nil
+}
+
+// removeReadinessTransientFiles removes the systemd timer and unit files
+// of the readiness healthcheck for the container
+func (c *Container) removeReadinessTransientFiles(ctx context.Context) error {
+	return nil
+}
@@ -0,0 +1,222 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/containers/podman/v4/libpod/define"
+	"github.com/sirupsen/logrus"
+)
+
+// postStartHookTimeout is the maximum time a post-start hook may run before
+// it is aborted.
+const postStartHookTimeout = time.Minute
+
+// hookExecStopInterval is the interval at which stopping the exec session of
+// a timed out hook is retried until the session has finished.
+const hookExecStopInterval = 100 * time.Millisecond
+
+// runPostStartHook runs the post-start hook of the container, if any, and
+// aborts it after postStartHookTimeout.  The container lock is released while
+// the hook is running, so the state of the container may have changed once it
+// returns.  A failing hook is logged and recorded as an event but not treated
+// as an error.
+func (c *Container) runPostStartHook() error {
+	if c.config.PostStartHook == nil {
+		return nil
+	}
+
+	if !c.batched {
+		c.lock.Unlock()
+	}
+
+	hookErr := c.runLifecycleHook(c.config.PostStartHook, postStartHookTimeout)
+
+	if !c.batched {
+		c.lock.Lock()
+		if err := c.syncContainer(); err != nil {
+			if errors.Is(err, define.ErrNoSuchCtr) || errors.Is(err, define.ErrCtrRemoved) {
+				return fmt.Errorf("container %s was removed while running its post-start hook: %w", c.ID(), err)
+			}
+			return err
+		}
+	}
+
+	if hookErr != nil {
+		logrus.Errorf("Post-start hook of container %s failed: %v", c.ID(), hookErr)
+		c.newLifecycleHookFailedEvent(define.LifecycleHookPostStart, hookErr)
+	}
+	return nil
+}
+
+// runPreStopHook runs the pre-stop hook of the container, if any.  The hook
+// is bounded by the specified stop timeout and the remaining timeout is
+// returned.  Like in Kubernetes, the hook is skipped if there is no time left
+// for it, that is if the timeout is 0.  The container lock is released while
+// the hook is running, so callers must check the state of the container
+// afterwards.
+func (c *Container) runPreStopHook(timeout uint) (uint, error) {
+	if c.config.PreStopHook == nil || timeout == 0 || !c.ensureState(define.ContainerStateRunning) {
+		return timeout, nil
+	}
+
+	if !c.batched {
+		c.lock.Unlock()
+	}
+
+	timeStart := time.Now()
+	hookErr := c.runLifecycleHook(c.config.PreStopHook, time.Duration(timeout)*time.Second)
+	elapsed := uint(time.Since(timeStart) / time.Second)
+
+	if !c.batched {
+		c.lock.Lock()
+		if err := c.syncContainer(); err != nil {
+			if errors.Is(err, define.ErrNoSuchCtr) || errors.Is(err, define.ErrCtrRemoved) {
+				return 0, fmt.Errorf("container %s was removed while running its pre-stop hook: %w", c.ID(), err)
+			}
+			return 0, err
+		}
+	}
+
+	if hookErr != nil {
+		logrus.Errorf("Pre-stop hook of container %s failed: %v", c.ID(), hookErr)
+		c.newLifecycleHookFailedEvent(define.LifecycleHookPreStop, hookErr)
+	}
+
+	if elapsed >= timeout {
+		return 0, nil
+	}
+	return timeout - elapsed, nil
+}
+
+// runLifecycleHook runs the specified hook and aborts it if it did not
+// complete within timeout.
+func (c *Container) runLifecycleHook(hook *define.LifecycleHook, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	var err error
+	if hook.HTTPGet != "" {
+		err = c.lifecycleHookHTTPGet(ctx, hook.HTTPGet)
+	} else {
+		err = c.lifecycleHookExec(ctx, hook.Exec)
+	}
+	if ctx.Err() != nil {
+		return fmt.Errorf("hook %q did not complete within %s", hook.String(), timeout)
+	}
+	return err
+}
+
+// lifecycleHookExec executes the command of a lifecycle hook inside the
+// container.  If ctx is done before the command completed, the exec session
+// is stopped and removed before returning.
+func (c *Container) lifecycleHookExec(ctx context.Context, command []string) (retErr error) {
+	config := new(ExecConfig)
+	config.Command = command
+	sessionID, err := c.ExecCreate(config)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := c.ExecRemove(sessionID, true); err != nil && !errors.Is(err, define.ErrNoSuchExecSession) {
+			if retErr == nil {
+				retErr = err
+			} else {
+				logrus.Errorf("Removing exec session %s of container %s: %v", sessionID, c.ID(), err)
+			}
+		}
+	}()
+
+	streams := new(define.AttachStreams)
+	streams.OutputStream = io.Discard
+	streams.ErrorStream = io.Discard
+	streams.AttachOutput = true
+	streams.AttachError = true
+
+	errChan := make(chan error, 1)
+	go func() {
+		errChan <- c.execStartAndAttach(sessionID, streams, nil, true)
+	}()
+
+	select {
+	case err := <-errChan:
+		if err != nil {
+			return fmt.Errorf("executing hook %v: %w", command, err)
+		}
+	case <-ctx.Done():
+		// Never leave the session running behind our back.  It may
+		// not have been started yet, so retry until it finished.
+		timeout := uint(0)
+		for {
+			if err := c.ExecStop(sessionID, &timeout); err != nil && !errors.Is(err, define.ErrExecSessionStateInvalid) {
+				logrus.Debugf("Stopping exec session %s of container %s: %v", sessionID, c.ID(), err)
+			}
+			select {
+			case <-errChan:
+				return ctx.Err()
+			case <-time.After(hookExecStopInterval):
+			}
+		}
+	}
+
+	exitCode := 0
+	session, err := c.execSessionNoCopy(sessionID)
+	switch {
+	case err == nil:
+		exitCode = session.ExitCode
+	case errors.Is(err, define.ErrNoSuchExecSession):
+		// The session was already cleaned up, its exit code is
+		// recorded in the died event.
+		diedEvent, err := c.runtime.GetExecDiedEvent(ctx, c.ID(), sessionID)
+		if err != nil {
+			return fmt.Errorf("retrieving exit code of hook %v: %w", command, err)
+		}
+		exitCode = *diedEvent.ContainerExitCode
+	default:
+		return err
+	}
+	if exitCode != 0 {
+		return fmt.Errorf("hook %v exited with code %d", command, exitCode)
+	}
+	return nil
+}
+
+// lifecycleHookHTTPGet sends a GET request to the specified URL from within
+// the network namespace of the container.  Like in Kubernetes, any status
+// code between 200 and 399 indicates success.
+func (c *Container) lifecycleHookHTTPGet(ctx context.Context, url string) error {
+	transport := &http.Transport{
+		DialContext: func(ctx context.Context, network, addr string) (net.Conn, error) {
+			return c.dialInNetNS(ctx, network, addr)
+		},
+		// Kubernetes does not verify certificates of HTTP hooks either.
+		TLSClientConfig:   &tls.Config{InsecureSkipVerify: true}, //nolint:gosec
+		DisableKeepAlives: true,
+	}
+	defer transport.CloseIdleConnections()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("User-Agent", "podman-lifecycle-hook")
+
+	resp, err := (&http.Client{Transport: transport}).Do(req)
+	if err != nil {
+		return fmt.Errorf("hook %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusBadRequest {
+		return fmt.Errorf("hook %s returned status %s", url, resp.Status)
+	}
+	return nil
+}
@@ -57,7 +57,9 @@ type LogLine struct {
 	ColorID      int64
 }
 
-// GetLogFile returns an hp tail for a container given options
+// GetLogFile returns an hp tail for a container given options.  Lines of
+// rotated log files are read into the returned slice of log lines, the tail
+// follows the current log file only.
 func GetLogFile(path string, options *LogOptions) (*tail.Tail, []*LogLine, error) {
 	var (
 		whence  int
@@ -74,6 +76,12 @@ func GetLogFile(path string, options *LogOptions) (*tail.Tail, []*LogLine, error
 			return nil, nil, err
 		}
 	}
+	if options.Tail < 0 {
+		logTail, err = getRotatedLog(path)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
 	seek := tail.SeekInfo{
 		Offset: 0,
 		Whence: whence,
@@ -83,30 +91,80 @@ func GetLogFile(path string, options *LogOptions) (*tail.Tail, []*LogLine, error
 	return t, logTail, err
 }
 
+// tailCollector collects the last lines of a log read backwards.
+type tailCollector struct {
+	tail    int
+	counter int
+	first   bool
+	lines   []*LogLine
+}
+
+// add adds a line to the tail and returns true once the tail is complete.
+func (t *tailCollector) add(nll *LogLine) bool {
+	if !nll.Partial() || t.first {
+		t.counter++
+		// Even if the last line is partial we need to count it as it will be printed as line.
+		// Because we read backwards the first line we read is the last line in the log.
+		t.first = false
+	}
+	// We explicitly need to check for more lines than tail because we have
+	// to read to next full line and must keep all partial lines
+	// https://github.com/containers/podman/issues/19545
+	if t.counter > t.tail {
+		return true
+	}
+	// only append after the return here because we do not want to include the next full line
+	t.lines = append(t.lines, nll)
+	return false
+}
+
 func getTailLog(path string, tail int) ([]*LogLine, error) {
+	collector := &tailCollector{tail: tail, first: true}
+	done, err := collector.readReverse(path)
+	if err != nil {
+		return nil, err
+	}
+	if !done {
+		// Continue with the rotated log files, newest first.
+		for _, rotated := range RotatedLogFiles(path) {
+			lines, err := readLogFile(rotated)
+			if err != nil {
+				return nil, err
+			}
+			for i := len(lines) - 1; i >= 0 && !done; i-- {
+				done = collector.add(lines[i])
+			}
+			if done {
+				break
+			}
+		}
+	}
+	// because we add lines in the inverse order we must invert the slice in the end
+	return reverseLog(collector.lines), nil
+}
+
+// readReverse reads the log file backwards until the tail is complete.  It
+// returns true if the tail is complete.
+func (t *tailCollector) readReverse(path string) (bool, error) {
 	var (
-		nllCounter int
-		leftover   string
-		tailLog    []*LogLine
-		eof        bool
+		leftover string
+		eof      bool
 	)
 	f, err := os.Open(path)
 	if err != nil {
-		return nil, err
+		return false, err
 	}
 	defer f.Close()
 	rr, err := reversereader.NewReverseReader(f)
 	if err != nil {
-		return nil, err
+		return false, err
 	}
 
-	first := true
-
 	for {
 		s, err := rr.Read()
 		if err != nil {
 			if !errors.Is(err, io.EOF) {
-				return nil, fmt.Errorf("reverse log read: %w", err)
+				return false, fmt.Errorf("reverse log read: %w", err)
 			}
 			eof = true
 		}
@@ -120,40 +178,41 @@ func getTailLog(path string, tail int) ([]*LogLine, error) {
 			}
 			nll, err := NewLogLine(lines[i])
 			if err != nil {
-				return nil, err
+				return false, err
 			}
-			if !nll.Partial() || first {
-				nllCounter++
-				// Even if the last line is partial we need to count it as it will be printed as line.
-				// Because we read backwards the first line we read is the last line in the log.
-				first = false
+			if t.add(nll) {
+				return true, nil
 			}
-			// We explicitly need to check for more lines than tail because we have
-			// to read to next full line and must keep all partial lines
-			// https://github.com/containers/podman/issues/19545
-			if nllCounter > tail {
-				// because we add lines in the inverse order we must invert the slice in the end
-				return reverseLog(tailLog), nil
-			}
-			// only append after the return here because we do not want to include the next full line
-			tailLog = append(tailLog, nll)
 		}
 		leftover = lines[0]
 
 		// eof was reached
 		if eof {
-			// when we have still a line and do not have enough tail lines already
-			if leftover != "" && nllCounter < tail {
+			if leftover != "" {
 				nll, err := NewLogLine(leftover)
 				if err != nil {
-					return nil, err
+					return false, err
 				}
-				tailLog = append(tailLog, nll)
+				return t.add(nll), nil
 			}
-			// because we add lines in the inverse order we must invert the slice in the end
-			return reverseLog(tailLog), nil
+			return false, nil
+		}
+	}
+}
+
+// getRotatedLog returns all lines of the rotated log files of the log file
+// at path, oldest first.
+func getRotatedLog(path string) ([]*LogLine, error) {
+	var logLines []*LogLine
+	rotated := RotatedLogFiles(path)
+	for i := len(rotated) - 1; i >= 0; i-- {
+		lines, err := readLogFile(rotated[i])
+		if err != nil {
+			return nil, err
 		}
+		logLines = append(logLines, lines...)
 	}
+	return logLines, nil
 }
 
 // reverseLog reverse the log line slice, needed for tail as we read lines backwards but still
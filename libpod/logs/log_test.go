@@ -1,6 +1,7 @@
 package logs
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
 	"testing"
@@ -135,3 +136,119 @@ func TestGetTailLogBigFiles(t *testing.T) {
 	// this will return the last 200 lines because of partial + full and we only count full lines for tail.
 	assert.Equal(t, want[1800:2000], got, "tail 100 log lines")
 }
+
+func TestGetTailLogRotated(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "log")
+	writeLog := func(path string, lines ...string) {
+		content := ""
+		for _, line := range lines {
+			content += "2023-08-07T19:56:34.223758260-06:00 stdout " + line + "\n"
+		}
+		assert.NoError(t, os.WriteFile(path, []byte(content), 0600), "write log file")
+	}
+
+	writeLog(file, "F line1", "P lin")
+	assert.NoError(t, RotateLogFile(file, 3, true), "rotate log file")
+	assert.Equal(t, []string{file + ".1"}, RotatedLogFiles(file), "newest rotated file is not compressed")
+	writeLog(file, "F e2", "F line3")
+	assert.NoError(t, RotateLogFile(file, 3, true), "rotate log file")
+	writeLog(file, "F line4")
+
+	assert.Equal(t, []string{file + ".1", file + ".2.gz"}, RotatedLogFiles(file), "rotated log files")
+
+	got, err := getTailLog(file, 3)
+	assert.NoError(t, err, "getTailLog()")
+	assert.Equal(t, []*LogLine{makeTestLogLine("P", "lin"), makeTestLogLine("F", "e2"),
+		makeTestLogLine("F", "line3"), makeTestLogLine("F", "line4")}, got, "tail across rotated files")
+
+	got, err = getRotatedLog(file)
+	assert.NoError(t, err, "getRotatedLog()")
+	assert.Equal(t, []*LogLine{makeTestLogLine("F", "line1"), makeTestLogLine("P", "lin"),
+		makeTestLogLine("F", "e2"), makeTestLogLine("F", "line3")}, got, "lines of rotated files")
+
+	// The oldest file is removed when rotating again.
+	assert.NoError(t, RotateLogFile(file, 3, false), "rotate log file")
+	assert.Equal(t, []string{file + ".1", file + ".2"}, RotatedLogFiles(file), "rotated log files")
+	got, err = getTailLog(file+"-missing", 1)
+	assert.Error(t, err, "getTailLog() of a missing file")
+	assert.Nil(t, got)
+}
+
+func TestRotateLogFileWhileWriting(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "log")
+	const rotations = 5
+
+	// The writer behaves like conmon: it appends to the log file it has
+	// open and keeps doing so for a while after being asked to reopen it.
+	reopen := make(chan struct{})
+	stop := make(chan struct{})
+	written := make(chan int)
+	go func() {
+		n := 0
+		f, err := os.OpenFile(file, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0600)
+		write := func() {
+			n++
+			if _, err := fmt.Fprintf(f, "2023-08-07T19:56:34.223758260-06:00 stdout F line%d\n", n); err != nil {
+				panic(err)
+			}
+		}
+		if err != nil {
+			panic(err)
+		}
+		for {
+			select {
+			case <-reopen:
+				for i := 0; i < 100; i++ {
+					write()
+				}
+				f.Close()
+				if f, err = os.OpenFile(file, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0600); err != nil {
+					panic(err)
+				}
+			case <-stop:
+				f.Close()
+				written <- n
+				return
+			default:
+				write()
+			}
+		}
+	}()
+
+	for i := 0; i < rotations; i++ {
+		// Like RotateLog, only rotate once the writer has created the
+		// new log file and written to it.
+		for {
+			info, err := os.Stat(file)
+			if err == nil && info.Size() > 1024 {
+				break
+			}
+			time.Sleep(time.Millisecond)
+		}
+		assert.NoError(t, RotateLogFile(file, rotations+2, true), "rotate log file")
+		reopen <- struct{}{}
+	}
+	close(stop)
+	n := <-written
+
+	rotated := RotatedLogFiles(file)
+	assert.Len(t, rotated, rotations, "rotated log files")
+	assert.Equal(t, file+".1", rotated[0], "newest rotated file is not compressed")
+	for _, p := range rotated[1:] {
+		assert.Equal(t, compressedSuffix, filepath.Ext(p), "older rotated files are compressed")
+	}
+
+	lines, err := getRotatedLog(file)
+	assert.NoError(t, err, "getRotatedLog()")
+	current, err := readLogFile(file)
+	assert.NoError(t, err, "readLogFile()")
+	lines = append(lines, current...)
+	assert.Len(t, lines, n, "no lines are lost")
+	for i, line := range lines {
+		if line.Msg != fmt.Sprintf("line%d", i+1) {
+			t.Fatalf("line %d: got %q", i+1, line.Msg)
+		}
+	}
+}
@@ -0,0 +1,166 @@
+package logs
+
+import (
+	"bufio"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+)
+
+// compressedSuffix is the suffix of compressed rotated log files.
+const compressedSuffix = ".gz"
+
+// rotatedLogFile returns the path of the n-th rotated file of the log file
+// at path, either compressed or not.  An empty string is returned if the file
+// does not exist.
+func rotatedLogFile(path string, n uint) string {
+	rotated := path + "." + strconv.FormatUint(uint64(n), 10)
+	for _, p := range []string{rotated, rotated + compressedSuffix} {
+		if _, err := os.Stat(p); err == nil {
+			return p
+		}
+	}
+	return ""
+}
+
+// RotatedLogFiles returns the rotated files of the log file at path, newest
+// first.  The n-th rotated file is named path.n, or path.n.gz if compressed.
+func RotatedLogFiles(path string) []string {
+	var rotated []string
+	for n := uint(1); ; n++ {
+		p := rotatedLogFile(path, n)
+		if p == "" {
+			return rotated
+		}
+		rotated = append(rotated, p)
+	}
+}
+
+// RotateLogFile renames the log file at path to path.1 after shifting the
+// existing rotated files by one.  At most maxFile-1 rotated files are kept,
+// older ones are removed.  The caller is responsible for making the writer
+// of the log reopen it.
+//
+// The writer may keep writing to path.1 until it has reopened the log, so
+// with compress the newest rotated file is only compressed by the next
+// rotation, when the writer has moved on to the new log file.
+func RotateLogFile(path string, maxFile uint, compress bool) error {
+	if maxFile < 2 {
+		return fmt.Errorf("cannot rotate log file %s: at least 2 log files must be kept", path)
+	}
+	if compress && maxFile > 2 {
+		if err := CompressLogFile(path + ".1"); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+	}
+	// Remove the rotated files exceeding the limit.  There may be more
+	// than one, e.g. if the limit was lowered.
+	if rotated := RotatedLogFiles(path); uint(len(rotated)) > maxFile-2 {
+		for _, p := range rotated[maxFile-2:] {
+			if err := os.Remove(p); err != nil && !errors.Is(err, os.ErrNotExist) {
+				return err
+			}
+		}
+	}
+	for n := maxFile - 2; n >= 1; n-- {
+		p := rotatedLogFile(path, n)
+		if p == "" {
+			continue
+		}
+		next := path + "." + strconv.FormatUint(uint64(n+1), 10)
+		if filepath.Ext(p) == compressedSuffix {
+			next += compressedSuffix
+		}
+		if err := os.Rename(p, next); err != nil {
+			return err
+		}
+	}
+	return os.Rename(path, path+".1")
+}
+
+// CompressLogFile compresses the log file at path into path.gz and removes
+// the uncompressed file.
+func CompressLogFile(path string) (retErr error) {
+	src, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+	info, err := src.Stat()
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*"+compressedSuffix)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if retErr != nil {
+			tmp.Close()
+			os.Remove(tmp.Name())
+		}
+	}()
+	if err := tmp.Chmod(info.Mode().Perm()); err != nil {
+		return err
+	}
+	zw := gzip.NewWriter(tmp)
+	if _, err := io.Copy(zw, src); err != nil {
+		return fmt.Errorf("compressing log file %s: %w", path, err)
+	}
+	if err := zw.Close(); err != nil {
+		return fmt.Errorf("compressing log file %s: %w", path, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp.Name(), path+compressedSuffix); err != nil {
+		return err
+	}
+	return os.Remove(path)
+}
+
+// readLogFile reads all lines of a, possibly compressed, log file.
+func readLogFile(path string) ([]*LogLine, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var reader io.Reader = f
+	if filepath.Ext(path) == compressedSuffix {
+		zr, err := gzip.NewReader(f)
+		if err != nil {
+			return nil, fmt.Errorf("reading log file %s: %w", path, err)
+		}
+		defer zr.Close()
+		reader = zr
+	}
+
+	var lines []*LogLine
+	br := bufio.NewReader(reader)
+	for {
+		line, err := br.ReadString('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("reading log file %s: %w", path, err)
+		}
+		if len(line) > 0 && line[len(line)-1] == '\n' {
+			line = line[:len(line)-1]
+		}
+		if line != "" {
+			nll, parseErr := NewLogLine(line)
+			if parseErr != nil {
+				return nil, parseErr
+			}
+			lines = append(lines, nll)
+		}
+		if err != nil {
+			return lines, nil
+		}
+	}
+}
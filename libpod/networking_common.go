@@ -632,6 +632,112 @@ func (r *Runtime) ConnectContainerToNetwork(nameOrID, netName string, netOpts ty
 	return ctr.NetworkConnect(nameOrID, netName, netOpts)
 }
 
+// UpdateNetwork replaces the configuration of an existing network.  It is
+// meant for changes the network backends cannot apply in place: the network
+// is removed and created again with the new configuration under the same
+// name, which gives it a new ID.  Running containers connected to the network
+// are disconnected before and connected again with their previous options
+// afterwards, which keeps static but not dynamic IP addresses.  All other
+// containers connected to the network stay locked while it is recreated so
+// that they cannot be started in the meantime.  The update is refused while
+// containers connected to the network are paused or being stopped.  If the
+// new configuration cannot be created, the old one is restored.
+func (r *Runtime) UpdateNetwork(network types.Network) (retErr error) {
+	if !r.valid {
+		return define.ErrRuntimeStopped
+	}
+
+	oldNetwork, err := r.network.NetworkInspect(network.Name)
+	if err != nil {
+		return err
+	}
+	if oldNetwork.Name == r.config.Network.DefaultNetwork {
+		return fmt.Errorf("default network %s cannot be updated: %w", oldNetwork.Name, define.ErrInvalidArg)
+	}
+
+	ctrs, err := r.state.AllContainers(false)
+	if err != nil {
+		return err
+	}
+	var running []*Container
+	for _, ctr := range ctrs {
+		networks, err := ctr.networks()
+		if err != nil {
+			if errors.Is(err, define.ErrNoSuchCtr) || errors.Is(err, define.ErrCtrRemoved) {
+				continue
+			}
+			return err
+		}
+		if _, ok := networks[oldNetwork.Name]; !ok {
+			continue
+		}
+		ctr.lock.Lock()
+		if err := ctr.syncContainer(); err != nil {
+			ctr.lock.Unlock()
+			if errors.Is(err, define.ErrNoSuchCtr) || errors.Is(err, define.ErrCtrRemoved) {
+				continue
+			}
+			return err
+		}
+		switch ctr.state.State {
+		case define.ContainerStateRunning:
+			ctr.lock.Unlock()
+			running = append(running, ctr)
+		case define.ContainerStatePaused, define.ContainerStateStopping:
+			ctr.lock.Unlock()
+			return fmt.Errorf("container %s connected to network %s is %s, the network cannot be updated: %w", ctr.ID(), oldNetwork.Name, ctr.state.State, define.ErrCtrStateInvalid)
+		default:
+			defer ctr.lock.Unlock()
+		}
+	}
+
+	reconnect := make(map[*Container]types.PerNetworkOptions)
+	defer func() {
+		for ctr, opts := range reconnect {
+			// NetworkConnect adds the extra aliases again.
+			extraAliases := getExtraNetworkAliases(ctr)
+			opts.Aliases = slices.DeleteFunc(opts.Aliases, func(alias string) bool {
+				return slices.Contains(extraAliases, alias)
+			})
+			if err := ctr.NetworkConnect(ctr.ID(), oldNetwork.Name, opts); err != nil {
+				err = fmt.Errorf("reconnecting container %s to network %s: %w", ctr.ID(), oldNetwork.Name, err)
+				if retErr == nil {
+					retErr = err
+				} else {
+					logrus.Error(err)
+				}
+			}
+		}
+	}()
+	for _, ctr := range running {
+		networks, err := ctr.networks()
+		if err != nil {
+			return err
+		}
+		opts, ok := networks[oldNetwork.Name]
+		if !ok {
+			continue
+		}
+		if err := ctr.NetworkDisconnect(ctr.ID(), oldNetwork.Name, true); err != nil {
+			return fmt.Errorf("disconnecting container %s from network %s: %w", ctr.ID(), oldNetwork.Name, err)
+		}
+		reconnect[ctr] = opts
+	}
+
+	if err := r.network.NetworkRemove(oldNetwork.Name); err != nil {
+		return err
+	}
+	network.ID = ""
+	if _, err := r.network.NetworkCreate(network, nil); err != nil {
+		oldNetwork.ID = ""
+		if _, restoreErr := r.network.NetworkCreate(oldNetwork, nil); restoreErr != nil {
+			logrus.Errorf("Restoring network %s: %v", oldNetwork.Name, restoreErr)
+		}
+		return err
+	}
+	return nil
+}
+
 // normalizeNetworkName takes a network name, a partial or a full network ID and returns the network name.
 // If the network is not found an error is returned.
 func (r *Runtime) normalizeNetworkName(nameOrID string) (string, error) {
@@ -3,6 +3,7 @@
 package libpod
 
 import (
+	"context"
 	"crypto/rand"
 	jdec "encoding/json"
 	"errors"
@@ -288,6 +289,11 @@ func (c *Container) reloadRootlessRLKPortMapping() error {
 	return errors.New("unsupported (*Container).reloadRootlessRLKPortMapping")
 }
 
+// exposeRootlessPorts is a no-op, there is no rootlessport process on FreeBSD.
+func (c *Container) exposeRootlessPorts(expose bool) error {
+	return nil
+}
+
 func (c *Container) setupRootlessNetwork() error {
 	return nil
 }
@@ -295,3 +301,7 @@ func (c *Container) setupRootlessNetwork() error {
 func getPastaIP(state *ContainerState) (net.IP, error) {
 	return nil, fmt.Errorf("pasta networking is Linux only")
 }
+
+func (c *Container) dialInNetNS(ctx context.Context, network, addr string) (net.Conn, error) {
+	return nil, errors.New("unsupported (*Container).dialInNetNS")
+}
@@ -3,6 +3,7 @@
 package libpod
 
 import (
+	"context"
 	"crypto/rand"
 	"fmt"
 	"net"
@@ -13,6 +14,7 @@ import (
 	"github.com/containers/common/libnetwork/types"
 	netUtil "github.com/containers/common/libnetwork/util"
 	"github.com/containers/common/pkg/netns"
+	"github.com/containers/podman/v4/libpod/define"
 	"github.com/containers/podman/v4/pkg/rootless"
 	"github.com/opencontainers/runtime-spec/specs-go"
 	"github.com/sirupsen/logrus"
@@ -315,3 +317,21 @@ func getPastaIP(state *ContainerState) (net.IP, error) {
 	})
 	return net.ParseIP(ip), err
 }
+
+// dialInNetNS connects to the specified address from within the network
+// namespace of the running container.
+func (c *Container) dialInNetNS(ctx context.Context, network, addr string) (net.Conn, error) {
+	if c.state.PID == 0 {
+		return nil, fmt.Errorf("container %s is not running: %w", c.ID(), define.ErrCtrStateInvalid)
+	}
+	var conn net.Conn
+	err := ns.WithNetNSPath(fmt.Sprintf("/proc/%d/ns/net", c.state.PID), func(_ ns.NetNS) error {
+		// Disable the fast fallback of the dialer, it would dial from
+		// other goroutines which are not in the namespace.
+		dialer := net.Dialer{FallbackDelay: -1}
+		var err error
+		conn, err = dialer.DialContext(ctx, network, addr)
+		return err
+	})
+	return conn, err
+}
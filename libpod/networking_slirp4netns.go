@@ -3,6 +3,7 @@
 package libpod
 
 import (
+	"errors"
 	"fmt"
 	"io"
 	"net"
@@ -12,6 +13,7 @@ import (
 	"github.com/containers/common/libnetwork/slirp4netns"
 	"github.com/containers/common/libnetwork/types"
 	"github.com/containers/podman/v4/pkg/errorhandling"
+	"github.com/containers/podman/v4/pkg/rootless"
 	"github.com/sirupsen/logrus"
 )
 
@@ -36,6 +38,13 @@ func (r *Runtime) setupSlirp4netns(ctr *Container, netns string) error {
 	if ctr.rootlessPortSyncR != nil {
 		defer errorhandling.CloseQuiet(ctr.rootlessPortSyncR)
 	}
+	if len(ports) > 0 {
+		cleanup, err := ctr.withholdRootlessPorts()
+		if err != nil {
+			return err
+		}
+		defer cleanup()
+	}
 
 	res, err := slirp4netns.Setup(&slirp4netns.SetupOptions{
 		Config:                r.config,
@@ -62,6 +71,11 @@ func (r *Runtime) setupRootlessPortMappingViaRLK(ctr *Container, netnsPath strin
 		}
 	}
 	defer errorhandling.CloseQuiet(ctr.rootlessPortSyncR)
+	cleanup, err := ctr.withholdRootlessPorts()
+	if err != nil {
+		return err
+	}
+	defer cleanup()
 	return slirp4netns.SetupRootlessPortMappingViaRLK(&slirp4netns.SetupOptions{
 		Config:                r.config,
 		ContainerID:           ctr.ID(),
@@ -71,6 +85,64 @@ func (r *Runtime) setupRootlessPortMappingViaRLK(ctr *Container, netnsPath strin
 	}, nil, netStatus)
 }
 
+// rootlessPortsWithheldSuffix is the suffix of the file next to the socket
+// of the rootlessport process of a container which makes it withhold the
+// ports from the start.  Must match withheldSuffix in cmd/rootlessport.
+const rootlessPortsWithheldSuffix = ".withheld"
+
+// withholdRootlessPorts makes the rootlessport process of a container with a
+// readiness gate, which is started next, withhold the ports until the
+// container is ready, so that they are never exposed before.  The returned
+// function removes the request once the process started.
+func (c *Container) withholdRootlessPorts() (func(), error) {
+	if !c.config.ReadinessGate || c.state.ReadinessHCPassed || !rootless.IsRootless() {
+		return func() {}, nil
+	}
+	socketDir := filepath.Join(c.runtime.config.Engine.TmpDir, "rp")
+	if err := os.MkdirAll(socketDir, 0700); err != nil {
+		return nil, err
+	}
+	withheldFile := filepath.Join(socketDir, c.config.ID+rootlessPortsWithheldSuffix)
+	if err := os.WriteFile(withheldFile, nil, 0600); err != nil {
+		return nil, fmt.Errorf("withholding ports of container %s until it is ready: %w", c.ID(), err)
+	}
+	return func() {
+		if err := os.Remove(withheldFile); err != nil && !errors.Is(err, os.ErrNotExist) {
+			logrus.Debugf("Removing %s: %v", withheldFile, err)
+		}
+	}, nil
+}
+
+// exposeRootlessPorts exposes or withholds the ports forwarded by the
+// rootlessport process of the container.
+func (c *Container) exposeRootlessPorts(expose bool) error {
+	if len(c.config.PortMappings) == 0 || !rootless.IsRootless() {
+		return nil
+	}
+	logrus.Debugf("setting rootless ports of container %s exposed to %t", c.config.ID, expose)
+
+	conn, err := openUnixSocket(filepath.Join(c.runtime.config.Engine.TmpDir, "rp", c.config.ID))
+	if err != nil {
+		return fmt.Errorf("could not connect to the rootlessport process: %w", err)
+	}
+	defer conn.Close()
+	// Must match the portGate type in cmd/rootlessport.
+	gate := struct {
+		Expose bool
+	}{Expose: expose}
+	if err := json.NewEncoder(conn).Encode(gate); err != nil {
+		return fmt.Errorf("port gating failed: %w", err)
+	}
+	b, err := io.ReadAll(conn)
+	if err != nil {
+		return fmt.Errorf("port gating failed: %w", err)
+	}
+	if data := string(b); data != "OK" {
+		return fmt.Errorf("port gating failed: %s", data)
+	}
+	return nil
+}
+
 // reloadRootlessRLKPortMapping will trigger a reload for the port mappings in the rootlessport process.
 // This should only be called by network connect/disconnect and only as rootless.
 func (c *Container) reloadRootlessRLKPortMapping() error {
@@ -71,6 +71,9 @@ type OCIRuntime interface { //nolint:interfacebloat
 	HTTPAttach(ctr *Container, r *http.Request, w http.ResponseWriter, streams *HTTPAttachStreams, detachKeys *string, cancel <-chan bool, hijackDone chan<- bool, streamAttach, streamLogs bool) error
 	// AttachResize resizes the terminal in use by the given container.
 	AttachResize(ctr *Container, newSize resize.TerminalSize) error
+	// ReopenContainerLog makes the monitor of the given container reopen
+	// the log file, e.g. after it was rotated.
+	ReopenContainerLog(ctr *Container) error
 
 	// ExecContainer executes a command in a running container.
 	// Returns an int (PID of exec session), error channel (errors from
@@ -804,6 +804,23 @@ func (r *ConmonOCIRuntime) AttachResize(ctr *Container, newSize resize.TerminalS
 	return nil
 }
 
+// ReopenContainerLog makes conmon reopen the log file of the given container.
+// Conmon replaces the log file with an empty one when reopening it.
+func (r *ConmonOCIRuntime) ReopenContainerLog(ctr *Container) error {
+	controlFile, err := openControlFile(ctr, ctr.bundlePath())
+	if err != nil {
+		return err
+	}
+	defer controlFile.Close()
+
+	logrus.Debugf("Reopening the log file of container %s", ctr.ID())
+	if _, err = fmt.Fprintf(controlFile, "%d %d %d\n", 2, 0, 0); err != nil {
+		return fmt.Errorf("failed to write to ctl file to reopen log file: %w", err)
+	}
+
+	return nil
+}
+
 // CheckpointContainer checkpoints the given container.
 func (r *ConmonOCIRuntime) CheckpointContainer(ctr *Container, options ContainerCheckpointOptions) (int64, error) {
 	// imagePath is used by CRIU to store the actual checkpoint files
@@ -1423,6 +1440,11 @@ func (r *ConmonOCIRuntime) sharedConmonArgs(ctr *Container, cuuid, bundlePath, p
 		size = ctr.config.LogSize
 	}
 	if size > 0 {
+		// Podman rotates the log at its maximum size, conmon only
+		// truncates it if it grows faster than it is rotated.
+		if logPath == ctr.LogPath() && ctr.rotatesLog() {
+			size *= logSizeHardCapFactor
+		}
 		args = append(args, "--log-size-max", strconv.FormatInt(size, 10))
 	}
 
@@ -131,6 +131,11 @@ func (r *MissingRuntime) AttachResize(ctr *Container, newSize resize.TerminalSiz
 	return r.printError()
 }
 
+// ReopenContainerLog is not available as the runtime is missing
+func (r *MissingRuntime) ReopenContainerLog(ctr *Container) error {
+	return r.printError()
+}
+
 // ExecContainer is not available as the runtime is missing
 func (r *MissingRuntime) ExecContainer(ctr *Container, sessionID string, options *ExecOptions, streams *define.AttachStreams, newSize *resize.TerminalSize) (int, chan error, error) {
 	return -1, nil, r.printError()
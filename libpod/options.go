@@ -28,6 +28,7 @@ import (
 	"github.com/opencontainers/runtime-spec/specs-go"
 	"github.com/opencontainers/runtime-tools/generate"
 	"github.com/sirupsen/logrus"
+	"golang.org/x/exp/slices"
 )
 
 var umaskRegex = regexp.Delayed(`^[0-7]{1,4}$`)
@@ -545,6 +546,31 @@ func WithMaxLogSize(limit int64) CtrCreateOption {
 	}
 }
 
+// WithLogMaxFile sets the number of log files kept when rotating the log of
+// the container.  Rotation requires a maximum log size.
+func WithLogMaxFile(maxFile uint) CtrCreateOption {
+	return func(ctr *Container) error {
+		if ctr.valid {
+			return define.ErrRuntimeFinalized
+		}
+		ctr.config.LogMaxFile = maxFile
+
+		return nil
+	}
+}
+
+// WithLogCompress compresses the rotated log files of the container.
+func WithLogCompress() CtrCreateOption {
+	return func(ctr *Container) error {
+		if ctr.valid {
+			return define.ErrRuntimeFinalized
+		}
+		ctr.config.LogCompress = true
+
+		return nil
+	}
+}
+
 // WithShmDir sets the directory that should be mounted on /dev/shm.
 func WithShmDir(dir string) CtrCreateOption {
 	return func(ctr *Container) error {
@@ -1054,6 +1080,34 @@ func WithDependencyCtrs(ctrs []*Container) CtrCreateOption {
 	}
 }
 
+// WithDependencyConditions sets the conditions the dependency containers,
+// keyed by their IDs, must meet before the container is started, and the time
+// in seconds to wait for them.  The dependencies must have been set with
+// WithDependencyCtrs before.
+func WithDependencyConditions(conditions map[string]define.DependencyCondition, timeout uint) CtrCreateOption {
+	return func(ctr *Container) error {
+		if ctr.valid {
+			return define.ErrCtrFinalized
+		}
+
+		for id, condition := range conditions {
+			if !slices.Contains(ctr.config.Dependencies, id) {
+				return fmt.Errorf("container %s is not a dependency, cannot set condition %q for it: %w", id, condition, define.ErrInvalidArg)
+			}
+			switch condition {
+			case define.DependencyConditionStarted, define.DependencyConditionHealthy, define.DependencyConditionCompletedSuccessfully:
+			default:
+				return fmt.Errorf("invalid condition %q of dependency %s: %w", condition, id, define.ErrInvalidArg)
+			}
+		}
+
+		ctr.config.DependencyConditions = conditions
+		ctr.config.DependencyTimeout = timeout
+
+		return nil
+	}
+}
+
 // WithNetNS indicates that the container should be given a new network
 // namespace with a minimal configuration.
 // An optional array of port mappings can be provided.
@@ -1418,6 +1472,30 @@ func WithRestartRetries(tries uint) CtrCreateOption {
 	}
 }
 
+// WithRestartBackoff sets the exponential backoff between restarts of the
+// container by its restart policy.  delay is the delay before the second
+// restart, and doubles with every restart up to maxDelay.  A delay of 0
+// disables the backoff.
+func WithRestartBackoff(delay, maxDelay time.Duration) CtrCreateOption {
+	return func(ctr *Container) error {
+		if ctr.valid {
+			return define.ErrCtrFinalized
+		}
+
+		if delay < 0 || maxDelay < 0 {
+			return fmt.Errorf("restart delays must not be negative: %w", define.ErrInvalidArg)
+		}
+		if delay > 0 && maxDelay < delay {
+			return fmt.Errorf("maximum restart delay %s must not be shorter than the restart delay %s: %w", maxDelay, delay, define.ErrInvalidArg)
+		}
+
+		ctr.config.RestartDelay = delay
+		ctr.config.RestartMaxDelay = maxDelay
+
+		return nil
+	}
+}
+
 // WithNamedVolumes adds the given named volumes to the container.
 func WithNamedVolumes(volumes []*ContainerNamedVolume) CtrCreateOption {
 	return func(ctr *Container) error {
@@ -1942,6 +2020,75 @@ func WithStartupHealthcheck(startupHC *define.StartupHealthCheck) CtrCreateOptio
 	}
 }
 
+// WithReadinessHealthcheck sets a readiness healthcheck for the container.
+func WithReadinessHealthcheck(readinessHC *define.ReadinessHealthCheck) CtrCreateOption {
+	return func(ctr *Container) error {
+		if ctr.valid {
+			return define.ErrCtrFinalized
+		}
+		ctr.config.ReadinessHealthCheckConfig = new(define.ReadinessHealthCheck)
+		if err := JSONDeepCopy(readinessHC, ctr.config.ReadinessHealthCheckConfig); err != nil {
+			return fmt.Errorf("error copying readiness healthcheck into container: %w", err)
+		}
+		return nil
+	}
+}
+
+// WithReadinessGate withholds the published ports of the container until its
+// readiness healthcheck passed.
+// Requires that a readiness healthcheck must be set.
+func WithReadinessGate() CtrCreateOption {
+	return func(ctr *Container) error {
+		if ctr.valid {
+			return define.ErrCtrFinalized
+		}
+		ctr.config.ReadinessGate = true
+		return nil
+	}
+}
+
+// WithSchedule sets a systemd calendar expression on which the container is
+// started by a transient systemd timer.
+func WithSchedule(schedule string) CtrCreateOption {
+	return func(ctr *Container) error {
+		if ctr.valid {
+			return define.ErrCtrFinalized
+		}
+		ctr.config.Schedule = schedule
+		return nil
+	}
+}
+
+// WithPostStartHook sets a lifecycle hook that is run after the container
+// was started.
+func WithPostStartHook(hook *define.LifecycleHook) CtrCreateOption {
+	return func(ctr *Container) error {
+		if ctr.valid {
+			return define.ErrCtrFinalized
+		}
+		if hook == nil {
+			return fmt.Errorf("must provide a valid post-start hook: %w", define.ErrInvalidArg)
+		}
+		ctr.config.PostStartHook = hook
+		return nil
+	}
+}
+
+// WithPreStopHook sets a lifecycle hook that is run before the container is
+// sent its stop signal.
+func WithPreStopHook(hook *define.LifecycleHook) CtrCreateOption {
+	return func(ctr *Container) error {
+		if ctr.valid {
+			return define.ErrCtrFinalized
+		}
+		if hook == nil {
+			return fmt.Errorf("must provide a valid pre-stop hook: %w", define.ErrInvalidArg)
+		}
+		ctr.config.PreStopHook = hook
+		return nil
+	}
+}
+
 // Pod Creation Options
 
 // WithPodCreateCommand adds the full command plus arguments of the current
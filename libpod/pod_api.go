@@ -8,6 +8,7 @@ import (
 	"fmt"
 
 	"github.com/containers/common/pkg/cgroups"
+	"github.com/containers/common/pkg/config"
 	"github.com/containers/podman/v4/libpod/define"
 	"github.com/containers/podman/v4/libpod/events"
 	"github.com/containers/podman/v4/pkg/parallel"
@@ -605,6 +606,100 @@ func containerStatusFromContainers(allCtrs []*Container) (map[string]define.Cont
 	return status, nil
 }
 
+// PodUpdateOptions describes the changes made to a pod by Update.  Nil fields
+// are left unchanged.
+type PodUpdateOptions struct {
+	// Resources are merged into the resource limits of the pod.  They are
+	// applied to the cgroup of the pod and to all of its containers, except
+	// for the infra container.
+	Resources *specs.LinuxResources
+	// RestartPolicy is the new restart policy of the pod.  It is also set
+	// on all containers of the pod, except for init containers.
+	RestartPolicy *string
+	// RestartRetries is the new number of retries for the "on-failure"
+	// restart policy.  It is only used if RestartPolicy is set.
+	RestartRetries *uint
+	// ExitPolicy is the new exit policy of the pod.
+	ExitPolicy *string
+}
+
+// Update changes the resource limits, the restart policy and the exit policy
+// of the pod in place.  The new configuration of the pod is persisted.
+// Resource limits are applied to the containers of the pod which are created,
+// running or paused; restart policies to all containers.
+// An error and a map[string]error are returned, with the same semantics as
+// for Start.
+func (p *Pod) Update(ctx context.Context, options *PodUpdateOptions) (map[string]error, error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if !p.valid {
+		return nil, define.ErrPodRemoved
+	}
+	if err := p.updatePod(); err != nil {
+		return nil, err
+	}
+
+	newConfig := new(PodConfig)
+	if err := JSONDeepCopy(p.config, newConfig); err != nil {
+		return nil, err
+	}
+	if options.ExitPolicy != nil {
+		parsed, err := config.ParsePodExitPolicy(*options.ExitPolicy)
+		if err != nil {
+			return nil, err
+		}
+		newConfig.ExitPolicy = parsed
+	}
+	var restartRetries uint
+	if options.RestartPolicy != nil {
+		switch *options.RestartPolicy {
+		case define.RestartPolicyNone, define.RestartPolicyNo, define.RestartPolicyOnFailure, define.RestartPolicyAlways, define.RestartPolicyUnlessStopped:
+		default:
+			return nil, fmt.Errorf("%q is not a valid restart policy: %w", *options.RestartPolicy, define.ErrInvalidArg)
+		}
+		if options.RestartRetries != nil {
+			restartRetries = *options.RestartRetries
+		}
+		newConfig.RestartPolicy = *options.RestartPolicy
+		newConfig.RestartRetries = &restartRetries
+	}
+	if options.Resources != nil {
+		// Decoding into the existing limits only overwrites the limits
+		// which are set in the update.
+		if err := JSONDeepCopy(options.Resources, &newConfig.ResourceLimits); err != nil {
+			return nil, err
+		}
+		if err := p.updatePodCgroup(&newConfig.ResourceLimits); err != nil {
+			return nil, fmt.Errorf("updating cgroup of pod %s: %w", p.ID(), err)
+		}
+	}
+
+	if err := p.runtime.state.RewritePodConfig(p, newConfig); err != nil {
+		return nil, fmt.Errorf("rewriting config of pod %s: %w", p.ID(), err)
+	}
+	p.config = newConfig
+
+	allCtrs, err := p.runtime.state.PodContainers(p)
+	if err != nil {
+		return nil, err
+	}
+
+	ctrErrors := make(map[string]error)
+	for _, ctr := range allCtrs {
+		if err := ctr.podUpdate(options.Resources, options.RestartPolicy, restartRetries); err != nil {
+			ctrErrors[ctr.ID()] = err
+		}
+	}
+
+	p.newPodEvent(events.Update)
+
+	if len(ctrErrors) > 0 {
+		return ctrErrors, fmt.Errorf("updating some containers: %w", define.ErrPodPartialFail)
+	}
+	return nil, nil
+}
+
 // Inspect returns a PodInspect struct to describe the pod.
 func (p *Pod) Inspect() (*define.InspectPodData, error) {
 	p.lock.Lock()
@@ -7,6 +7,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"io/fs"
 	"os"
 	"path/filepath"
@@ -289,6 +290,15 @@ func getLockManager(runtime *Runtime) (lock.Manager, error) {
 	return manager, nil
 }
 
+// boltStatePath returns the path of the BoltDB database of the runtime.
+func boltStatePath(runtime *Runtime) string {
+	baseDir := runtime.config.Engine.StaticDir
+	if runtime.storageConfig.TransientStore {
+		baseDir = runtime.config.Engine.TmpDir
+	}
+	return filepath.Join(baseDir, "bolt_state.db")
+}
+
 func getDBState(runtime *Runtime) (State, error) {
 	// TODO - if we further break out the state implementation into
 	// libpod/state, the config could take care of the code below.  It
@@ -300,11 +310,7 @@ func getDBState(runtime *Runtime) (State, error) {
 	}
 
 	// get default boltdb path
-	baseDir := runtime.config.Engine.StaticDir
-	if runtime.storageConfig.TransientStore {
-		baseDir = runtime.config.Engine.TmpDir
-	}
-	boltDBPath := filepath.Join(baseDir, "bolt_state.db")
+	boltDBPath := boltStatePath(runtime)
 
 	switch backend {
 	case config.DBBackendDefault:
@@ -797,6 +803,13 @@ func (r *Runtime) Shutdown(force bool) error {
 			lastError = fmt.Errorf("shutting down container storage: %w", err)
 		}
 	}
+
+	// Wait for the events to be delivered to the event sinks.
+	if closer, ok := r.eventer.(io.Closer); ok {
+		if err := closer.Close(); err != nil {
+			logrus.Errorf("Closing event sinks: %v", err)
+		}
+	}
 	if err := r.state.Close(); err != nil {
 		if lastError != nil {
 			logrus.Error(lastError)
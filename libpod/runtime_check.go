@@ -0,0 +1,485 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/containers/podman/v4/libpod/define"
+	"github.com/containers/storage"
+	"github.com/containers/storage/pkg/stringid"
+	"github.com/sirupsen/logrus"
+)
+
+// Categories of the problems found by SystemCheck.
+const (
+	// SystemCheckLock is a lock used by more than one container, pod or
+	// volume.
+	SystemCheckLock = "lock"
+	// SystemCheckLayer is a damaged or unreferenced layer.
+	SystemCheckLayer = "layer"
+	// SystemCheckImage is a damaged image.
+	SystemCheckImage = "image"
+	// SystemCheckContainer is a container in the database whose storage
+	// is missing or damaged.
+	SystemCheckContainer = "container"
+	// SystemCheckStorageContainer is a container in storage which is not
+	// in the database.
+	SystemCheckStorageContainer = "storage container"
+	// SystemCheckVolume is a volume whose mountpoint is missing.
+	SystemCheckVolume = "volume"
+	// SystemCheckExecSession is an exec session which is stale, or the
+	// directory of an exec session which does not exist.
+	SystemCheckExecSession = "exec session"
+)
+
+// SystemCheckOptions describes the checks and repairs done by SystemCheck.
+type SystemCheckOptions struct {
+	// Quick skips the slow checks of the contents of layers.
+	Quick bool
+	// Repair repairs the problems which can be repaired without losing
+	// data.
+	Repair bool
+	// RepairLossy also removes damaged containers and containers in
+	// storage which are not in the database.  Requires Repair.
+	RepairLossy bool
+	// UnreferencedLayerMaximumAge is the age a layer which no image or
+	// container uses must reach before it is reported.  Defaults to 24
+	// hours if nil.
+	UnreferencedLayerMaximumAge *time.Duration
+}
+
+// SystemCheckProblem is a problem found by SystemCheck.
+type SystemCheckProblem struct {
+	// Category is one of the SystemCheck* constants.
+	Category string
+	// ID identifies the problematic object within its category.
+	ID string
+	// Description describes the problem.
+	Description string
+	// Repaired is set if the problem was repaired.
+	Repaired bool
+	// RepairError is the error which occurred when repairing the problem.
+	RepairError error
+}
+
+// SystemCheckReport lists the problems found by SystemCheck.
+type SystemCheckReport struct {
+	Problems []SystemCheckProblem
+}
+
+// systemCheck collects the problems found by SystemCheck.
+type systemCheck struct {
+	options  SystemCheckOptions
+	problems []SystemCheckProblem
+}
+
+// add records a problem.  If repair is not nil and repairs are requested, the
+// problem is repaired by calling it.
+func (s *systemCheck) add(category, id, description string, repair func() error) {
+	problem := SystemCheckProblem{
+		Category:    category,
+		ID:          id,
+		Description: description,
+	}
+	if repair != nil && s.options.Repair {
+		if err := repair(); err != nil {
+			problem.RepairError = err
+		} else {
+			problem.Repaired = true
+		}
+	}
+	s.problems = append(s.problems, problem)
+}
+
+// SystemCheck cross-checks the database of the runtime against the storage
+// of its containers, images and volumes, and looks for lock conflicts and
+// stale exec sessions.  If options.Repair is set, the problems which can be
+// repaired safely are repaired.  Lock conflicts are only repaired if no
+// container is running, and repairing them shuts the runtime down, as
+// RenumberLocks does.
+func (r *Runtime) SystemCheck(ctx context.Context, options SystemCheckOptions) (*SystemCheckReport, error) {
+	if !r.valid {
+		return nil, define.ErrRuntimeStopped
+	}
+	if options.RepairLossy && !options.Repair {
+		return nil, fmt.Errorf("removing damaged containers requires repairing: %w", define.ErrInvalidArg)
+	}
+
+	check := &systemCheck{options: options}
+
+	lockConflicts, err := r.checkLocks(check)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.checkStorage(check); err != nil {
+		return nil, err
+	}
+	if err := r.checkContainerStorage(ctx, check); err != nil {
+		return nil, err
+	}
+	if err := r.checkVolumes(check); err != nil {
+		return nil, err
+	}
+	if err := r.checkExecSessions(check); err != nil {
+		return nil, err
+	}
+
+	// Renumbering the locks shuts the runtime down, so it goes last.  It
+	// allocates all locks again, so the locks freed by the repairs above
+	// for objects which shared them with others do not matter.
+	if options.Repair && lockConflicts {
+		err := r.repairLocks()
+		for i := range check.problems {
+			if check.problems[i].Category != SystemCheckLock {
+				continue
+			}
+			if err != nil {
+				check.problems[i].RepairError = err
+			} else {
+				check.problems[i].Repaired = true
+			}
+		}
+	}
+
+	return &SystemCheckReport{Problems: check.problems}, nil
+}
+
+// checkLocks reports locks used by more than one object and returns whether
+// there are any.  They are repaired by repairLocks.
+func (r *Runtime) checkLocks(check *systemCheck) (bool, error) {
+	conflicts, _, err := r.LockConflicts()
+	if err != nil {
+		return false, err
+	}
+	lockNums := make([]uint32, 0, len(conflicts))
+	for lockNum := range conflicts {
+		lockNums = append(lockNums, lockNum)
+	}
+	sort.Slice(lockNums, func(i, j int) bool { return lockNums[i] < lockNums[j] })
+
+	for _, lockNum := range lockNums {
+		objects := conflicts[lockNum]
+		sort.Strings(objects)
+		check.add(SystemCheckLock, fmt.Sprintf("%d", lockNum), "used by "+strings.Join(objects, ", "), nil)
+	}
+	return len(lockNums) > 0, nil
+}
+
+// repairLocks renumbers all locks, which shuts the runtime down.  Renumbering
+// the locks of running containers would break them, so it refuses to if any
+// container is running.
+func (r *Runtime) repairLocks() error {
+	ctrs, err := r.state.AllContainers(false)
+	if err != nil {
+		return err
+	}
+	for _, ctr := range ctrs {
+		running, err := ctr.checkRunning()
+		if err != nil {
+			return err
+		}
+		if running {
+			return fmt.Errorf("container %s is running, stop all containers to renumber the locks: %w", ctr.ID(), define.ErrCtrStateInvalid)
+		}
+	}
+	return r.RenumberLocks()
+}
+
+// checkRunning returns whether the container is running or paused, after
+// syncing its state.  A container removed meanwhile is not running.
+func (c *Container) checkRunning() (bool, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if err := c.syncContainer(); err != nil {
+		if errors.Is(err, define.ErrNoSuchCtr) || errors.Is(err, define.ErrCtrRemoved) {
+			return false, nil
+		}
+		return false, err
+	}
+	return c.ensureState(define.ContainerStateRunning, define.ContainerStatePaused), nil
+}
+
+// checkStorage reports damaged layers, images and containers, and layers
+// which no image or container uses, as found by c/storage.  Damaged
+// containers are only removed if lossy repairs are requested.
+func (r *Runtime) checkStorage(check *systemCheck) error {
+	checkOptions := storage.CheckEverything()
+	if check.options.Quick {
+		checkOptions = storage.CheckMost()
+	}
+	checkOptions.LayerUnreferencedMaximumAge = check.options.UnreferencedLayerMaximumAge
+	report, err := r.store.Check(checkOptions)
+	if err != nil {
+		return fmt.Errorf("checking storage: %w", err)
+	}
+
+	// Running containers keep their storage, however damaged it is.
+	damagedCtrs := report.Containers
+	running := make(map[string]bool)
+	report.Containers = make(map[string][]error, len(damagedCtrs))
+	for id, errs := range damagedCtrs {
+		ctr, err := r.state.Container(id)
+		if err == nil {
+			ctrRunning, err := ctr.checkRunning()
+			if err != nil {
+				return err
+			}
+			if ctrRunning {
+				running[id] = true
+				continue
+			}
+		} else if !errors.Is(err, define.ErrNoSuchCtr) {
+			return err
+		}
+		report.Containers[id] = errs
+	}
+
+	var repairErrs []error
+	if check.options.Repair {
+		repairErrs = r.store.Repair(report, &storage.RepairOptions{RemoveContainers: check.options.RepairLossy})
+	}
+	// c/storage does not tell which repair failed, so the errors are
+	// matched by the ID they mention.
+	repair := func(id string) func() error {
+		return func() error {
+			for _, err := range repairErrs {
+				if strings.Contains(err.Error(), id) {
+					return err
+				}
+			}
+			return nil
+		}
+	}
+
+	addAll := func(category, kind string, damaged map[string][]error, readOnly bool) {
+		ids := make([]string, 0, len(damaged))
+		for id := range damaged {
+			ids = append(ids, id)
+		}
+		sort.Strings(ids)
+		for _, id := range ids {
+			for _, err := range damaged[id] {
+				var fix func() error
+				if !readOnly && !running[id] && (kind != "container" || check.options.RepairLossy) {
+					fix = repair(id)
+				}
+				description := err.Error()
+				switch {
+				case readOnly:
+					description = fmt.Sprintf("read-only %s: %s", kind, description)
+				case running[id]:
+					description += ", not removing it as it is running"
+				}
+				check.add(category, id, description, fix)
+			}
+		}
+	}
+	addAll(SystemCheckLayer, "layer", report.Layers, false)
+	addAll(SystemCheckLayer, "layer", report.ROLayers, true)
+	addAll(SystemCheckImage, "image", report.Images, false)
+	addAll(SystemCheckImage, "image", report.ROImages, true)
+	addAll(SystemCheckContainer, "container", damagedCtrs, false)
+	return nil
+}
+
+// checkContainerStorage reports containers in the database without a
+// container in storage, which are evicted, and containers in storage
+// without a container in the database, which are only removed if lossy
+// repairs are requested as they may belong to other tools such as Buildah.
+func (r *Runtime) checkContainerStorage(ctx context.Context, check *systemCheck) error {
+	ctrs, err := r.state.AllContainers(false)
+	if err != nil {
+		return err
+	}
+	for _, ctr := range ctrs {
+		// Containers with a rootfs have no container in storage.
+		if ctr.config.Rootfs != "" {
+			continue
+		}
+		if _, err := r.store.Container(ctr.ID()); err == nil {
+			continue
+		} else if !errors.Is(err, storage.ErrContainerUnknown) {
+			return fmt.Errorf("looking up container %s in storage: %w", ctr.ID(), err)
+		}
+
+		running, err := ctr.checkRunning()
+		if err != nil {
+			return err
+		}
+		description := "storage of the container is missing"
+		var evict func() error
+		if running {
+			description += ", not evicting it as it is running"
+		} else {
+			evict = func() error {
+				_, err := r.EvictContainer(ctx, ctr.ID(), false)
+				return err
+			}
+		}
+		check.add(SystemCheckContainer, ctr.ID(), description, evict)
+	}
+
+	storageCtrs, err := r.ListStorageContainers()
+	if err != nil {
+		return err
+	}
+	for _, storageCtr := range storageCtrs {
+		if storageCtr.PresentInLibpod {
+			continue
+		}
+		id := storageCtr.ID
+		var remove func() error
+		if check.options.RepairLossy {
+			remove = func() error {
+				return r.RemoveStorageContainer(id, true)
+			}
+		}
+		check.add(SystemCheckStorageContainer, id, "not known to Podman, it may have been created by another tool such as Buildah", remove)
+	}
+	return nil
+}
+
+// checkVolumes reports local volumes whose mountpoint is missing.  The
+// mountpoints are created again, empty.
+func (r *Runtime) checkVolumes(check *systemCheck) error {
+	vols, err := r.state.AllVolumes()
+	if err != nil {
+		return err
+	}
+	for _, vol := range vols {
+		if vol.UsesVolumeDriver() || vol.config.Driver == define.VolumeDriverImage || vol.config.MountPoint == "" {
+			continue
+		}
+		if _, err := os.Lstat(vol.config.MountPoint); err == nil {
+			continue
+		} else if !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("checking mountpoint of volume %s: %w", vol.Name(), err)
+		}
+
+		vol := vol
+		check.add(SystemCheckVolume, vol.Name(), fmt.Sprintf("mountpoint %s is missing, recreating it leaves the volume empty", vol.config.MountPoint), func() error {
+			vol.lock.Lock()
+			defer vol.lock.Unlock()
+			_, err := r.createVolumeMountPoint(vol)
+			return err
+		})
+	}
+	return nil
+}
+
+// checkExecSessions reports exec sessions recorded as running in containers
+// which are not running, and directories of exec sessions of containers which
+// no exec session refers to.  Both are removed.
+func (r *Runtime) checkExecSessions(check *systemCheck) error {
+	ctrs, err := r.state.AllContainers(false)
+	if err != nil {
+		return err
+	}
+	for _, ctr := range ctrs {
+		if err := ctr.checkExecSessions(check); err != nil {
+			if errors.Is(err, define.ErrNoSuchCtr) || errors.Is(err, define.ErrCtrRemoved) {
+				continue
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// checkExecSessions reports the stale exec sessions and exec session
+// directories of the container.
+func (c *Container) checkExecSessions(check *systemCheck) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if err := c.syncContainer(); err != nil {
+		return err
+	}
+
+	if c.state.State != define.ContainerStateRunning && c.state.State != define.ContainerStatePaused {
+		sessionIDs := make([]string, 0, len(c.state.ExecSessions))
+		for id, session := range c.state.ExecSessions {
+			if session.State == define.ExecStateRunning {
+				sessionIDs = append(sessionIDs, id)
+			}
+		}
+		sort.Strings(sessionIDs)
+		for _, id := range sessionIDs {
+			id := id
+			check.add(SystemCheckExecSession, id, fmt.Sprintf("running in container %s, which is %s", c.ID(), c.state.State), func() error {
+				return c.removeStaleExecSession(id)
+			})
+		}
+	}
+
+	known := make(map[string]bool, len(c.state.ExecSessions)+len(c.state.LegacyExecSessions))
+	for id := range c.state.ExecSessions {
+		known[id] = true
+	}
+	for id := range c.state.LegacyExecSessions {
+		known[id] = true
+	}
+	orphaned, err := orphanedExecBundles(c.bundlePath(), known)
+	if err != nil {
+		return err
+	}
+	for _, id := range orphaned {
+		path := c.execBundlePath(id)
+		check.add(SystemCheckExecSession, id, fmt.Sprintf("directory %s of container %s belongs to no exec session", path, c.ID()), func() error {
+			return os.RemoveAll(path)
+		})
+	}
+	return nil
+}
+
+// removeStaleExecSession removes an exec session which is recorded as running
+// although the container is not.  Its processes died with the container, so
+// there is nothing to stop.
+func (c *Container) removeStaleExecSession(sessionID string) error {
+	session, ok := c.state.ExecSessions[sessionID]
+	if !ok {
+		return nil
+	}
+	if err := c.cleanupExecBundle(sessionID); err != nil {
+		return err
+	}
+	if err := c.runtime.state.RemoveExecSession(session); err != nil && !errors.Is(err, define.ErrNoSuchExecSession) {
+		return err
+	}
+	delete(c.state.ExecSessions, sessionID)
+	return c.save()
+}
+
+// orphanedExecBundles returns the IDs of the exec session directories in the
+// bundle directory of a container which are not known exec sessions.  Exec
+// session directories are named by the session ID and contain an exit
+// directory.
+func orphanedExecBundles(bundlePath string, known map[string]bool) ([]string, error) {
+	entries, err := os.ReadDir(bundlePath)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("reading container directory %s: %w", bundlePath, err)
+	}
+	var orphaned []string
+	for _, entry := range entries {
+		id := entry.Name()
+		if !entry.IsDir() || known[id] || stringid.ValidateID(id) != nil {
+			continue
+		}
+		if info, err := os.Stat(filepath.Join(bundlePath, id, "exit")); err != nil || !info.IsDir() {
+			logrus.Debugf("Ignoring directory %s of container, it is not an exec session directory", id)
+			continue
+		}
+		orphaned = append(orphaned, id)
+	}
+	return orphaned, nil
+}
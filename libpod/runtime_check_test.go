@@ -0,0 +1,38 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/containers/storage/pkg/stringid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrphanedExecBundles(t *testing.T) {
+	bundlePath := t.TempDir()
+
+	orphaned, err := orphanedExecBundles(filepath.Join(bundlePath, "missing"), nil)
+	require.NoError(t, err)
+	assert.Empty(t, orphaned)
+
+	known := stringid.GenerateRandomID()
+	unknown := stringid.GenerateRandomID()
+	withoutExit := stringid.GenerateRandomID()
+	for _, dir := range []string{
+		filepath.Join(known, "exit"),
+		filepath.Join(unknown, "exit"),
+		withoutExit,
+		filepath.Join("artifacts", "exit"),
+	} {
+		require.NoError(t, os.MkdirAll(filepath.Join(bundlePath, dir), 0700))
+	}
+	require.NoError(t, os.WriteFile(filepath.Join(bundlePath, "config.json"), nil, 0600))
+
+	orphaned, err = orphanedExecBundles(bundlePath, map[string]bool{known: true})
+	require.NoError(t, err)
+	assert.Equal(t, []string{unknown}, orphaned)
+}
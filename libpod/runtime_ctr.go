@@ -562,6 +562,19 @@ func (r *Runtime) setupContainer(ctx context.Context, ctr *Container) (_ *Contai
 		ctr.config.Mounts = append(ctr.config.Mounts, ctr.config.ShmDir)
 	}
 
+	if ctr.config.Schedule != "" {
+		if err := ctr.createScheduleTimer(); err != nil {
+			return nil, fmt.Errorf("creating schedule timer: %w", err)
+		}
+		defer func() {
+			if retErr != nil {
+				if err := ctr.removeScheduleTimer(context.Background()); err != nil {
+					logrus.Errorf("Removing schedule timer of container %s: %v", ctr.ID(), err)
+				}
+			}
+		}()
+	}
+
 	// Add the container to the state
 	// TODO: May be worth looking into recovering from name/ID collisions here
 	if ctr.config.Pod != "" {
@@ -984,6 +997,13 @@ func (r *Runtime) removeContainer(ctx context.Context, c *Container, opts ctrRmO
 		reportErrorf("cleaning up storage: %w", err)
 	}
 
+	// Remove the timer of the container's schedule.
+	if c.config.Schedule != "" {
+		if err := c.removeScheduleTimer(ctx); err != nil {
+			reportErrorf("removing schedule timer: %w", err)
+		}
+	}
+
 	// Remove the container's CID file on container removal.
 	if cidFile, ok := c.config.Spec.Annotations[define.InspectAnnotationCIDFile]; ok {
 		if err := os.Remove(cidFile); err != nil && !errors.Is(err, os.ErrNotExist) {
@@ -0,0 +1,512 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+
+	"github.com/containers/common/libnetwork/types"
+	"github.com/containers/common/pkg/config"
+	"github.com/containers/podman/v4/libpod/define"
+	"github.com/containers/podman/v4/pkg/util"
+	"github.com/sirupsen/logrus"
+)
+
+// DBMigrationReport describes the migration of the database of the runtime to
+// another database backend.
+type DBMigrationReport struct {
+	// From is the database backend the runtime used before the migration.
+	From string
+	// To is the database backend the runtime uses after the migration.
+	To string
+	// Path is the path of the database of the new backend.
+	Path string
+	// Backup is the path an already existing database of the new backend
+	// is moved to, if there is one.
+	Backup string
+	// DryRun is set if nothing was copied.
+	DryRun bool
+	// Number of copied containers.
+	Containers int
+	// Number of copied pods.
+	Pods int
+	// Number of copied volumes.
+	Volumes int
+	// Number of copied exec sessions.
+	ExecSessions int
+	// Number of copied network attachments of containers.
+	Networks int
+	// Number of copied container exit codes.
+	ExitCodes int
+}
+
+// dbMigrationData is everything copied from one database to another.
+type dbMigrationData struct {
+	volumes []*Volume
+	pods    []*Pod
+	// Sorted so that each container comes after its dependencies.
+	ctrs []*Container
+	// Indexed by container ID.
+	networks     map[string]map[string]types.PerNetworkOptions
+	execSessions map[string][]string
+	exitCodes    map[string]int32
+}
+
+// MigrateDatabase copies all containers, pods, volumes, exec sessions, network
+// attachments and exit codes from the database of the runtime to a database of
+// the given backend, verifies the copy and switches the database backend in
+// containers.conf.  If any of this fails, the new database is removed again.
+// The database of the previous backend is left untouched, so migrating back to
+// it rolls back the migration.  No container may be running.
+// If dryRun is set, the database is only read and the report describes what
+// would be copied.
+func (r *Runtime) MigrateDatabase(backend string, dryRun bool) (_ *DBMigrationReport, retErr error) {
+	to, err := config.ParseDBBackend(backend)
+	if err != nil {
+		return nil, err
+	}
+	if to == config.DBBackendDefault {
+		return nil, fmt.Errorf("database backend to migrate to must be %s or %s: %w", config.DBBackendBoltDB, config.DBBackendSQLite, define.ErrInvalidArg)
+	}
+	from, err := config.ParseDBBackend(r.config.Engine.DBBackend)
+	if err != nil {
+		return nil, err
+	}
+	if from == to {
+		return nil, fmt.Errorf("database backend is already %s: %w", to, define.ErrInvalidArg)
+	}
+
+	// Acquire the alive lock and hold it.
+	// Ensures that we don't let other Podman commands run while we are
+	// copying the DB.
+	aliveLock, err := r.getRuntimeAliveLock()
+	if err != nil {
+		return nil, fmt.Errorf("retrieving alive lock: %w", err)
+	}
+	aliveLock.Lock()
+	defer aliveLock.Unlock()
+
+	if !r.valid {
+		return nil, define.ErrRuntimeStopped
+	}
+
+	var path string
+	switch to {
+	case config.DBBackendBoltDB:
+		path = boltStatePath(r)
+	case config.DBBackendSQLite:
+		path = filepath.Join(sqliteStateDir(r), sqliteDBFile)
+	}
+	exists := true
+	if _, err := os.Stat(path); err != nil {
+		if !errors.Is(err, fs.ErrNotExist) {
+			return nil, err
+		}
+		exists = false
+	}
+	// BoltDB is deprecated, so we only allow migrating back to an existing
+	// BoltDB database.
+	if to == config.DBBackendBoltDB && !exists {
+		return nil, fmt.Errorf("the BoltDB backend has been deprecated, no new BoltDB databases can be created, %s does not exist: %w", path, define.ErrInvalidArg)
+	}
+
+	data, err := readMigrationData(r.state)
+	if err != nil {
+		return nil, err
+	}
+
+	report := data.report()
+	report.From = from.String()
+	report.To = to.String()
+	report.Path = path
+	if exists {
+		report.Backup = path + ".bak"
+	}
+	if dryRun {
+		report.DryRun = true
+		return report, nil
+	}
+
+	for _, ctr := range data.ctrs {
+		switch ctr.state.State {
+		case define.ContainerStateRunning, define.ContainerStatePaused, define.ContainerStateStopping:
+			return nil, fmt.Errorf("container %s is %s, all containers must be stopped to migrate the database: %w", ctr.ID(), ctr.state.State, define.ErrCtrStateInvalid)
+		}
+	}
+
+	logrus.Infof("Migrating database from %s to %s at %s", from, to, path)
+
+	if exists {
+		if err := renameDBFiles(path, report.Backup); err != nil {
+			return nil, fmt.Errorf("moving existing %s database out of the way: %w", to, err)
+		}
+	}
+	defer func() {
+		if retErr == nil {
+			return
+		}
+		logrus.Infof("Rolling back migration of the database to %s", to)
+		if err := removeDBFiles(path); err != nil {
+			logrus.Errorf("Removing %s database %s: %v", to, path, err)
+			return
+		}
+		if exists {
+			if err := renameDBFiles(report.Backup, path); err != nil {
+				logrus.Errorf("Restoring %s database %s: %v", to, path, err)
+			}
+		}
+	}()
+
+	var state State
+	switch to {
+	case config.DBBackendBoltDB:
+		state, err = newBoltState(path, r, true)
+	case config.DBBackendSQLite:
+		state, err = NewSqliteState(r)
+	}
+	if err != nil {
+		return nil, err
+	}
+	err = state.ValidateDBConfig(r)
+	if err == nil {
+		err = data.copyTo(state)
+	}
+	if err != nil {
+		if err := state.Close(); err != nil {
+			logrus.Errorf("Closing %s database: %v", to, err)
+		}
+		return nil, err
+	}
+	if err := state.Close(); err != nil {
+		return nil, fmt.Errorf("closing %s database: %w", to, err)
+	}
+
+	// Switch to the new database backend.
+	cfg, err := config.ReadCustomConfig()
+	if err != nil {
+		return nil, err
+	}
+	cfg.Engine.DBBackend = to.String()
+	if err := cfg.Write(); err != nil {
+		return nil, fmt.Errorf("switching database backend in containers.conf: %w", err)
+	}
+
+	return report, nil
+}
+
+// readMigrationData reads everything that is copied by a database migration
+// from the given state.
+func readMigrationData(state State) (*dbMigrationData, error) {
+	data := &dbMigrationData{
+		networks:     make(map[string]map[string]types.PerNetworkOptions),
+		execSessions: make(map[string][]string),
+	}
+
+	volumes, err := state.AllVolumes()
+	if err != nil {
+		return nil, fmt.Errorf("retrieving volumes: %w", err)
+	}
+	for _, vol := range volumes {
+		if err := state.UpdateVolume(vol); err != nil {
+			return nil, fmt.Errorf("retrieving state of volume %s: %w", vol.Name(), err)
+		}
+	}
+	data.volumes = volumes
+
+	pods, err := state.AllPods()
+	if err != nil {
+		return nil, fmt.Errorf("retrieving pods: %w", err)
+	}
+	for _, pod := range pods {
+		if err := state.UpdatePod(pod); err != nil {
+			return nil, fmt.Errorf("retrieving state of pod %s: %w", pod.ID(), err)
+		}
+	}
+	data.pods = pods
+
+	ctrs, err := state.AllContainers(true)
+	if err != nil {
+		return nil, fmt.Errorf("retrieving containers: %w", err)
+	}
+	data.ctrs, err = sortContainersByDependencies(ctrs)
+	if err != nil {
+		return nil, err
+	}
+	for _, ctr := range ctrs {
+		networks, err := state.GetNetworks(ctr)
+		if err != nil {
+			return nil, fmt.Errorf("retrieving networks of container %s: %w", ctr.ID(), err)
+		}
+		data.networks[ctr.ID()] = networks
+
+		sessions, err := state.GetContainerExecSessions(ctr)
+		if err != nil {
+			return nil, fmt.Errorf("retrieving exec sessions of container %s: %w", ctr.ID(), err)
+		}
+		sort.Strings(sessions)
+		data.execSessions[ctr.ID()] = sessions
+	}
+
+	data.exitCodes, err = state.AllContainerExitCodes()
+	if err != nil {
+		return nil, fmt.Errorf("retrieving exit codes: %w", err)
+	}
+
+	return data, nil
+}
+
+// sortContainersByDependencies sorts the containers so that each container
+// comes after its dependencies, as the state requires dependencies to be added
+// first.
+func sortContainersByDependencies(ctrs []*Container) ([]*Container, error) {
+	return util.SortByDependencies(ctrs,
+		func(ctr *Container) string { return ctr.ID() },
+		func(ctr *Container) ([]string, error) { return ctr.Dependencies(), nil },
+		func(ctr *Container, dep string) error {
+			return fmt.Errorf("dependency %s of container %s: %w", dep, ctr.ID(), define.ErrNoSuchCtr)
+		})
+}
+
+// report returns a report with the number of items of the data.
+func (d *dbMigrationData) report() *DBMigrationReport {
+	report := &DBMigrationReport{
+		Containers: len(d.ctrs),
+		Pods:       len(d.pods),
+		Volumes:    len(d.volumes),
+		ExitCodes:  len(d.exitCodes),
+	}
+	for _, networks := range d.networks {
+		report.Networks += len(networks)
+	}
+	for _, sessions := range d.execSessions {
+		report.ExecSessions += len(sessions)
+	}
+	return report
+}
+
+// copyTo adds the data to the given empty state and verifies the result.
+func (d *dbMigrationData) copyTo(state State) error {
+	for _, vol := range d.volumes {
+		if err := state.AddVolume(vol); err != nil {
+			return fmt.Errorf("adding volume %s: %w", vol.Name(), err)
+		}
+	}
+
+	pods := make(map[string]*Pod, len(d.pods))
+	for _, pod := range d.pods {
+		// The infra container is added with the other containers of
+		// the pod below, so the pod is added without it first, just
+		// as when it is created.
+		infraID := pod.state.InfraContainerID
+		pod.state.InfraContainerID = ""
+		err := state.AddPod(pod)
+		pod.state.InfraContainerID = infraID
+		if err != nil {
+			return fmt.Errorf("adding pod %s: %w", pod.ID(), err)
+		}
+		pods[pod.ID()] = pod
+	}
+
+	for _, ctr := range d.ctrs {
+		// The network attachments of the container are stored
+		// separately by BoltDB, add them to the config.
+		ctr.config.Networks = d.networks[ctr.ID()]
+		if ctr.config.Pod != "" {
+			pod, ok := pods[ctr.config.Pod]
+			if !ok {
+				return fmt.Errorf("pod %s of container %s: %w", ctr.config.Pod, ctr.ID(), define.ErrNoSuchPod)
+			}
+			if err := state.AddContainerToPod(pod, ctr); err != nil {
+				return fmt.Errorf("adding container %s: %w", ctr.ID(), err)
+			}
+		} else if err := state.AddContainer(ctr); err != nil {
+			return fmt.Errorf("adding container %s: %w", ctr.ID(), err)
+		}
+		for _, id := range d.execSessions[ctr.ID()] {
+			session := &ExecSession{Id: id, ContainerId: ctr.ID()}
+			if err := state.AddExecSession(ctr, session); err != nil {
+				return fmt.Errorf("adding exec session %s of container %s: %w", id, ctr.ID(), err)
+			}
+		}
+	}
+
+	for _, pod := range d.pods {
+		if err := state.SavePod(pod); err != nil {
+			return fmt.Errorf("saving state of pod %s: %w", pod.ID(), err)
+		}
+	}
+
+	for id, exitCode := range d.exitCodes {
+		if err := state.AddContainerExitCode(id, exitCode); err != nil {
+			return fmt.Errorf("adding exit code of container %s: %w", id, err)
+		}
+	}
+
+	return d.verify(state)
+}
+
+// verify checks that the given state holds exactly the data.
+func (d *dbMigrationData) verify(state State) error {
+	volumes, err := state.AllVolumes()
+	if err != nil {
+		return fmt.Errorf("verifying volumes: %w", err)
+	}
+	if len(volumes) != len(d.volumes) {
+		return fmt.Errorf("verifying volumes: found %d volumes instead of %d: %w", len(volumes), len(d.volumes), define.ErrInternal)
+	}
+	for _, vol := range d.volumes {
+		newVol, err := state.Volume(vol.Name())
+		if err != nil {
+			return fmt.Errorf("verifying volume %s: %w", vol.Name(), err)
+		}
+		if err := state.UpdateVolume(newVol); err != nil {
+			return fmt.Errorf("verifying volume %s: %w", vol.Name(), err)
+		}
+		if err := compareJSON(vol.config, newVol.config); err != nil {
+			return fmt.Errorf("verifying config of volume %s: %w", vol.Name(), err)
+		}
+		if err := compareJSON(vol.state, newVol.state); err != nil {
+			return fmt.Errorf("verifying state of volume %s: %w", vol.Name(), err)
+		}
+	}
+
+	pods, err := state.AllPods()
+	if err != nil {
+		return fmt.Errorf("verifying pods: %w", err)
+	}
+	if len(pods) != len(d.pods) {
+		return fmt.Errorf("verifying pods: found %d pods instead of %d: %w", len(pods), len(d.pods), define.ErrInternal)
+	}
+	for _, pod := range d.pods {
+		newPod, err := state.Pod(pod.ID())
+		if err != nil {
+			return fmt.Errorf("verifying pod %s: %w", pod.ID(), err)
+		}
+		if err := state.UpdatePod(newPod); err != nil {
+			return fmt.Errorf("verifying pod %s: %w", pod.ID(), err)
+		}
+		if err := compareJSON(pod.config, newPod.config); err != nil {
+			return fmt.Errorf("verifying config of pod %s: %w", pod.ID(), err)
+		}
+		if err := compareJSON(pod.state, newPod.state); err != nil {
+			return fmt.Errorf("verifying state of pod %s: %w", pod.ID(), err)
+		}
+	}
+
+	ctrs, err := state.AllContainers(false)
+	if err != nil {
+		return fmt.Errorf("verifying containers: %w", err)
+	}
+	if len(ctrs) != len(d.ctrs) {
+		return fmt.Errorf("verifying containers: found %d containers instead of %d: %w", len(ctrs), len(d.ctrs), define.ErrInternal)
+	}
+	for _, ctr := range d.ctrs {
+		newCtr, err := state.Container(ctr.ID())
+		if err != nil {
+			return fmt.Errorf("verifying container %s: %w", ctr.ID(), err)
+		}
+		if err := state.UpdateContainer(newCtr); err != nil {
+			return fmt.Errorf("verifying container %s: %w", ctr.ID(), err)
+		}
+		// Network attachments are compared separately, as BoltDB does
+		// not store them in the config.
+		oldConfig, newConfig := *ctr.config, *newCtr.config
+		oldConfig.Networks, newConfig.Networks = nil, nil
+		if err := compareJSON(&oldConfig, &newConfig); err != nil {
+			return fmt.Errorf("verifying config of container %s: %w", ctr.ID(), err)
+		}
+		if err := compareJSON(ctr.state, newCtr.state); err != nil {
+			return fmt.Errorf("verifying state of container %s: %w", ctr.ID(), err)
+		}
+
+		networks, err := state.GetNetworks(newCtr)
+		if err != nil {
+			return fmt.Errorf("verifying networks of container %s: %w", ctr.ID(), err)
+		}
+		if len(networks) > 0 || len(d.networks[ctr.ID()]) > 0 {
+			if err := compareJSON(d.networks[ctr.ID()], networks); err != nil {
+				return fmt.Errorf("verifying networks of container %s: %w", ctr.ID(), err)
+			}
+		}
+
+		sessions, err := state.GetContainerExecSessions(newCtr)
+		if err != nil {
+			return fmt.Errorf("verifying exec sessions of container %s: %w", ctr.ID(), err)
+		}
+		sort.Strings(sessions)
+		if len(sessions) > 0 || len(d.execSessions[ctr.ID()]) > 0 {
+			if !reflect.DeepEqual(sessions, d.execSessions[ctr.ID()]) {
+				return fmt.Errorf("verifying exec sessions of container %s: found %v instead of %v: %w", ctr.ID(), sessions, d.execSessions[ctr.ID()], define.ErrInternal)
+			}
+		}
+	}
+
+	exitCodes, err := state.AllContainerExitCodes()
+	if err != nil {
+		return fmt.Errorf("verifying exit codes: %w", err)
+	}
+	if len(exitCodes) > 0 || len(d.exitCodes) > 0 {
+		if !reflect.DeepEqual(exitCodes, d.exitCodes) {
+			return fmt.Errorf("verifying exit codes: found %v instead of %v: %w", exitCodes, d.exitCodes, define.ErrInternal)
+		}
+	}
+
+	return nil
+}
+
+// compareJSON returns an error if the JSON encodings of the given values
+// differ.
+func compareJSON(expected, actual interface{}) error {
+	expectedJSON, err := json.Marshal(expected)
+	if err != nil {
+		return err
+	}
+	actualJSON, err := json.Marshal(actual)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(expectedJSON, actualJSON) {
+		return fmt.Errorf("copy differs from the original: %w", define.ErrInternal)
+	}
+	return nil
+}
+
+// dbFileSuffixes are the suffixes of the files a database consists of, next
+// to the database file itself.  Only SQLite uses them, for its write-ahead
+// log and shared memory index.
+var dbFileSuffixes = []string{"", "-wal", "-shm"}
+
+// renameDBFiles renames the files of the database at the given path.
+func renameDBFiles(oldPath, newPath string) error {
+	for _, suffix := range dbFileSuffixes {
+		if err := os.Rename(oldPath+suffix, newPath+suffix); err != nil {
+			if suffix != "" && errors.Is(err, fs.ErrNotExist) {
+				// A stale file of a previous rename must not
+				// be mistaken for part of the new database.
+				if err := os.Remove(newPath + suffix); err != nil && !errors.Is(err, fs.ErrNotExist) {
+					return err
+				}
+				continue
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// removeDBFiles removes the files of the database at the given path.
+func removeDBFiles(path string) error {
+	for _, suffix := range dbFileSuffixes {
+		if err := os.Remove(path + suffix); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,157 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"os"
+	"testing"
+
+	"github.com/containers/common/pkg/config"
+	"github.com/containers/podman/v4/libpod/lock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMigrateDBData(t *testing.T) {
+	src, srcDir, manager, err := getEmptyBoltState()
+	require.NoError(t, err)
+	defer os.RemoveAll(srcDir)
+	defer src.Close()
+
+	dst, dstDir, _, err := getEmptyBoltState()
+	require.NoError(t, err)
+	defer os.RemoveAll(dstDir)
+	defer dst.Close()
+
+	testPod, err := getTestPodN("3", manager)
+	require.NoError(t, err)
+	require.NoError(t, src.AddPod(testPod))
+
+	testCtr1, err := getTestCtr1(manager)
+	require.NoError(t, err)
+	testCtr1.config.Pod = testPod.ID()
+	require.NoError(t, src.AddContainerToPod(testPod, testCtr1))
+
+	testPod.state.InfraContainerID = testCtr1.ID()
+	require.NoError(t, src.SavePod(testPod))
+
+	testCtr2, err := getTestCtr2(manager)
+	require.NoError(t, err)
+	testCtr2.config.Pod = testPod.ID()
+	testCtr2.config.NetNsCtr = testCtr1.ID()
+	require.NoError(t, src.AddContainerToPod(testPod, testCtr2))
+
+	require.NoError(t, src.AddExecSession(testCtr2, &ExecSession{Id: "exec1", ContainerId: testCtr2.ID()}))
+	require.NoError(t, src.AddContainerExitCode(testCtr1.ID(), 1))
+	require.NoError(t, src.AddContainerExitCode("removed", 0))
+
+	data, err := readMigrationData(src)
+	require.NoError(t, err)
+
+	report := data.report()
+	assert.Equal(t, 2, report.Containers)
+	assert.Equal(t, 1, report.Pods)
+	assert.Equal(t, 1, report.ExecSessions)
+	assert.Equal(t, 2, report.ExitCodes)
+
+	require.Len(t, data.ctrs, 2)
+	assert.Equal(t, testCtr1.ID(), data.ctrs[0].ID())
+	assert.Equal(t, testCtr2.ID(), data.ctrs[1].ID())
+
+	require.NoError(t, data.copyTo(dst))
+
+	pod, err := dst.Pod(testPod.ID())
+	require.NoError(t, err)
+	require.NoError(t, dst.UpdatePod(pod))
+	assert.Equal(t, testCtr1.ID(), pod.state.InfraContainerID)
+
+	ctrIDs, err := dst.PodContainersByID(pod)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{testCtr1.ID(), testCtr2.ID()}, ctrIDs)
+
+	ctr, err := dst.Container(testCtr2.ID())
+	require.NoError(t, err)
+	testContainersEqual(t, ctr, testCtr2, true)
+
+	sessions, err := dst.GetContainerExecSessions(ctr)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"exec1"}, sessions)
+
+	exitCodes, err := dst.AllContainerExitCodes()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int32{testCtr1.ID(): 1, "removed": 0}, exitCodes)
+}
+
+func TestSortContainersByDependencies(t *testing.T) {
+	manager, err := lock.NewInMemoryManager(16)
+	require.NoError(t, err)
+
+	testCtr1, err := getTestCtr1(manager)
+	require.NoError(t, err)
+	testCtr2, err := getTestCtr2(manager)
+	require.NoError(t, err)
+	testCtr3, err := getTestCtrN("3", manager)
+	require.NoError(t, err)
+
+	testCtr1.config.Dependencies = []string{testCtr3.ID()}
+	testCtr3.config.IPCNsCtr = testCtr2.ID()
+
+	sorted, err := sortContainersByDependencies([]*Container{testCtr1, testCtr2, testCtr3})
+	require.NoError(t, err)
+	require.Len(t, sorted, 3)
+	assert.Equal(t, testCtr2.ID(), sorted[0].ID())
+	assert.Equal(t, testCtr3.ID(), sorted[1].ID())
+	assert.Equal(t, testCtr1.ID(), sorted[2].ID())
+
+	testCtr2.config.Dependencies = []string{testCtr1.ID()}
+	_, err = sortContainersByDependencies([]*Container{testCtr1, testCtr2, testCtr3})
+	assert.Error(t, err)
+}
+
+func TestMigrateDBDataToSqlite(t *testing.T) {
+	src, srcDir, manager, err := getEmptyBoltState()
+	require.NoError(t, err)
+	defer os.RemoveAll(srcDir)
+	defer src.Close()
+
+	dstDir := t.TempDir()
+	runtime := new(Runtime)
+	runtime.config = new(config.Config)
+	runtime.config.Engine.StaticDir = dstDir
+	runtime.lockManager = manager
+	dst, err := NewSqliteState(runtime)
+	require.NoError(t, err)
+	defer dst.Close()
+
+	testPod, err := getTestPodN("3", manager)
+	require.NoError(t, err)
+	require.NoError(t, src.AddPod(testPod))
+
+	testCtr1, err := getTestCtr1(manager)
+	require.NoError(t, err)
+	testCtr1.config.Pod = testPod.ID()
+	require.NoError(t, src.AddContainerToPod(testPod, testCtr1))
+	testPod.state.InfraContainerID = testCtr1.ID()
+	require.NoError(t, src.SavePod(testPod))
+
+	testCtr2, err := getTestCtr2(manager)
+	require.NoError(t, err)
+	require.NoError(t, src.AddContainer(testCtr2))
+	require.NoError(t, src.AddExecSession(testCtr2, &ExecSession{Id: "exec1", ContainerId: testCtr2.ID()}))
+	require.NoError(t, src.AddContainerExitCode(testCtr1.ID(), 1))
+
+	data, err := readMigrationData(src)
+	require.NoError(t, err)
+	require.NoError(t, data.copyTo(dst))
+
+	// And back again.
+	back, backDir, _, err := getEmptyBoltState()
+	require.NoError(t, err)
+	defer os.RemoveAll(backDir)
+	defer back.Close()
+
+	data, err = readMigrationData(dst)
+	require.NoError(t, err)
+	assert.Equal(t, 2, data.report().Containers)
+	require.NoError(t, data.copyTo(back))
+}
@@ -10,6 +10,10 @@ func (r *Runtime) platformMakePod(pod *Pod, resourceLimits *spec.LinuxResources)
 	return "", nil
 }
 
+func (p *Pod) updatePodCgroup(resources *spec.LinuxResources) error {
+	return nil
+}
+
 func (p *Pod) removePodCgroup() error {
 	return nil
 }
@@ -88,6 +88,23 @@ func (r *Runtime) platformMakePod(pod *Pod, resourceLimits *spec.LinuxResources)
 	return cgroupParent, nil
 }
 
+// updatePodCgroup applies the resource limits to the cgroup of the pod, if
+// it has one.
+func (p *Pod) updatePodCgroup(resources *spec.LinuxResources) error {
+	if p.state.CgroupPath == "" {
+		return nil
+	}
+	res, err := GetLimits(resources)
+	if err != nil {
+		return err
+	}
+	cgc, err := cgroups.Load(p.state.CgroupPath)
+	if err != nil {
+		return err
+	}
+	return cgc.Update(&res)
+}
+
 func (p *Pod) removePodCgroup() error {
 	// Remove pod cgroup, if present
 	if p.state.CgroupPath == "" {
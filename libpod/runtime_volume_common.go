@@ -159,52 +159,10 @@ func (r *Runtime) newVolume(ctx context.Context, noCreatePluginVolume bool, opti
 			return nil, err
 		}
 	} else {
-		// Create the mountpoint of this volume
-		volPathRoot := filepath.Join(r.config.Engine.VolumePath, volume.config.Name)
-		if err := os.MkdirAll(volPathRoot, 0700); err != nil {
-			return nil, fmt.Errorf("creating volume directory %q: %w", volPathRoot, err)
-		}
-		if err := idtools.SafeChown(volPathRoot, volume.config.UID, volume.config.GID); err != nil {
-			return nil, fmt.Errorf("chowning volume directory %q to %d:%d: %w", volPathRoot, volume.config.UID, volume.config.GID, err)
-		}
-		fullVolPath := filepath.Join(volPathRoot, "_data")
-		if err := os.MkdirAll(fullVolPath, 0755); err != nil {
-			return nil, fmt.Errorf("creating volume directory %q: %w", fullVolPath, err)
-		}
-		if err := idtools.SafeChown(fullVolPath, volume.config.UID, volume.config.GID); err != nil {
-			return nil, fmt.Errorf("chowning volume directory %q to %d:%d: %w", fullVolPath, volume.config.UID, volume.config.GID, err)
-		}
-		if err := LabelVolumePath(fullVolPath, volume.config.MountLabel); err != nil {
+		fullVolPath, err := r.createVolumeMountPoint(volume)
+		if err != nil {
 			return nil, err
 		}
-		switch {
-		case volume.config.DisableQuota:
-			if volume.config.Size > 0 || volume.config.Inodes > 0 {
-				return nil, errors.New("volume options size and inodes cannot be used without quota")
-			}
-		case volume.config.Options["type"] == define.TypeTmpfs:
-			// tmpfs only supports Size
-			if volume.config.Inodes > 0 {
-				return nil, errors.New("volume option inodes not supported on tmpfs filesystem")
-			}
-		case volume.config.Inodes > 0 || volume.config.Size > 0:
-			projectQuotaSupported := false
-			q, err := quota.NewControl(r.config.Engine.VolumePath)
-			if err == nil {
-				projectQuotaSupported = true
-			}
-			if !projectQuotaSupported {
-				return nil, errors.New("volume options size and inodes not supported. Filesystem does not support Project Quota")
-			}
-			quota := quota.Quota{
-				Inodes: volume.config.Inodes,
-				Size:   volume.config.Size,
-			}
-			if err := q.SetQuota(fullVolPath, quota); err != nil {
-				return nil, fmt.Errorf("failed to set size quota size=%d inodes=%d for volume directory %q: %w", volume.config.Size, volume.config.Inodes, fullVolPath, err)
-			}
-		}
-
 		volume.config.MountPoint = fullVolPath
 	}
 
@@ -485,3 +443,55 @@ func (r *Runtime) removeVolume(ctx context.Context, v *Volume, force bool, timeo
 	logrus.Debugf("Removed volume %s", v.Name())
 	return removalErr
 }
+
+// createVolumeMountPoint creates the directory of a local volume, which is
+// mounted into containers, and sets its owner, label and quota.  It returns
+// the path of the directory.
+func (r *Runtime) createVolumeMountPoint(volume *Volume) (string, error) {
+	volPathRoot := filepath.Join(r.config.Engine.VolumePath, volume.config.Name)
+	if err := os.MkdirAll(volPathRoot, 0700); err != nil {
+		return "", fmt.Errorf("creating volume directory %q: %w", volPathRoot, err)
+	}
+	if err := idtools.SafeChown(volPathRoot, volume.config.UID, volume.config.GID); err != nil {
+		return "", fmt.Errorf("chowning volume directory %q to %d:%d: %w", volPathRoot, volume.config.UID, volume.config.GID, err)
+	}
+	fullVolPath := filepath.Join(volPathRoot, "_data")
+	if err := os.MkdirAll(fullVolPath, 0755); err != nil {
+		return "", fmt.Errorf("creating volume directory %q: %w", fullVolPath, err)
+	}
+	if err := idtools.SafeChown(fullVolPath, volume.config.UID, volume.config.GID); err != nil {
+		return "", fmt.Errorf("chowning volume directory %q to %d:%d: %w", fullVolPath, volume.config.UID, volume.config.GID, err)
+	}
+	if err := LabelVolumePath(fullVolPath, volume.config.MountLabel); err != nil {
+		return "", err
+	}
+	switch {
+	case volume.config.DisableQuota:
+		if volume.config.Size > 0 || volume.config.Inodes > 0 {
+			return "", errors.New("volume options size and inodes cannot be used without quota")
+		}
+	case volume.config.Options["type"] == define.TypeTmpfs:
+		// tmpfs only supports Size
+		if volume.config.Inodes > 0 {
+			return "", errors.New("volume option inodes not supported on tmpfs filesystem")
+		}
+	case volume.config.Inodes > 0 || volume.config.Size > 0:
+		projectQuotaSupported := false
+		q, err := quota.NewControl(r.config.Engine.VolumePath)
+		if err == nil {
+			projectQuotaSupported = true
+		}
+		if !projectQuotaSupported {
+			return "", errors.New("volume options size and inodes not supported. Filesystem does not support Project Quota")
+		}
+		quota := quota.Quota{
+			Inodes: volume.config.Inodes,
+			Size:   volume.config.Size,
+		}
+		if err := q.SetQuota(fullVolPath, quota); err != nil {
+			return "", fmt.Errorf("failed to set size quota size=%d inodes=%d for volume directory %q: %w", volume.config.Size, volume.config.Inodes, fullVolPath, err)
+		}
+	}
+
+	return fullVolPath, nil
+}
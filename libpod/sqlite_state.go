@@ -44,8 +44,11 @@ const (
 	// Timeout is in ms, so set it to 100s to have enough time to retry the operations.
 	sqliteOptionBusyTimeout = "&_busy_timeout=100000"
 
+	// Name of the database file.
+	sqliteDBFile = "db.sql"
+
 	// Assembled sqlite options used when opening the database.
-	sqliteOptions = "db.sql?" +
+	sqliteOptions = sqliteDBFile + "?" +
 		sqliteOptionLocation +
 		sqliteOptionSynchronous +
 		sqliteOptionForeignKeys +
@@ -58,12 +61,7 @@ func NewSqliteState(runtime *Runtime) (_ State, defErr error) {
 	logrus.Info("Using sqlite as database backend")
 	state := new(SQLiteState)
 
-	basePath := runtime.storageConfig.GraphRoot
-	if runtime.storageConfig.TransientStore {
-		basePath = runtime.storageConfig.RunRoot
-	} else if !runtime.storageSet.StaticDirSet {
-		basePath = runtime.config.Engine.StaticDir
-	}
+	basePath := sqliteStateDir(runtime)
 
 	// c/storage is set up *after* the DB - so even though we use the c/s
 	// root (or, for transient, runroot) dir, we need to make the dir
@@ -95,6 +93,16 @@ func NewSqliteState(runtime *Runtime) (_ State, defErr error) {
 	return state, nil
 }
 
+// sqliteStateDir returns the directory of the SQLite database of the runtime.
+func sqliteStateDir(runtime *Runtime) string {
+	if runtime.storageConfig.TransientStore {
+		return runtime.storageConfig.RunRoot
+	} else if !runtime.storageSet.StaticDirSet {
+		return runtime.config.Engine.StaticDir
+	}
+	return runtime.storageConfig.GraphRoot
+}
+
 // Close closes the state and prevents further use
 func (s *SQLiteState) Close() error {
 	if err := s.conn.Close(); err != nil {
@@ -970,6 +978,37 @@ func (s *SQLiteState) GetContainerExitCode(id string) (int32, error) {
 	return exitCode, nil
 }
 
+// AllContainerExitCodes returns the exit codes of all containers in the
+// database, indexed by container ID.
+func (s *SQLiteState) AllContainerExitCodes() (map[string]int32, error) {
+	if !s.valid {
+		return nil, define.ErrDBClosed
+	}
+
+	rows, err := s.conn.Query("SELECT ID, ExitCode FROM ContainerExitCode;")
+	if err != nil {
+		return nil, fmt.Errorf("retrieving all exit codes from database: %w", err)
+	}
+	defer rows.Close()
+
+	exitCodes := make(map[string]int32)
+	for rows.Next() {
+		var (
+			id       string
+			exitCode int32
+		)
+		if err := rows.Scan(&id, &exitCode); err != nil {
+			return nil, fmt.Errorf("scanning exit code from database: %w", err)
+		}
+		exitCodes[id] = exitCode
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return exitCodes, nil
+}
+
 // GetContainerExitCodeTimeStamp returns the time stamp when the exit code of
 // the specified container was added to the database.
 func (s *SQLiteState) GetContainerExitCodeTimeStamp(id string) (*time.Time, error) {
@@ -115,6 +115,10 @@ type State interface { //nolint:interfacebloat
 	GetContainerExitCode(id string) (int32, error)
 	// Remove exit codes older than 5 minutes.
 	PruneContainerExitCodes() error
+	// Return the exit codes of all containers in the database, including
+	// removed containers whose exit codes have not been pruned yet.
+	// Indexed by full container ID.
+	AllContainerExitCodes() (map[string]int32, error)
 
 	// Add creates a reference to an exec session in the database.
 	// The container the exec session is attached to will be recorded.
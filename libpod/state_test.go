@@ -2593,6 +2593,23 @@ func TestGetContainerConfigNonExistentIDFails(t *testing.T) {
 	})
 }
 
+func TestAllContainerExitCodes(t *testing.T) {
+	runForAllStates(t, func(t *testing.T, state State, manager lock.Manager) {
+		exitCodes, err := state.AllContainerExitCodes()
+		assert.NoError(t, err)
+		assert.Empty(t, exitCodes)
+
+		err = state.AddContainerExitCode("ctr1", 0)
+		assert.NoError(t, err)
+		err = state.AddContainerExitCode("ctr2", 137)
+		assert.NoError(t, err)
+
+		exitCodes, err = state.AllContainerExitCodes()
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]int32{"ctr1": 0, "ctr2": 137}, exitCodes)
+	})
+}
+
 // Test that the state will convert the ports to the new format
 func TestConvertPortMapping(t *testing.T) {
 	runForAllStates(t, func(t *testing.T, state State, manager lock.Manager) {
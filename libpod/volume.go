@@ -102,6 +102,8 @@ type VolumeState struct {
 	UIDChowned int `json:"uidChowned,omitempty"`
 	// GIDChowned is the GID the volume was chowned to.
 	GIDChowned int `json:"gidChowned,omitempty"`
+	// Snapshots of the volume, in the order they were created.
+	Snapshots []VolumeSnapshot `json:"snapshots,omitempty"`
 }
 
 // Name retrieves the volume's name
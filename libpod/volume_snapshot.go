@@ -0,0 +1,257 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/containers/podman/v4/libpod/define"
+	"github.com/containers/storage/drivers/copy"
+	"github.com/containers/storage/pkg/directory"
+	"github.com/sirupsen/logrus"
+)
+
+// VolumeSnapshot is a point-in-time copy of the contents of a volume.
+type VolumeSnapshot struct {
+	// Name of the snapshot, unique per volume.
+	Name string `json:"name"`
+	// Time the snapshot was created at.
+	CreatedTime time.Time `json:"createdAt"`
+	// Size of the snapshot in bytes.
+	Size int64 `json:"size"`
+	// IDs of the containers which were paused while creating the
+	// snapshot.
+	PausedContainers []string `json:"pausedContainers,omitempty"`
+}
+
+// snapshotsDir returns the directory holding the snapshots of the volume.
+// It is next to the data of the volume, so the snapshots are removed along
+// with the volume.
+func (v *Volume) snapshotsDir() string {
+	return filepath.Join(v.runtime.config.Engine.VolumePath, v.Name(), "_snapshots")
+}
+
+// supportsSnapshots returns an error if the volume does not support
+// snapshots.  Only volumes of the local driver store their data in a
+// location managed by Podman.
+func (v *Volume) supportsSnapshots() error {
+	if v.UsesVolumeDriver() || v.config.Driver == define.VolumeDriverImage {
+		return fmt.Errorf("volume %s uses the %s driver: snapshots are only supported for volumes of the local driver: %w", v.Name(), v.config.Driver, define.ErrNotImplemented)
+	}
+	return nil
+}
+
+// snapshotIndex returns the index of the snapshot with the given name or -1.
+// Must be called with the volume locked.
+func (v *Volume) snapshotIndex(name string) int {
+	for i, snapshot := range v.state.Snapshots {
+		if snapshot.Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// CreateSnapshot creates a snapshot of the contents of the volume.  If name is
+// empty, a name is generated from the current time.  If pause is set, running
+// containers using the volume are paused while the snapshot is created, so it
+// is consistent.  The files are copied using reflinks where the filesystem
+// supports them.
+func (v *Volume) CreateSnapshot(name string, pause bool) (*VolumeSnapshot, error) {
+	if err := v.supportsSnapshots(); err != nil {
+		return nil, err
+	}
+	if name == "" {
+		name = time.Now().UTC().Format("20060102T150405Z")
+	}
+	if !define.NameRegex.MatchString(name) {
+		return nil, fmt.Errorf("snapshot name %q: %w", name, define.RegexError)
+	}
+
+	// Containers must be paused before locking the volume, as containers
+	// lock their volumes while holding their own lock.
+	var paused []string
+	if pause {
+		ctrIDs, err := v.VolumeInUse()
+		if err != nil {
+			return nil, err
+		}
+		defer func() {
+			for _, id := range paused {
+				ctr, err := v.runtime.LookupContainer(id)
+				if err == nil {
+					err = ctr.Unpause()
+				}
+				if err != nil {
+					logrus.Errorf("Unpausing container %s after snapshot of volume %s: %v", id, v.Name(), err)
+				}
+			}
+		}()
+		for _, id := range ctrIDs {
+			ctr, err := v.runtime.LookupContainer(id)
+			if err != nil {
+				return nil, err
+			}
+			state, err := ctr.State()
+			if err != nil {
+				return nil, err
+			}
+			if state != define.ContainerStateRunning {
+				continue
+			}
+			if err := ctr.Pause(); err != nil {
+				return nil, fmt.Errorf("pausing container %s for snapshot of volume %s: %w", id, v.Name(), err)
+			}
+			paused = append(paused, id)
+		}
+	}
+
+	v.lock.Lock()
+	defer v.lock.Unlock()
+
+	if err := v.update(); err != nil {
+		return nil, err
+	}
+	if v.snapshotIndex(name) >= 0 {
+		return nil, fmt.Errorf("snapshot %s of volume %s: %w", name, v.Name(), define.ErrSnapshotExists)
+	}
+
+	if err := v.mount(); err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := v.unmount(false); err != nil {
+			logrus.Errorf("Unmounting volume %s after snapshot: %v", v.Name(), err)
+		}
+	}()
+
+	dir := filepath.Join(v.snapshotsDir(), name)
+	if err := os.MkdirAll(v.snapshotsDir(), 0o700); err != nil {
+		return nil, fmt.Errorf("creating snapshots directory of volume %s: %w", v.Name(), err)
+	}
+	if err := copy.DirCopy(v.mountPoint(), dir, copy.Content, true); err != nil {
+		if rmErr := os.RemoveAll(dir); rmErr != nil {
+			logrus.Errorf("Removing incomplete snapshot %s of volume %s: %v", name, v.Name(), rmErr)
+		}
+		return nil, fmt.Errorf("copying volume %s to snapshot %s: %w", v.Name(), name, err)
+	}
+	size, err := directory.Size(dir)
+	if err != nil {
+		logrus.Debugf("Getting size of snapshot %s of volume %s: %v", name, v.Name(), err)
+	}
+
+	snapshot := VolumeSnapshot{
+		Name:             name,
+		CreatedTime:      time.Now(),
+		Size:             size,
+		PausedContainers: paused,
+	}
+	v.state.Snapshots = append(v.state.Snapshots, snapshot)
+	if err := v.save(); err != nil {
+		v.state.Snapshots = v.state.Snapshots[:len(v.state.Snapshots)-1]
+		if rmErr := os.RemoveAll(dir); rmErr != nil {
+			logrus.Errorf("Removing snapshot %s of volume %s: %v", name, v.Name(), rmErr)
+		}
+		return nil, err
+	}
+	return &snapshot, nil
+}
+
+// Snapshots returns the snapshots of the volume in the order they were
+// created.
+func (v *Volume) Snapshots() ([]VolumeSnapshot, error) {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+
+	if err := v.update(); err != nil {
+		return nil, err
+	}
+	return append([]VolumeSnapshot{}, v.state.Snapshots...), nil
+}
+
+// RestoreSnapshot replaces the contents of the volume with the contents of
+// the snapshot.  The volume must not be used by running or paused containers.
+func (v *Volume) RestoreSnapshot(name string) error {
+	if err := v.supportsSnapshots(); err != nil {
+		return err
+	}
+
+	ctrIDs, err := v.VolumeInUse()
+	if err != nil {
+		return err
+	}
+	for _, id := range ctrIDs {
+		ctr, err := v.runtime.LookupContainer(id)
+		if err != nil {
+			return err
+		}
+		state, err := ctr.State()
+		if err != nil {
+			return err
+		}
+		if state == define.ContainerStateRunning || state == define.ContainerStatePaused {
+			return fmt.Errorf("volume %s is being used by the %s container %s: %w", v.Name(), state, id, define.ErrVolumeBeingUsed)
+		}
+	}
+
+	v.lock.Lock()
+	defer v.lock.Unlock()
+
+	if err := v.update(); err != nil {
+		return err
+	}
+	if v.snapshotIndex(name) < 0 {
+		return fmt.Errorf("snapshot %s of volume %s: %w", name, v.Name(), define.ErrNoSuchSnapshot)
+	}
+
+	if err := v.mount(); err != nil {
+		return err
+	}
+	defer func() {
+		if err := v.unmount(false); err != nil {
+			logrus.Errorf("Unmounting volume %s after restoring snapshot: %v", v.Name(), err)
+		}
+	}()
+
+	mountPoint := v.mountPoint()
+	entries, err := os.ReadDir(mountPoint)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		if err := os.RemoveAll(filepath.Join(mountPoint, entry.Name())); err != nil {
+			return fmt.Errorf("removing contents of volume %s: %w", v.Name(), err)
+		}
+	}
+	if err := copy.DirCopy(filepath.Join(v.snapshotsDir(), name), mountPoint, copy.Content, true); err != nil {
+		return fmt.Errorf("copying snapshot %s to volume %s: %w", name, v.Name(), err)
+	}
+
+	// The volume has content now, which must not be overwritten by the
+	// image.
+	v.state.NeedsCopyUp = false
+	return v.save()
+}
+
+// RemoveSnapshot removes the snapshot of the volume.
+func (v *Volume) RemoveSnapshot(name string) error {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+
+	if err := v.update(); err != nil {
+		return err
+	}
+	i := v.snapshotIndex(name)
+	if i < 0 {
+		return fmt.Errorf("snapshot %s of volume %s: %w", name, v.Name(), define.ErrNoSuchSnapshot)
+	}
+
+	if err := os.RemoveAll(filepath.Join(v.snapshotsDir(), name)); err != nil {
+		return fmt.Errorf("removing snapshot %s of volume %s: %w", name, v.Name(), err)
+	}
+	v.state.Snapshots = append(v.state.Snapshots[:i], v.state.Snapshots[i+1:]...)
+	return v.save()
+}
@@ -441,6 +441,11 @@ func LibpodToContainerJSON(l *libpod.Container, sz bool) (*types.ContainerJSON,
 		state.Status = define.ContainerStateConfigured.String()
 	}
 
+	// Docker reports containers waiting to be restarted as restarting
+	if state.Restarting {
+		state.Status = "restarting"
+	}
+
 	if l.HasHealthCheck() && state.Status != "created" {
 		state.Health = &types.Health{
 			Status:        inspect.State.Health.Status,
@@ -0,0 +1,173 @@
+package compat
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/containers/podman/v4/libpod"
+	"github.com/containers/podman/v4/libpod/define"
+	"github.com/containers/podman/v4/pkg/api/handlers/utils"
+	api "github.com/containers/podman/v4/pkg/api/types"
+	"github.com/containers/podman/v4/pkg/domain/entities"
+	"github.com/containers/podman/v4/pkg/domain/infra/abi"
+	"github.com/containers/podman/v4/pkg/specgen"
+	"github.com/docker/docker/api/types/container"
+	spec "github.com/opencontainers/runtime-spec/specs-go"
+)
+
+// UpdateContainer updates the resource limits and the restart policy of a
+// container.
+func UpdateContainer(w http.ResponseWriter, r *http.Request) {
+	runtime := r.Context().Value(api.RuntimeKey).(*libpod.Runtime)
+
+	// /{version}/containers/(name)/update
+	name := utils.GetName(r)
+	if _, err := runtime.LookupContainer(name); err != nil {
+		utils.ContainerNotFound(w, name, err)
+		return
+	}
+
+	var config container.UpdateConfig
+	if err := json.NewDecoder(r.Body).Decode(&config); err != nil {
+		utils.Error(w, http.StatusBadRequest, fmt.Errorf("decode(): %w", err))
+		return
+	}
+
+	s := &specgen.SpecGenerator{}
+	s.ResourceLimits = updateResources(s, &config.Resources)
+	if config.RestartPolicy.Name != "" {
+		s.RestartPolicy = string(config.RestartPolicy.Name)
+		retries := uint(config.RestartPolicy.MaximumRetryCount)
+		s.RestartRetries = &retries
+	}
+
+	containerEngine := abi.ContainerEngine{Libpod: runtime}
+	options := &entities.ContainerUpdateOptions{
+		NameOrID: name,
+		Specgen:  s,
+	}
+	if _, err := containerEngine.ContainerUpdate(r.Context(), options); err != nil {
+		if errors.Is(err, define.ErrInvalidArg) {
+			utils.Error(w, http.StatusBadRequest, err)
+			return
+		}
+		utils.InternalServerError(w, err)
+		return
+	}
+
+	utils.WriteResponse(w, http.StatusOK, container.ContainerUpdateOKBody{Warnings: []string{}})
+}
+
+// updateResources converts the resources of a Docker update request to the
+// resource limits of a container.  Block IO limits of devices are stored in
+// the spec generator, they are resolved by ContainerUpdate.  Returns nil if
+// the request does not change any resource limits.
+func updateResources(s *specgen.SpecGenerator, res *container.Resources) *spec.LinuxResources {
+	limits := &spec.LinuxResources{}
+
+	cpu := &spec.LinuxCPU{
+		Cpus: res.CpusetCpus,
+		Mems: res.CpusetMems,
+	}
+	if res.CPUShares > 0 {
+		shares := uint64(res.CPUShares)
+		cpu.Shares = &shares
+	}
+	if res.CPUPeriod > 0 {
+		period := uint64(res.CPUPeriod)
+		cpu.Period = &period
+	}
+	if res.CPUQuota != 0 {
+		quota := res.CPUQuota
+		cpu.Quota = &quota
+	}
+	if res.NanoCPUs > 0 {
+		// Same conversion as for containers created with NanoCpus.
+		period := uint64(100000)
+		quota := res.NanoCPUs / 10000
+		cpu.Period = &period
+		cpu.Quota = &quota
+	}
+	if res.CPURealtimePeriod > 0 {
+		period := uint64(res.CPURealtimePeriod)
+		cpu.RealtimePeriod = &period
+	}
+	if res.CPURealtimeRuntime > 0 {
+		runtime := res.CPURealtimeRuntime
+		cpu.RealtimeRuntime = &runtime
+	}
+	if *cpu != (spec.LinuxCPU{}) {
+		limits.CPU = cpu
+	}
+
+	memory := &spec.LinuxMemory{DisableOOMKiller: res.OomKillDisable}
+	if res.Memory > 0 {
+		limit := res.Memory
+		memory.Limit = &limit
+	}
+	if res.MemoryReservation > 0 {
+		reservation := res.MemoryReservation
+		memory.Reservation = &reservation
+	}
+	if res.MemorySwap != 0 {
+		swap := res.MemorySwap
+		memory.Swap = &swap
+	}
+	if res.MemorySwappiness != nil && *res.MemorySwappiness >= 0 {
+		swappiness := uint64(*res.MemorySwappiness)
+		memory.Swappiness = &swappiness
+	}
+	if *memory != (spec.LinuxMemory{}) {
+		limits.Memory = memory
+	}
+
+	if res.PidsLimit != nil {
+		limits.Pids = &spec.LinuxPids{Limit: *res.PidsLimit}
+	}
+
+	if res.BlkioWeight > 0 {
+		weight := res.BlkioWeight
+		limits.BlockIO = &spec.LinuxBlockIO{Weight: &weight}
+	}
+	for _, dev := range res.BlkioWeightDevice {
+		if s.WeightDevice == nil {
+			s.WeightDevice = make(map[string]spec.LinuxWeightDevice)
+		}
+		weight := dev.Weight
+		s.WeightDevice[dev.Path] = spec.LinuxWeightDevice{Weight: &weight}
+	}
+	for _, dev := range res.BlkioDeviceReadBps {
+		if s.ThrottleReadBpsDevice == nil {
+			s.ThrottleReadBpsDevice = make(map[string]spec.LinuxThrottleDevice)
+		}
+		s.ThrottleReadBpsDevice[dev.Path] = spec.LinuxThrottleDevice{Rate: dev.Rate}
+	}
+	for _, dev := range res.BlkioDeviceWriteBps {
+		if s.ThrottleWriteBpsDevice == nil {
+			s.ThrottleWriteBpsDevice = make(map[string]spec.LinuxThrottleDevice)
+		}
+		s.ThrottleWriteBpsDevice[dev.Path] = spec.LinuxThrottleDevice{Rate: dev.Rate}
+	}
+	for _, dev := range res.BlkioDeviceReadIOps {
+		if s.ThrottleReadIOPSDevice == nil {
+			s.ThrottleReadIOPSDevice = make(map[string]spec.LinuxThrottleDevice)
+		}
+		s.ThrottleReadIOPSDevice[dev.Path] = spec.LinuxThrottleDevice{Rate: dev.Rate}
+	}
+	for _, dev := range res.BlkioDeviceWriteIOps {
+		if s.ThrottleWriteIOPSDevice == nil {
+			s.ThrottleWriteIOPSDevice = make(map[string]spec.LinuxThrottleDevice)
+		}
+		s.ThrottleWriteIOPSDevice[dev.Path] = spec.LinuxThrottleDevice{Rate: dev.Rate}
+	}
+
+	if limits.CPU == nil && limits.Memory == nil && limits.Pids == nil && limits.BlockIO == nil &&
+		len(s.WeightDevice) == 0 && len(s.ThrottleReadBpsDevice) == 0 && len(s.ThrottleWriteBpsDevice) == 0 &&
+		len(s.ThrottleReadIOPSDevice) == 0 && len(s.ThrottleWriteIOPSDevice) == 0 {
+		// Only the restart policy is updated.
+		return nil
+	}
+	return limits
+}
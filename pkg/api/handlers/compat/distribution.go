@@ -0,0 +1,70 @@
+package compat
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	DockerClient "github.com/containers/image/v5/docker"
+	"github.com/containers/image/v5/types"
+	"github.com/containers/podman/v4/libpod"
+	"github.com/containers/podman/v4/pkg/api/handlers/utils"
+	api "github.com/containers/podman/v4/pkg/api/types"
+	"github.com/containers/podman/v4/pkg/auth"
+	"github.com/containers/podman/v4/pkg/domain/entities"
+	"github.com/containers/podman/v4/pkg/domain/infra/abi"
+	"github.com/docker/docker/api/types/registry"
+)
+
+// InspectDistribution returns the descriptor of the manifest of an image in
+// a registry, and the platforms the image supports.
+func InspectDistribution(w http.ResponseWriter, r *http.Request) {
+	runtime := r.Context().Value(api.RuntimeKey).(*libpod.Runtime)
+
+	decoder := utils.GetDecoder(r)
+
+	query := struct {
+		TLSVerify bool `schema:"tlsVerify"`
+	}{
+		// This is where you can override the golang default value for one of fields
+		TLSVerify: true,
+	}
+	if err := decoder.Decode(&query, r.URL.Query()); err != nil {
+		utils.Error(w, http.StatusBadRequest, fmt.Errorf("failed to parse parameters for %s: %w", r.URL.String(), err))
+		return
+	}
+
+	// /{version}/distribution/(name)/json
+	name := utils.GetName(r)
+
+	authConf, authfile, err := auth.GetCredentials(r)
+	if err != nil {
+		utils.Error(w, http.StatusBadRequest, err)
+		return
+	}
+	defer auth.RemoveAuthfile(authfile)
+
+	imageEngine := abi.ImageEngine{Libpod: runtime}
+	options := entities.ManifestInspectOptions{
+		Authfile:    authfile,
+		Credentials: authConf,
+	}
+	if _, found := r.URL.Query()["tlsVerify"]; found {
+		options.SkipTLSVerify = types.NewOptionalBool(!query.TLSVerify)
+	}
+	report, err := imageEngine.ManifestDistributionInspect(r.Context(), name, options)
+	if err != nil {
+		var unauthErr DockerClient.ErrUnauthorizedForCredentials
+		if errors.As(err, &unauthErr) {
+			utils.Error(w, http.StatusUnauthorized, err)
+			return
+		}
+		utils.InternalServerError(w, fmt.Errorf("inspecting %s in registry: %w", name, err))
+		return
+	}
+
+	utils.WriteResponse(w, http.StatusOK, registry.DistributionInspect{
+		Descriptor: report.Descriptor,
+		Platforms:  report.Platforms,
+	})
+}
@@ -1,18 +1,32 @@
 package libpod
 
 import (
+	"fmt"
 	"net/http"
 
 	"github.com/containers/podman/v4/libpod"
 	"github.com/containers/podman/v4/libpod/define"
 	"github.com/containers/podman/v4/pkg/api/handlers/utils"
 	api "github.com/containers/podman/v4/pkg/api/types"
+	"github.com/gorilla/schema"
 )
 
 func RunHealthCheck(w http.ResponseWriter, r *http.Request) {
 	runtime := r.Context().Value(api.RuntimeKey).(*libpod.Runtime)
+	decoder := r.Context().Value(api.DecoderKey).(*schema.Decoder)
+	query := struct {
+		Readiness bool `schema:"readiness"`
+	}{}
+	if err := decoder.Decode(&query, r.URL.Query()); err != nil {
+		utils.Error(w, http.StatusBadRequest, fmt.Errorf("failed to parse parameters for %s: %w", r.URL.String(), err))
+		return
+	}
 	name := utils.GetName(r)
-	status, err := runtime.HealthCheck(r.Context(), name)
+	runCheck := runtime.HealthCheck
+	if query.Readiness {
+		runCheck = runtime.ReadinessCheck
+	}
+	status, err := runCheck(r.Context(), name)
 	if err != nil {
 		if status == define.HealthCheckContainerNotFound {
 			utils.ContainerNotFound(w, name, err)
@@ -30,9 +44,14 @@ func RunHealthCheck(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	hcStatus := define.HealthCheckUnhealthy
-	if status == define.HealthCheckSuccess {
+	switch {
+	case query.Readiness && status == define.HealthCheckSuccess:
+		hcStatus = define.HealthCheckReady
+	case query.Readiness:
+		hcStatus = define.HealthCheckUnready
+	case status == define.HealthCheckSuccess:
 		hcStatus = define.HealthCheckHealthy
-	} else if status == define.HealthCheckStartup {
+	case status == define.HealthCheckStartup:
 		hcStatus = define.HealthCheckStarting
 	}
 	report := define.HealthCheckResults{
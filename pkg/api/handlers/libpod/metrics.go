@@ -0,0 +1,266 @@
+package libpod
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/containers/podman/v4/libpod"
+	"github.com/containers/podman/v4/libpod/define"
+	"github.com/containers/podman/v4/pkg/api/handlers/utils"
+	"github.com/containers/podman/v4/pkg/api/metrics"
+	api "github.com/containers/podman/v4/pkg/api/types"
+	"github.com/containers/podman/v4/pkg/domain/entities"
+	"github.com/containers/podman/v4/pkg/domain/infra/abi"
+	"github.com/sirupsen/logrus"
+)
+
+// statMetric describes the metric of a field of define.ContainerStats.
+type statMetric struct {
+	name  string
+	typ   string
+	help  string
+	value func(*define.ContainerStats) float64
+	// pod indicates that the metric is summed up for the containers of a
+	// pod.
+	pod bool
+	// network indicates a metric of the network namespace, which is not
+	// summed up for pods whose containers share it.
+	network bool
+}
+
+var statMetrics = []statMetric{
+	{name: "cpu_average_percent", typ: metrics.Gauge, help: "Average CPU usage since the start in percent.",
+		value: func(s *define.ContainerStats) float64 { return s.AvgCPU }},
+	{name: "cpu_seconds_total", typ: metrics.Counter, help: "Total CPU time consumed in seconds.", pod: true,
+		value: func(s *define.ContainerStats) float64 { return float64(s.CPUNano) / 1e9 }},
+	{name: "cpu_system_seconds_total", typ: metrics.Counter, help: "Total CPU time consumed in kernel mode in seconds.", pod: true,
+		value: func(s *define.ContainerStats) float64 { return float64(s.CPUSystemNano) / 1e9 }},
+	{name: "memory_usage_bytes", typ: metrics.Gauge, help: "Memory usage in bytes.", pod: true,
+		value: func(s *define.ContainerStats) float64 { return float64(s.MemUsage) }},
+	{name: "memory_limit_bytes", typ: metrics.Gauge, help: "Memory limit in bytes.",
+		value: func(s *define.ContainerStats) float64 { return float64(s.MemLimit) }},
+	{name: "memory_percent", typ: metrics.Gauge, help: "Memory usage in percent of the limit.",
+		value: func(s *define.ContainerStats) float64 { return s.MemPerc }},
+	{name: "network_input_bytes_total", typ: metrics.Counter, help: "Total bytes received over the network.", pod: true, network: true,
+		value: func(s *define.ContainerStats) float64 { return float64(s.NetInput) }},
+	{name: "network_output_bytes_total", typ: metrics.Counter, help: "Total bytes sent over the network.", pod: true, network: true,
+		value: func(s *define.ContainerStats) float64 { return float64(s.NetOutput) }},
+	{name: "block_input_bytes_total", typ: metrics.Counter, help: "Total bytes read from block devices.", pod: true,
+		value: func(s *define.ContainerStats) float64 { return float64(s.BlockInput) }},
+	{name: "block_output_bytes_total", typ: metrics.Counter, help: "Total bytes written to block devices.", pod: true,
+		value: func(s *define.ContainerStats) float64 { return float64(s.BlockOutput) }},
+	{name: "pids", typ: metrics.Gauge, help: "Number of processes.", pod: true,
+		value: func(s *define.ContainerStats) float64 { return float64(s.PIDs) }},
+	{name: "uptime_seconds", typ: metrics.Gauge, help: "Time since the start in seconds.",
+		value: func(s *define.ContainerStats) float64 { return s.UpTime.Seconds() }},
+}
+
+// Metrics exposes metrics of containers, pods, disk usage and API requests in
+// the Prometheus text exposition format.
+func Metrics(w http.ResponseWriter, r *http.Request) {
+	runtime := r.Context().Value(api.RuntimeKey).(*libpod.Runtime)
+
+	families, err := runtimeMetrics(r.Context(), runtime)
+	if err != nil {
+		utils.InternalServerError(w, err)
+		return
+	}
+	if requests, ok := r.Context().Value(api.MetricsKey).(*metrics.RequestCollector); ok {
+		families = append(families, requests.Families()...)
+	}
+
+	w.Header().Set("Content-Type", metrics.ContentType)
+	w.WriteHeader(http.StatusOK)
+	if err := metrics.Write(w, families); err != nil {
+		logrus.Errorf("Writing metrics: %v", err)
+	}
+}
+
+func runtimeMetrics(ctx context.Context, runtime *libpod.Runtime) ([]*metrics.Family, error) {
+	var (
+		info     = metrics.NewFamily("podman_container_info", metrics.Gauge, "Information about the container, always 1.")
+		state    = metrics.NewFamily("podman_container_state", metrics.Gauge, "Container state: 0=unknown, 1=configured, 2=created, 3=running, 4=stopped, 5=paused, 6=exited, 7=removing, 8=stopping.")
+		health   = metrics.NewFamily("podman_container_health", metrics.Gauge, "Container health: -1=unknown, 0=healthy, 1=unhealthy, 2=starting.")
+		restarts = metrics.NewFamily("podman_container_restarts_total", metrics.Counter, "Number of restarts by the restart policy.")
+		exitCode = metrics.NewFamily("podman_container_exit_code", metrics.Gauge, "Exit code of the last run of an exited container.")
+		perCPU   = metrics.NewFamily("podman_container_per_cpu_seconds_total", metrics.Counter, "Total CPU time consumed per CPU in seconds.")
+		rwSize   = metrics.NewFamily("podman_container_rw_size_bytes", metrics.Gauge, "Size of the writable layer in bytes.")
+
+		podContainers = metrics.NewFamily("podman_pod_containers", metrics.Gauge, "Number of containers in the pod.")
+
+		imageSize       = metrics.NewFamily("podman_image_size_bytes", metrics.Gauge, "Size of the image in bytes.")
+		imageSharedSize = metrics.NewFamily("podman_image_shared_size_bytes", metrics.Gauge, "Size of the image shared with other images in bytes.")
+		imageContainers = metrics.NewFamily("podman_image_containers", metrics.Gauge, "Number of containers using the image.")
+
+		volumeSize        = metrics.NewFamily("podman_volume_size_bytes", metrics.Gauge, "Size of the volume in bytes.")
+		volumeReclaimable = metrics.NewFamily("podman_volume_reclaimable_bytes", metrics.Gauge, "Size of the volume reclaimable by pruning in bytes.")
+		volumeLinks       = metrics.NewFamily("podman_volume_links", metrics.Gauge, "Number of containers using the volume.")
+	)
+	containerStats := make([]*metrics.Family, len(statMetrics))
+	podStats := make([]*metrics.Family, len(statMetrics))
+	for i, m := range statMetrics {
+		containerStats[i] = metrics.NewFamily("podman_container_"+m.name, m.typ, m.help)
+		podStats[i] = metrics.NewFamily("podman_pod_"+m.name, m.typ, m.help)
+	}
+
+	pods, err := runtime.GetAllPods()
+	if err != nil {
+		return nil, err
+	}
+	podNames := make(map[string]string, len(pods))
+	for _, pod := range pods {
+		podNames[pod.ID()] = pod.Name()
+	}
+
+	ctrs, err := runtime.GetAllContainers()
+	if err != nil {
+		return nil, err
+	}
+	ctrLabels := make(map[string][]metrics.Label, len(ctrs))
+	stats := make(map[string]*define.ContainerStats, len(ctrs))
+	for _, ctr := range ctrs {
+		labels := append([]metrics.Label{
+			{Name: "id", Value: ctr.ID()},
+			{Name: "name", Value: ctr.Name()},
+			{Name: "pod", Value: podNames[ctr.PodID()]},
+		}, metrics.UserLabels(ctr.Labels())...)
+		ctrLabels[ctr.ID()] = labels
+
+		_, imageName := ctr.Image()
+		info.Add(1, append(labels[:3:3], metrics.Label{Name: "image", Value: imageName})...)
+
+		ctrState, err := ctr.State()
+		if err != nil {
+			// The container may have been removed in the meantime.
+			logrus.Debugf("Getting state of container %s for metrics: %v", ctr.ID(), err)
+			continue
+		}
+		state.Add(float64(ctrState), labels...)
+
+		if ctr.HasHealthCheck() {
+			status, err := ctr.HealthCheckStatus()
+			if err != nil {
+				logrus.Debugf("Getting health of container %s for metrics: %v", ctr.ID(), err)
+			}
+			health.Add(healthValue(status), labels...)
+		}
+
+		if restartCount, err := ctr.RestartCount(); err == nil {
+			restarts.Add(float64(restartCount), labels...)
+		}
+
+		switch ctrState {
+		case define.ContainerStateStopped, define.ContainerStateExited:
+			if code, exited, err := ctr.ExitCode(); err == nil && exited {
+				exitCode.Add(float64(code), labels...)
+			}
+		case define.ContainerStateRunning, define.ContainerStatePaused:
+			ctrStats, err := ctr.GetContainerStats(nil)
+			if err != nil {
+				logrus.Debugf("Getting stats of container %s for metrics: %v", ctr.ID(), err)
+				continue
+			}
+			stats[ctr.ID()] = ctrStats
+			for i, m := range statMetrics {
+				containerStats[i].Add(m.value(ctrStats), labels...)
+			}
+			for cpu, nano := range ctrStats.PerCPU {
+				perCPU.Add(float64(nano)/1e9, append(labels[:len(labels):len(labels)], metrics.Label{Name: "cpu", Value: strconv.Itoa(cpu)})...)
+			}
+		}
+	}
+
+	for _, pod := range pods {
+		labels := append([]metrics.Label{
+			{Name: "id", Value: pod.ID()},
+			{Name: "name", Value: pod.Name()},
+		}, metrics.UserLabels(pod.Labels())...)
+
+		podCtrs, err := pod.AllContainers()
+		if err != nil {
+			logrus.Debugf("Getting containers of pod %s for metrics: %v", pod.ID(), err)
+			continue
+		}
+		podContainers.Add(float64(len(podCtrs)), labels...)
+
+		sums := make([]float64, len(statMetrics))
+		running := false
+		for _, ctr := range podCtrs {
+			ctrStats, ok := stats[ctr.ID()]
+			if !ok {
+				continue
+			}
+			running = true
+			for i, m := range statMetrics {
+				value := m.value(ctrStats)
+				// Containers sharing the network namespace of the
+				// pod report the same network statistics.
+				if m.network && pod.SharesNet() {
+					if value > sums[i] {
+						sums[i] = value
+					}
+					continue
+				}
+				sums[i] += value
+			}
+		}
+		if !running {
+			continue
+		}
+		for i, m := range statMetrics {
+			if m.pod {
+				podStats[i].Add(sums[i], labels...)
+			}
+		}
+	}
+
+	ic := abi.ContainerEngine{Libpod: runtime}
+	df, err := ic.SystemDf(ctx, entities.SystemDfOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("getting disk usage for metrics: %w", err)
+	}
+	for _, ctr := range df.Containers {
+		if labels, ok := ctrLabels[ctr.ContainerID]; ok {
+			rwSize.Add(float64(ctr.RWSize), labels...)
+		}
+	}
+	for _, image := range df.Images {
+		labels := []metrics.Label{
+			{Name: "id", Value: image.ImageID},
+			{Name: "repository", Value: image.Repository},
+			{Name: "tag", Value: image.Tag},
+		}
+		imageSize.Add(float64(image.Size), labels...)
+		imageSharedSize.Add(float64(image.SharedSize), labels...)
+		imageContainers.Add(float64(image.Containers), labels...)
+	}
+	for _, volume := range df.Volumes {
+		labels := []metrics.Label{{Name: "name", Value: volume.VolumeName}}
+		volumeSize.Add(float64(volume.Size), labels...)
+		volumeReclaimable.Add(float64(volume.ReclaimableSize), labels...)
+		volumeLinks.Add(float64(volume.Links), labels...)
+	}
+
+	families := []*metrics.Family{info, state, health, restarts, exitCode}
+	families = append(families, containerStats...)
+	families = append(families, perCPU, rwSize, podContainers)
+	families = append(families, podStats...)
+	families = append(families, imageSize, imageSharedSize, imageContainers, volumeSize, volumeReclaimable, volumeLinks)
+	return families, nil
+}
+
+// healthValue maps a health status to the value of the health metric.
+func healthValue(status string) float64 {
+	switch status {
+	case define.HealthCheckHealthy:
+		return 0
+	case define.HealthCheckUnhealthy:
+		return 1
+	case define.HealthCheckStarting:
+		return 2
+	default:
+		return -1
+	}
+}
@@ -67,7 +67,14 @@ func UpdateNetwork(w http.ResponseWriter, r *http.Request) {
 
 	err := ic.NetworkUpdate(r.Context(), name, networkUpdateOptions)
 	if err != nil {
-		utils.Error(w, http.StatusInternalServerError, err)
+		switch {
+		case errors.Is(err, define.ErrNoSuchNetwork):
+			utils.NetworkNotFound(w, name, err)
+		case errors.Is(err, define.ErrInvalidArg):
+			utils.Error(w, http.StatusBadRequest, err)
+		default:
+			utils.Error(w, http.StatusInternalServerError, err)
+		}
 		return
 	}
 
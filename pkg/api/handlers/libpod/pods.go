@@ -4,13 +4,16 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
+	"os"
 	"strings"
 	"time"
 
 	"github.com/containers/podman/v4/libpod"
 	"github.com/containers/podman/v4/libpod/define"
 	"github.com/containers/podman/v4/pkg/api/handlers"
+	"github.com/containers/podman/v4/pkg/api/handlers/compat"
 	"github.com/containers/podman/v4/pkg/api/handlers/utils"
 	api "github.com/containers/podman/v4/pkg/api/types"
 	"github.com/containers/podman/v4/pkg/domain/entities"
@@ -21,6 +24,7 @@ import (
 	"github.com/containers/podman/v4/pkg/util"
 	"github.com/gorilla/schema"
 	"github.com/hashicorp/go-multierror"
+	"github.com/opencontainers/runtime-spec/specs-go"
 	"github.com/sirupsen/logrus"
 )
 
@@ -346,6 +350,60 @@ func PodPause(w http.ResponseWriter, r *http.Request) {
 	utils.WriteResponse(w, code, report)
 }
 
+func PodUpdate(w http.ResponseWriter, r *http.Request) {
+	runtime := r.Context().Value(api.RuntimeKey).(*libpod.Runtime)
+	decoder := r.Context().Value(api.DecoderKey).(*schema.Decoder)
+	query := struct {
+		RestartPolicy  *string `schema:"restartPolicy"`
+		RestartRetries *uint   `schema:"restartRetries"`
+		ExitPolicy     *string `schema:"exitPolicy"`
+	}{
+		// override any golang type defaults
+	}
+	if err := decoder.Decode(&query, r.URL.Query()); err != nil {
+		utils.Error(w, http.StatusBadRequest, fmt.Errorf("failed to parse parameters for %s: %w", r.URL.String(), err))
+		return
+	}
+
+	var resources *specs.LinuxResources
+	if err := json.NewDecoder(r.Body).Decode(&resources); err != nil && !errors.Is(err, io.EOF) {
+		utils.Error(w, http.StatusBadRequest, fmt.Errorf("decode(): %w", err))
+		return
+	}
+
+	name := utils.GetName(r)
+	pod, err := runtime.LookupPod(name)
+	if err != nil {
+		utils.PodNotFound(w, name, err)
+		return
+	}
+	responses, err := pod.Update(r.Context(), &libpod.PodUpdateOptions{
+		Resources:      resources,
+		RestartPolicy:  query.RestartPolicy,
+		RestartRetries: query.RestartRetries,
+		ExitPolicy:     query.ExitPolicy,
+	})
+	if err != nil && !errors.Is(err, define.ErrPodPartialFail) {
+		code := http.StatusInternalServerError
+		if errors.Is(err, define.ErrInvalidArg) {
+			code = http.StatusBadRequest
+		}
+		utils.Error(w, code, err)
+		return
+	}
+
+	report := entities.PodUpdateReport{Id: pod.ID()}
+	for id, v := range responses {
+		report.Errs = append(report.Errs, fmt.Errorf("updating container %s: %w", id, v))
+	}
+
+	code := http.StatusOK
+	if len(report.Errs) > 0 {
+		code = http.StatusConflict
+	}
+	utils.WriteResponse(w, code, report)
+}
+
 func PodUnpause(w http.ResponseWriter, r *http.Request) {
 	runtime := r.Context().Value(api.RuntimeKey).(*libpod.Runtime)
 	name := utils.GetName(r)
@@ -605,3 +663,164 @@ func PodStats(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 }
+
+func PodCheckpoint(w http.ResponseWriter, r *http.Request) {
+	runtime := r.Context().Value(api.RuntimeKey).(*libpod.Runtime)
+	containerEngine := abi.ContainerEngine{Libpod: runtime}
+	decoder := r.Context().Value(api.DecoderKey).(*schema.Decoder)
+	query := struct {
+		Keep           bool   `schema:"keep"`
+		LeaveRunning   bool   `schema:"leaveRunning"`
+		TCPEstablished bool   `schema:"tcpEstablished"`
+		Export         bool   `schema:"export"`
+		CreateImage    string `schema:"createImage"`
+		IgnoreRootfs   bool   `schema:"ignoreRootfs"`
+		IgnoreVolumes  bool   `schema:"ignoreVolumes"`
+		FileLocks      bool   `schema:"fileLocks"`
+		PrintStats     bool   `schema:"printStats"`
+	}{
+		// override any golang type defaults
+	}
+	if err := decoder.Decode(&query, r.URL.Query()); err != nil {
+		utils.Error(w, http.StatusBadRequest, fmt.Errorf("failed to parse parameters for %s: %w", r.URL.String(), err))
+		return
+	}
+
+	name := utils.GetName(r)
+	if _, err := runtime.LookupPod(name); err != nil {
+		utils.PodNotFound(w, name, err)
+		return
+	}
+
+	options := entities.PodCheckpointOptions{
+		Keep:           query.Keep,
+		LeaveRunning:   query.LeaveRunning,
+		TCPEstablished: query.TCPEstablished,
+		CreateImage:    query.CreateImage,
+		IgnoreRootFS:   query.IgnoreRootfs,
+		IgnoreVolumes:  query.IgnoreVolumes,
+		FileLocks:      query.FileLocks,
+		PrintStats:     query.PrintStats,
+	}
+	if query.Export {
+		f, err := os.CreateTemp("", "pod-checkpoint")
+		if err != nil {
+			utils.InternalServerError(w, err)
+			return
+		}
+		defer os.Remove(f.Name())
+		if err := f.Close(); err != nil {
+			utils.InternalServerError(w, err)
+			return
+		}
+		options.Export = f.Name()
+	}
+
+	reports, err := containerEngine.PodCheckpoint(r.Context(), []string{name}, options)
+	if err != nil {
+		utils.InternalServerError(w, err)
+		return
+	}
+	if len(reports) != 1 {
+		utils.InternalServerError(w, fmt.Errorf("expected 1 checkpoint report but got %d", len(reports)))
+		return
+	}
+	if reports[0].Err != nil {
+		utils.InternalServerError(w, reports[0].Err)
+		return
+	}
+
+	if !query.Export {
+		utils.WriteResponse(w, http.StatusOK, reports[0])
+		return
+	}
+	f, err := os.Open(options.Export)
+	if err != nil {
+		utils.InternalServerError(w, err)
+		return
+	}
+	defer f.Close()
+	utils.WriteResponse(w, http.StatusOK, f)
+}
+
+func PodRestore(w http.ResponseWriter, r *http.Request) {
+	runtime := r.Context().Value(api.RuntimeKey).(*libpod.Runtime)
+	containerEngine := abi.ContainerEngine{Libpod: runtime}
+	decoder := r.Context().Value(api.DecoderKey).(*schema.Decoder)
+	query := struct {
+		Keep            bool   `schema:"keep"`
+		TCPEstablished  bool   `schema:"tcpEstablished"`
+		Import          bool   `schema:"import"`
+		Name            string `schema:"name"`
+		IgnoreRootfs    bool   `schema:"ignoreRootfs"`
+		IgnoreVolumes   bool   `schema:"ignoreVolumes"`
+		IgnoreStaticIP  bool   `schema:"ignoreStaticIP"`
+		IgnoreStaticMAC bool   `schema:"ignoreStaticMAC"`
+		FileLocks       bool   `schema:"fileLocks"`
+		PrintStats      bool   `schema:"printStats"`
+	}{
+		// override any golang type defaults
+	}
+	if err := decoder.Decode(&query, r.URL.Query()); err != nil {
+		utils.Error(w, http.StatusBadRequest, fmt.Errorf("failed to parse parameters for %s: %w", r.URL.String(), err))
+		return
+	}
+
+	options := entities.PodRestoreOptions{
+		Name:            query.Name,
+		Keep:            query.Keep,
+		TCPEstablished:  query.TCPEstablished,
+		IgnoreRootFS:    query.IgnoreRootfs,
+		IgnoreVolumes:   query.IgnoreVolumes,
+		IgnoreStaticIP:  query.IgnoreStaticIP,
+		IgnoreStaticMAC: query.IgnoreStaticMAC,
+		FileLocks:       query.FileLocks,
+		PrintStats:      query.PrintStats,
+	}
+
+	var names []string
+	if query.Import {
+		t, err := os.CreateTemp("", "pod-restore")
+		if err != nil {
+			utils.InternalServerError(w, err)
+			return
+		}
+		defer os.Remove(t.Name())
+		if err := compat.SaveFromBody(t, r); err != nil {
+			utils.InternalServerError(w, err)
+			return
+		}
+		options.Import = t.Name()
+	} else {
+		name := utils.GetName(r)
+		if _, err := runtime.LookupPod(name); err != nil {
+			// If the pod was not found, check if this is a checkpoint image
+			ir := abi.ImageEngine{Libpod: runtime}
+			report, err := ir.Exists(r.Context(), name)
+			if err != nil {
+				utils.Error(w, http.StatusNotFound, fmt.Errorf("failed to find pod or checkpoint image %s: %w", name, err))
+				return
+			}
+			if !report.Value {
+				utils.Error(w, http.StatusNotFound, fmt.Errorf("failed to find pod or checkpoint image %s", name))
+				return
+			}
+		}
+		names = []string{name}
+	}
+
+	reports, err := containerEngine.PodRestore(r.Context(), names, options)
+	if err != nil {
+		utils.InternalServerError(w, err)
+		return
+	}
+	if len(reports) != 1 {
+		utils.InternalServerError(w, fmt.Errorf("expected 1 restore report but got %d", len(reports)))
+		return
+	}
+	if reports[0].Err != nil {
+		utils.InternalServerError(w, reports[0].Err)
+		return
+	}
+	utils.WriteResponse(w, http.StatusOK, reports[0])
+}
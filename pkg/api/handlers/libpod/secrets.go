@@ -1,11 +1,13 @@
 package libpod
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 
 	"github.com/containers/common/pkg/secrets"
 	"github.com/containers/podman/v4/libpod"
+	"github.com/containers/podman/v4/libpod/define"
 	"github.com/containers/podman/v4/pkg/api/handlers/utils"
 	api "github.com/containers/podman/v4/pkg/api/types"
 	"github.com/containers/podman/v4/pkg/domain/entities"
@@ -48,6 +50,39 @@ func CreateSecret(w http.ResponseWriter, r *http.Request) {
 	utils.WriteResponse(w, http.StatusOK, report)
 }
 
+func UpdateSecret(w http.ResponseWriter, r *http.Request) {
+	var (
+		runtime = r.Context().Value(api.RuntimeKey).(*libpod.Runtime)
+		decoder = r.Context().Value(api.DecoderKey).(*schema.Decoder)
+	)
+
+	query := struct {
+		Propagate string `schema:"propagate"`
+	}{
+		// override any golang type defaults
+	}
+	if err := decoder.Decode(&query, r.URL.Query()); err != nil {
+		utils.Error(w, http.StatusBadRequest, fmt.Errorf("failed to parse parameters for %s: %w", r.URL.String(), err))
+		return
+	}
+
+	name := utils.GetName(r)
+	ic := abi.ContainerEngine{Libpod: runtime}
+	report, err := ic.SecretUpdate(r.Context(), name, r.Body, entities.SecretUpdateOptions{Propagate: query.Propagate})
+	if err != nil {
+		switch {
+		case errors.Is(err, secrets.ErrNoSuchSecret):
+			utils.SecretNotFound(w, name, err)
+		case errors.Is(err, define.ErrInvalidArg):
+			utils.Error(w, http.StatusBadRequest, err)
+		default:
+			utils.InternalServerError(w, err)
+		}
+		return
+	}
+	utils.WriteResponse(w, http.StatusOK, report)
+}
+
 func SecretExists(w http.ResponseWriter, r *http.Request) {
 	runtime := r.Context().Value(api.RuntimeKey).(*libpod.Runtime)
 	name := utils.GetName(r)
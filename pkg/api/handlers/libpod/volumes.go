@@ -18,6 +18,7 @@ import (
 	"github.com/containers/podman/v4/pkg/domain/infra/abi"
 	"github.com/containers/podman/v4/pkg/domain/infra/abi/parse"
 	"github.com/containers/podman/v4/pkg/util"
+	"github.com/gorilla/mux"
 	"github.com/gorilla/schema"
 )
 
@@ -233,3 +234,89 @@ func ExistsVolume(w http.ResponseWriter, r *http.Request) {
 	}
 	utils.WriteResponse(w, http.StatusNoContent, "")
 }
+
+// CreateVolumeSnapshot creates a snapshot of a volume
+func CreateVolumeSnapshot(w http.ResponseWriter, r *http.Request) {
+	var (
+		runtime = r.Context().Value(api.RuntimeKey).(*libpod.Runtime)
+		decoder = r.Context().Value(api.DecoderKey).(*schema.Decoder)
+	)
+	query := struct {
+		Name  string `schema:"name"`
+		Pause bool   `schema:"pause"`
+	}{
+		// override any golang type defaults
+	}
+	if err := decoder.Decode(&query, r.URL.Query()); err != nil {
+		utils.Error(w, http.StatusBadRequest,
+			fmt.Errorf("failed to parse parameters for %s: %w", r.URL.String(), err))
+		return
+	}
+	name := utils.GetName(r)
+	ic := abi.ContainerEngine{Libpod: runtime}
+	report, err := ic.VolumeSnapshotCreate(r.Context(), name, entities.VolumeSnapshotCreateOptions{
+		Name:  query.Name,
+		Pause: query.Pause,
+	})
+	if err != nil {
+		volumeSnapshotError(w, name, err)
+		return
+	}
+	utils.WriteResponse(w, http.StatusCreated, report)
+}
+
+// ListVolumeSnapshots lists the snapshots of a volume
+func ListVolumeSnapshots(w http.ResponseWriter, r *http.Request) {
+	runtime := r.Context().Value(api.RuntimeKey).(*libpod.Runtime)
+	name := utils.GetName(r)
+	ic := abi.ContainerEngine{Libpod: runtime}
+	reports, err := ic.VolumeSnapshotList(r.Context(), []string{name})
+	if err != nil {
+		volumeSnapshotError(w, name, err)
+		return
+	}
+	utils.WriteResponse(w, http.StatusOK, reports)
+}
+
+// RestoreVolumeSnapshot replaces the contents of a volume with a snapshot
+func RestoreVolumeSnapshot(w http.ResponseWriter, r *http.Request) {
+	runtime := r.Context().Value(api.RuntimeKey).(*libpod.Runtime)
+	name := utils.GetName(r)
+	ic := abi.ContainerEngine{Libpod: runtime}
+	if err := ic.VolumeSnapshotRestore(r.Context(), name, mux.Vars(r)["snapshot"]); err != nil {
+		volumeSnapshotError(w, name, err)
+		return
+	}
+	utils.WriteResponse(w, http.StatusNoContent, "")
+}
+
+// RemoveVolumeSnapshot removes a snapshot of a volume
+func RemoveVolumeSnapshot(w http.ResponseWriter, r *http.Request) {
+	runtime := r.Context().Value(api.RuntimeKey).(*libpod.Runtime)
+	name := utils.GetName(r)
+	vol, err := runtime.LookupVolume(name)
+	if err != nil {
+		utils.VolumeNotFound(w, name, err)
+		return
+	}
+	if err := vol.RemoveSnapshot(mux.Vars(r)["snapshot"]); err != nil {
+		volumeSnapshotError(w, name, err)
+		return
+	}
+	utils.WriteResponse(w, http.StatusNoContent, "")
+}
+
+func volumeSnapshotError(w http.ResponseWriter, name string, err error) {
+	switch {
+	case errors.Is(err, define.ErrNoSuchVolume):
+		utils.VolumeNotFound(w, name, err)
+	case errors.Is(err, define.ErrNoSuchSnapshot):
+		utils.Error(w, http.StatusNotFound, err)
+	case errors.Is(err, define.ErrSnapshotExists), errors.Is(err, define.ErrVolumeBeingUsed):
+		utils.Error(w, http.StatusConflict, err)
+	case errors.Is(err, define.RegexError), errors.Is(err, define.ErrNotImplemented):
+		utils.Error(w, http.StatusBadRequest, err)
+	default:
+		utils.InternalServerError(w, err)
+	}
+}
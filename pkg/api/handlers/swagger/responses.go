@@ -10,7 +10,9 @@ import (
 	"github.com/containers/podman/v4/pkg/domain/entities/reports"
 	"github.com/containers/podman/v4/pkg/inspect"
 	dockerAPI "github.com/docker/docker/api/types"
+	dockerContainer "github.com/docker/docker/api/types/container"
 	dockerImage "github.com/docker/docker/api/types/image"
+	dockerRegistry "github.com/docker/docker/api/types/registry"
 	dockerVolume "github.com/docker/docker/api/types/volume"
 )
 
@@ -265,6 +267,20 @@ type volumeListLibpod struct {
 	Body []entities.VolumeConfigResponse
 }
 
+// Volume snapshot
+// swagger:response
+type volumeSnapshotLibpod struct {
+	// in:body
+	Body entities.VolumeSnapshotReport
+}
+
+// Volume snapshot list
+// swagger:response
+type volumeSnapshotListLibpod struct {
+	// in:body
+	Body []entities.VolumeSnapshotReport
+}
+
 // Image Prune
 // swagger:response
 type imagesPruneLibpod struct {
@@ -319,6 +335,20 @@ type containerUpdateResponse struct {
 	ID string
 }
 
+// Update container
+// swagger:response
+type containerUpdateResponseCompat struct {
+	// in:body
+	Body dockerContainer.ContainerUpdateOKBody
+}
+
+// Inspect an image in a registry
+// swagger:response
+type distributionInspectResponse struct {
+	// in:body
+	Body dockerRegistry.DistributionInspect
+}
+
 // Wait container
 // swagger:response
 type containerWaitResponse struct {
@@ -381,6 +411,13 @@ type podUnpauseResponse struct {
 	Body entities.PodUnpauseReport
 }
 
+// Update pod
+// swagger:response
+type podUpdateResponse struct {
+	// in:body
+	Body entities.PodUpdateReport
+}
+
 // Stop pod
 // swagger:response
 type podStopResponse struct {
@@ -0,0 +1,145 @@
+// Package metrics writes metrics in the Prometheus text exposition format and
+// records the latency of API requests.
+package metrics
+
+import (
+	"bufio"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ContentType is the content type of the Prometheus text exposition format.
+const ContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// Metric types of the exposition format.
+const (
+	Counter   = "counter"
+	Gauge     = "gauge"
+	Histogram = "histogram"
+)
+
+// Label is a name-value pair identifying a sample.
+type Label struct {
+	Name  string
+	Value string
+}
+
+// Sample is a single value of a metric family.
+type Sample struct {
+	// Suffix is appended to the name of the family, e.g. "_bucket" for
+	// the buckets of histograms.
+	Suffix string
+	Labels []Label
+	Value  float64
+}
+
+// Family is a metric with all of its samples.
+type Family struct {
+	Name    string
+	Help    string
+	Type    string
+	Samples []Sample
+}
+
+// NewFamily returns an empty metric family.
+func NewFamily(name, typ, help string) *Family {
+	return &Family{Name: name, Help: help, Type: typ}
+}
+
+// Add adds a sample with the specified labels to the family.
+func (f *Family) Add(value float64, labels ...Label) {
+	f.Samples = append(f.Samples, Sample{Labels: labels, Value: value})
+}
+
+// Write writes the metric families in the text exposition format.  Families
+// without samples are skipped.
+func Write(w io.Writer, families []*Family) error {
+	bw := bufio.NewWriter(w)
+	for _, f := range families {
+		if len(f.Samples) == 0 {
+			continue
+		}
+		bw.WriteString("# HELP " + f.Name + " " + escapeHelp(f.Help) + "\n")
+		bw.WriteString("# TYPE " + f.Name + " " + f.Type + "\n")
+		for _, s := range f.Samples {
+			bw.WriteString(f.Name + s.Suffix)
+			if len(s.Labels) > 0 {
+				bw.WriteByte('{')
+				for i, l := range s.Labels {
+					if i > 0 {
+						bw.WriteByte(',')
+					}
+					bw.WriteString(l.Name + `="` + escapeLabelValue(l.Value) + `"`)
+				}
+				bw.WriteByte('}')
+			}
+			bw.WriteString(" " + formatValue(s.Value) + "\n")
+		}
+	}
+	return bw.Flush()
+}
+
+// sanitizeLabelName replaces all characters which are invalid in label names
+// with underscores.  Digits are kept, so the name must be prefixed.
+func sanitizeLabelName(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '_':
+		default:
+			r = '_'
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// UserLabels returns the user-defined labels of an object as metric labels.
+// The names are prefixed with "label_", ordered and made valid.  If several
+// labels map to the same name, the first one in order wins.
+func UserLabels(labels map[string]string) []Label {
+	keys := make([]string, 0, len(labels))
+	for k := range labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	result := make([]Label, 0, len(keys))
+	seen := make(map[string]bool, len(keys))
+	for _, k := range keys {
+		name := "label_" + sanitizeLabelName(k)
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+		result = append(result, Label{Name: name, Value: labels[k]})
+	}
+	return result
+}
+
+func formatValue(v float64) string {
+	switch {
+	case math.IsInf(v, 1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	case math.IsNaN(v):
+		return "NaN"
+	}
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+var helpReplacer = strings.NewReplacer(`\`, `\\`, "\n", `\n`)
+
+func escapeHelp(s string) string {
+	return helpReplacer.Replace(s)
+}
+
+var labelValueReplacer = strings.NewReplacer(`\`, `\\`, "\n", `\n`, `"`, `\"`)
+
+func escapeLabelValue(s string) string {
+	return labelValueReplacer.Replace(s)
+}
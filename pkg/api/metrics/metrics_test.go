@@ -0,0 +1,53 @@
+package metrics
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWrite(t *testing.T) {
+	mem := NewFamily("podman_container_mem_usage_bytes", Gauge, "Memory usage\nin bytes.")
+	mem.Add(1024, Label{Name: "name", Value: `web "1"`}, Label{Name: "label_app", Value: "a\\b"})
+	mem.Add(2048)
+	empty := NewFamily("podman_empty", Counter, "Not written.")
+
+	var sb strings.Builder
+	assert.NoError(t, Write(&sb, []*Family{mem, empty}))
+	assert.Equal(t, `# HELP podman_container_mem_usage_bytes Memory usage\nin bytes.
+# TYPE podman_container_mem_usage_bytes gauge
+podman_container_mem_usage_bytes{name="web \"1\"",label_app="a\\b"} 1024
+podman_container_mem_usage_bytes 2048
+`, sb.String())
+}
+
+func TestUserLabels(t *testing.T) {
+	labels := UserLabels(map[string]string{
+		"io.containers.autoupdate": "registry",
+		"io_containers_autoupdate": "local",
+		"1st":                      "x",
+	})
+	assert.Equal(t, []Label{
+		{Name: "label_1st", Value: "x"},
+		{Name: "label_io_containers_autoupdate", Value: "registry"},
+	}, labels)
+}
+
+func TestRequestCollector(t *testing.T) {
+	c := NewRequestCollector()
+	c.Observe("GET", "/v{version:[0-9][0-9A-Za-z.-]*}/libpod/_ping", 200, 20*time.Millisecond)
+	c.Observe("GET", "/v{version:[0-9][0-9A-Za-z.-]*}/libpod/_ping", 200, 2*time.Second)
+
+	var sb strings.Builder
+	assert.NoError(t, Write(&sb, c.Families()))
+	out := sb.String()
+	assert.Contains(t, out, "# TYPE podman_api_request_duration_seconds histogram\n")
+	labels := `method="GET",path="/v{version:[0-9][0-9A-Za-z.-]*}/libpod/_ping",code="200"`
+	assert.Contains(t, out, "podman_api_request_duration_seconds_bucket{"+labels+`,le="0.01"} 0`+"\n")
+	assert.Contains(t, out, "podman_api_request_duration_seconds_bucket{"+labels+`,le="0.025"} 1`+"\n")
+	assert.Contains(t, out, "podman_api_request_duration_seconds_bucket{"+labels+`,le="+Inf"} 2`+"\n")
+	assert.Contains(t, out, "podman_api_request_duration_seconds_sum{"+labels+"} 2.02\n")
+	assert.Contains(t, out, "podman_api_request_duration_seconds_count{"+labels+"} 2\n")
+}
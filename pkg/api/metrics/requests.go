@@ -0,0 +1,102 @@
+package metrics
+
+import (
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// requestDurationBuckets are the upper bounds of the buckets of the request
+// duration histogram in seconds.
+var requestDurationBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// requestKey identifies the requests of one histogram.
+type requestKey struct {
+	method string
+	path   string
+	code   int
+}
+
+type requestHistogram struct {
+	buckets []uint64
+	count   uint64
+	sum     float64
+}
+
+// RequestCollector records the duration of API requests by method, path
+// template and status code.
+type RequestCollector struct {
+	lock       sync.Mutex
+	histograms map[requestKey]*requestHistogram
+}
+
+// NewRequestCollector returns an empty request collector.
+func NewRequestCollector() *RequestCollector {
+	return &RequestCollector{histograms: make(map[requestKey]*requestHistogram)}
+}
+
+// Observe records a request which took the specified duration.
+func (c *RequestCollector) Observe(method, path string, code int, duration time.Duration) {
+	key := requestKey{method: method, path: path, code: code}
+	seconds := duration.Seconds()
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	h, ok := c.histograms[key]
+	if !ok {
+		h = &requestHistogram{buckets: make([]uint64, len(requestDurationBuckets))}
+		c.histograms[key] = h
+	}
+	for i, bound := range requestDurationBuckets {
+		if seconds <= bound {
+			h.buckets[i]++
+		}
+	}
+	h.count++
+	h.sum += seconds
+}
+
+// Families returns the request metrics.
+func (c *RequestCollector) Families() []*Family {
+	duration := NewFamily("podman_api_request_duration_seconds", Histogram, "Duration of API requests in seconds.")
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	keys := make([]requestKey, 0, len(c.histograms))
+	for key := range c.histograms {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].path != keys[j].path {
+			return keys[i].path < keys[j].path
+		}
+		if keys[i].method != keys[j].method {
+			return keys[i].method < keys[j].method
+		}
+		return keys[i].code < keys[j].code
+	})
+
+	for _, key := range keys {
+		h := c.histograms[key]
+		labels := []Label{
+			{Name: "method", Value: key.method},
+			{Name: "path", Value: key.path},
+			{Name: "code", Value: strconv.Itoa(key.code)},
+		}
+		withLE := func(le string) []Label {
+			return append(append(make([]Label, 0, len(labels)+1), labels...), Label{Name: "le", Value: le})
+		}
+		for i, bound := range requestDurationBuckets {
+			duration.Samples = append(duration.Samples, Sample{Suffix: "_bucket", Labels: withLE(formatValue(bound)), Value: float64(h.buckets[i])})
+		}
+		duration.Samples = append(duration.Samples,
+			Sample{Suffix: "_bucket", Labels: withLE("+Inf"), Value: float64(h.count)},
+			Sample{Suffix: "_sum", Labels: labels, Value: h.sum},
+			Sample{Suffix: "_count", Labels: labels, Value: float64(h.count)},
+		)
+	}
+	return []*Family{duration}
+}
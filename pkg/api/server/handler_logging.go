@@ -8,6 +8,7 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/containers/podman/v4/pkg/api/metrics"
 	"github.com/gorilla/mux"
 	"github.com/sirupsen/logrus"
 )
@@ -73,3 +74,65 @@ func loggingHandler() mux.MiddlewareFunc {
 		})
 	}
 }
+
+// statusResponseWriter records the status code of a response.
+type statusResponseWriter struct {
+	http.ResponseWriter
+	status int
+}
+
+func (s *statusResponseWriter) Write(b []byte) (int, error) {
+	if s.status == 0 {
+		s.status = http.StatusOK
+	}
+	return s.ResponseWriter.Write(b)
+}
+
+func (s *statusResponseWriter) WriteHeader(statusCode int) {
+	if s.status == 0 {
+		s.status = statusCode
+	}
+	s.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (s *statusResponseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	if wrapped, ok := s.ResponseWriter.(http.Hijacker); ok {
+		// Hijacked connections switch protocols, e.g. for attach.
+		if s.status == 0 {
+			s.status = http.StatusSwitchingProtocols
+		}
+		return wrapped.Hijack()
+	}
+
+	return nil, nil, errors.New("ResponseWriter does not support hijacking")
+}
+
+func (s *statusResponseWriter) Flush() {
+	if wrapped, ok := s.ResponseWriter.(http.Flusher); ok {
+		wrapped.Flush()
+	}
+}
+
+// metricsHandler records the duration of requests by method, path template
+// and status code.
+func metricsHandler(requests *metrics.RequestCollector) mux.MiddlewareFunc {
+	return func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			sw := &statusResponseWriter{ResponseWriter: w}
+			h.ServeHTTP(sw, r)
+
+			path := "<N/A>"
+			if route := mux.CurrentRoute(r); route != nil {
+				if tmpl, err := route.GetPathTemplate(); err == nil {
+					path = tmpl
+				}
+			}
+			status := sw.status
+			if status == 0 {
+				status = http.StatusOK
+			}
+			requests.Observe(r.Method, path, status, time.Since(start))
+		})
+	}
+}
@@ -1,13 +1,47 @@
 package server
 
 import (
+	"net/http"
+
 	"github.com/containers/podman/v4/pkg/api/handlers/compat"
 	"github.com/gorilla/mux"
 )
 
 func (s *APIServer) registerDistributionHandlers(r *mux.Router) error {
-	r.HandleFunc(VersionedPath("/distribution/{name}/json"), compat.UnsupportedHandler)
+	// swagger:operation GET /distribution/{name}/json compat DistributionInspect
+	// ---
+	// tags:
+	//   - images (compat)
+	// summary: Inspect an image in a registry
+	// description: Return the manifest descriptor of an image in a registry, and the platforms it supports.
+	// parameters:
+	//  - in: path
+	//    name: name
+	//    type: string
+	//    required: true
+	//    description: the name of the image in the registry
+	//  - in: query
+	//    name: tlsVerify
+	//    type: boolean
+	//    default: true
+	//    description: Require TLS verification.
+	//  - in: header
+	//    name: X-Registry-Auth
+	//    type: string
+	//    description: "base-64 encoded auth config. Must include the following four values: username, password, email and server address OR simply just an identity token."
+	// produces:
+	// - application/json
+	// responses:
+	//   200:
+	//     $ref: "#/responses/distributionInspectResponse"
+	//   400:
+	//     $ref: "#/responses/badParamError"
+	//   401:
+	//     description: the registry denied access to the image
+	//   500:
+	//     $ref: "#/responses/internalError"
+	r.HandleFunc(VersionedPath("/distribution/{name:.*}/json"), s.APIHandler(compat.InspectDistribution)).Methods(http.MethodGet)
 	// Added non version path to URI to support docker non versioned paths
-	r.HandleFunc("/distribution/{name}/json", compat.UnsupportedHandler)
+	r.HandleFunc("/distribution/{name:.*}/json", s.APIHandler(compat.InspectDistribution)).Methods(http.MethodGet)
 	return nil
 }
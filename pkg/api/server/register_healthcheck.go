@@ -20,6 +20,11 @@ func (s *APIServer) registerHealthCheckHandlers(r *mux.Router) error {
 	//    type: string
 	//    required: true
 	//    description: the name or ID of the container
+	//  - in: query
+	//    name: readiness
+	//    type: boolean
+	//    default: false
+	//    description: run the readiness healthcheck instead of the healthcheck
 	// produces:
 	// - application/json
 	// responses:
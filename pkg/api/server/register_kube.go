@@ -57,6 +57,11 @@ func (s *APIServer) registerKubeHandlers(r *mux.Router) error {
 	//    type: boolean
 	//    description: Whether to publish all ports defined in the K8S YAML file (containerPort, hostPort), if false only hostPort will be published
 	//  - in: query
+	//    name: readinessGate
+	//    type: boolean
+	//    default: false
+	//    description: Withhold the published ports of containers with a readinessProbe until they are ready and wait for them to be ready
+	//  - in: query
 	//    name: replace
 	//    type: boolean
 	//    default: false
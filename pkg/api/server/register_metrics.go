@@ -0,0 +1,32 @@
+package server
+
+import (
+	"net/http"
+
+	"github.com/containers/podman/v4/pkg/api/handlers/libpod"
+	"github.com/gorilla/mux"
+)
+
+func (s *APIServer) registerMetricsHandlers(r *mux.Router) error {
+	// The endpoint is opt-in, see podman system service --metrics.
+	if s.requestMetrics == nil {
+		return nil
+	}
+	// swagger:operation GET /metrics libpod SystemMetricsLibpod
+	// ---
+	// tags:
+	//   - system
+	// summary: Get metrics
+	// description: |
+	//   Return metrics of containers, pods, images, volumes and API requests in the Prometheus text exposition format.
+	//   Only available if the service was started with --metrics.
+	// produces:
+	// - text/plain
+	// responses:
+	//   200:
+	//     description: metrics in the Prometheus text exposition format
+	//   500:
+	//     $ref: "#/responses/internalError"
+	r.Handle("/metrics", s.APIHandler(libpod.Metrics)).Methods(http.MethodGet)
+	return nil
+}
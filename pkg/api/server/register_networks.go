@@ -241,7 +241,10 @@ func (s *APIServer) registerNetworkHandlers(r *mux.Router) error {
 	// tags:
 	//  - networks
 	// summary: Update existing podman network
-	// description: Update existing podman network
+	// description: |
+	//   Update existing podman network.
+	//   Changes to the DNS servers are applied in place. All other changes recreate the network under the
+	//   same name with a new ID, and containers connected to it are disconnected and reconnected.
 	// produces:
 	// - application/json
 	// parameters:
@@ -252,7 +255,7 @@ func (s *APIServer) registerNetworkHandlers(r *mux.Router) error {
 	//    description: the name or ID of the network
 	//  - in: body
 	//    name: update
-	//    description: attributes for updating a netavark network
+	//    description: attributes for updating a network
 	//    schema:
 	//      $ref: "#/definitions/networkUpdateRequestLibpod"
 	// responses:
@@ -260,6 +263,8 @@ func (s *APIServer) registerNetworkHandlers(r *mux.Router) error {
 	//     description: OK
 	//   400:
 	//     $ref: "#/responses/badParamError"
+	//   404:
+	//     $ref: "#/responses/networkNotFound"
 	//   500:
 	//     $ref: "#/responses/internalError"
 	r.HandleFunc(VersionedPath("/libpod/networks/{name}/update"), s.APIHandler(libpod.UpdateNetwork)).Methods(http.MethodPost)
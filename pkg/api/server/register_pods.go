@@ -191,6 +191,122 @@ func (s *APIServer) registerPodsHandlers(r *mux.Router) error {
 	//   500:
 	//     $ref: "#/responses/internalError"
 	r.Handle(VersionedPath("/libpod/pods/{name}/pause"), s.APIHandler(libpod.PodPause)).Methods(http.MethodPost)
+	// swagger:operation POST /libpod/pods/{name}/checkpoint pods PodCheckpointLibpod
+	// ---
+	// summary: Checkpoint a pod
+	// description: Checkpoint all running containers of a pod. The containers are frozen while the pod is checkpointed.
+	// parameters:
+	//  - in: path
+	//    name: name
+	//    type: string
+	//    required: true
+	//    description: the name or ID of the pod
+	//  - in: query
+	//    name: keep
+	//    type: boolean
+	//    description: keep all temporary checkpoint files
+	//  - in: query
+	//    name: leaveRunning
+	//    type: boolean
+	//    description: leave the containers running after writing the checkpoint to disk
+	//  - in: query
+	//    name: tcpEstablished
+	//    type: boolean
+	//    description: checkpoint containers with established TCP connections
+	//  - in: query
+	//    name: export
+	//    type: boolean
+	//    description: export the checkpoint of the pod and its containers to a tarball
+	//  - in: query
+	//    name: createImage
+	//    type: string
+	//    description: create a checkpoint image of the pod with the given name
+	//  - in: query
+	//    name: ignoreRootfs
+	//    type: boolean
+	//    description: do not include root file-system changes when exporting. can only be used with export
+	//  - in: query
+	//    name: ignoreVolumes
+	//    type: boolean
+	//    description: do not include associated volumes. can only be used with export
+	//  - in: query
+	//    name: fileLocks
+	//    type: boolean
+	//    description: checkpoint containers with file locks
+	//  - in: query
+	//    name: printStats
+	//    type: boolean
+	//    description: add checkpoint statistics to the returned report
+	// produces:
+	// - application/json
+	// responses:
+	//   200:
+	//     description: tarball is returned in body if exported
+	//   404:
+	//     $ref: "#/responses/podNotFound"
+	//   500:
+	//     $ref: "#/responses/internalError"
+	r.Handle(VersionedPath("/libpod/pods/{name}/checkpoint"), s.APIHandler(libpod.PodCheckpoint)).Methods(http.MethodPost)
+	// swagger:operation POST /libpod/pods/{name}/restore pods PodRestoreLibpod
+	// ---
+	// summary: Restore a pod
+	// description: Restore the checkpointed containers of a pod, or re-create a pod from a checkpoint image or tarball.
+	// parameters:
+	//  - in: path
+	//    name: name
+	//    type: string
+	//    required: true
+	//    description: the name or ID of the pod, or the name of a checkpoint image
+	//  - in: query
+	//    name: name
+	//    type: string
+	//    description: the name of the pod when restored from a tarball or an image
+	//  - in: query
+	//    name: keep
+	//    type: boolean
+	//    description: keep all temporary checkpoint files
+	//  - in: query
+	//    name: tcpEstablished
+	//    type: boolean
+	//    description: restore containers with established TCP connections
+	//  - in: query
+	//    name: import
+	//    type: boolean
+	//    description: import the checkpoint of the pod from the tarball in the body
+	//  - in: query
+	//    name: ignoreRootfs
+	//    type: boolean
+	//    description: do not restore root file-system changes
+	//  - in: query
+	//    name: ignoreVolumes
+	//    type: boolean
+	//    description: do not restore associated volumes
+	//  - in: query
+	//    name: ignoreStaticIP
+	//    type: boolean
+	//    description: ignore IP addresses of the pod if set statically
+	//  - in: query
+	//    name: ignoreStaticMAC
+	//    type: boolean
+	//    description: ignore MAC addresses of the pod if set statically
+	//  - in: query
+	//    name: fileLocks
+	//    type: boolean
+	//    description: restore containers with file locks
+	//  - in: query
+	//    name: printStats
+	//    type: boolean
+	//    description: add restore statistics to the returned report
+	// produces:
+	// - application/json
+	// responses:
+	//   200:
+	//     description: restore report of the pod
+	//   404:
+	//     $ref: "#/responses/podNotFound"
+	//   500:
+	//     $ref: "#/responses/internalError"
+	r.Handle(VersionedPath("/libpod/pods/{name}/restore"), s.APIHandler(libpod.PodRestore)).Methods(http.MethodPost)
 	// swagger:operation POST /libpod/pods/{name}/restart pods PodRestartLibpod
 	// ---
 	// summary: Restart a pod
@@ -285,6 +401,47 @@ func (s *APIServer) registerPodsHandlers(r *mux.Router) error {
 	//   500:
 	//     $ref: "#/responses/internalError"
 	r.Handle(VersionedPath("/libpod/pods/{name}/unpause"), s.APIHandler(libpod.PodUnpause)).Methods(http.MethodPost)
+	// swagger:operation POST /libpod/pods/{name}/update pods PodUpdateLibpod
+	// ---
+	// summary: Update a pod
+	// description: Update the resource limits, the restart policy and the exit policy of an existing pod.  The resource limits are also applied to all containers of the pod.
+	// produces:
+	// - application/json
+	// parameters:
+	//  - in: path
+	//    name: name
+	//    type: string
+	//    required: true
+	//    description: the name or ID of the pod
+	//  - in: query
+	//    name: restartPolicy
+	//    type: string
+	//    description: new restart policy of the pod and its containers
+	//  - in: query
+	//    name: restartRetries
+	//    type: integer
+	//    description: number of restart retries for the on-failure restart policy
+	//  - in: query
+	//    name: exitPolicy
+	//    type: string
+	//    description: new exit policy of the pod ("continue"|"stop")
+	//  - in: body
+	//    name: resources
+	//    description: resource limits to update
+	//    schema:
+	//      $ref: "#/definitions/LinuxResources"
+	// responses:
+	//   200:
+	//     $ref: '#/responses/podUpdateResponse'
+	//   400:
+	//     $ref: "#/responses/badParamError"
+	//   404:
+	//     $ref: "#/responses/podNotFound"
+	//   409:
+	//     $ref: '#/responses/podUpdateResponse'
+	//   500:
+	//     $ref: "#/responses/internalError"
+	r.Handle(VersionedPath("/libpod/pods/{name}/update"), s.APIHandler(libpod.PodUpdate)).Methods(http.MethodPost)
 	// swagger:operation GET /libpod/pods/{name}/top pods PodTopLibpod
 	// ---
 	// summary: List processes
@@ -115,6 +115,44 @@ func (s *APIServer) registerSecretHandlers(r *mux.Router) error {
 	//   '500':
 	//     "$ref": "#/responses/internalError"
 	r.Handle(VersionedPath("/libpod/secrets/{name}/exists"), s.APIHandler(libpod.SecretExists)).Methods(http.MethodGet)
+	// swagger:operation POST /libpod/secrets/{name}/update libpod SecretUpdateLibpod
+	// ---
+	// tags:
+	//  - secrets
+	// summary: Update a secret
+	// description: Replace the data of a secret while keeping its name and metadata. The secret gets a new ID.
+	// parameters:
+	//  - in: path
+	//    name: name
+	//    type: string
+	//    required: true
+	//    description: the name or ID of the secret
+	//  - in: query
+	//    name: propagate
+	//    type: string
+	//    description: |
+	//      How to propagate the new data to containers using the secret:
+	//        - `none`: do not touch containers (default)
+	//        - `reinject`: rewrite the secret in all containers that mount it
+	//        - `restart`: like `reinject`, and restart the running containers using the secret
+	//    default: none
+	//  - in: body
+	//    name: request
+	//    description: Secret data
+	//    schema:
+	//      type: string
+	// produces:
+	// - application/json
+	// responses:
+	//   '200':
+	//     $ref: "#/responses/SecretUpdateResponse"
+	//   '400':
+	//     "$ref": "#/responses/badParamError"
+	//   '404':
+	//     "$ref": "#/responses/NoSuchSecret"
+	//   '500':
+	//     "$ref": "#/responses/internalError"
+	r.Handle(VersionedPath("/libpod/secrets/{name}/update"), s.APIHandler(libpod.UpdateSecret)).Methods(http.MethodPost)
 	// swagger:operation DELETE /libpod/secrets/{name} libpod SecretDeleteLibpod
 	// ---
 	// tags:
@@ -145,6 +145,121 @@ func (s *APIServer) registerVolumeHandlers(r *mux.Router) error {
 	//   500:
 	//     $ref: "#/responses/internalError"
 	r.Handle(VersionedPath("/libpod/volumes/{name}"), s.APIHandler(libpod.RemoveVolume)).Methods(http.MethodDelete)
+	// swagger:operation POST /libpod/volumes/{name}/snapshots libpod VolumeSnapshotCreateLibpod
+	// ---
+	// tags:
+	//  - volumes
+	// summary: Create a snapshot of a volume
+	// description: |
+	//   Copy the contents of a volume to a snapshot. Files are copied using reflinks if the filesystem supports them.
+	//   Only volumes of the local driver are supported.
+	// parameters:
+	//  - in: path
+	//    name: name
+	//    type: string
+	//    required: true
+	//    description: the name or ID of the volume
+	//  - in: query
+	//    name: name
+	//    type: string
+	//    description: name of the snapshot, generated from the current time if not set
+	//  - in: query
+	//    name: pause
+	//    type: boolean
+	//    default: false
+	//    description: pause the running containers using the volume while creating the snapshot
+	// produces:
+	// - application/json
+	// responses:
+	//   201:
+	//     $ref: "#/responses/volumeSnapshotLibpod"
+	//   400:
+	//     $ref: "#/responses/badParamError"
+	//   404:
+	//     $ref: "#/responses/volumeNotFound"
+	//   409:
+	//     $ref: "#/responses/conflictError"
+	//   500:
+	//     $ref: "#/responses/internalError"
+	r.Handle(VersionedPath("/libpod/volumes/{name}/snapshots"), s.APIHandler(libpod.CreateVolumeSnapshot)).Methods(http.MethodPost)
+	// swagger:operation GET /libpod/volumes/{name}/snapshots libpod VolumeSnapshotListLibpod
+	// ---
+	// tags:
+	//  - volumes
+	// summary: List the snapshots of a volume
+	// parameters:
+	//  - in: path
+	//    name: name
+	//    type: string
+	//    required: true
+	//    description: the name or ID of the volume
+	// produces:
+	// - application/json
+	// responses:
+	//   200:
+	//     $ref: "#/responses/volumeSnapshotListLibpod"
+	//   404:
+	//     $ref: "#/responses/volumeNotFound"
+	//   500:
+	//     $ref: "#/responses/internalError"
+	r.Handle(VersionedPath("/libpod/volumes/{name}/snapshots"), s.APIHandler(libpod.ListVolumeSnapshots)).Methods(http.MethodGet)
+	// swagger:operation POST /libpod/volumes/{name}/snapshots/{snapshot}/restore libpod VolumeSnapshotRestoreLibpod
+	// ---
+	// tags:
+	//  - volumes
+	// summary: Restore a snapshot of a volume
+	// description: Replace the contents of a volume with the contents of a snapshot. The volume must not be used by running or paused containers.
+	// parameters:
+	//  - in: path
+	//    name: name
+	//    type: string
+	//    required: true
+	//    description: the name or ID of the volume
+	//  - in: path
+	//    name: snapshot
+	//    type: string
+	//    required: true
+	//    description: the name of the snapshot
+	// produces:
+	// - application/json
+	// responses:
+	//   204:
+	//     description: no error
+	//   400:
+	//     $ref: "#/responses/badParamError"
+	//   404:
+	//     $ref: "#/responses/volumeNotFound"
+	//   409:
+	//     description: Volume is being used by a running container
+	//   500:
+	//     $ref: "#/responses/internalError"
+	r.Handle(VersionedPath("/libpod/volumes/{name}/snapshots/{snapshot}/restore"), s.APIHandler(libpod.RestoreVolumeSnapshot)).Methods(http.MethodPost)
+	// swagger:operation DELETE /libpod/volumes/{name}/snapshots/{snapshot} libpod VolumeSnapshotDeleteLibpod
+	// ---
+	// tags:
+	//  - volumes
+	// summary: Remove a snapshot of a volume
+	// parameters:
+	//  - in: path
+	//    name: name
+	//    type: string
+	//    required: true
+	//    description: the name or ID of the volume
+	//  - in: path
+	//    name: snapshot
+	//    type: string
+	//    required: true
+	//    description: the name of the snapshot
+	// produces:
+	// - application/json
+	// responses:
+	//   204:
+	//     description: no error
+	//   404:
+	//     $ref: "#/responses/volumeNotFound"
+	//   500:
+	//     $ref: "#/responses/internalError"
+	r.Handle(VersionedPath("/libpod/volumes/{name}/snapshots/{snapshot}"), s.APIHandler(libpod.RemoveVolumeSnapshot)).Methods(http.MethodDelete)
 
 	/*
 	 * Docker compatibility endpoints
@@ -2,6 +2,7 @@ package server
 
 import (
 	"context"
+	"crypto/tls"
 	"fmt"
 	"log"
 	"net"
@@ -17,6 +18,7 @@ import (
 	"github.com/containers/podman/v4/libpod"
 	"github.com/containers/podman/v4/libpod/shutdown"
 	"github.com/containers/podman/v4/pkg/api/handlers"
+	"github.com/containers/podman/v4/pkg/api/metrics"
 	"github.com/containers/podman/v4/pkg/api/server/idle"
 	"github.com/containers/podman/v4/pkg/api/types"
 	"github.com/containers/podman/v4/pkg/domain/entities"
@@ -27,15 +29,16 @@ import (
 )
 
 type APIServer struct {
-	http.Server                      // The  HTTP work happens here
-	net.Listener                     // mux for routing HTTP API calls to libpod routines
-	*libpod.Runtime                  // Where the real work happens
-	*schema.Decoder                  // Decoder for Query parameters to structs
-	context.CancelFunc               // Stop APIServer
-	context.Context                  // Context to carry objects to handlers
-	CorsHeaders        string        // Inject Cross-Origin Resource Sharing (CORS) headers
-	PProfAddr          string        // Binding network address for pprof profiles
-	idleTracker        *idle.Tracker // Track connections to support idle shutdown
+	http.Server                                  // The  HTTP work happens here
+	net.Listener                                 // mux for routing HTTP API calls to libpod routines
+	*libpod.Runtime                              // Where the real work happens
+	*schema.Decoder                              // Decoder for Query parameters to structs
+	context.CancelFunc                           // Stop APIServer
+	context.Context                              // Context to carry objects to handlers
+	CorsHeaders        string                    // Inject Cross-Origin Resource Sharing (CORS) headers
+	PProfAddr          string                    // Binding network address for pprof profiles
+	idleTracker        *idle.Tracker             // Track connections to support idle shutdown
+	requestMetrics     *metrics.RequestCollector // Duration of requests, nil if metrics are disabled
 }
 
 // Number of seconds to wait for next request, if exceeded shutdown server
@@ -63,6 +66,18 @@ func NewServerWithSettings(runtime *libpod.Runtime, listener net.Listener, opts
 
 func newServer(runtime *libpod.Runtime, listener net.Listener, opts entities.ServiceOptions) (*APIServer, error) {
 	logrus.Infof("API service listening on %q. URI: %q", listener.Addr(), runtime.RemoteURI())
+	if opts.TLSCertFile != "" || opts.TLSKeyFile != "" {
+		tlsConfig, err := NewTLSConfig(opts.TLSCertFile, opts.TLSKeyFile, opts.TLSClientCAFile)
+		if err != nil {
+			return nil, err
+		}
+		listener = tls.NewListener(listener, tlsConfig)
+		if tlsConfig.ClientCAs != nil {
+			logrus.Info("API service requires TLS client certificates")
+		} else {
+			logrus.Info("API service using TLS")
+		}
+	}
 	if opts.CorsHeaders == "" {
 		logrus.Debug("CORS Headers were not set")
 	} else {
@@ -87,18 +102,28 @@ func newServer(runtime *libpod.Runtime, listener net.Listener, opts entities.Ser
 		PProfAddr:   opts.PProfAddr,
 		idleTracker: tracker,
 	}
+	if opts.Metrics {
+		logrus.Info("API service exposing metrics on /metrics")
+		server.requestMetrics = metrics.NewRequestCollector()
+	}
 
 	server.BaseContext = func(l net.Listener) context.Context {
 		ctx := context.WithValue(context.Background(), types.DecoderKey, handlers.NewAPIDecoder())
 		ctx = context.WithValue(ctx, types.CompatDecoderKey, handlers.NewCompatAPIDecoder())
 		ctx = context.WithValue(ctx, types.RuntimeKey, runtime)
 		ctx = context.WithValue(ctx, types.IdleTrackerKey, tracker)
+		if server.requestMetrics != nil {
+			ctx = context.WithValue(ctx, types.MetricsKey, server.requestMetrics)
+		}
 		return ctx
 	}
 
 	// Capture panics and print stack traces for diagnostics,
 	// additionally process X-Reference-Id Header to support event correlation
 	router.Use(panicHandler(), referenceIDHandler())
+	if server.requestMetrics != nil {
+		router.Use(metricsHandler(server.requestMetrics))
+	}
 	router.NotFoundHandler = http.HandlerFunc(
 		func(w http.ResponseWriter, r *http.Request) {
 			// We can track user errors...
@@ -127,6 +152,7 @@ func newServer(runtime *libpod.Runtime, listener net.Listener, opts entities.Ser
 		server.registerImagesHandlers,
 		server.registerInfoHandlers,
 		server.registerManifestHandlers,
+		server.registerMetricsHandlers,
 		server.registerMonitorHandlers,
 		server.registerNetworkHandlers,
 		server.registerPingHandlers,
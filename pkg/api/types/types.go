@@ -8,4 +8,5 @@ const (
 	IdleTrackerKey
 	ConnKey
 	CompatDecoderKey
+	MetricsKey
 )
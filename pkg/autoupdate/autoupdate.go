@@ -7,7 +7,9 @@ import (
 	"fmt"
 	"os"
 	"sort"
+	"time"
 
+	"github.com/blang/semver/v4"
 	"github.com/containers/common/libimage"
 	"github.com/containers/common/pkg/config"
 	"github.com/containers/image/v5/docker"
@@ -31,6 +33,9 @@ const (
 	PolicyRegistryImage = "registry"
 	// PolicyLocalImage is the policy to run auto-update based on a local image
 	PolicyLocalImage = "local"
+	// PolicySemverImage is the policy to update to the newest tag on the
+	// registry matching a semantic version constraint.
+	PolicySemverImage = "semver"
 )
 
 // Map for easy lookups of supported policies.
@@ -40,8 +45,13 @@ var supportedPolicies = map[string]Policy{
 	"image":                     PolicyRegistryImage, // Deprecated in favor of PolicyRegistryImage
 	string(PolicyRegistryImage): PolicyRegistryImage,
 	string(PolicyLocalImage):    PolicyLocalImage,
+	string(PolicySemverImage):   PolicySemverImage,
 }
 
+// healthCheckInterval is the interval in which the health of updated
+// containers is checked in the canary rollout mode.
+const healthCheckInterval = time.Second
+
 // updater includes shared state for auto-updating one or more containers.
 type updater struct {
 	conn             *dbus.Conn                  // DBUS connection
@@ -57,18 +67,23 @@ const (
 	statusNotUpdated = "false"       // No update was needed
 	statusPending    = "pending"     // The update is pending (see options.DryRun)
 	statusRolledBack = "rolled back" // Rollback after a failed update
+	statusSkipped    = "skipped"     // Outside of the maintenance window
+	statusHalted     = "halted"      // The rollout was halted before the update
 )
 
 // task includes data and state for updating a container
 type task struct {
-	authfile     string            // Container-specific authfile
-	auto         *updater          // Reverse pointer to the updater
-	container    *libpod.Container // Container to update
-	policy       Policy            // Update policy
-	image        *libimage.Image   // Original image before the update
-	rawImageName string            // The container's raw image name
-	status       string            // Auto-update status
-	unit         string            // Name of the systemd unit
+	authfile        string              // Container-specific authfile
+	auto            *updater            // Reverse pointer to the updater
+	container       *libpod.Container   // Container to update
+	policy          Policy              // Update policy
+	image           *libimage.Image     // Original image before the update
+	rawImageName    string              // The container's raw image name
+	semverRange     semver.Range        // Constraint of the semver policy
+	semverImageName string              // Image of the newest tag matching semverRange
+	windows         []maintenanceWindow // Maintenance windows of the container
+	status          string              // Auto-update status
+	unit            string              // Name of the systemd unit
 }
 
 // LookupPolicy looks up the corresponding Policy for the specified
@@ -106,6 +121,16 @@ func LookupPolicy(s string) (Policy, error) {
 // of a running container is different than the local one. If the image digests
 // differ, it restarts the systemd unit with the new image.
 //
+// If the policy is set to PolicySemverImage, it looks up the newest tag on the
+// remote registry matching the semver constraint of the container.  If its
+// digest differs from the local image, it pulls the image, moves the floating
+// tag of the container's image name to it and restarts the systemd unit
+// running the container.
+//
+// Units with containers outside of their maintenance windows are skipped.  In
+// the canary rollout mode, the units are updated in stages and the update is
+// rolled back if the containers do not become healthy (see rolloutCanary).
+//
 // It returns a slice of successfully restarted systemd units and a slice of
 // errors encountered during auto update.
 func AutoUpdate(ctx context.Context, runtime *libpod.Runtime, options entities.AutoUpdateOptions) ([]*entities.AutoUpdateReport, []error) {
@@ -113,6 +138,13 @@ func AutoUpdate(ctx context.Context, runtime *libpod.Runtime, options entities.A
 	// misconfigured container does not prevent others from being updated
 	// (which could be a security threat).
 
+	switch options.Rollout {
+	case "", entities.AutoUpdateRolloutAll, entities.AutoUpdateRolloutCanary:
+	default:
+		return nil, []error{fmt.Errorf("invalid rollout mode %q: valid modes are %+q", options.Rollout,
+			[]string{entities.AutoUpdateRolloutAll, entities.AutoUpdateRolloutCanary})}
+	}
+
 	auto := updater{
 		options:          &options,
 		runtime:          runtime,
@@ -140,10 +172,17 @@ func AutoUpdate(ctx context.Context, runtime *libpod.Runtime, options entities.A
 	runtime.NewSystemEvent(events.AutoUpdate)
 
 	// Update all images/container according to their auto-update policy.
+	if options.Rollout == entities.AutoUpdateRolloutCanary {
+		allErrors = append(allErrors, auto.rolloutCanary(ctx)...)
+	} else {
+		for unit, tasks := range auto.unitToTasks {
+			unitErrors := auto.updateUnit(ctx, unit, tasks)
+			allErrors = append(allErrors, unitErrors...)
+		}
+	}
+
 	var allReports []*entities.AutoUpdateReport
-	for unit, tasks := range auto.unitToTasks {
-		unitErrors := auto.updateUnit(ctx, unit, tasks)
-		allErrors = append(allErrors, unitErrors...)
+	for _, tasks := range auto.unitToTasks {
 		for _, task := range tasks {
 			allReports = append(allReports, task.report())
 		}
@@ -154,9 +193,49 @@ func AutoUpdate(ctx context.Context, runtime *libpod.Runtime, options entities.A
 
 // updateUnit auto updates the tasks in the specified systemd unit.
 func (u *updater) updateUnit(ctx context.Context, unit string, tasks []*task) []error {
+	tasksUpdated, errors := u.prepareUnit(ctx, unit, tasks)
+
+	// If no task has been updated, we can jump directly to the next unit.
+	if !tasksUpdated {
+		return errors
+	}
+
+	updateError := u.restartSystemdUnit(ctx, unit)
+	for _, task := range tasks {
+		if updateError == nil {
+			task.status = statusUpdated
+		} else {
+			task.status = statusFailed
+		}
+	}
+
+	// Jump to the next unit on successful update or if rollbacks are disabled.
+	if updateError == nil || !u.options.Rollback {
+		if updateError != nil {
+			errors = append(errors, fmt.Errorf("restarting unit %s during update: %w", unit, updateError))
+		}
+		return errors
+	}
+
+	// The update has failed and rollbacks are enabled.
+	return append(errors, u.rollbackUnit(ctx, unit, tasks)...)
+}
+
+// prepareUnit checks the tasks in the specified systemd unit for updates and
+// pulls down new images.  It returns whether any task has been updated and
+// the unit must be restarted.
+func (u *updater) prepareUnit(ctx context.Context, unit string, tasks []*task) (bool, []error) {
 	var errors []error
 	tasksUpdated := false
 
+	if !unitInMaintenanceWindow(tasks, time.Now()) {
+		logrus.Infof("Skipping systemd unit %q outside of its maintenance window", unit)
+		for _, task := range tasks {
+			task.status = statusSkipped
+		}
+		return false, nil
+	}
+
 	for _, task := range tasks {
 		err := func() error { // Use an anonymous function to avoid spaghetti continue's
 			updateAvailable, err := task.updateAvailable(ctx)
@@ -189,52 +268,234 @@ func (u *updater) updateUnit(ctx context.Context, unit string, tasks []*task) []
 		}
 	}
 
-	// If no task has been updated, we can jump directly to the next unit.
-	if !tasksUpdated {
-		return errors
-	}
+	return tasksUpdated, errors
+}
 
-	updateError := u.restartSystemdUnit(ctx, unit)
+// rollbackUnit rolls back the images of the tasks in the specified systemd
+// unit and restarts it.
+func (u *updater) rollbackUnit(ctx context.Context, unit string, tasks []*task) []error {
+	var errors []error
 	for _, task := range tasks {
-		if updateError == nil {
-			task.status = statusUpdated
-		} else {
-			task.status = statusFailed
+		if err := task.rollbackImage(); err != nil {
+			err = fmt.Errorf("rolling back image for container %s in unit %s: %w", task.container.ID(), unit, err)
+			errors = append(errors, err)
 		}
 	}
 
-	// Jump to the next unit on successful update or if rollbacks are disabled.
-	if updateError == nil || !u.options.Rollback {
-		if updateError != nil {
-			errors = append(errors, fmt.Errorf("restarting unit %s during update: %w", unit, updateError))
+	if err := u.restartSystemdUnit(ctx, unit); err != nil {
+		for _, task := range tasks {
+			task.status = statusFailed
 		}
+		err = fmt.Errorf("restarting unit %s during rollback: %w", unit, err)
+		errors = append(errors, err)
 		return errors
 	}
 
-	// The update has failed and rollbacks are enabled.
 	for _, task := range tasks {
-		if err := task.rollbackImage(); err != nil {
-			err = fmt.Errorf("rolling back image for container %s in unit %s: %w", task.container.ID(), unit, err)
+		task.status = statusRolledBack
+	}
+
+	return errors
+}
+
+// rolloutCanary updates the systemd units in stages.  The first unit with an
+// update is the canary: it is restarted first and its containers must become
+// healthy within the soak time.  Only then are the remaining units updated at
+// once and their containers must become healthy within the soak time as
+// well.  If a unit fails to restart or to become healthy, the rollout is
+// halted and all updated units are rolled back unless rollbacks are disabled.
+func (u *updater) rolloutCanary(ctx context.Context) []error {
+	var errors []error
+
+	units := make([]string, 0, len(u.unitToTasks))
+	for unit := range u.unitToTasks {
+		units = append(units, unit)
+	}
+	sort.Strings(units)
+
+	// Find and update the canary.
+	canary := ""
+	i := 0
+	for ; i < len(units) && canary == ""; i++ {
+		tasksUpdated, unitErrors := u.prepareUnit(ctx, units[i], u.unitToTasks[units[i]])
+		errors = append(errors, unitErrors...)
+		if tasksUpdated {
+			canary = units[i]
+		}
+	}
+	if canary == "" {
+		return errors
+	}
+	if err := u.deployUnit(ctx, canary, time.Now().Add(u.options.SoakTime)); err != nil {
+		errors = append(errors, err)
+		for _, unit := range units[i:] {
+			for _, task := range u.unitToTasks[unit] {
+				task.status = statusHalted
+			}
+		}
+		return append(errors, u.rollbackUnits(ctx, []string{canary})...)
+	}
+
+	// The canary is healthy, so update the remaining units.
+	updated := []string{canary}
+	restartFailed := false
+	for _, unit := range units[i:] {
+		tasks := u.unitToTasks[unit]
+		tasksUpdated, unitErrors := u.prepareUnit(ctx, unit, tasks)
+		errors = append(errors, unitErrors...)
+		if !tasksUpdated {
+			continue
+		}
+		updated = append(updated, unit)
+		if err := u.restartSystemdUnit(ctx, unit); err != nil {
+			errors = append(errors, fmt.Errorf("restarting unit %s during update: %w", unit, err))
+			restartFailed = true
+		}
+	}
+	if restartFailed {
+		return append(errors, u.rollbackUnits(ctx, updated)...)
+	}
+
+	deadline := time.Now().Add(u.options.SoakTime)
+	for _, unit := range updated[1:] {
+		for _, task := range u.unitToTasks[unit] {
+			task.status = statusUpdated
+		}
+		if err := u.waitHealthy(ctx, unit, deadline); err != nil {
 			errors = append(errors, err)
+			return append(errors, u.rollbackUnits(ctx, updated)...)
 		}
 	}
 
+	return errors
+}
+
+// deployUnit restarts the specified systemd unit and waits until its
+// containers are healthy or the deadline has passed.
+func (u *updater) deployUnit(ctx context.Context, unit string, deadline time.Time) error {
+	tasks := u.unitToTasks[unit]
 	if err := u.restartSystemdUnit(ctx, unit); err != nil {
 		for _, task := range tasks {
 			task.status = statusFailed
 		}
-		err = fmt.Errorf("restarting unit %s during rollback: %w", unit, err)
-		errors = append(errors, err)
-		return errors
+		return fmt.Errorf("restarting unit %s during update: %w", unit, err)
 	}
-
 	for _, task := range tasks {
-		task.status = statusRolledBack
+		task.status = statusUpdated
 	}
+	return u.waitHealthy(ctx, unit, deadline)
+}
 
+// rollbackUnits rolls back the specified systemd units if rollbacks are
+// enabled.  Otherwise, the tasks of the units are marked as failed.
+func (u *updater) rollbackUnits(ctx context.Context, units []string) []error {
+	var errors []error
+	for _, unit := range units {
+		tasks := u.unitToTasks[unit]
+		if !u.options.Rollback {
+			for _, task := range tasks {
+				task.status = statusFailed
+			}
+			continue
+		}
+		errors = append(errors, u.rollbackUnit(ctx, unit, tasks)...)
+	}
 	return errors
 }
 
+// waitHealthy waits until the containers of the specified systemd unit are
+// running and healthy.  Containers without a healthcheck must keep running
+// until the deadline.  An error is returned if a container stops, becomes
+// unhealthy or is not healthy at the deadline.
+func (u *updater) waitHealthy(ctx context.Context, unit string, deadline time.Time) error {
+	for {
+		healthy, allHealthChecks := true, true
+		for _, task := range u.unitToTasks[unit] {
+			ok, hasHealthCheck, err := u.containerHealth(task)
+			if err != nil {
+				return fmt.Errorf("unit %s: %w", unit, err)
+			}
+			healthy = healthy && ok
+			allHealthChecks = allHealthChecks && hasHealthCheck
+		}
+
+		now := time.Now()
+		if healthy && (allHealthChecks || !now.Before(deadline)) {
+			logrus.Infof("Containers of systemd unit %q are healthy", unit)
+			return nil
+		}
+		if !now.Before(deadline) {
+			return fmt.Errorf("containers of unit %s did not become healthy within %s", unit, u.options.SoakTime)
+		}
+
+		wait := healthCheckInterval
+		if remaining := deadline.Sub(now); remaining < wait {
+			wait = remaining
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(wait):
+		}
+	}
+}
+
+// containerHealth returns whether the container of the task is running and
+// healthy, and whether it has a healthcheck.  An error is returned if the
+// container has stopped or is unhealthy.
+func (u *updater) containerHealth(t *task) (bool, bool, error) {
+	// Restarting the unit may have replaced the container, so look it up
+	// by name.
+	name := t.container.Name()
+	ctr, err := u.runtime.LookupContainer(name)
+	if err != nil {
+		logrus.Debugf("Looking up container %s after update: %v", name, err)
+		return false, false, nil
+	}
+	state, err := ctr.State()
+	if err != nil {
+		logrus.Debugf("Getting state of container %s after update: %v", name, err)
+		return false, false, nil
+	}
+	switch state {
+	case define.ContainerStateRunning:
+	case define.ContainerStateStopped, define.ContainerStateExited:
+		return false, false, fmt.Errorf("container %s has stopped after the update", name)
+	default:
+		return false, false, nil
+	}
+
+	if !ctr.HasHealthCheck() {
+		return true, false, nil
+	}
+	// The healthcheck timer of the container runs the checks, running them
+	// here as well would turn the container unhealthy prematurely.
+	status, err := ctr.HealthCheckStatus()
+	if err != nil {
+		logrus.Debugf("Getting health status of container %s after update: %v", name, err)
+		return false, true, nil
+	}
+	switch status {
+	case define.HealthCheckHealthy:
+		return true, true, nil
+	case define.HealthCheckUnhealthy:
+		return false, true, fmt.Errorf("container %s is unhealthy after the update", name)
+	default:
+		return false, true, nil
+	}
+}
+
+// unitInMaintenanceWindow returns whether all tasks of a unit are in their
+// maintenance windows at the specified time.
+func unitInMaintenanceWindow(tasks []*task, now time.Time) bool {
+	for _, task := range tasks {
+		if !inMaintenanceWindow(task.windows, now) {
+			return false
+		}
+	}
+	return true
+}
+
 // report creates an auto-update report for the task.
 func (t *task) report() *entities.AutoUpdateReport {
 	return &entities.AutoUpdateReport{
@@ -254,6 +515,8 @@ func (t *task) updateAvailable(ctx context.Context) (bool, error) {
 		return t.registryUpdateAvailable(ctx)
 	case PolicyLocalImage:
 		return t.localUpdateAvailable()
+	case PolicySemverImage:
+		return t.semverUpdateAvailable(ctx)
 	default:
 		return false, fmt.Errorf("unexpected auto-update policy %s for container %s", t.policy, t.container.ID())
 	}
@@ -267,6 +530,8 @@ func (t *task) update(ctx context.Context) error {
 	case PolicyLocalImage:
 		// Nothing to do as the image is already available in the local storage.
 		return nil
+	case PolicySemverImage:
+		return t.semverUpdate(ctx)
 	default:
 		return fmt.Errorf("unexpected auto-update policy %s for container %s", t.policy, t.container.ID())
 	}
@@ -428,6 +693,33 @@ func (u *updater) assembleTasks(ctx context.Context) []error {
 		if fromContainer, ok := labels[define.AutoUpdateAuthfileLabel]; ok {
 			authfile = fromContainer
 		}
+		var semverRange semver.Range
+		if policy == PolicySemverImage {
+			constraint, exists := labels[define.AutoUpdateSemverLabel]
+			if !exists {
+				errors = append(errors, fmt.Errorf("auto-updating container %q: no %s label found", ctr.ID(), define.AutoUpdateSemverLabel))
+				continue
+			}
+			semverRange, err = semver.ParseRange(constraint)
+			if err != nil {
+				errors = append(errors, fmt.Errorf("auto-updating container %q: invalid semver constraint %q: %w", ctr.ID(), constraint, err))
+				continue
+			}
+			if isVersionTag(rawImageName) {
+				errors = append(errors, fmt.Errorf("auto-updating container %q: image %s has a version tag, the semver policy requires a floating tag such as \"stable\"", ctr.ID(), rawImageName))
+				continue
+			}
+		}
+
+		var windows []maintenanceWindow
+		if value, exists := labels[define.AutoUpdateWindowLabel]; exists {
+			windows, err = parseMaintenanceWindows(value)
+			if err != nil {
+				errors = append(errors, fmt.Errorf("auto-updating container %q: %w", ctr.ID(), err))
+				continue
+			}
+		}
+
 		t := task{
 			authfile:     authfile,
 			auto:         u,
@@ -436,6 +728,8 @@ func (u *updater) assembleTasks(ctx context.Context) []error {
 			image:        image,
 			unit:         unit,
 			rawImageName: rawImageName,
+			semverRange:  semverRange,
+			windows:      windows,
 			status:       statusFailed, // must be updated later on
 		}
 
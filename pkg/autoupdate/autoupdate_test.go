@@ -0,0 +1,71 @@
+//go:build !remote
+
+package autoupdate
+
+import (
+	"testing"
+	"time"
+
+	"github.com/blang/semver/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewestMatchingTag(t *testing.T) {
+	tags := []string{"latest", "1.1.0", "v1.2", "1.2.5", "1.3.0-rc1", "1.9.1", "2.0.0", "1.9.1-alpine"}
+
+	for _, tc := range []struct {
+		constraint string
+		expected   string
+	}{
+		{">=1.0.0 <2.0.0", "1.9.1"},
+		{"<1.3.0", "1.2.5"},
+		{"1.2.x", "1.2.5"},
+		{"1.2.0", "v1.2"},
+		{">=1.0.0", "2.0.0"},
+	} {
+		r, err := semver.ParseRange(tc.constraint)
+		require.NoError(t, err)
+		tag, err := newestMatchingTag(tags, r)
+		require.NoError(t, err, tc.constraint)
+		assert.Equal(t, tc.expected, tag, tc.constraint)
+	}
+
+	_, err := newestMatchingTag(tags, semver.MustParseRange(">=3.0.0"))
+	assert.Error(t, err)
+}
+
+func TestIsVersionTag(t *testing.T) {
+	assert.True(t, isVersionTag("quay.io/foo/bar:1.2.0"))
+	assert.True(t, isVersionTag("quay.io/foo/bar:v1.2.0"))
+	assert.False(t, isVersionTag("quay.io/foo/bar:1.2"))
+	assert.False(t, isVersionTag("quay.io/foo/bar:1"))
+	assert.False(t, isVersionTag("quay.io/foo/bar:stable"))
+	assert.False(t, isVersionTag("quay.io/foo/bar"))
+}
+
+func TestMaintenanceWindows(t *testing.T) {
+	windows, err := parseMaintenanceWindows("02:00-04:30, 22:00-01:00")
+	require.NoError(t, err)
+	assert.Equal(t, []maintenanceWindow{
+		{start: 2 * time.Hour, end: 4*time.Hour + 30*time.Minute},
+		{start: 22 * time.Hour, end: time.Hour},
+	}, windows)
+
+	at := func(hour, minute int) time.Time {
+		return time.Date(2023, 6, 1, hour, minute, 0, 0, time.Local)
+	}
+	assert.True(t, inMaintenanceWindow(windows, at(2, 0)))
+	assert.True(t, inMaintenanceWindow(windows, at(4, 29)))
+	assert.False(t, inMaintenanceWindow(windows, at(4, 30)))
+	assert.False(t, inMaintenanceWindow(windows, at(12, 0)))
+	assert.True(t, inMaintenanceWindow(windows, at(23, 0)))
+	assert.True(t, inMaintenanceWindow(windows, at(0, 30)))
+	assert.False(t, inMaintenanceWindow(windows, at(1, 0)))
+	assert.True(t, inMaintenanceWindow(nil, at(12, 0)))
+
+	for _, invalid := range []string{"", "02:00", "02:00-25:00", "2am-4am", "03:00-03:00"} {
+		_, err := parseMaintenanceWindows(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
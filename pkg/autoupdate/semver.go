@@ -0,0 +1,128 @@
+//go:build !remote
+
+package autoupdate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/blang/semver/v4"
+	"github.com/containers/common/libimage"
+	"github.com/containers/common/pkg/config"
+	"github.com/containers/image/v5/docker"
+	"github.com/containers/image/v5/docker/reference"
+	"github.com/containers/image/v5/types"
+)
+
+// semverUpdateAvailable returns whether the newest tag on the registry
+// matching the semver constraint of the task refers to a different image.
+func (t *task) semverUpdateAvailable(ctx context.Context) (bool, error) {
+	// The newer image has already been pulled for another task, so we know
+	// there's a newer one available.
+	if _, exists := t.auto.updatedRawImages[t.rawImageName]; exists {
+		return true, nil
+	}
+
+	named, err := reference.ParseNormalizedNamed(t.rawImageName)
+	if err != nil {
+		return false, err
+	}
+	repoRef, err := docker.ParseReference("//" + t.rawImageName)
+	if err != nil {
+		return false, err
+	}
+	sys := &types.SystemContext{
+		AuthFilePath:                t.authfile,
+		DockerInsecureSkipTLSVerify: t.auto.options.InsecureSkipTLSVerify,
+	}
+	tags, err := docker.GetRepositoryTags(ctx, sys, repoRef)
+	if err != nil {
+		return false, fmt.Errorf("listing tags of %s: %w", reference.TrimNamed(named).String(), err)
+	}
+	tag, err := newestMatchingTag(tags, t.semverRange)
+	if err != nil {
+		return false, fmt.Errorf("%s: %w", reference.TrimNamed(named).String(), err)
+	}
+	t.semverImageName = reference.TrimNamed(named).String() + ":" + tag
+
+	remoteRef, err := docker.ParseReference("//" + t.semverImageName)
+	if err != nil {
+		return false, err
+	}
+	options := &libimage.HasDifferentDigestOptions{
+		AuthFilePath:          t.authfile,
+		InsecureSkipTLSVerify: t.auto.options.InsecureSkipTLSVerify,
+	}
+	return t.image.HasDifferentDigest(ctx, remoteRef, options)
+}
+
+// semverUpdate pulls down the image of the newest matching tag from the
+// registry, which keeps its version tag, and moves the floating tag of the
+// container's raw image name to it, such that the restarted unit picks it up.
+func (t *task) semverUpdate(ctx context.Context) error {
+	// The newer image has already been pulled for another task.
+	if _, exists := t.auto.updatedRawImages[t.rawImageName]; exists {
+		return nil
+	}
+
+	pullOptions := &libimage.PullOptions{}
+	pullOptions.AuthFilePath = t.authfile
+	pullOptions.Writer = os.Stderr
+	pullOptions.InsecureSkipTLSVerify = t.auto.options.InsecureSkipTLSVerify
+	pulledImages, err := t.auto.runtime.LibimageRuntime().Pull(ctx, t.semverImageName, config.PullPolicyAlways, pullOptions)
+	if err != nil {
+		return err
+	}
+	if err := pulledImages[0].Tag(t.rawImageName); err != nil {
+		return err
+	}
+
+	t.auto.updatedRawImages[t.rawImageName] = true
+	return nil
+}
+
+// newestMatchingTag returns the tag with the highest semantic version
+// satisfying the constraint.  Tags which are no semantic versions and
+// pre-releases are ignored.
+func newestMatchingTag(tags []string, constraint semver.Range) (string, error) {
+	var (
+		newestTag     string
+		newestVersion semver.Version
+	)
+	for _, tag := range tags {
+		version, err := semver.ParseTolerant(tag)
+		if err != nil || len(version.Pre) > 0 {
+			continue
+		}
+		if !constraint(version) {
+			continue
+		}
+		if newestTag == "" || version.GT(newestVersion) {
+			newestTag = tag
+			newestVersion = version
+		}
+	}
+	if newestTag == "" {
+		return "", errors.New("no tag matches the semver constraint")
+	}
+	return newestTag, nil
+}
+
+// isVersionTag returns whether the tag of the image name is a complete
+// semantic version such as 1.2.0 or v1.2.0.  Such a tag must never be moved to
+// another image, unlike floating tags such as 1.2 or stable.
+func isVersionTag(imageName string) bool {
+	named, err := reference.ParseNormalizedNamed(imageName)
+	if err != nil {
+		return false
+	}
+	tagged, ok := named.(reference.NamedTagged)
+	if !ok {
+		return false
+	}
+	_, err = semver.Parse(strings.TrimPrefix(tagged.Tag(), "v"))
+	return err == nil
+}
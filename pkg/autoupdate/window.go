@@ -0,0 +1,75 @@
+//go:build !remote
+
+package autoupdate
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// maintenanceWindow is a daily time range in which a container may be
+// updated.  The start and end are durations since midnight in local time.
+// A window whose end is before its start spans midnight.
+type maintenanceWindow struct {
+	start time.Duration
+	end   time.Duration
+}
+
+// parseMaintenanceWindows parses a comma-separated list of maintenance
+// windows in the format "HH:MM-HH:MM".
+func parseMaintenanceWindows(s string) ([]maintenanceWindow, error) {
+	var windows []maintenanceWindow
+	for _, w := range strings.Split(s, ",") {
+		w = strings.TrimSpace(w)
+		startStr, endStr, ok := strings.Cut(w, "-")
+		if !ok {
+			return nil, fmt.Errorf("invalid maintenance window %q: expected format HH:MM-HH:MM", w)
+		}
+		start, err := parseTimeOfDay(startStr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid maintenance window %q: %w", w, err)
+		}
+		end, err := parseTimeOfDay(endStr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid maintenance window %q: %w", w, err)
+		}
+		if start == end {
+			return nil, fmt.Errorf("invalid maintenance window %q: start and end are equal", w)
+		}
+		windows = append(windows, maintenanceWindow{start: start, end: end})
+	}
+	return windows, nil
+}
+
+// parseTimeOfDay parses a time of day in the format "HH:MM" and returns it
+// as duration since midnight.
+func parseTimeOfDay(s string) (time.Duration, error) {
+	t, err := time.Parse("15:04", strings.TrimSpace(s))
+	if err != nil {
+		return 0, fmt.Errorf("invalid time of day %q", s)
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+// inMaintenanceWindow returns whether the specified time is in one of the
+// maintenance windows.  If no window is specified, it returns true.
+func inMaintenanceWindow(windows []maintenanceWindow, now time.Time) bool {
+	if len(windows) == 0 {
+		return true
+	}
+	sinceMidnight := time.Duration(now.Hour())*time.Hour + time.Duration(now.Minute())*time.Minute + time.Duration(now.Second())*time.Second
+	for _, w := range windows {
+		if w.start < w.end {
+			if sinceMidnight >= w.start && sinceMidnight < w.end {
+				return true
+			}
+			continue
+		}
+		// The window spans midnight.
+		if sinceMidnight >= w.start || sinceMidnight < w.end {
+			return true
+		}
+	}
+	return false
+}
@@ -2,6 +2,8 @@ package bindings
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"errors"
 	"fmt"
 	"io"
@@ -87,6 +89,7 @@ func NewConnection(ctx context.Context, uri string) (context.Context, error) {
 // For example tcp://localhost:<port>
 // or unix:///run/podman/podman.sock
 // or ssh://<user>@<host>[:port]/run/podman/podman.sock?secure=True
+// or tcp+tls://localhost:<port>?tls-ca=<ca.pem>&tls-cert=<cert.pem>&tls-key=<key.pem>
 func NewConnectionWithIdentity(ctx context.Context, uri string, identity string, machine bool) (context.Context, error) {
 	var (
 		err error
@@ -148,6 +151,15 @@ func NewConnectionWithIdentity(ctx context.Context, uri string, identity string,
 			return nil, newConnectError(err)
 		}
 		connection = conn
+	case "tcp+tls":
+		if !strings.HasPrefix(uri, "tcp+tls://") {
+			return nil, errors.New("tcp+tls URIs should begin with tcp+tls://")
+		}
+		conn, err := tlsClient(_url)
+		if err != nil {
+			return nil, newConnectError(err)
+		}
+		connection = conn
 	default:
 		return nil, fmt.Errorf("unable to create connection. %q is not a supported schema", _url.Scheme)
 	}
@@ -202,6 +214,71 @@ func tcpClient(_url *url.URL) (Connection, error) {
 	return connection, nil
 }
 
+// tlsClient returns a tcp connection which performs a TLS handshake on every
+// dialed connection. The CA, client certificate and key are taken from the
+// tls-ca, tls-cert and tls-key query parameters of the URI.
+//
+// The handshake is done by the dialer rather than the http.Transport so
+// hijacked connections (attach, exec) are encrypted as well.
+func tlsClient(_url *url.URL) (Connection, error) {
+	tlsConfig, err := clientTLSConfig(_url)
+	if err != nil {
+		return Connection{URI: _url}, err
+	}
+	connection, err := tcpClient(_url)
+	if err != nil {
+		return connection, err
+	}
+	transport := connection.Client.Transport.(*http.Transport)
+	dialContext := transport.DialContext
+	transport.DialContext = func(ctx context.Context, network, address string) (net.Conn, error) {
+		conn, err := dialContext(ctx, network, address)
+		if err != nil {
+			return nil, err
+		}
+		tlsConn := tls.Client(conn, tlsConfig)
+		if err := tlsConn.HandshakeContext(ctx); err != nil {
+			conn.Close()
+			return nil, err
+		}
+		return tlsConn, nil
+	}
+	return connection, nil
+}
+
+func clientTLSConfig(_url *url.URL) (*tls.Config, error) {
+	query := _url.Query()
+	config := &tls.Config{
+		ServerName: _url.Hostname(),
+		MinVersion: tls.VersionTLS12,
+	}
+
+	if caFile := query.Get("tls-ca"); caFile != "" {
+		pem, err := os.ReadFile(caFile)
+		if err != nil {
+			return nil, fmt.Errorf("reading TLS CA: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no PEM encoded certificates found in %s", caFile)
+		}
+		config.RootCAs = pool
+	}
+
+	certFile, keyFile := query.Get("tls-cert"), query.Get("tls-key")
+	switch {
+	case certFile != "" && keyFile != "":
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, fmt.Errorf("loading TLS client key pair: %w", err)
+		}
+		config.Certificates = []tls.Certificate{cert}
+	case certFile != "" || keyFile != "":
+		return nil, errors.New("tls-cert and tls-key must be used together")
+	}
+	return config, nil
+}
+
 // pingNewConnection pings to make sure the RESTFUL service is up
 // and running. it should only be used when initializing a connection
 func pingNewConnection(ctx context.Context) (*semver.Version, error) {
@@ -14,7 +14,6 @@ func RunHealthCheck(ctx context.Context, nameOrID string, options *HealthCheckOp
 	if options == nil {
 		options = new(HealthCheckOptions)
 	}
-	_ = options
 	conn, err := bindings.GetClient(ctx)
 	if err != nil {
 		return nil, err
@@ -22,7 +21,11 @@ func RunHealthCheck(ctx context.Context, nameOrID string, options *HealthCheckOp
 	var (
 		status define.HealthCheckResults
 	)
-	response, err := conn.DoRequest(ctx, nil, http.MethodGet, "/containers/%s/healthcheck", nil, nil, nameOrID)
+	params, err := options.ToParams()
+	if err != nil {
+		return nil, err
+	}
+	response, err := conn.DoRequest(ctx, nil, http.MethodGet, "/containers/%s/healthcheck", params, nil, nameOrID)
 	if err != nil {
 		return nil, err
 	}
@@ -121,7 +121,9 @@ type ExecStartOptions struct {
 // the health of a container
 //
 //go:generate go run ../generator/generator.go HealthCheckOptions
-type HealthCheckOptions struct{}
+type HealthCheckOptions struct {
+	Readiness *bool
+}
 
 // MountOptions are optional options for mounting
 // containers
@@ -16,3 +16,18 @@ func (o *HealthCheckOptions) Changed(fieldName string) bool {
 func (o *HealthCheckOptions) ToParams() (url.Values, error) {
 	return util.ToParams(o)
 }
+
+// WithReadiness set field Readiness to given value
+func (o *HealthCheckOptions) WithReadiness(value bool) *HealthCheckOptions {
+	o.Readiness = &value
+	return o
+}
+
+// GetReadiness returns value of field Readiness
+func (o *HealthCheckOptions) GetReadiness() bool {
+	if o.Readiness == nil {
+		var z bool
+		return z
+	}
+	return *o.Readiness
+}
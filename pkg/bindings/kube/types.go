@@ -58,6 +58,9 @@ type PlayOptions struct {
 	// PublishAllPorts - whether to publish all ports defined in the K8S YAML file
 	// (containerPort, hostPort) otherwise only hostPort will be published
 	PublishAllPorts *bool
+	// ReadinessGate - withhold the ports of containers with a readinessProbe
+	// until they are ready and wait for them to be ready
+	ReadinessGate *bool
 	// Wait - indicates whether to return after having created the pods
 	Wait             *bool
 	ServiceContainer *bool
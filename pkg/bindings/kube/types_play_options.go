@@ -363,6 +363,21 @@ func (o *PlayOptions) GetPublishAllPorts() bool {
 	return *o.PublishAllPorts
 }
 
+// WithReadinessGate set field ReadinessGate to given value
+func (o *PlayOptions) WithReadinessGate(value bool) *PlayOptions {
+	o.ReadinessGate = &value
+	return o
+}
+
+// GetReadinessGate returns value of field ReadinessGate
+func (o *PlayOptions) GetReadinessGate() bool {
+	if o.ReadinessGate == nil {
+		var z bool
+		return z
+	}
+	return *o.ReadinessGate
+}
+
 // WithWait set field Wait to given value
 func (o *PlayOptions) WithWait(value bool) *PlayOptions {
 	o.Wait = &value
@@ -2,6 +2,8 @@ package network
 
 import (
 	"net"
+
+	"github.com/containers/common/libnetwork/types"
 )
 
 // CreateOptions are optional options for creating networks
@@ -62,8 +64,17 @@ type ListOptions struct {
 //
 //go:generate go run ../generator/generator.go UpdateOptions
 type UpdateOptions struct {
-	AddDNSServers    []string `json:"adddnsservers"`
-	RemoveDNSServers []string `json:"removednsservers"`
+	AddDNSServers    []string          `json:"adddnsservers"`
+	RemoveDNSServers []string          `json:"removednsservers"`
+	AddLabels        map[string]string `json:"addlabels,omitempty"`
+	RemoveLabels     []string          `json:"removelabels,omitempty"`
+	AddOptions       map[string]string `json:"addoptions,omitempty"`
+	RemoveOptions    []string          `json:"removeoptions,omitempty"`
+	AddRoutes        []types.Route     `json:"addroutes,omitempty"`
+	RemoveRoutes     []string          `json:"removeroutes,omitempty"`
+	AddSubnets       []types.Subnet    `json:"addsubnets,omitempty"`
+	Internal         *bool             `json:"internal,omitempty"`
+	DisableDNS       *bool             `json:"disabledns,omitempty"`
 }
 
 // DisconnectOptions are optional options for disconnecting
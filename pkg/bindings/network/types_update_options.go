@@ -4,6 +4,7 @@ package network
 import (
 	"net/url"
 
+	"github.com/containers/common/libnetwork/types"
 	"github.com/containers/podman/v4/pkg/bindings/internal/util"
 )
 
@@ -46,3 +47,138 @@ func (o *UpdateOptions) GetRemoveDNSServers() []string {
 	}
 	return o.RemoveDNSServers
 }
+
+// WithAddLabels set field AddLabels to given value
+func (o *UpdateOptions) WithAddLabels(value map[string]string) *UpdateOptions {
+	o.AddLabels = value
+	return o
+}
+
+// GetAddLabels returns value of field AddLabels
+func (o *UpdateOptions) GetAddLabels() map[string]string {
+	if o.AddLabels == nil {
+		var z map[string]string
+		return z
+	}
+	return o.AddLabels
+}
+
+// WithRemoveLabels set field RemoveLabels to given value
+func (o *UpdateOptions) WithRemoveLabels(value []string) *UpdateOptions {
+	o.RemoveLabels = value
+	return o
+}
+
+// GetRemoveLabels returns value of field RemoveLabels
+func (o *UpdateOptions) GetRemoveLabels() []string {
+	if o.RemoveLabels == nil {
+		var z []string
+		return z
+	}
+	return o.RemoveLabels
+}
+
+// WithAddOptions set field AddOptions to given value
+func (o *UpdateOptions) WithAddOptions(value map[string]string) *UpdateOptions {
+	o.AddOptions = value
+	return o
+}
+
+// GetAddOptions returns value of field AddOptions
+func (o *UpdateOptions) GetAddOptions() map[string]string {
+	if o.AddOptions == nil {
+		var z map[string]string
+		return z
+	}
+	return o.AddOptions
+}
+
+// WithRemoveOptions set field RemoveOptions to given value
+func (o *UpdateOptions) WithRemoveOptions(value []string) *UpdateOptions {
+	o.RemoveOptions = value
+	return o
+}
+
+// GetRemoveOptions returns value of field RemoveOptions
+func (o *UpdateOptions) GetRemoveOptions() []string {
+	if o.RemoveOptions == nil {
+		var z []string
+		return z
+	}
+	return o.RemoveOptions
+}
+
+// WithAddRoutes set field AddRoutes to given value
+func (o *UpdateOptions) WithAddRoutes(value []types.Route) *UpdateOptions {
+	o.AddRoutes = value
+	return o
+}
+
+// GetAddRoutes returns value of field AddRoutes
+func (o *UpdateOptions) GetAddRoutes() []types.Route {
+	if o.AddRoutes == nil {
+		var z []types.Route
+		return z
+	}
+	return o.AddRoutes
+}
+
+// WithRemoveRoutes set field RemoveRoutes to given value
+func (o *UpdateOptions) WithRemoveRoutes(value []string) *UpdateOptions {
+	o.RemoveRoutes = value
+	return o
+}
+
+// GetRemoveRoutes returns value of field RemoveRoutes
+func (o *UpdateOptions) GetRemoveRoutes() []string {
+	if o.RemoveRoutes == nil {
+		var z []string
+		return z
+	}
+	return o.RemoveRoutes
+}
+
+// WithAddSubnets set field AddSubnets to given value
+func (o *UpdateOptions) WithAddSubnets(value []types.Subnet) *UpdateOptions {
+	o.AddSubnets = value
+	return o
+}
+
+// GetAddSubnets returns value of field AddSubnets
+func (o *UpdateOptions) GetAddSubnets() []types.Subnet {
+	if o.AddSubnets == nil {
+		var z []types.Subnet
+		return z
+	}
+	return o.AddSubnets
+}
+
+// WithInternal set field Internal to given value
+func (o *UpdateOptions) WithInternal(value bool) *UpdateOptions {
+	o.Internal = &value
+	return o
+}
+
+// GetInternal returns value of field Internal
+func (o *UpdateOptions) GetInternal() bool {
+	if o.Internal == nil {
+		var z bool
+		return z
+	}
+	return *o.Internal
+}
+
+// WithDisableDNS set field DisableDNS to given value
+func (o *UpdateOptions) WithDisableDNS(value bool) *UpdateOptions {
+	o.DisableDNS = &value
+	return o
+}
+
+// GetDisableDNS returns value of field DisableDNS
+func (o *UpdateOptions) GetDisableDNS() bool {
+	if o.DisableDNS == nil {
+		var z bool
+		return z
+	}
+	return *o.DisableDNS
+}
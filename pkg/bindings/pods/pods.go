@@ -2,8 +2,10 @@ package pods
 
 import (
 	"context"
+	"io"
 	"net/http"
 	"net/url"
+	"os"
 	"strings"
 
 	"github.com/containers/podman/v4/pkg/api/handlers"
@@ -321,6 +323,38 @@ func Unpause(ctx context.Context, nameOrID string, options *UnpauseOptions) (*en
 	return &report, response.ProcessWithError(&report, &errorhandling.PodConflictErrorModel{})
 }
 
+// Update changes the resource limits, the restart policy and the exit policy
+// of a pod.  The resource limits are also applied to the containers of the pod.
+func Update(ctx context.Context, nameOrID string, options *UpdateOptions) (*entities.PodUpdateReport, error) {
+	var report entities.PodUpdateReport
+	if options == nil {
+		options = new(UpdateOptions)
+	}
+	conn, err := bindings.GetClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	params, err := options.ToParams()
+	if err != nil {
+		return nil, err
+	}
+	var body io.Reader
+	if options.Resources != nil {
+		resources, err := jsoniter.MarshalToString(options.Resources)
+		if err != nil {
+			return nil, err
+		}
+		body = strings.NewReader(resources)
+	}
+	response, err := conn.DoRequest(ctx, body, http.MethodPost, "/pods/%s/update", params, nil, nameOrID)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	return &report, response.ProcessWithError(&report, &errorhandling.PodConflictErrorModel{})
+}
+
 // Stats display resource-usage statistics of one or more pods.
 func Stats(ctx context.Context, namesOrIDs []string, options *StatsOptions) ([]*entities.PodStatsReport, error) {
 	if options == nil {
@@ -347,3 +381,88 @@ func Stats(ctx context.Context, namesOrIDs []string, options *StatsOptions) ([]*
 
 	return reports, response.Process(&reports)
 }
+
+// Checkpoint checkpoints all running containers of a pod.  If Export is set,
+// the checkpoint of the pod is written to the given archive.
+func Checkpoint(ctx context.Context, nameOrID string, options *CheckpointOptions) (*entities.PodCheckpointReport, error) {
+	var report entities.PodCheckpointReport
+	if options == nil {
+		options = new(CheckpointOptions)
+	}
+	conn, err := bindings.GetClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	params, err := options.ToParams()
+	if err != nil {
+		return nil, err
+	}
+
+	// "export" is a bool for the server so override it in the parameters
+	// if set.
+	export := false
+	if options.Export != nil && *options.Export != "" {
+		export = true
+		params.Set("export", "true")
+	}
+	response, err := conn.DoRequest(ctx, nil, http.MethodPost, "/pods/%s/checkpoint", params, nil, nameOrID)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK || !export {
+		return &report, response.Process(&report)
+	}
+
+	f, err := os.OpenFile(*options.Export, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0o600)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	if _, err := io.Copy(f, response.Body); err != nil {
+		return nil, err
+	}
+
+	return &entities.PodCheckpointReport{}, nil
+}
+
+// Restore restores the checkpointed containers of a pod, or re-creates a pod
+// from a checkpoint image or, if ImportArchive is set, from a checkpoint
+// archive.
+func Restore(ctx context.Context, nameOrID string, options *RestoreOptions) (*entities.PodRestoreReport, error) {
+	var report entities.PodRestoreReport
+	if options == nil {
+		options = new(RestoreOptions)
+	}
+	conn, err := bindings.GetClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	params, err := options.ToParams()
+	if err != nil {
+		return nil, err
+	}
+	params.Del("ImportArchive") // The import key is a reserved golang term
+
+	var r io.Reader
+	if i := options.GetImportArchive(); i != "" {
+		params.Set("import", "true")
+		f, err := os.Open(i)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		r = f
+		// Hard-code the name since it will be ignored in any case.
+		nameOrID = "import"
+	}
+
+	response, err := conn.DoRequest(ctx, r, http.MethodPost, "/pods/%s/restore", params, nil, nameOrID)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	return &report, response.Process(&report)
+}
@@ -1,5 +1,9 @@
 package pods
 
+import (
+	"github.com/opencontainers/runtime-spec/specs-go"
+)
+
 // CreateOptions are optional options for creating pods
 //
 //go:generate go run ../generator/generator.go CreateOptions
@@ -85,8 +89,54 @@ type RemoveOptions struct {
 	Timeout *uint
 }
 
+// UpdateOptions are optional options for updating pods
+//
+//go:generate go run ../generator/generator.go UpdateOptions
+type UpdateOptions struct {
+	// Resources are sent in the body of the request
+	Resources      *specs.LinuxResources `schema:"-"`
+	RestartPolicy  *string               `schema:"restartPolicy"`
+	RestartRetries *uint                 `schema:"restartRetries"`
+	ExitPolicy     *string               `schema:"exitPolicy"`
+}
+
 // ExistsOptions are optional options for checking if a pod exists
 //
 //go:generate go run ../generator/generator.go ExistsOptions
 type ExistsOptions struct {
 }
+
+// CheckpointOptions are optional options for checkpointing pods
+//
+//go:generate go run ../generator/generator.go CheckpointOptions
+type CheckpointOptions struct {
+	// Export is the path of the archive the checkpoint of the pod is
+	// written to.
+	Export         *string
+	CreateImage    *string
+	FileLocks      *bool
+	IgnoreRootfs   *bool
+	IgnoreVolumes  *bool
+	Keep           *bool
+	LeaveRunning   *bool
+	PrintStats     *bool
+	TCPEstablished *bool
+}
+
+// RestoreOptions are optional options for restoring pods
+//
+//go:generate go run ../generator/generator.go RestoreOptions
+type RestoreOptions struct {
+	// ImportArchive is the path to an archive which contains the
+	// checkpoint of the pod.
+	ImportArchive   *string
+	Name            *string
+	FileLocks       *bool
+	IgnoreRootfs    *bool
+	IgnoreStaticIP  *bool
+	IgnoreStaticMAC *bool
+	IgnoreVolumes   *bool
+	Keep            *bool
+	PrintStats      *bool
+	TCPEstablished  *bool
+}
@@ -95,6 +95,30 @@ func Create(ctx context.Context, reader io.Reader, options *CreateOptions) (*ent
 	return create, response.Process(&create)
 }
 
+// Update replaces the data of a secret while keeping its ID
+func Update(ctx context.Context, nameOrID string, reader io.Reader, options *UpdateOptions) (*entities.SecretUpdateReport, error) {
+	var (
+		update *entities.SecretUpdateReport
+	)
+	conn, err := bindings.GetClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	params, err := options.ToParams()
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := conn.DoRequest(ctx, reader, http.MethodPost, "/secrets/%s/update", params, nil, nameOrID)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	return update, response.Process(&update)
+}
+
 func Exists(ctx context.Context, nameOrID string) (bool, error) {
 	conn, err := bindings.GetClient(ctx)
 	if err != nil {
@@ -30,3 +30,10 @@ type CreateOptions struct {
 	Labels     map[string]string
 	Replace    *bool
 }
+
+// UpdateOptions are optional options for updating secrets
+//
+//go:generate go run ../generator/generator.go UpdateOptions
+type UpdateOptions struct {
+	Propagate *string
+}
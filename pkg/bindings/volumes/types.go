@@ -43,3 +43,36 @@ type RemoveOptions struct {
 //go:generate go run ../generator/generator.go ExistsOptions
 type ExistsOptions struct {
 }
+
+// SnapshotCreateOptions are optional options for creating snapshots of
+// volumes
+//
+//go:generate go run ../generator/generator.go SnapshotCreateOptions
+type SnapshotCreateOptions struct {
+	// Name of the snapshot, generated from the current time if not set
+	Name *string
+	// Pause the running containers using the volume while creating the
+	// snapshot
+	Pause *bool
+}
+
+// SnapshotListOptions are optional options for listing the snapshots of
+// a volume
+//
+//go:generate go run ../generator/generator.go SnapshotListOptions
+type SnapshotListOptions struct {
+}
+
+// SnapshotRestoreOptions are optional options for restoring a snapshot of
+// a volume
+//
+//go:generate go run ../generator/generator.go SnapshotRestoreOptions
+type SnapshotRestoreOptions struct {
+}
+
+// SnapshotRemoveOptions are optional options for removing a snapshot of
+// a volume
+//
+//go:generate go run ../generator/generator.go SnapshotRemoveOptions
+type SnapshotRemoveOptions struct {
+}
@@ -139,3 +139,86 @@ func Exists(ctx context.Context, nameOrID string, options *ExistsOptions) (bool,
 
 	return response.IsSuccess(), nil
 }
+
+// CreateSnapshot creates a snapshot of the contents of the given volume.
+func CreateSnapshot(ctx context.Context, nameOrID string, options *SnapshotCreateOptions) (*entities.VolumeSnapshotReport, error) {
+	var (
+		snapshot entities.VolumeSnapshotReport
+	)
+	conn, err := bindings.GetClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	params, err := options.ToParams()
+	if err != nil {
+		return nil, err
+	}
+	response, err := conn.DoRequest(ctx, nil, http.MethodPost, "/volumes/%s/snapshots", params, nil, nameOrID)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	return &snapshot, response.Process(&snapshot)
+}
+
+// ListSnapshots returns the snapshots of the given volume.
+func ListSnapshots(ctx context.Context, nameOrID string, options *SnapshotListOptions) ([]*entities.VolumeSnapshotReport, error) {
+	var (
+		snapshots []*entities.VolumeSnapshotReport
+	)
+	if options == nil {
+		options = new(SnapshotListOptions)
+	}
+	_ = options
+	conn, err := bindings.GetClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	response, err := conn.DoRequest(ctx, nil, http.MethodGet, "/volumes/%s/snapshots", nil, nil, nameOrID)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	return snapshots, response.Process(&snapshots)
+}
+
+// RestoreSnapshot replaces the contents of the given volume with the contents
+// of the snapshot.
+func RestoreSnapshot(ctx context.Context, nameOrID, snapshot string, options *SnapshotRestoreOptions) error {
+	if options == nil {
+		options = new(SnapshotRestoreOptions)
+	}
+	_ = options
+	conn, err := bindings.GetClient(ctx)
+	if err != nil {
+		return err
+	}
+	response, err := conn.DoRequest(ctx, nil, http.MethodPost, "/volumes/%s/snapshots/%s/restore", nil, nil, nameOrID, snapshot)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	return response.Process(nil)
+}
+
+// RemoveSnapshot removes the snapshot of the given volume.
+func RemoveSnapshot(ctx context.Context, nameOrID, snapshot string, options *SnapshotRemoveOptions) error {
+	if options == nil {
+		options = new(SnapshotRemoveOptions)
+	}
+	_ = options
+	conn, err := bindings.GetClient(ctx)
+	if err != nil {
+		return err
+	}
+	response, err := conn.DoRequest(ctx, nil, http.MethodDelete, "/volumes/%s/snapshots/%s", nil, nil, nameOrID, snapshot)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	return response.Process(nil)
+}
@@ -45,6 +45,13 @@ func CRImportCheckpointTar(ctx context.Context, runtime *libpod.Runtime, restore
 // CRImportCheckpoint it the function which imports the information
 // from checkpoint tarball and re-creates the container from that information
 func CRImportCheckpoint(ctx context.Context, runtime *libpod.Runtime, restoreOptions entities.RestoreOptions, dir string) ([]*libpod.Container, error) {
+	return crImportCheckpoint(ctx, runtime, restoreOptions, dir, true)
+}
+
+// crImportCheckpoint re-creates the container of the checkpoint in dir.  If
+// checkVolumes is false, the caller has made sure the volumes to restore do
+// not exist yet.
+func crImportCheckpoint(ctx context.Context, runtime *libpod.Runtime, restoreOptions entities.RestoreOptions, dir string, checkVolumes bool) ([]*libpod.Container, error) {
 	// Load spec.dump from temporary directory
 	dumpSpec := new(spec.Spec)
 	if _, err := metadata.ReadJSONFile(dumpSpec, dir, metadata.SpecDumpFile); err != nil {
@@ -71,7 +78,7 @@ func CRImportCheckpoint(ctx context.Context, runtime *libpod.Runtime, restoreOpt
 	}
 
 	// Volumes included in the checkpoint should not exist
-	if !restoreOptions.IgnoreVolumes {
+	if !restoreOptions.IgnoreVolumes && checkVolumes {
 		for _, vol := range ctrConfig.NamedVolumes {
 			exists, err := runtime.HasVolume(vol.Name)
 			if err != nil {
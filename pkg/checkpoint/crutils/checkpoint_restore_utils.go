@@ -254,3 +254,73 @@ func CRGetRuntimeFromArchive(input string) (*string, error) {
 
 	return &ctrConfig.OCIRuntime, nil
 }
+
+const (
+	// PodDumpFile is the file in a pod checkpoint which contains the
+	// configuration of the pod and of its infra container.
+	PodDumpFile = "pod.dump"
+	// PodContainersDirectory is the directory in a pod checkpoint which
+	// contains the checkpoint archives of the containers of the pod.
+	PodContainersDirectory = "containers"
+)
+
+// CRCreatePodCheckpointArchive writes the pod checkpoint in the directory
+// source to the archive target.
+func CRCreatePodCheckpointArchive(source, target string, compression archive.Compression) error {
+	input, err := archive.TarWithOptions(source, &archive.TarOptions{
+		Compression:      compression,
+		IncludeSourceDir: true,
+		IncludeFiles: []string{
+			PodDumpFile,
+			PodContainersDirectory,
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("reading pod checkpoint directory %q: %w", source, err)
+	}
+	defer input.Close()
+
+	outFile, err := os.OpenFile(target, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0o600)
+	if err != nil {
+		return fmt.Errorf("creating pod checkpoint export file %q: %w", target, err)
+	}
+	defer outFile.Close()
+	if _, err := io.Copy(outFile, input); err != nil {
+		return fmt.Errorf("writing pod checkpoint export file %q: %w", target, err)
+	}
+	return nil
+}
+
+// CRImportPodCheckpoint unpacks the pod checkpoint archive input into the
+// directory destination.
+func CRImportPodCheckpoint(destination, input string) error {
+	archiveFile, err := os.Open(input)
+	if err != nil {
+		return fmt.Errorf("failed to open pod checkpoint archive %s for import: %w", input, err)
+	}
+	defer archiveFile.Close()
+	if err := archive.Untar(archiveFile, destination, nil); err != nil {
+		return fmt.Errorf("unpacking of pod checkpoint archive %s failed: %w", input, err)
+	}
+	if _, err := os.Stat(filepath.Join(destination, PodDumpFile)); err != nil {
+		return fmt.Errorf("%s is not a pod checkpoint archive: %w", input, err)
+	}
+	return nil
+}
+
+// CRPodCheckpointContainers returns the paths of the checkpoint archives of
+// the containers in the pod checkpoint in the directory dir.
+func CRPodCheckpointContainers(dir string) ([]string, error) {
+	entries, err := os.ReadDir(filepath.Join(dir, PodContainersDirectory))
+	if err != nil {
+		return nil, fmt.Errorf("reading containers of pod checkpoint: %w", err)
+	}
+	archives := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".tar" {
+			continue
+		}
+		archives = append(archives, filepath.Join(dir, PodContainersDirectory, entry.Name()))
+	}
+	return archives, nil
+}
@@ -0,0 +1,488 @@
+//go:build !remote
+
+package checkpoint
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	metadata "github.com/checkpoint-restore/checkpointctl/lib"
+	"github.com/containers/common/libimage"
+	"github.com/containers/podman/v4/libpod"
+	"github.com/containers/podman/v4/libpod/define"
+	"github.com/containers/podman/v4/pkg/checkpoint/crutils"
+	"github.com/containers/podman/v4/pkg/criu"
+	"github.com/containers/podman/v4/pkg/domain/entities"
+	"github.com/containers/podman/v4/pkg/specgen"
+	"github.com/containers/podman/v4/pkg/specgen/generate"
+	"github.com/containers/podman/v4/version"
+	"github.com/containers/storage/pkg/archive"
+	"github.com/sirupsen/logrus"
+)
+
+// podDump is the content of crutils.PodDumpFile.  The infra container is not
+// checkpointed, as it only holds the namespaces of the pod, but created anew
+// from its configuration on restore.  State held only by these namespaces is
+// therefore not part of the checkpoint, see checkPodNamespaceState.
+type podDump struct {
+	Pod   *specgen.PodSpecGenerator `json:"pod"`
+	Infra *specgen.SpecGenerator    `json:"infra"`
+}
+
+// CRCheckpointPod checkpoints all running containers of the pod except the
+// infra container.  All of them are paused first, and each one is only thawed
+// for the time it is checkpointed, so no container of the pod makes progress
+// while another one is checkpointed.  If an export or an image is requested,
+// the configuration of the pod and its infra container, including its port
+// mappings and networks, is written along with the checkpoints of all
+// containers into a single archive or image.
+func CRCheckpointPod(ctx context.Context, runtime *libpod.Runtime, pod *libpod.Pod, options entities.PodCheckpointOptions) ([]*entities.CheckpointReport, error) {
+	if !pod.HasInfraContainer() {
+		return nil, fmt.Errorf("pod %s has no infra container: only pods with an infra container can be checkpointed", pod.Name())
+	}
+	infraID, err := pod.InfraContainerID()
+	if err != nil {
+		return nil, err
+	}
+	ctrs, err := pod.AllContainers()
+	if err != nil {
+		return nil, err
+	}
+	var running []*libpod.Container
+	for _, ctr := range ctrs {
+		if ctr.ID() == infraID {
+			continue
+		}
+		state, err := ctr.State()
+		if err != nil {
+			return nil, err
+		}
+		if state == define.ContainerStateRunning {
+			running = append(running, ctr)
+		}
+	}
+	if len(running) == 0 {
+		return nil, fmt.Errorf("pod %s has no running containers to checkpoint: %w", pod.Name(), define.ErrCtrStateInvalid)
+	}
+	if err := checkPodNamespaceState(pod); err != nil {
+		return nil, err
+	}
+
+	export := options.Export != "" || options.CreateImage != ""
+	var dir string
+	if export {
+		dir, err = os.MkdirTemp("", "pod-checkpoint")
+		if err != nil {
+			return nil, err
+		}
+		defer func() {
+			if err := os.RemoveAll(dir); err != nil {
+				logrus.Errorf("Could not recursively remove %s: %q", dir, err)
+			}
+		}()
+		if err := os.Mkdir(filepath.Join(dir, crutils.PodContainersDirectory), 0o700); err != nil {
+			return nil, err
+		}
+		dump, err := podCheckpointDump(runtime, pod)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := metadata.WriteJSONFile(dump, dir, crutils.PodDumpFile); err != nil {
+			return nil, err
+		}
+	}
+
+	paused := make(map[string]*libpod.Container, len(running))
+	defer func() {
+		for id, ctr := range paused {
+			if err := ctr.Unpause(); err != nil {
+				logrus.Errorf("Unpausing container %s of pod %s after checkpoint: %v", id, pod.Name(), err)
+			}
+		}
+	}()
+	for _, ctr := range running {
+		if err := ctr.Pause(); err != nil {
+			return nil, fmt.Errorf("pausing container %s of pod %s: %w", ctr.ID(), pod.Name(), err)
+		}
+		paused[ctr.ID()] = ctr
+	}
+
+	reports := make([]*entities.CheckpointReport, 0, len(running))
+	for _, ctr := range running {
+		if err := ctr.Unpause(); err != nil {
+			return reports, fmt.Errorf("unpausing container %s of pod %s for checkpoint: %w", ctr.ID(), pod.Name(), err)
+		}
+		delete(paused, ctr.ID())
+
+		checkOpts := libpod.ContainerCheckpointOptions{
+			Keep:           options.Keep,
+			TCPEstablished: options.TCPEstablished,
+			IgnoreRootfs:   options.IgnoreRootFS,
+			IgnoreVolumes:  options.IgnoreVolumes,
+			KeepRunning:    options.LeaveRunning,
+			PrintStats:     options.PrintStats,
+			FileLocks:      options.FileLocks,
+		}
+		if export {
+			// The archive of the pod is compressed as a whole.
+			checkOpts.TargetFile = filepath.Join(dir, crutils.PodContainersDirectory, ctr.ID()+".tar")
+			checkOpts.Compression = archive.Uncompressed
+		}
+		criuStatistics, runtimeCheckpointDuration, err := ctr.Checkpoint(ctx, checkOpts)
+		reports = append(reports, &entities.CheckpointReport{
+			Err:             err,
+			Id:              ctr.ID(),
+			RuntimeDuration: runtimeCheckpointDuration,
+			CRIUStatistics:  criuStatistics,
+		})
+		if err != nil {
+			return reports, fmt.Errorf("checkpointing container %s of pod %s: %w", ctr.ID(), pod.Name(), err)
+		}
+
+		// Keep the container frozen until all containers are
+		// checkpointed.
+		if options.LeaveRunning {
+			if err := ctr.Pause(); err != nil {
+				return reports, fmt.Errorf("pausing container %s of pod %s: %w", ctr.ID(), pod.Name(), err)
+			}
+			paused[ctr.ID()] = ctr
+		}
+	}
+
+	if options.Export != "" {
+		if err := crutils.CRCreatePodCheckpointArchive(dir, options.Export, options.Compression); err != nil {
+			return reports, err
+		}
+	}
+	if options.CreateImage != "" {
+		if err := createPodCheckpointImage(ctx, runtime, pod, dir, options.CreateImage); err != nil {
+			return reports, fmt.Errorf("creating checkpoint image of pod %s: %w", pod.Name(), err)
+		}
+	}
+	return reports, nil
+}
+
+// checkPodNamespaceState refuses to checkpoint pods whose containers depend
+// on state held by the namespaces of the infra container, which is not
+// checkpointed: the processes of a shared PID namespace, and the IPC objects
+// of a shared IPC namespace.
+func checkPodNamespaceState(pod *libpod.Pod) error {
+	if pod.SharesPID() {
+		return fmt.Errorf("pod %s shares the PID namespace of its infra container, which cannot be checkpointed: %w", pod.Name(), define.ErrNotImplemented)
+	}
+	if !pod.SharesIPC() {
+		return nil
+	}
+	infra, err := pod.InfraContainer()
+	if err != nil {
+		return err
+	}
+	pid, err := infra.PID()
+	if err != nil {
+		return err
+	}
+	// Without a running infra container, its IPC namespace is gone.
+	if pid == 0 {
+		return nil
+	}
+	objects, err := ipcNamespaceObjects(pid)
+	if err != nil {
+		return fmt.Errorf("listing IPC objects of pod %s: %w", pod.Name(), err)
+	}
+	if len(objects) > 0 {
+		return fmt.Errorf("the IPC namespace of pod %s holds %s, which would be lost as the infra container is not checkpointed: %w", pod.Name(), strings.Join(objects, ", "), define.ErrNotImplemented)
+	}
+	return nil
+}
+
+// podCheckpointDump returns the configuration of the pod and of its infra
+// container, from which they are re-created on restore.
+func podCheckpointDump(runtime *libpod.Runtime, pod *libpod.Pod) (*podDump, error) {
+	spec := specgen.NewPodSpecGenerator()
+	infraOptions := entities.ContainerCreateOptions{MemorySwappiness: -1}
+	if _, err := generate.PodConfigToSpec(runtime, spec, &infraOptions, pod.ID()); err != nil {
+		return nil, fmt.Errorf("reading configuration of pod %s: %w", pod.Name(), err)
+	}
+	podConfig, err := pod.Config()
+	if err != nil {
+		return nil, err
+	}
+	spec.Name = pod.Name()
+	spec.Hostname = pod.Hostname()
+	spec.Labels = pod.Labels()
+	spec.ExitPolicy = string(podConfig.ExitPolicy)
+	spec.RestartPolicy = podConfig.RestartPolicy
+	spec.RestartRetries = podConfig.RestartRetries
+	// The infra container gets the default name of the restored pod.
+	spec.InfraContainerSpec.Name = ""
+	return &podDump{
+		Pod:   spec,
+		Infra: spec.InfraContainerSpec,
+	}, nil
+}
+
+// createPodCheckpointImage creates an image from the pod checkpoint in the
+// directory dir.  The files of the checkpoint are the content of the image.
+func createPodCheckpointImage(ctx context.Context, runtime *libpod.Runtime, pod *libpod.Pod, dir, name string) error {
+	tarFile := filepath.Join(dir, "checkpoint.tar")
+	if err := crutils.CRCreatePodCheckpointArchive(dir, tarFile, archive.Uncompressed); err != nil {
+		return err
+	}
+	importOptions := &libimage.ImportOptions{
+		Tag: name,
+		Changes: []string{
+			fmt.Sprintf("LABEL %s=%s", define.CheckpointAnnotationPodName, pod.Name()),
+			fmt.Sprintf("LABEL %s=%s", define.CheckpointAnnotationPodmanVersion, version.Version.String()),
+		},
+		CommitMessage: fmt.Sprintf("checkpoint of pod %s", pod.Name()),
+	}
+	id, err := runtime.LibimageRuntime().Import(ctx, tarFile, importOptions)
+	if err != nil {
+		return err
+	}
+	logrus.Debugf("Created pod checkpoint image: %s", id)
+	return nil
+}
+
+// CRRestorePod restores the checkpointed containers of the pod in place.  The
+// infra container is started first, if it is not running, so the containers
+// are restored into its namespaces.
+func CRRestorePod(ctx context.Context, runtime *libpod.Runtime, pod *libpod.Pod, options entities.PodRestoreOptions) ([]*entities.RestoreReport, error) {
+	infra, err := pod.InfraContainer()
+	if err != nil {
+		return nil, fmt.Errorf("pod %s has no infra container: %w", pod.Name(), err)
+	}
+	ctrs, err := pod.AllContainers()
+	if err != nil {
+		return nil, err
+	}
+	var checkpointed []*libpod.Container
+	for _, ctr := range ctrs {
+		if ctr.ID() == infra.ID() {
+			continue
+		}
+		data, err := ctr.Inspect(false)
+		if err != nil {
+			return nil, err
+		}
+		if data.State.Checkpointed {
+			checkpointed = append(checkpointed, ctr)
+		}
+	}
+	if len(checkpointed) == 0 {
+		return nil, fmt.Errorf("pod %s has no checkpointed containers to restore: %w", pod.Name(), define.ErrCtrStateInvalid)
+	}
+	if err := startPodInfra(ctx, infra); err != nil {
+		return nil, err
+	}
+
+	reports := make([]*entities.RestoreReport, 0, len(checkpointed))
+	for _, ctr := range checkpointed {
+		criuStatistics, runtimeRestoreDuration, err := ctr.Restore(ctx, podRestoreOptions(pod, options, "", ""))
+		reports = append(reports, &entities.RestoreReport{
+			Err:             err,
+			Id:              ctr.ID(),
+			RuntimeDuration: runtimeRestoreDuration,
+			CRIUStatistics:  criuStatistics,
+		})
+		if err != nil {
+			return reports, fmt.Errorf("restoring container %s of pod %s: %w", ctr.ID(), pod.Name(), err)
+		}
+	}
+	return reports, nil
+}
+
+// CRImportPodCheckpoint re-creates the pod of the pod checkpoint in the
+// directory dir, with the port mappings and networks of the original pod, and
+// restores all of its containers into it.  If a new name is given for the
+// pod, the containers get new names as well: a prefix of the name of the
+// original pod is replaced with the new name, other names are prefixed with
+// it.  On failure, the pod is removed again.
+func CRImportPodCheckpoint(ctx context.Context, runtime *libpod.Runtime, options entities.PodRestoreOptions, dir string) (_ *libpod.Pod, _ []*entities.RestoreReport, finalErr error) {
+	dump := podDump{}
+	if _, err := metadata.ReadJSONFile(&dump, dir, crutils.PodDumpFile); err != nil {
+		return nil, nil, err
+	}
+	if dump.Pod == nil || dump.Infra == nil {
+		return nil, nil, fmt.Errorf("invalid pod checkpoint: %s does not contain the configuration of the pod", crutils.PodDumpFile)
+	}
+	archives, err := crutils.CRPodCheckpointContainers(dir)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(archives) == 0 {
+		return nil, nil, errors.New("invalid pod checkpoint: no containers to restore")
+	}
+
+	// Restoring into a pod requires much newer versions of CRIU, and
+	// support by the runtime.  Check before creating the pod.
+	if err := criu.CheckForCriu(criu.PodCriuVersion); err != nil {
+		return nil, nil, fmt.Errorf("restoring pod: %w", err)
+	}
+	if !crutils.CRRuntimeSupportsPodCheckpointRestore(runtime.GetOCIRuntimePath()) {
+		return nil, nil, fmt.Errorf("runtime %s does not support pod restore", runtime.GetOCIRuntimePath())
+	}
+
+	// Read the configuration of all containers up front, so the
+	// volumes shared by them are only checked once.
+	configDirs := make([]string, 0, len(archives))
+	defer func() {
+		for _, configDir := range configDirs {
+			if err := os.RemoveAll(configDir); err != nil {
+				logrus.Errorf("Could not recursively remove %s: %q", configDir, err)
+			}
+		}
+	}()
+	ctrConfigs := make([]*libpod.ContainerConfig, 0, len(archives))
+	for _, ctrArchive := range archives {
+		configDir, err := os.MkdirTemp("", "checkpoint")
+		if err != nil {
+			return nil, nil, err
+		}
+		configDirs = append(configDirs, configDir)
+		if err := crutils.CRImportCheckpointConfigOnly(configDir, ctrArchive); err != nil {
+			return nil, nil, err
+		}
+		ctrConfig := new(libpod.ContainerConfig)
+		if _, err := metadata.ReadJSONFile(ctrConfig, configDir, metadata.ConfigDumpFile); err != nil {
+			return nil, nil, err
+		}
+		ctrConfigs = append(ctrConfigs, ctrConfig)
+	}
+	if !options.IgnoreVolumes {
+		checked := make(map[string]bool)
+		for _, ctrConfig := range ctrConfigs {
+			for _, vol := range ctrConfig.NamedVolumes {
+				if checked[vol.Name] {
+					continue
+				}
+				checked[vol.Name] = true
+				exists, err := runtime.HasVolume(vol.Name)
+				if err != nil {
+					return nil, nil, err
+				}
+				if exists {
+					return nil, nil, fmt.Errorf("volume with name %s already exists. Use --ignore-volumes to not restore content of volumes", vol.Name)
+				}
+			}
+		}
+	}
+
+	oldName := dump.Pod.Name
+	if options.Name != "" {
+		dump.Pod.Name = options.Name
+	}
+	if options.IgnoreStaticIP || options.IgnoreStaticMAC {
+		for net, opts := range dump.Infra.Networks {
+			if options.IgnoreStaticIP {
+				opts.StaticIPs = nil
+			}
+			if options.IgnoreStaticMAC {
+				opts.StaticMAC = nil
+			}
+			dump.Infra.Networks[net] = opts
+		}
+		dump.Pod.Networks = dump.Infra.Networks
+	}
+	dump.Pod.InfraContainerSpec = dump.Infra
+
+	pod, err := generate.MakePod(&entities.PodSpec{PodSpecGen: *dump.Pod}, runtime)
+	if err != nil {
+		return nil, nil, fmt.Errorf("creating pod %s: %w", dump.Pod.Name, err)
+	}
+	defer func() {
+		if finalErr != nil {
+			if _, err := runtime.RemovePod(ctx, pod, true, true, nil); err != nil {
+				logrus.Errorf("Removing pod %s after failed restore: %v", pod.Name(), err)
+			}
+		}
+	}()
+	infra, err := pod.InfraContainer()
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := startPodInfra(ctx, infra); err != nil {
+		return nil, nil, err
+	}
+
+	reports := make([]*entities.RestoreReport, 0, len(archives))
+	for i, ctrArchive := range archives {
+		name := ""
+		if options.Name != "" {
+			name = podContainerName(ctrConfigs[i].Name, oldName, options.Name)
+		}
+		restoreOptions := entities.RestoreOptions{
+			Import:          ctrArchive,
+			Name:            name,
+			Pod:             pod.ID(),
+			IgnoreVolumes:   options.IgnoreVolumes,
+			IgnoreRootFS:    options.IgnoreRootFS,
+			IgnoreStaticIP:  options.IgnoreStaticIP,
+			IgnoreStaticMAC: options.IgnoreStaticMAC,
+		}
+		ctrs, err := crImportCheckpoint(ctx, runtime, restoreOptions, configDirs[i], false)
+		if err != nil {
+			return nil, reports, fmt.Errorf("importing container %s of pod %s: %w", ctrConfigs[i].Name, oldName, err)
+		}
+		ctr := ctrs[0]
+		criuStatistics, runtimeRestoreDuration, err := ctr.Restore(ctx, podRestoreOptions(pod, options, ctrArchive, name))
+		reports = append(reports, &entities.RestoreReport{
+			Err:             err,
+			Id:              ctr.ID(),
+			RuntimeDuration: runtimeRestoreDuration,
+			CRIUStatistics:  criuStatistics,
+		})
+		if err != nil {
+			return nil, reports, fmt.Errorf("restoring container %s of pod %s: %w", ctrConfigs[i].Name, oldName, err)
+		}
+	}
+	return pod, reports, nil
+}
+
+// podRestoreOptions returns the options for restoring a container into the
+// pod from the checkpoint archive ctrArchive, or in place if it is empty.
+func podRestoreOptions(pod *libpod.Pod, options entities.PodRestoreOptions, ctrArchive, name string) libpod.ContainerCheckpointOptions {
+	return libpod.ContainerCheckpointOptions{
+		Keep:           options.Keep,
+		TCPEstablished: options.TCPEstablished,
+		TargetFile:     ctrArchive,
+		Name:           name,
+		IgnoreRootfs:   options.IgnoreRootFS,
+		IgnoreVolumes:  options.IgnoreVolumes,
+		// Containers of the pod use the network namespace of the
+		// infra container.
+		IgnoreStaticIP:  true,
+		IgnoreStaticMAC: true,
+		Pod:             pod.ID(),
+		PrintStats:      options.PrintStats,
+		FileLocks:       options.FileLocks,
+	}
+}
+
+// startPodInfra starts the infra container of a pod, unless it is running.
+func startPodInfra(ctx context.Context, infra *libpod.Container) error {
+	state, err := infra.State()
+	if err != nil {
+		return err
+	}
+	if state == define.ContainerStateRunning {
+		return nil
+	}
+	if err := infra.Start(ctx, false); err != nil {
+		return fmt.Errorf("starting infra container %s: %w", infra.ID(), err)
+	}
+	return nil
+}
+
+// podContainerName returns the name of a container of a pod restored with a
+// new name.
+func podContainerName(name, oldPodName, newPodName string) string {
+	if rest, ok := strings.CutPrefix(name, oldPodName+"-"); ok {
+		return newPodName + "-" + rest
+	}
+	return newPodName + "-" + name
+}
@@ -0,0 +1,106 @@
+//go:build !remote
+
+package checkpoint
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/containers/podman/v4/pkg/errorhandling"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/sys/unix"
+)
+
+// ipcNamespaceObjects returns the System V IPC objects and POSIX message
+// queues in the IPC namespace of the process with the given PID.
+func ipcNamespaceObjects(pid int) ([]string, error) {
+	type result struct {
+		objects []string
+		err     error
+	}
+	ch := make(chan result)
+	go func() {
+		runtime.LockOSThread()
+		objects, restored, err := sysvIPCObjectsOfNamespace(pid)
+		// Leave the thread locked if it is still in the IPC namespace
+		// of the process, so the Go runtime terminates it.
+		if restored {
+			runtime.UnlockOSThread()
+		}
+		ch <- result{objects: objects, err: err}
+	}()
+	res := <-ch
+	if res.err != nil {
+		return nil, res.err
+	}
+
+	// The message queues are visible in the mqueue file system the
+	// process mounted in its IPC namespace.
+	entries, err := os.ReadDir(filepath.Join("/proc", fmt.Sprint(pid), "root", "dev", "mqueue"))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+	for _, entry := range entries {
+		res.objects = append(res.objects, "POSIX message queue /"+entry.Name())
+	}
+	return res.objects, nil
+}
+
+// sysvIPCObjectsOfNamespace joins the IPC namespace of the process with the
+// given PID on the current thread to list its System V IPC objects.  It
+// reports whether the thread returned to its original IPC namespace.
+func sysvIPCObjectsOfNamespace(pid int) ([]string, bool, error) {
+	self, err := os.Open(fmt.Sprintf("/proc/%d/task/%d/ns/ipc", os.Getpid(), unix.Gettid()))
+	if err != nil {
+		return nil, true, err
+	}
+	defer errorhandling.CloseQuiet(self)
+	target, err := os.Open(fmt.Sprintf("/proc/%d/ns/ipc", pid))
+	if err != nil {
+		return nil, true, err
+	}
+	defer errorhandling.CloseQuiet(target)
+
+	if err := unix.Setns(int(target.Fd()), unix.CLONE_NEWIPC); err != nil {
+		return nil, true, fmt.Errorf("joining IPC namespace of process %d: %w", pid, err)
+	}
+	objects, err := sysvIPCObjects()
+	if err := unix.Setns(int(self.Fd()), unix.CLONE_NEWIPC); err != nil {
+		logrus.Errorf("Unable to return to the original IPC namespace: %v", err)
+		return nil, false, err
+	}
+	return objects, true, err
+}
+
+// sysvIPCObjects lists the System V IPC objects of the IPC namespace of the
+// current thread.
+func sysvIPCObjects() ([]string, error) {
+	var objects []string
+	for _, kind := range []struct{ file, name string }{
+		{"shm", "shared memory segment"},
+		{"msg", "message queue"},
+		{"sem", "semaphore set"},
+	} {
+		data, err := os.ReadFile(filepath.Join("/proc/sysvipc", kind.file))
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				continue
+			}
+			return nil, err
+		}
+		lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+		// The first line is the header, the second column the ID.
+		for _, line := range lines[1:] {
+			fields := strings.Fields(line)
+			if len(fields) < 2 {
+				continue
+			}
+			objects = append(objects, fmt.Sprintf("System V %s %s", kind.name, fields[1]))
+		}
+	}
+	return objects, nil
+}
@@ -0,0 +1,11 @@
+//go:build !remote && !linux
+
+package checkpoint
+
+import (
+	"errors"
+)
+
+func ipcNamespaceObjects(pid int) ([]string, error) {
+	return nil, errors.New("not implemented ipcNamespaceObjects")
+}
@@ -1,6 +1,18 @@
 package entities
 
-import "github.com/containers/image/v5/types"
+import (
+	"time"
+
+	"github.com/containers/image/v5/types"
+)
+
+const (
+	// AutoUpdateRolloutAll updates all systemd units at once.
+	AutoUpdateRolloutAll = "all"
+	// AutoUpdateRolloutCanary updates one systemd unit first and waits for
+	// it to become healthy before updating the remaining ones.
+	AutoUpdateRolloutCanary = "canary"
+)
 
 // AutoUpdateOptions are the options for running auto-update.
 type AutoUpdateOptions struct {
@@ -16,6 +28,12 @@ type AutoUpdateOptions struct {
 	// Allow contacting registries over HTTP, or HTTPS with failed TLS
 	// verification. Note that this does not affect other TLS connections.
 	InsecureSkipTLSVerify types.OptionalBool
+	// Rollout mode, either AutoUpdateRolloutAll (default) or
+	// AutoUpdateRolloutCanary.
+	Rollout string
+	// Time to wait for the containers of updated units to become healthy
+	// in the canary rollout mode.
+	SoakTime time.Duration
 }
 
 // AutoUpdateReport contains the results from running auto-update.
@@ -31,6 +49,7 @@ type AutoUpdateReport struct {
 	// SystemdUnit running a container configured for auto updates.
 	SystemdUnit string
 	// Indicates the update status: true, false, failed, pending (see
-	// DryRun).
+	// DryRun), rolled back, skipped (outside of the maintenance window)
+	// or halted (see Rollout).
 	Updated string
 }
@@ -57,6 +57,12 @@ type ListContainer struct {
 	PodName string
 	// Port mappings
 	Ports []types.PortMapping
+	// Ready is set if the container is running and its readiness
+	// healthcheck, if any, passed.
+	Ready bool
+	// CrashLoop is set if the container keeps crashing, and waits to be
+	// restarted by its restart policy.
+	CrashLoop bool
 	// Restarts is how many times the container was restarted by its
 	// restart policy. This is NOT incremented by normal container restarts
 	// (only by restart policy).
@@ -516,3 +516,18 @@ type ContainerUpdateOptions struct {
 	NameOrID string
 	Specgen  *specgen.SpecGenerator
 }
+
+// ContainerScheduleReport describes a scheduled container
+type ContainerScheduleReport struct {
+	ID   string
+	Name string
+	// Schedule is the systemd calendar expression
+	Schedule string
+	State    string
+	LastRun  time.Time
+	// LastExitCode is nil if the container never ran on its schedule or
+	// the last run is still active
+	LastExitCode *int32
+	// NextRun is zero if unknown
+	NextRun time.Time
+}
@@ -42,8 +42,11 @@ type ContainerEngine interface { //nolint:interfacebloat
 	ContainerRestart(ctx context.Context, namesOrIds []string, options RestartOptions) ([]*RestartReport, error)
 	ContainerRestore(ctx context.Context, namesOrIds []string, options RestoreOptions) ([]*RestoreReport, error)
 	ContainerRm(ctx context.Context, namesOrIds []string, options RmOptions) ([]*reports.RmReport, error)
+	ContainerRotateLog(ctx context.Context, nameOrID string) error
 	ContainerRun(ctx context.Context, opts ContainerRunOptions) (*ContainerRunReport, error)
 	ContainerRunlabel(ctx context.Context, label string, image string, args []string, opts ContainerRunlabelOptions) error
+	ContainerScheduleList(ctx context.Context) ([]*ContainerScheduleReport, error)
+	ContainerScheduleRun(ctx context.Context, nameOrID string) error
 	ContainerStart(ctx context.Context, namesOrIds []string, options ContainerStartOptions) ([]*ContainerStartReport, error)
 	ContainerStat(ctx context.Context, nameOrDir string, path string) (*ContainerStatReport, error)
 	ContainerStats(ctx context.Context, namesOrIds []string, options ContainerStatsOptions) (chan ContainerStatsReport, error)
@@ -63,7 +66,7 @@ type ContainerEngine interface { //nolint:interfacebloat
 	Info(ctx context.Context) (*define.Info, error)
 	KubeApply(ctx context.Context, body io.Reader, opts ApplyOptions) error
 	Locks(ctx context.Context) (*LocksReport, error)
-	Migrate(ctx context.Context, options SystemMigrateOptions) error
+	Migrate(ctx context.Context, options SystemMigrateOptions) (*SystemMigrateReport, error)
 	NetworkConnect(ctx context.Context, networkname string, options NetworkConnectOptions) error
 	NetworkCreate(ctx context.Context, network types.Network, createOptions *types.NetworkCreateOptions) (*types.Network, error)
 	NetworkUpdate(ctx context.Context, networkname string, options NetworkUpdateOptions) error
@@ -77,6 +80,7 @@ type ContainerEngine interface { //nolint:interfacebloat
 	PlayKube(ctx context.Context, body io.Reader, opts PlayKubeOptions) (*PlayKubeReport, error)
 	PlayKubeDown(ctx context.Context, body io.Reader, opts PlayKubeDownOptions) (*PlayKubeReport, error)
 	PodCreate(ctx context.Context, specg PodSpec) (*PodCreateReport, error)
+	PodCheckpoint(ctx context.Context, namesOrIds []string, options PodCheckpointOptions) ([]*PodCheckpointReport, error)
 	PodClone(ctx context.Context, podClone PodCloneOptions) (*PodCloneReport, error)
 	PodExists(ctx context.Context, nameOrID string) (*BoolReport, error)
 	PodInspect(ctx context.Context, namesOrID []string, options InspectOptions) ([]*PodInspectReport, []error, error)
@@ -86,12 +90,19 @@ type ContainerEngine interface { //nolint:interfacebloat
 	PodPrune(ctx context.Context, options PodPruneOptions) ([]*PodPruneReport, error)
 	PodPs(ctx context.Context, options PodPSOptions) ([]*ListPodsReport, error)
 	PodRestart(ctx context.Context, namesOrIds []string, options PodRestartOptions) ([]*PodRestartReport, error)
+	PodRestore(ctx context.Context, namesOrIds []string, options PodRestoreOptions) ([]*PodRestoreReport, error)
 	PodRm(ctx context.Context, namesOrIds []string, options PodRmOptions) ([]*PodRmReport, error)
 	PodStart(ctx context.Context, namesOrIds []string, options PodStartOptions) ([]*PodStartReport, error)
 	PodStats(ctx context.Context, namesOrIds []string, options PodStatsOptions) ([]*PodStatsReport, error)
 	PodStop(ctx context.Context, namesOrIds []string, options PodStopOptions) ([]*PodStopReport, error)
 	PodTop(ctx context.Context, options PodTopOptions) (*StringSliceReport, error)
 	PodUnpause(ctx context.Context, namesOrIds []string, options PodunpauseOptions) ([]*PodUnpauseReport, error)
+	PodUpdate(ctx context.Context, options *PodUpdateOptions) (*PodUpdateReport, error)
+	QuadletInstall(ctx context.Context, pathsOrURLs []string, options QuadletInstallOptions) (*QuadletInstallReport, error)
+	QuadletList(ctx context.Context, options QuadletListOptions) ([]*ListQuadlet, error)
+	QuadletPrint(ctx context.Context, quadlet string) (string, error)
+	QuadletRemove(ctx context.Context, quadlets []string, options QuadletRemoveOptions) (*QuadletRemoveReport, error)
+	QuadletValidate(ctx context.Context, quadlets []string) ([]*QuadletValidateReport, error)
 	Renumber(ctx context.Context) error
 	Reset(ctx context.Context) error
 	SetupRootless(ctx context.Context, noMoveProcess bool) error
@@ -100,8 +111,12 @@ type ContainerEngine interface { //nolint:interfacebloat
 	SecretList(ctx context.Context, opts SecretListRequest) ([]*SecretInfoReport, error)
 	SecretRm(ctx context.Context, nameOrID []string, opts SecretRmOptions) ([]*SecretRmReport, error)
 	SecretExists(ctx context.Context, nameOrID string) (*BoolReport, error)
+	SecretUpdate(ctx context.Context, nameOrID string, reader io.Reader, options SecretUpdateOptions) (*SecretUpdateReport, error)
 	Shutdown(ctx context.Context)
+	SystemBackup(ctx context.Context, w io.Writer, options SystemBackupOptions) (*SystemBackupReport, error)
+	SystemCheck(ctx context.Context, options SystemCheckOptions) (*SystemCheckReport, error)
 	SystemDf(ctx context.Context, options SystemDfOptions) (*SystemDfReport, error)
+	SystemRestore(ctx context.Context, r io.Reader, options SystemRestoreOptions) (*SystemRestoreReport, error)
 	Unshare(ctx context.Context, args []string, options SystemUnshareOptions) error
 	Version(ctx context.Context) (*SystemVersionReport, error)
 	VolumeCreate(ctx context.Context, opts VolumeCreateOptions) (*IDOrNameResponse, error)
@@ -112,6 +127,10 @@ type ContainerEngine interface { //nolint:interfacebloat
 	VolumeMount(ctx context.Context, namesOrIds []string) ([]*VolumeMountReport, error)
 	VolumePrune(ctx context.Context, options VolumePruneOptions) ([]*reports.PruneReport, error)
 	VolumeRm(ctx context.Context, namesOrIds []string, opts VolumeRmOptions) ([]*VolumeRmReport, error)
+	VolumeSnapshotCreate(ctx context.Context, nameOrID string, opts VolumeSnapshotCreateOptions) (*VolumeSnapshotReport, error)
+	VolumeSnapshotList(ctx context.Context, namesOrIds []string) ([]*VolumeSnapshotReport, error)
+	VolumeSnapshotRestore(ctx context.Context, nameOrID, snapshot string) error
+	VolumeSnapshotRm(ctx context.Context, nameOrID string, snapshots []string) ([]*VolumeSnapshotRmReport, error)
 	VolumeUnmount(ctx context.Context, namesOrIds []string) ([]*VolumeUnmountReport, error)
 	VolumeReload(ctx context.Context) (*VolumeReloadReport, error)
 }
@@ -14,7 +14,8 @@ type Event struct {
 	// TODO: it would be nice to have full control over the types at some
 	// point and fork such Docker types.
 	dockerEvents.Message
-	HealthStatus string `json:",omitempty"`
+	HealthStatus    string `json:",omitempty"`
+	ReadinessStatus string `json:",omitempty"`
 }
 
 // ConvertToLibpodEvent converts an entities event to a libpod one.
@@ -51,6 +52,7 @@ func ConvertToLibpodEvent(e Event) *libpodEvents.Event {
 		Time:              time.Unix(0, e.TimeNano),
 		Type:              t,
 		HealthStatus:      e.HealthStatus,
+		ReadinessStatus:   e.ReadinessStatus,
 		Details: libpodEvents.Details{
 			PodID:      podID,
 			Attributes: details,
@@ -88,5 +90,6 @@ func ConvertToEntitiesEvent(e libpodEvents.Event) *Event {
 	return &Event{
 		message,
 		e.HealthStatus,
+		e.ReadinessStatus,
 	}
 }
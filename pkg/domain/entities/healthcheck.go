@@ -1,3 +1,6 @@
 package entities
 
-type HealthCheckOptions struct{}
+type HealthCheckOptions struct {
+	// Readiness runs the readiness healthcheck instead of the healthcheck.
+	Readiness bool
+}
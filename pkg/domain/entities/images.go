@@ -491,4 +491,9 @@ type FarmInspectReport struct {
 	OS                string
 	Arch              string
 	Variant           string
+	// Number of CPUs of the node.
+	CPUs int
+	// Number of containers running on the node, used as a measure of
+	// its load when scheduling builds.
+	RunningContainers int
 }
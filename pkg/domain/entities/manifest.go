@@ -1,6 +1,9 @@
 package entities
 
-import "github.com/containers/image/v5/types"
+import (
+	"github.com/containers/image/v5/types"
+	imgspecv1 "github.com/opencontainers/image-spec/specs-go/v1"
+)
 
 // ManifestCreateOptions provides model for creating manifest
 type ManifestCreateOptions struct {
@@ -18,6 +21,18 @@ type ManifestInspectOptions struct {
 	Authfile string `json:"-" schema:"-"`
 	// Should TLS registry certificate be verified?
 	SkipTLSVerify types.OptionalBool `json:"-" schema:"-"`
+	// Credentials for the registry, overriding the authentication file.
+	Credentials *types.DockerAuthConfig `json:"-" schema:"-"`
+}
+
+// ManifestDistributionReport describes the manifest of an image or manifest
+// list in a registry.
+type ManifestDistributionReport struct {
+	// Descriptor of the manifest, including its digest.
+	Descriptor imgspecv1.Descriptor
+	// Platforms supported by the image, or by the images in the manifest
+	// list.
+	Platforms []imgspecv1.Platform
 }
 
 // ManifestAddOptions provides model for adding digests to manifest list
@@ -64,6 +64,25 @@ type NetworkCreateOptions struct {
 type NetworkUpdateOptions struct {
 	AddDNSServers    []string `json:"adddnsservers"`
 	RemoveDNSServers []string `json:"removednsservers"`
+	// AddLabels are added to the labels of the network, replacing
+	// existing labels with the same key.
+	AddLabels    map[string]string `json:"addlabels,omitempty"`
+	RemoveLabels []string          `json:"removelabels,omitempty"`
+	// AddOptions are added to the driver options of the network,
+	// replacing existing options with the same key.
+	AddOptions    map[string]string `json:"addoptions,omitempty"`
+	RemoveOptions []string          `json:"removeoptions,omitempty"`
+	// AddRoutes are added to the static routes of the network, replacing
+	// existing routes with the same destination.
+	AddRoutes []types.Route `json:"addroutes,omitempty"`
+	// RemoveRoutes are the destinations of the static routes to remove.
+	RemoveRoutes []string `json:"removeroutes,omitempty"`
+	// AddSubnets are added to the subnets of the network.  If the network
+	// already has a subnet with the same address range, its gateway and
+	// lease range are updated instead.
+	AddSubnets []types.Subnet `json:"addsubnets,omitempty"`
+	Internal   *bool          `json:"internal,omitempty"`
+	DisableDNS *bool          `json:"disabledns,omitempty"`
 }
 
 // NetworkCreateReport describes a created network for the cli
@@ -73,6 +73,9 @@ type PlayKubeOptions struct {
 	// PublishAllPorts - whether to publish all ports defined in the K8S YAML file
 	// (containerPort, hostPort) otherwise only hostPort will be published
 	PublishAllPorts bool
+	// ReadinessGate - withhold the ports of containers with a readinessProbe
+	// until they are ready and wait for them to be ready
+	ReadinessGate bool
 	// Wait - indicates whether to return after having created the pods
 	Wait bool
 	// SystemContext - used when building the image
@@ -100,10 +103,28 @@ type PlayKubeVolume struct {
 	Name string
 }
 
+// PlayKubeJob represents a single job run to completion by play kube.
+type PlayKubeJob struct {
+	// Name - name of the job.
+	Name string
+	// Pods - IDs of all pods created for the job, including failed attempts.
+	Pods []string
+	// Succeeded - number of pods which completed successfully.
+	Succeeded int32
+	// Failed - number of failed pod attempts.
+	Failed int32
+	// Completed - set if the job reached the requested number of completions.
+	Completed bool
+	// Reason - why the job did not complete, e.g., BackoffLimitExceeded.
+	Reason string
+}
+
 // PlayKubeReport contains the results of running play kube.
 type PlayKubeReport struct {
 	// Pods - pods created by play kube.
 	Pods []PlayKubePod
+	// Jobs - jobs run by play kube.
+	Jobs []PlayKubeJob
 	// Volumes - volumes created by play kube.
 	Volumes []PlayKubeVolume
 	PlayKubeTeardown
@@ -9,6 +9,7 @@ import (
 	"github.com/containers/podman/v4/libpod/define"
 	"github.com/containers/podman/v4/pkg/specgen"
 	"github.com/containers/podman/v4/pkg/util"
+	"github.com/containers/storage/pkg/archive"
 	"github.com/opencontainers/runtime-spec/specs-go"
 )
 
@@ -86,6 +87,75 @@ type PodRestartReport struct {
 	Id   string //nolint:revive,stylecheck
 }
 
+// PodUpdateOptions describes the changes to apply to an existing pod.  Nil
+// fields are left unchanged.
+type PodUpdateOptions struct {
+	NameOrID string
+	// Specgen holds the new resource limits of the pod and its
+	// containers.
+	Specgen        *specgen.SpecGenerator
+	RestartPolicy  *string
+	RestartRetries *uint
+	ExitPolicy     *string
+}
+
+type PodUpdateReport struct {
+	Errs []error
+	Id   string //nolint:revive,stylecheck
+}
+
+// PodCheckpointOptions are the options for checkpointing pods.
+type PodCheckpointOptions struct {
+	All    bool
+	Latest bool
+	// Export writes the checkpoint of the pod to the given archive.
+	Export string
+	// CreateImage creates an OCI image of the given name from the
+	// checkpoint of the pod.
+	CreateImage    string
+	Compression    archive.Compression
+	FileLocks      bool
+	IgnoreRootFS   bool
+	IgnoreVolumes  bool
+	Keep           bool
+	LeaveRunning   bool
+	PrintStats     bool
+	TCPEstablished bool
+}
+
+type PodCheckpointReport struct {
+	Err        error               `json:"-"`
+	Id         string              `json:"Id"` //nolint:revive,stylecheck
+	RawInput   string              `json:"-"`
+	Containers []*CheckpointReport `json:"containers"`
+}
+
+// PodRestoreOptions are the options for restoring pods.
+type PodRestoreOptions struct {
+	All    bool
+	Latest bool
+	// Import restores the pod from the given checkpoint archive.
+	Import string
+	// Name of the restored pod, when restoring from an archive or an
+	// image.
+	Name            string
+	FileLocks       bool
+	IgnoreRootFS    bool
+	IgnoreStaticIP  bool
+	IgnoreStaticMAC bool
+	IgnoreVolumes   bool
+	Keep            bool
+	PrintStats      bool
+	TCPEstablished  bool
+}
+
+type PodRestoreReport struct {
+	Err        error            `json:"-"`
+	Id         string           `json:"Id"` //nolint:revive,stylecheck
+	RawInput   string           `json:"-"`
+	Containers []*RestoreReport `json:"containers"`
+}
+
 type PodStartOptions struct {
 	All    bool
 	Latest bool
@@ -213,11 +283,18 @@ type ContainerCreateOptions struct {
 	GIDMap             []string
 	GroupAdd           []string
 	HealthCmd          string
+	HealthGRPC         string
+	HealthHTTPGet      string
+	HealthHTTPHeader   []string
+	HealthHTTPStatus   string
 	HealthInterval     string
 	HealthRetries      uint
 	HealthStartPeriod  string
+	HealthTCP          string
 	HealthTimeout      string
 	HealthOnFailure    string
+	HookPostStart      string
+	HookPreStop        string
 	Hostname           string `json:"hostname,omitempty"`
 	HTTPProxy          bool
 	HostUsers          []string
@@ -258,10 +335,14 @@ type ContainerCreateOptions struct {
 	ReadOnly           bool
 	ReadWriteTmpFS     bool
 	Restart            string
+	RestartDelay       string
+	RestartMaxDelay    string
 	Replace            bool
 	Requires           []string
+	RequiresTimeout    uint
 	Rm                 bool
 	RootFS             bool
+	Schedule           string
 	Secrets            []string
 	SecurityOpt        []string `json:"security_opt,omitempty"`
 	SdNotifyMode       string
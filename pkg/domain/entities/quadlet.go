@@ -0,0 +1,65 @@
+package entities
+
+// QuadletInstallOptions are the options for installing quadlet files.
+type QuadletInstallOptions struct {
+	// Replace files which are already installed.
+	Replace bool
+	// Reload the systemd daemon after installing, so the units are
+	// generated.
+	ReloadSystemd bool
+}
+
+// QuadletInstallReport contains the results of installing quadlet files.
+type QuadletInstallReport struct {
+	// Paths the quadlet files were installed to, keyed by their source.
+	InstalledQuadlets map[string]string
+	// Errors installing quadlet files, keyed by their source.
+	QuadletErrors map[string]error
+}
+
+// QuadletListOptions are the options for listing quadlet files.
+type QuadletListOptions struct {
+	// Also list quadlet files shadowed by a file of the same name in a
+	// directory with a higher precedence.
+	All bool
+}
+
+// ListQuadlet describes a quadlet file.
+type ListQuadlet struct {
+	// Name of the quadlet file.
+	Name string
+	// Name of the systemd unit generated for the quadlet file.
+	UnitName string
+	// Path to the quadlet file.
+	Path string
+	// Active state of the generated unit, as reported by systemd.
+	Status string
+}
+
+// QuadletRemoveOptions are the options for removing quadlet files.
+type QuadletRemoveOptions struct {
+	// Stop the generated unit if it is active.
+	Force bool
+	// Do not error for quadlet files which are not installed.
+	Ignore bool
+	// Reload the systemd daemon after removing, so the units are removed.
+	ReloadSystemd bool
+}
+
+// QuadletRemoveReport contains the results of removing quadlet files.
+type QuadletRemoveReport struct {
+	// Removed quadlet files.
+	Removed []string
+	// Errors removing quadlet files, keyed by their name.
+	Errors map[string]error
+}
+
+// QuadletValidateReport contains the results of validating a quadlet file.
+type QuadletValidateReport struct {
+	// Name of the quadlet file.
+	Name string
+	// Path to the quadlet file.
+	Path string
+	// Problems found in the quadlet file.  It is valid if empty.
+	Errors []string
+}
@@ -17,6 +17,31 @@ type SecretCreateOptions struct {
 	Replace    bool
 }
 
+// Supported values for SecretUpdateOptions.Propagate.
+const (
+	// SecretPropagateNone leaves containers using the secret untouched.
+	SecretPropagateNone = "none"
+	// SecretPropagateReinject rewrites the secret in all containers that
+	// mount it.
+	SecretPropagateReinject = "reinject"
+	// SecretPropagateRestart rewrites the secret in all containers that
+	// mount it and restarts the running containers that use it.
+	SecretPropagateRestart = "restart"
+)
+
+type SecretUpdateOptions struct {
+	// Propagate describes how the new data is propagated to containers
+	// using the secret: none (default), reinject or restart.
+	Propagate string
+}
+
+type SecretUpdateReport struct {
+	ID string
+	// Containers lists the IDs of the containers the new data was
+	// propagated to.
+	Containers []string `json:"Containers,omitempty"`
+}
+
 type SecretInspectOptions struct {
 	ShowSecret bool
 }
@@ -92,6 +117,15 @@ type SwagSecretCreateResponse struct {
 	}
 }
 
+// Secret update response
+// swagger:response SecretUpdateResponse
+type SwagSecretUpdateResponse struct {
+	// in:body
+	Body struct {
+		SecretUpdateReport
+	}
+}
+
 // Secret list response
 // swagger:response SecretListResponse
 type SwagSecretListResponse struct {
@@ -10,10 +10,14 @@ import (
 
 // ServiceOptions provides the input for starting an API and sidecar pprof services
 type ServiceOptions struct {
-	CorsHeaders string        // Cross-Origin Resource Sharing (CORS) headers
-	PProfAddr   string        // Network address to bind pprof profiles service
-	Timeout     time.Duration // Duration of inactivity the service should wait before shutting down
-	URI         string        // Path to unix domain socket service should listen on
+	CorsHeaders     string        // Cross-Origin Resource Sharing (CORS) headers
+	PProfAddr       string        // Network address to bind pprof profiles service
+	Timeout         time.Duration // Duration of inactivity the service should wait before shutting down
+	URI             string        // Path to unix domain socket service should listen on
+	TLSCertFile     string        // Path to PEM encoded server certificate, enables TLS
+	TLSKeyFile      string        // Path to PEM encoded private key for TLSCertFile
+	TLSClientCAFile string        // Path to PEM encoded CA bundle, require and verify client certificates
+	Metrics         bool          // Expose metrics in the Prometheus format on /metrics
 }
 
 // SystemPruneOptions provides options to prune system.
@@ -38,6 +42,106 @@ type SystemPruneReport struct {
 // cli to migrate runtimes of containers
 type SystemMigrateOptions struct {
 	NewRuntime string
+	// DatabaseBackend is the database backend to migrate the database to.
+	DatabaseBackend string
+	// DryRun only reports what the database migration would copy.
+	DryRun bool
+}
+
+// SystemMigrateReport describes the migration of the database to another
+// database backend.
+type SystemMigrateReport struct {
+	// From is the database backend used before the migration.
+	From string
+	// To is the database backend used after the migration.
+	To string
+	// Path is the path of the database of the new backend.
+	Path string
+	// Backup is the path an already existing database of the new backend
+	// is moved to, if there is one.
+	Backup string
+	// DryRun is set if nothing was copied.
+	DryRun       bool
+	Containers   int
+	Pods         int
+	Volumes      int
+	ExecSessions int
+	Networks     int
+	ExitCodes    int
+}
+
+// SystemBackupOptions describes the options for backing up the containers,
+// pods, networks, volumes and secrets of the host.
+type SystemBackupOptions struct {
+	// IncludeImages stores the images used by the containers in the
+	// backup, instead of only their names.
+	IncludeImages bool
+	// Passphrase encrypts the data of the secrets in the backup.  If
+	// empty, the data is stored unencrypted.
+	Passphrase []byte
+}
+
+// SystemBackupReport lists the names of everything stored in a backup.
+type SystemBackupReport struct {
+	Containers []string
+	Pods       []string
+	Networks   []string
+	Volumes    []string
+	Secrets    []string
+	Images     []string
+}
+
+// SystemRestoreOptions describes the options for restoring a backup created
+// by SystemBackup.
+type SystemRestoreOptions struct {
+	// NameMap renames containers, pods, networks, volumes and secrets.
+	// Keys are either the old name, which renames everything of that
+	// name, or KIND:NAME with KIND being container, pod, network, volume
+	// or secret.
+	NameMap map[string]string
+	// PathMap replaces prefixes of host paths, of bind mounts for
+	// example, with other paths.
+	PathMap map[string]string
+	// UIDMap and GIDMap replace the owners of the files in volumes and the
+	// owners set by the options of volumes.
+	UIDMap map[int]int
+	GIDMap map[int]int
+	// Passphrase decrypts the data of the secrets in the backup.
+	Passphrase []byte
+}
+
+// SystemRestoreReport lists the names of everything restored from a backup.
+type SystemRestoreReport SystemBackupReport
+
+// SystemCheckOptions describes the options for checking and repairing the
+// database and the storage.
+type SystemCheckOptions struct {
+	// Quick skips the slow checks of the contents of layers.
+	Quick bool
+	// Repair repairs the problems which can be repaired without losing
+	// data.
+	Repair bool
+	// RepairLossy also removes damaged containers and containers in
+	// storage which Podman does not know.
+	RepairLossy bool
+	// UnreferencedLayerMaximumAge is the age a layer no image or
+	// container uses must reach before it is reported.
+	UnreferencedLayerMaximumAge *time.Duration
+}
+
+// SystemCheckProblem describes a problem found by SystemCheck.
+type SystemCheckProblem struct {
+	Category    string
+	ID          string
+	Description string
+	Repaired    bool
+	// RepairError is set if repairing the problem failed.
+	RepairError string
+}
+
+// SystemCheckReport lists the problems found by SystemCheck.
+type SystemCheckReport struct {
+	Problems []SystemCheckProblem
 }
 
 // SystemDfOptions describes the options for getting df information
@@ -85,6 +189,8 @@ type SystemDfVolumeReport struct {
 	Links           int
 	Size            int64
 	ReclaimableSize int64
+	Snapshots       int
+	SnapshotsSize   int64
 }
 
 // SystemVersionReport describes version information about the running Podman service
@@ -2,6 +2,7 @@ package entities
 
 import (
 	"net/url"
+	"time"
 
 	"github.com/containers/podman/v4/libpod/define"
 )
@@ -57,6 +58,36 @@ type VolumeListReport struct {
 	VolumeConfigResponse
 }
 
+// VolumeSnapshotCreateOptions describes the options for creating a snapshot
+// of a volume
+type VolumeSnapshotCreateOptions struct {
+	// Name of the snapshot. Generated from the current time if empty
+	Name string
+	// Pause the running containers using the volume while creating the
+	// snapshot
+	Pause bool
+}
+
+// VolumeSnapshotReport describes a snapshot of a volume
+type VolumeSnapshotReport struct {
+	// Name of the volume
+	Volume string
+	// Name of the snapshot
+	Name string
+	// Time the snapshot was created at
+	CreatedAt time.Time
+	// Size of the snapshot in bytes
+	Size int64
+	// IDs of the containers paused while creating the snapshot
+	PausedContainers []string `json:",omitempty"`
+}
+
+// VolumeSnapshotRmReport describes the response from removing a snapshot
+type VolumeSnapshotRmReport struct {
+	Err  error
+	Name string
+}
+
 // VolumeReloadReport describes the response from reload volume plugins
 type VolumeReloadReport struct {
 	define.VolumeReload
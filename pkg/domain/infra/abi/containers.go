@@ -1750,6 +1750,8 @@ func (ic *ContainerEngine) ContainerClone(ctx context.Context, ctrCloneOpts enti
 
 // ContainerUpdate finds and updates the given container's cgroup config with the specified options
 func (ic *ContainerEngine) ContainerUpdate(ctx context.Context, updateOptions *entities.ContainerUpdateOptions) (string, error) {
+	// Without resource limits, only the restart policy is updated.
+	updateResources := updateOptions.Specgen.ResourceLimits != nil
 	err := specgen.WeightDevices(updateOptions.Specgen)
 	if err != nil {
 		return "", err
@@ -1766,8 +1768,84 @@ func (ic *ContainerEngine) ContainerUpdate(ctx context.Context, updateOptions *e
 		return "", fmt.Errorf("container not found")
 	}
 
-	if err = containers[0].Update(updateOptions.Specgen.ResourceLimits); err != nil {
-		return "", err
+	// Validate the restart policy first, so that an invalid policy does
+	// not leave the container with updated resources.
+	var retries uint
+	if updateOptions.Specgen.RestartRetries != nil {
+		retries = *updateOptions.Specgen.RestartRetries
+	}
+	if updateOptions.Specgen.RestartPolicy != "" {
+		if err = containers[0].ValidateRestartPolicy(updateOptions.Specgen.RestartPolicy, retries); err != nil {
+			return "", err
+		}
+	}
+
+	if updateResources {
+		if err = containers[0].Update(updateOptions.Specgen.ResourceLimits); err != nil {
+			return "", err
+		}
+	}
+	if updateOptions.Specgen.RestartPolicy != "" {
+		if err = containers[0].UpdateRestartPolicy(updateOptions.Specgen.RestartPolicy, retries); err != nil {
+			return "", err
+		}
 	}
 	return containers[0].ID(), nil
 }
+
+// ContainerScheduleList lists the containers started on a schedule.
+func (ic *ContainerEngine) ContainerScheduleList(ctx context.Context) ([]*entities.ContainerScheduleReport, error) {
+	ctrs, err := ic.Libpod.GetContainers(false, func(c *libpod.Container) bool {
+		return c.Schedule() != ""
+	})
+	if err != nil {
+		return nil, err
+	}
+	reports := make([]*entities.ContainerScheduleReport, 0, len(ctrs))
+	for _, ctr := range ctrs {
+		data, err := ctr.Inspect(false)
+		if err != nil {
+			// The container may have been removed in the meantime.
+			if errors.Is(err, define.ErrNoSuchCtr) || errors.Is(err, define.ErrCtrRemoved) {
+				continue
+			}
+			return nil, err
+		}
+		reports = append(reports, scheduleReport(data))
+	}
+	return reports, nil
+}
+
+// ContainerScheduleRun starts a container the way its schedule does.
+func (ic *ContainerEngine) ContainerScheduleRun(ctx context.Context, nameOrID string) error {
+	ctr, err := ic.Libpod.LookupContainer(nameOrID)
+	if err != nil {
+		return err
+	}
+	return ctr.RunScheduled(ctx)
+}
+
+func scheduleReport(data *define.InspectContainerData) *entities.ContainerScheduleReport {
+	report := &entities.ContainerScheduleReport{
+		ID:       data.ID,
+		Name:     data.Name,
+		Schedule: data.Config.Schedule,
+		State:    data.State.Status,
+	}
+	if schedule := data.State.Schedule; schedule != nil {
+		report.LastRun = schedule.LastRun
+		report.LastExitCode = schedule.LastExitCode
+		report.NextRun = schedule.NextRun
+	}
+	return report
+}
+
+// ContainerRotateLog rotates the log file of a container if it reached its
+// maximum size.
+func (ic *ContainerEngine) ContainerRotateLog(ctx context.Context, nameOrID string) error {
+	ctr, err := ic.Libpod.LookupContainer(nameOrID)
+	if err != nil {
+		return err
+	}
+	return ctr.RotateLog()
+}
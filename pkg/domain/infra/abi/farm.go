@@ -5,6 +5,7 @@ package abi
 import (
 	"context"
 	"fmt"
+	goruntime "runtime"
 	"strings"
 
 	"github.com/containers/buildah/pkg/parse"
@@ -43,14 +44,25 @@ func (ir *ImageEngine) fetchInfo(_ context.Context) (os, arch, variant string, n
 	return os, arch, variant, append([]string{}, nativePlatform), emulatedPlatforms, nil
 }
 
-// FarmNodeInspect returns information about the remote engines in the farm
+// FarmNodeInspect returns information about the local engine in the farm.
+// The platforms of the engine are only retrieved once, its load every time.
 func (ir *ImageEngine) FarmNodeInspect(ctx context.Context) (*entities.FarmInspectReport, error) {
 	ir.platforms.Do(func() {
 		ir.os, ir.arch, ir.variant, ir.nativePlatforms, ir.emulatedPlatforms, ir.platformsErr = ir.fetchInfo(ctx)
 	})
-	return &entities.FarmInspectReport{NativePlatforms: ir.nativePlatforms,
+	report := &entities.FarmInspectReport{NativePlatforms: ir.nativePlatforms,
 		EmulatedPlatforms: ir.emulatedPlatforms,
 		OS:                ir.os,
 		Arch:              ir.arch,
-		Variant:           ir.variant}, ir.platformsErr
+		Variant:           ir.variant,
+		CPUs:              goruntime.NumCPU()}
+	if ir.platformsErr != nil {
+		return report, ir.platformsErr
+	}
+	running, err := ir.Libpod.GetRunningContainers()
+	if err != nil {
+		return report, fmt.Errorf("retrieving running containers: %w", err)
+	}
+	report.RunningContainers = len(running)
+	return report, nil
 }
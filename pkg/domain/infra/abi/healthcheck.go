@@ -8,6 +8,9 @@ import (
 )
 
 func (ic *ContainerEngine) HealthCheckRun(ctx context.Context, nameOrID string, options entities.HealthCheckOptions) (*define.HealthCheckResults, error) {
+	if options.Readiness {
+		return ic.readinessCheckRun(ctx, nameOrID)
+	}
 	status, err := ic.Libpod.HealthCheck(ctx, nameOrID)
 	if err != nil {
 		return nil, err
@@ -23,3 +26,18 @@ func (ic *ContainerEngine) HealthCheckRun(ctx context.Context, nameOrID string,
 	}
 	return &report, nil
 }
+
+func (ic *ContainerEngine) readinessCheckRun(ctx context.Context, nameOrID string) (*define.HealthCheckResults, error) {
+	status, err := ic.Libpod.ReadinessCheck(ctx, nameOrID)
+	if err != nil {
+		return nil, err
+	}
+	hcStatus := define.HealthCheckUnready
+	if status == define.HealthCheckSuccess {
+		hcStatus = define.HealthCheckReady
+	}
+	report := define.HealthCheckResults{
+		Status: hcStatus,
+	}
+	return &report, nil
+}
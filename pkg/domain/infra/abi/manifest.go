@@ -12,6 +12,7 @@ import (
 
 	"github.com/containers/common/libimage"
 	cp "github.com/containers/image/v5/copy"
+	"github.com/containers/image/v5/image"
 	"github.com/containers/image/v5/manifest"
 	"github.com/containers/image/v5/pkg/compression"
 	"github.com/containers/image/v5/pkg/shortnames"
@@ -102,13 +103,18 @@ func (ir *ImageEngine) ManifestInspect(ctx context.Context, name string, opts en
 	return b.Bytes(), nil
 }
 
-// inspect a remote manifest list.
-func (ir *ImageEngine) remoteManifestInspect(ctx context.Context, name string, opts entities.ManifestInspectOptions) ([]byte, error) {
+// remoteManifest fetches the manifest of an image or manifest list from a
+// registry, trying all candidates of a short name.  The returned image source
+// must be closed by the caller.
+func (ir *ImageEngine) remoteManifest(ctx context.Context, name string, opts entities.ManifestInspectOptions) (types.ImageSource, []byte, string, error) {
 	sys := ir.Libpod.SystemContext()
 
 	if opts.Authfile != "" {
 		sys.AuthFilePath = opts.Authfile
 	}
+	if opts.Credentials != nil {
+		sys.DockerAuthConfig = opts.Credentials
+	}
 
 	sys.DockerInsecureSkipTLSVerify = opts.SkipTLSVerify
 	if opts.SkipTLSVerify == types.OptionalBoolTrue {
@@ -117,15 +123,10 @@ func (ir *ImageEngine) remoteManifestInspect(ctx context.Context, name string, o
 
 	resolved, err := shortnames.Resolve(sys, name)
 	if err != nil {
-		return nil, err
+		return nil, nil, "", err
 	}
 
-	var (
-		latestErr error
-		result    []byte
-		manType   string
-		b         bytes.Buffer
-	)
+	var latestErr error
 	appendErr := func(e error) {
 		if latestErr == nil {
 			latestErr = e
@@ -140,30 +141,38 @@ func (ir *ImageEngine) remoteManifestInspect(ctx context.Context, name string, o
 	for _, candidate := range resolved.PullCandidates {
 		ref, err := alltransports.ParseImageName("docker://" + candidate.Value.String())
 		if err != nil {
-			return nil, err
+			return nil, nil, "", err
 		}
 		src, err := ref.NewImageSource(ctx, sys)
 		if err != nil {
 			appendErr(fmt.Errorf("reading image %q: %w", transports.ImageName(ref), err))
 			continue
 		}
-		defer src.Close()
 
 		manifestBytes, manifestType, err := src.GetManifest(ctx, nil)
 		if err != nil {
+			src.Close()
 			appendErr(fmt.Errorf("loading manifest %q: %w", transports.ImageName(ref), err))
 			continue
 		}
+		return src, manifestBytes, manifestType, nil
+	}
 
-		result = manifestBytes
-		manType = manifestType
-		break
+	if latestErr == nil {
+		latestErr = fmt.Errorf("no candidates to inspect for %q", name)
 	}
+	return nil, nil, "", latestErr
+}
 
-	if len(result) == 0 && latestErr != nil {
-		return nil, latestErr
+// inspect a remote manifest list.
+func (ir *ImageEngine) remoteManifestInspect(ctx context.Context, name string, opts entities.ManifestInspectOptions) ([]byte, error) {
+	src, result, manType, err := ir.remoteManifest(ctx, name, opts)
+	if err != nil {
+		return nil, err
 	}
+	src.Close()
 
+	var b bytes.Buffer
 	switch manType {
 	case manifest.DockerV2Schema2MediaType:
 		logrus.Warnf("The manifest type %s is not a manifest list but a single image.", manType)
@@ -194,6 +203,58 @@ func (ir *ImageEngine) remoteManifestInspect(ctx context.Context, name string, o
 	return b.Bytes(), nil
 }
 
+// ManifestDistributionInspect returns the descriptor of the manifest of an
+// image or manifest list in a registry, and the platforms it supports.
+func (ir *ImageEngine) ManifestDistributionInspect(ctx context.Context, name string, opts entities.ManifestInspectOptions) (*entities.ManifestDistributionReport, error) {
+	src, manifestBytes, manType, err := ir.remoteManifest(ctx, name, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer src.Close()
+
+	report := &entities.ManifestDistributionReport{
+		Descriptor: imgspecv1.Descriptor{
+			MediaType: manType,
+			Digest:    digest.FromBytes(manifestBytes),
+			Size:      int64(len(manifestBytes)),
+		},
+		Platforms: []imgspecv1.Platform{},
+	}
+
+	if manifest.MIMETypeIsMultiImage(manType) {
+		list, err := manifest.ListFromBlob(manifestBytes, manType)
+		if err != nil {
+			return nil, fmt.Errorf("parsing manifest list of %s: %w", name, err)
+		}
+		for _, instance := range list.Instances() {
+			update, err := list.Instance(instance)
+			if err != nil {
+				return nil, err
+			}
+			if update.ReadOnly.Platform != nil {
+				report.Platforms = append(report.Platforms, *update.ReadOnly.Platform)
+			}
+		}
+		return report, nil
+	}
+
+	img, err := image.FromUnparsedImage(ctx, ir.Libpod.SystemContext(), image.UnparsedInstance(src, nil))
+	if err != nil {
+		return nil, fmt.Errorf("parsing manifest of %s: %w", name, err)
+	}
+	config, err := img.OCIConfig(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("reading image config of %s: %w", name, err)
+	}
+	report.Platforms = append(report.Platforms, imgspecv1.Platform{
+		Architecture: config.Architecture,
+		OS:           config.OS,
+		OSVersion:    config.OSVersion,
+		Variant:      config.Variant,
+	})
+	return report, nil
+}
+
 // ManifestAdd adds images to the manifest list
 func (ir *ImageEngine) ManifestAdd(ctx context.Context, name string, images []string, opts entities.ManifestAddOptions) (string, error) {
 	if len(images) < 1 {
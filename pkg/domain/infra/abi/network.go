@@ -14,13 +14,116 @@ import (
 )
 
 func (ic *ContainerEngine) NetworkUpdate(ctx context.Context, netName string, options entities.NetworkUpdateOptions) error {
-	var networkUpdateOptions types.NetworkUpdateOptions
-	networkUpdateOptions.AddDNSServers = options.AddDNSServers
-	networkUpdateOptions.RemoveDNSServers = options.RemoveDNSServers
-	err := ic.Libpod.Network().NetworkUpdate(netName, networkUpdateOptions)
+	// The network backend only updates the DNS servers in place, all other
+	// changes require the network to be recreated.
+	if !networkUpdateRecreates(options) {
+		var networkUpdateOptions types.NetworkUpdateOptions
+		networkUpdateOptions.AddDNSServers = options.AddDNSServers
+		networkUpdateOptions.RemoveDNSServers = options.RemoveDNSServers
+		err := ic.Libpod.Network().NetworkUpdate(netName, networkUpdateOptions)
+		if err != nil {
+			return err
+		}
+		return nil
+	}
+
+	network, err := ic.Libpod.Network().NetworkInspect(netName)
 	if err != nil {
 		return err
 	}
+	if err := applyNetworkUpdate(&network, options); err != nil {
+		return err
+	}
+	return ic.Libpod.UpdateNetwork(network)
+}
+
+// networkUpdateRecreates returns whether the update requires the network to
+// be recreated.
+func networkUpdateRecreates(options entities.NetworkUpdateOptions) bool {
+	return len(options.AddLabels) > 0 || len(options.RemoveLabels) > 0 ||
+		len(options.AddOptions) > 0 || len(options.RemoveOptions) > 0 ||
+		len(options.AddRoutes) > 0 || len(options.RemoveRoutes) > 0 ||
+		len(options.AddSubnets) > 0 || options.Internal != nil || options.DisableDNS != nil
+}
+
+// applyNetworkUpdate applies the changes of the update options to the
+// configuration of the network.
+func applyNetworkUpdate(network *types.Network, options entities.NetworkUpdateOptions) error {
+	servers := make([]string, 0, len(network.NetworkDNSServers)+len(options.AddDNSServers))
+	for _, server := range network.NetworkDNSServers {
+		if !slices.Contains(options.RemoveDNSServers, server) {
+			servers = append(servers, server)
+		}
+	}
+	for _, server := range options.AddDNSServers {
+		if !slices.Contains(servers, server) {
+			servers = append(servers, server)
+		}
+	}
+	network.NetworkDNSServers = servers
+
+	if network.Labels == nil {
+		network.Labels = make(map[string]string, len(options.AddLabels))
+	}
+	for _, key := range options.RemoveLabels {
+		delete(network.Labels, key)
+	}
+	for key, value := range options.AddLabels {
+		network.Labels[key] = value
+	}
+
+	if network.Options == nil {
+		network.Options = make(map[string]string, len(options.AddOptions))
+	}
+	for _, key := range options.RemoveOptions {
+		delete(network.Options, key)
+	}
+	for key, value := range options.AddOptions {
+		network.Options[key] = value
+	}
+
+	for _, destination := range options.RemoveRoutes {
+		dst, err := types.ParseCIDR(destination)
+		if err != nil {
+			return fmt.Errorf("invalid route destination %s: %v: %w", destination, err, define.ErrInvalidArg)
+		}
+		network.Routes = slices.DeleteFunc(network.Routes, func(route types.Route) bool {
+			return route.Destination.String() == dst.String()
+		})
+	}
+	for _, route := range options.AddRoutes {
+		i := slices.IndexFunc(network.Routes, func(r types.Route) bool {
+			return r.Destination.String() == route.Destination.String()
+		})
+		if i < 0 {
+			network.Routes = append(network.Routes, route)
+			continue
+		}
+		network.Routes[i] = route
+	}
+
+	for _, subnet := range options.AddSubnets {
+		i := slices.IndexFunc(network.Subnets, func(s types.Subnet) bool {
+			return s.Subnet.String() == subnet.Subnet.String()
+		})
+		if i < 0 {
+			network.Subnets = append(network.Subnets, subnet)
+			continue
+		}
+		if subnet.Gateway != nil {
+			network.Subnets[i].Gateway = subnet.Gateway
+		}
+		if subnet.LeaseRange != nil {
+			network.Subnets[i].LeaseRange = subnet.LeaseRange
+		}
+	}
+
+	if options.Internal != nil {
+		network.Internal = *options.Internal
+	}
+	if options.DisableDNS != nil {
+		network.DNSEnabled = !*options.DisableDNS
+	}
 	return nil
 }
 
@@ -0,0 +1,66 @@
+package abi
+
+import (
+	"net"
+	"testing"
+
+	"github.com/containers/common/libnetwork/types"
+	"github.com/containers/podman/v4/pkg/domain/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func mustParseCIDR(t *testing.T, cidr string) types.IPNet {
+	n, err := types.ParseCIDR(cidr)
+	assert.NoError(t, err)
+	return n
+}
+
+func TestApplyNetworkUpdate(t *testing.T) {
+	internal := true
+	network := types.Network{
+		Name:              "net1",
+		DNSEnabled:        true,
+		NetworkDNSServers: []string{"1.1.1.1", "8.8.8.8"},
+		Labels:            map[string]string{"a": "1", "b": "2"},
+		Subnets: []types.Subnet{{
+			Subnet:  mustParseCIDR(t, "10.89.0.0/24"),
+			Gateway: net.ParseIP("10.89.0.1"),
+		}},
+		Routes: []types.Route{
+			{Destination: mustParseCIDR(t, "10.10.0.0/16"), Gateway: net.ParseIP("10.89.0.2")},
+			{Destination: mustParseCIDR(t, "10.20.0.0/16"), Gateway: net.ParseIP("10.89.0.2")},
+		},
+	}
+	leaseRange := &types.LeaseRange{StartIP: net.ParseIP("10.89.0.100"), EndIP: net.ParseIP("10.89.0.200")}
+
+	err := applyNetworkUpdate(&network, entities.NetworkUpdateOptions{
+		AddDNSServers:    []string{"9.9.9.9", "8.8.8.8"},
+		RemoveDNSServers: []string{"1.1.1.1"},
+		AddLabels:        map[string]string{"b": "3", "c": "4"},
+		RemoveLabels:     []string{"a"},
+		AddOptions:       map[string]string{"mtu": "1400"},
+		AddRoutes:        []types.Route{{Destination: mustParseCIDR(t, "10.20.0.0/16"), Gateway: net.ParseIP("10.89.0.3")}},
+		RemoveRoutes:     []string{"10.10.0.0/16"},
+		AddSubnets: []types.Subnet{
+			{Subnet: mustParseCIDR(t, "10.89.0.0/24"), LeaseRange: leaseRange},
+			{Subnet: mustParseCIDR(t, "fd00::/64")},
+		},
+		Internal:   &internal,
+		DisableDNS: &internal,
+	})
+	assert.NoError(t, err)
+
+	assert.Equal(t, []string{"8.8.8.8", "9.9.9.9"}, network.NetworkDNSServers)
+	assert.Equal(t, map[string]string{"b": "3", "c": "4"}, network.Labels)
+	assert.Equal(t, map[string]string{"mtu": "1400"}, network.Options)
+	assert.Equal(t, []types.Route{{Destination: mustParseCIDR(t, "10.20.0.0/16"), Gateway: net.ParseIP("10.89.0.3")}}, network.Routes)
+	assert.Len(t, network.Subnets, 2)
+	assert.Equal(t, net.ParseIP("10.89.0.1"), network.Subnets[0].Gateway)
+	assert.Equal(t, leaseRange, network.Subnets[0].LeaseRange)
+	assert.Equal(t, "fd00::/64", network.Subnets[1].Subnet.String())
+	assert.True(t, network.Internal)
+	assert.False(t, network.DNSEnabled)
+
+	err = applyNetworkUpdate(&network, entities.NetworkUpdateOptions{RemoveRoutes: []string{"bogus"}})
+	assert.Error(t, err)
+}
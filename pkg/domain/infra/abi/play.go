@@ -11,6 +11,7 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	buildahDefine "github.com/containers/buildah/define"
 	bparse "github.com/containers/buildah/pkg/parse"
@@ -25,6 +26,7 @@ import (
 	"github.com/containers/podman/v4/libpod/define"
 	"github.com/containers/podman/v4/pkg/domain/entities"
 	v1apps "github.com/containers/podman/v4/pkg/k8s.io/api/apps/v1"
+	v1batch "github.com/containers/podman/v4/pkg/k8s.io/api/batch/v1"
 	v1 "github.com/containers/podman/v4/pkg/k8s.io/api/core/v1"
 	metav1 "github.com/containers/podman/v4/pkg/k8s.io/apimachinery/pkg/apis/meta/v1"
 	"github.com/containers/podman/v4/pkg/specgen"
@@ -38,6 +40,7 @@ import (
 	"github.com/opencontainers/go-digest"
 	"github.com/opencontainers/selinux/go-selinux"
 	"github.com/sirupsen/logrus"
+	"golang.org/x/exp/slices"
 	yamlv3 "gopkg.in/yaml.v3"
 	"k8s.io/kubernetes/third_party/forked/golang/expansion"
 	"sigs.k8s.io/yaml"
@@ -211,7 +214,7 @@ func (ic *ContainerEngine) PlayKube(ctx context.Context, body io.Reader, options
 		}
 
 		// TODO: create constants for the various "kinds" of yaml files.
-		if options.ServiceContainer && serviceContainer == nil && (kind == "Pod" || kind == "Deployment") {
+		if options.ServiceContainer && serviceContainer == nil && (kind == "Pod" || kind == "Deployment" || kind == "Job") {
 			ctr, err := ic.createServiceContainer(ctx, k8sName(content, "service"), options)
 			if err != nil {
 				return nil, err
@@ -297,6 +300,23 @@ func (ic *ContainerEngine) PlayKube(ctx context.Context, body io.Reader, options
 			report.Pods = append(report.Pods, r.Pods...)
 			validKinds++
 			ranContainers = true
+		case "Job":
+			var jobYAML v1batch.Job
+
+			if err := yaml.Unmarshal(document, &jobYAML); err != nil {
+				return nil, fmt.Errorf("unable to read YAML as Kube Job: %w", err)
+			}
+
+			r, proxies, err := ic.playKubeJob(ctx, &jobYAML, options, &ipIndex, configMaps, serviceContainer)
+			if err != nil {
+				return nil, err
+			}
+			notifyProxies = append(notifyProxies, proxies...)
+
+			report.Pods = append(report.Pods, r.Pods...)
+			report.Jobs = append(report.Jobs, r.Jobs...)
+			validKinds++
+			ranContainers = true
 		case "PersistentVolumeClaim":
 			var pvcYAML v1.PersistentVolumeClaim
 
@@ -452,6 +472,278 @@ func (ic *ContainerEngine) playKubeDeployment(ctx context.Context, deploymentYAM
 	return &report, proxies, nil
 }
 
+// jobBackoff is the delay before a failed job pod is retried.  Like in
+// Kubernetes, it doubles with every failure up to maxJobBackoff.
+const (
+	jobBackoff    = 10 * time.Second
+	maxJobBackoff = 6 * time.Minute
+)
+
+// jobAttempt is the outcome of running a single pod of a job.
+type jobAttempt struct {
+	pod       *libpod.Pod
+	succeeded bool
+	err       error
+}
+
+// playKubeJob plays a Kubernetes Job.  Podman has no controller that outlives
+// the command, so by default the pods of the job are only started, and libpod
+// restarts them according to the restart policy of the job.  With --wait, the
+// pods are started, restarted or replaced by kube play itself until the
+// requested number of completions is reached, the backoff limit is exceeded,
+// or the active deadline passes.
+func (ic *ContainerEngine) playKubeJob(ctx context.Context, jobYAML *v1batch.Job, options entities.PlayKubeOptions, ipIndex *int, configMaps []v1.ConfigMap, serviceContainer *libpod.Container) (*entities.PlayKubeReport, []*notifyproxy.NotifyProxy, error) {
+	var (
+		report  entities.PlayKubeReport
+		proxies []*notifyproxy.NotifyProxy
+	)
+
+	jobName := jobYAML.ObjectMeta.Name
+	if jobName == "" {
+		return nil, nil, errors.New("job does not have a name")
+	}
+
+	restartPolicy := jobYAML.Spec.Template.Spec.RestartPolicy
+	switch restartPolicy {
+	case v1.RestartPolicyNever, v1.RestartPolicyOnFailure:
+	default:
+		return nil, nil, fmt.Errorf("job %s: restartPolicy must be %q or %q, not %q", jobName, v1.RestartPolicyNever, v1.RestartPolicyOnFailure, restartPolicy)
+	}
+
+	if mode := jobYAML.Spec.CompletionMode; mode != nil && *mode != v1batch.NonIndexedCompletion {
+		return nil, nil, fmt.Errorf("job %s: completionMode %q is not supported", jobName, *mode)
+	}
+
+	completions, parallelism, backoffLimit := int32(1), int32(1), int32(6)
+	if jobYAML.Spec.Completions != nil {
+		completions = *jobYAML.Spec.Completions
+	}
+	if jobYAML.Spec.Parallelism != nil {
+		parallelism = *jobYAML.Spec.Parallelism
+	}
+	if jobYAML.Spec.BackoffLimit != nil {
+		backoffLimit = *jobYAML.Spec.BackoffLimit
+	}
+	if completions < 1 || parallelism < 1 || backoffLimit < 0 {
+		return nil, nil, fmt.Errorf("job %s: completions and parallelism must be positive and backoffLimit must not be negative", jobName)
+	}
+	if parallelism > completions {
+		parallelism = completions
+	}
+
+	// Retries are handled below, so libpod must not restart the containers
+	// on its own.  The job name label allows kube down to find all pods of
+	// the job.
+	podSpec := jobYAML.Spec.Template
+	podSpec.Spec.RestartPolicy = v1.RestartPolicyNever
+	podSpec.ObjectMeta.Labels = make(map[string]string, len(jobYAML.Spec.Template.Labels)+1)
+	for k, v := range jobYAML.Spec.Template.Labels {
+		podSpec.ObjectMeta.Labels[k] = v
+	}
+	podSpec.ObjectMeta.Labels[v1batch.JobNameLabel] = jobName
+
+	podOptions := options
+	podOptions.Start = types.OptionalBoolFalse
+
+	job := entities.PlayKubeJob{Name: jobName}
+	createPod := func() (*libpod.Pod, error) {
+		podName := fmt.Sprintf("%s-pod-%d", jobName, len(job.Pods))
+		r, p, err := ic.playKubePod(ctx, podName, &podSpec, podOptions, ipIndex, jobYAML.Annotations, configMaps, serviceContainer)
+		if err != nil {
+			return nil, fmt.Errorf("encountered while bringing up pod %s: %w", podName, err)
+		}
+		proxies = append(proxies, p...)
+		report.Pods = append(report.Pods, r.Pods...)
+		job.Pods = append(job.Pods, r.Pods[0].ID)
+		return ic.Libpod.LookupPod(r.Pods[0].ID)
+	}
+
+	if options.Start == types.OptionalBoolFalse {
+		for i := int32(0); i < parallelism; i++ {
+			if _, err := createPod(); err != nil {
+				return nil, nil, err
+			}
+		}
+		report.Jobs = append(report.Jobs, job)
+		return &report, proxies, nil
+	}
+
+	if !options.Wait {
+		// Nobody is left to create more pods or to stop them once the
+		// deadline passes, so the job must be done by the first pods.
+		if completions > parallelism {
+			return nil, nil, fmt.Errorf("job %s: %d completions with a parallelism of %d require --wait", jobName, completions, parallelism)
+		}
+		if jobYAML.Spec.ActiveDeadlineSeconds != nil {
+			return nil, nil, fmt.Errorf("job %s: activeDeadlineSeconds requires --wait", jobName)
+		}
+		for i := int32(0); i < parallelism; i++ {
+			pod, err := createPod()
+			if err != nil {
+				return nil, nil, err
+			}
+			if err := startJobPod(ctx, pod, restartPolicy, backoffLimit); err != nil {
+				return nil, nil, fmt.Errorf("starting pod %s of job %s: %w", pod.Name(), jobName, err)
+			}
+		}
+		report.Jobs = append(report.Jobs, job)
+		return &report, proxies, nil
+	}
+
+	runCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	if deadline := jobYAML.Spec.ActiveDeadlineSeconds; deadline != nil {
+		runCtx, cancel = context.WithTimeout(runCtx, time.Duration(*deadline)*time.Second)
+		defer cancel()
+	}
+
+	results := make(chan jobAttempt)
+	active := make(map[string]*libpod.Pod)
+	run := func(pod *libpod.Pod, delay time.Duration) {
+		active[pod.ID()] = pod
+		go func() {
+			results <- runJobPod(runCtx, pod, delay)
+		}()
+	}
+
+	var runErr error
+	backoff := jobBackoff
+	for job.Succeeded < completions && job.Reason == "" && runErr == nil {
+		for int32(len(active)) < parallelism && job.Succeeded+int32(len(active)) < completions {
+			pod, err := createPod()
+			if err != nil {
+				runErr = err
+				break
+			}
+			run(pod, 0)
+		}
+		if runErr != nil {
+			break
+		}
+
+		attempt := <-results
+		delete(active, attempt.pod.ID())
+		switch {
+		case attempt.err != nil && errors.Is(runCtx.Err(), context.DeadlineExceeded):
+			job.Reason = v1batch.JobReasonDeadlineExceeded
+		case attempt.err != nil:
+			runErr = fmt.Errorf("running pod %s of job %s: %w", attempt.pod.Name(), jobName, attempt.err)
+		case attempt.succeeded:
+			job.Succeeded++
+		default:
+			job.Failed++
+			if job.Failed > backoffLimit {
+				job.Reason = v1batch.JobReasonBackoffLimitExceeded
+				break
+			}
+			logrus.Infof("Pod %s of job %s failed, retrying in %s", attempt.pod.Name(), jobName, backoff)
+			pod := attempt.pod
+			if restartPolicy == v1.RestartPolicyNever {
+				// Failed pods are not restarted, the next attempt runs in a new pod.
+				var err error
+				if pod, err = createPod(); err != nil {
+					runErr = err
+					break
+				}
+			}
+			run(pod, backoff)
+			if backoff *= 2; backoff > maxJobBackoff {
+				backoff = maxJobBackoff
+			}
+		}
+	}
+
+	// Stop whatever is still running, e.g., after the deadline passed.
+	cancel()
+	for _, pod := range active {
+		if _, err := pod.Stop(ctx, true); err != nil && !errors.Is(err, define.ErrPodPartialFail) {
+			logrus.Errorf("Stopping pod %s of job %s: %v", pod.Name(), jobName, err)
+		}
+		<-results
+	}
+	if runErr != nil {
+		return nil, nil, runErr
+	}
+
+	job.Completed = job.Succeeded >= completions
+	report.Jobs = append(report.Jobs, job)
+	return &report, proxies, nil
+}
+
+// startJobPod starts a pod of a job which is not waited for.  Failed
+// containers of an OnFailure job are restarted by libpod up to backoffLimit
+// times.
+func startJobPod(ctx context.Context, pod *libpod.Pod, restartPolicy v1.RestartPolicy, backoffLimit int32) error {
+	if restartPolicy == v1.RestartPolicyOnFailure && backoffLimit > 0 {
+		ctrs, err := pod.AllContainers()
+		if err != nil {
+			return err
+		}
+		for _, ctr := range ctrs {
+			if ctr.IsInfra() || ctr.IsInitCtr() {
+				continue
+			}
+			if err := ctr.UpdateRestartPolicy(define.RestartPolicyOnFailure, uint(backoffLimit)); err != nil {
+				return err
+			}
+		}
+	}
+
+	startErrors, err := pod.Start(ctx)
+	if err != nil && !errors.Is(err, define.ErrPodPartialFail) {
+		return err
+	}
+	for id, err := range startErrors {
+		logrus.Errorf("Starting container %s of pod %s: %v", id, pod.Name(), err)
+	}
+	return nil
+}
+
+// runJobPod starts the pod after the given delay and waits for all of its
+// containers to exit.  The attempt succeeded if every container exited with
+// code 0.
+func runJobPod(ctx context.Context, pod *libpod.Pod, delay time.Duration) jobAttempt {
+	attempt := jobAttempt{pod: pod}
+	if delay > 0 {
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			attempt.err = ctx.Err()
+			return attempt
+		}
+	}
+
+	startErrors, err := pod.Start(ctx)
+	if err != nil && !errors.Is(err, define.ErrPodPartialFail) {
+		attempt.err = err
+		return attempt
+	}
+	for id, err := range startErrors {
+		logrus.Errorf("Starting container %s of pod %s: %v", id, pod.Name(), err)
+	}
+
+	ctrs, err := pod.AllContainers()
+	if err != nil {
+		attempt.err = err
+		return attempt
+	}
+	attempt.succeeded = len(startErrors) == 0
+	for _, ctr := range ctrs {
+		if ctr.IsInfra() || ctr.IsInitCtr() {
+			continue
+		}
+		exitCode, err := ctr.Wait(ctx)
+		if err != nil {
+			attempt.err = err
+			return attempt
+		}
+		if exitCode != 0 {
+			attempt.succeeded = false
+		}
+	}
+	return attempt
+}
+
 func (ic *ContainerEngine) playKubePod(ctx context.Context, podName string, podYAML *v1.PodTemplateSpec, options entities.PlayKubeOptions, ipIndex *int, annotations map[string]string, configMaps []v1.ConfigMap, serviceContainer *libpod.Container) (*entities.PlayKubeReport, []*notifyproxy.NotifyProxy, error) {
 	var (
 		writer      io.Writer
@@ -609,15 +901,27 @@ func (ic *ContainerEngine) playKubePod(ctx context.Context, podName string, podY
 		return nil, nil, err
 	}
 
-	volumes, err := kube.InitializeVolumes(podYAML.Spec.Volumes, configMaps, secretsManager, mountLabel)
+	namespace := podYAML.ObjectMeta.Namespace
+	if namespace == "" {
+		namespace = "default"
+	}
+	downwardAPIPod := kube.DownwardAPIPod{
+		Name:        podName,
+		Namespace:   namespace,
+		Labels:      podYAML.ObjectMeta.Labels,
+		Annotations: annotations,
+		Containers:  append(slices.Clone(podYAML.Spec.InitContainers), podYAML.Spec.Containers...),
+	}
+
+	volumes, err := kube.InitializeVolumes(podYAML.Spec.Volumes, configMaps, secretsManager, &downwardAPIPod, mountLabel)
 	if err != nil {
 		return nil, nil, err
 	}
 
 	// Go through the volumes and create a podman volume for all volumes that have been
-	// defined by a configmap or secret
+	// defined by a configmap, secret, downwardAPI or projected source
 	for _, v := range volumes {
-		if (v.Type == kube.KubeVolumeTypeConfigMap || v.Type == kube.KubeVolumeTypeSecret) && !v.Optional {
+		if (v.Type == kube.KubeVolumeTypeConfigMap || v.Type == kube.KubeVolumeTypeSecret || v.Type == kube.KubeVolumeTypeDownwardAPI || v.Type == kube.KubeVolumeTypeProjected) && !v.Optional {
 			volumeOptions := []libpod.VolumeCreateOption{
 				libpod.WithVolumeName(v.Source),
 				libpod.WithVolumeMountLabel(mountLabel),
@@ -643,6 +947,10 @@ func (ic *ContainerEngine) playKubePod(ctx context.Context, podName string, podY
 			// Create files and add data to the volume mountpoint based on the Items in the volume
 			for k, v := range v.Items {
 				dataPath := filepath.Join(mountPoint, k)
+				// Projected and downwardAPI items may be placed in subdirectories
+				if err := os.MkdirAll(filepath.Dir(dataPath), 0755); err != nil {
+					return nil, nil, fmt.Errorf("cannot create directory for file %q at volume mountpoint %q: %w", k, mountPoint, err)
+				}
 				f, err := os.Create(dataPath)
 				if err != nil {
 					return nil, nil, fmt.Errorf("cannot create file %q at volume mountpoint %q: %w", k, mountPoint, err)
@@ -845,7 +1153,12 @@ func (ic *ContainerEngine) playKubePod(ctx context.Context, podName string, podY
 	// Callers are expected to close the proxies
 	var sdNotifyProxies []*notifyproxy.NotifyProxy
 
-	for _, container := range podYAML.Spec.Containers {
+	// Containers must be created after the containers they require.
+	podContainers, err := kube.SortContainersByRequires(podYAML.Spec.Containers, annotations)
+	if err != nil {
+		return nil, nil, fmt.Errorf("the pod %q is invalid: %w", podName, err)
+	}
+	for _, container := range podContainers {
 		// Error out if the same name is used for more than one container
 		if _, ok := ctrNames[container.Name]; ok {
 			return nil, nil, fmt.Errorf("the pod %q is invalid; duplicate container name %q detected", podName, container.Name)
@@ -877,6 +1190,7 @@ func (ic *ContainerEngine) playKubePod(ctx context.Context, podName string, podY
 			PodName:            podName,
 			PodSecurityContext: podYAML.Spec.SecurityContext,
 			ReadOnly:           readOnly,
+			ReadinessGate:      options.ReadinessGate,
 			SeccompPaths:       seccompPaths,
 			SecretsManager:     secretsManager,
 			UserNSIsHost:       p.Userns.IsHost(),
@@ -986,6 +1300,12 @@ func (ic *ContainerEngine) playKubePod(ctx context.Context, podName string, podY
 				return nil, nil, err
 			}
 		}
+
+		if options.ReadinessGate {
+			if err := ic.waitForReadiness(ctx, containers); err != nil {
+				return nil, nil, err
+			}
+		}
 	}
 
 	playKubePod.ID = pod.ID()
@@ -1001,6 +1321,38 @@ func (ic *ContainerEngine) playKubePod(ctx context.Context, podName string, podY
 	return &report, sdNotifyProxies, nil
 }
 
+// waitForReadiness waits until all containers with a readiness healthcheck
+// are ready, one of them fails, or ctx is cancelled.  The readiness checks
+// are run directly so that this does not depend on the systemd timers of the
+// checks.
+func (ic *ContainerEngine) waitForReadiness(ctx context.Context, containers []*libpod.Container) error {
+	for _, ctr := range containers {
+		readinessConfig := ctr.ConfigNoCopy().ReadinessHealthCheckConfig
+		if readinessConfig == nil {
+			continue
+		}
+		interval := readinessConfig.Interval
+		if interval <= 0 {
+			interval = time.Second
+		}
+		for {
+			status, err := ic.Libpod.ReadinessCheck(ctx, ctr.ID())
+			if err != nil {
+				return fmt.Errorf("waiting for container %s to be ready: %w", ctr.Name(), err)
+			}
+			if status == define.HealthCheckSuccess {
+				break
+			}
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(interval):
+			}
+		}
+	}
+	return nil
+}
+
 // getImageAndLabelInfo returns the image information and how the image should be pulled plus as well as labels to be used for the container in the pod.
 // Moved this to a separate function so that it can be used for both init and regular containers when playing a kube yaml.
 func (ic *ContainerEngine) getImageAndLabelInfo(ctx context.Context, cwd string, annotations map[string]string, writer io.Writer, container v1.Container, options entities.PlayKubeOptions) (*libimage.Image, map[string]string, error) {
@@ -1093,6 +1445,8 @@ func (ic *ContainerEngine) getImageAndLabelInfo(ctx context.Context, cwd string,
 
 	setLabel(define.AutoUpdateLabel)
 	setLabel(define.AutoUpdateAuthfileLabel)
+	setLabel(define.AutoUpdateSemverLabel)
+	setLabel(define.AutoUpdateWindowLabel)
 
 	return pulledImage, labels, nil
 }
@@ -1365,7 +1719,7 @@ func getKubeKind(obj []byte) (string, error) {
 // sortKubeKinds adds the correct creation order for the kube kinds.
 // Any pod dependency will be created first like volumes, secrets, etc.
 func sortKubeKinds(documentList [][]byte) ([][]byte, error) {
-	var sortedDocumentList [][]byte
+	var sortedDocumentList, jobs [][]byte
 
 	for _, document := range documentList {
 		kind, err := getKubeKind(document)
@@ -1376,12 +1730,16 @@ func sortKubeKinds(documentList [][]byte) ([][]byte, error) {
 		switch kind {
 		case "Pod", "Deployment", "DaemonSet":
 			sortedDocumentList = append(sortedDocumentList, document)
+		case "Job":
+			// Jobs run to completion, so the pods they may depend on
+			// must be running before.
+			jobs = append(jobs, document)
 		default:
 			sortedDocumentList = append([][]byte{document}, sortedDocumentList...)
 		}
 	}
 
-	return sortedDocumentList, nil
+	return append(sortedDocumentList, jobs...), nil
 }
 func imageNamePrefix(imageName string) string {
 	prefix := imageName
@@ -1477,6 +1835,8 @@ func (ic *ContainerEngine) PlayKubeDown(ctx context.Context, body io.Reader, opt
 					volumeNames = append(volumeNames, vs.ConfigMap.Name)
 				case vs.Secret != nil:
 					volumeNames = append(volumeNames, vs.Secret.SecretName)
+				case vs.DownwardAPI != nil, vs.Projected != nil:
+					volumeNames = append(volumeNames, kube.PodVolumeName(podYAML.ObjectMeta.Name, vol.Name))
 				}
 			}
 		case "DaemonSet":
@@ -1504,6 +1864,22 @@ func (ic *ContainerEngine) PlayKubeDown(ctx context.Context, body io.Reader, opt
 			}
 			podName := fmt.Sprintf("%s-pod", deploymentName)
 			podNames = append(podNames, podName)
+		case "Job":
+			var jobYAML v1batch.Job
+
+			if err := yaml.Unmarshal(document, &jobYAML); err != nil {
+				return nil, fmt.Errorf("unable to read YAML as Kube Job: %w", err)
+			}
+			// A job may have created any number of pods, find them all by label.
+			pods, err := ic.Libpod.Pods(func(p *libpod.Pod) bool {
+				return p.Labels()[v1batch.JobNameLabel] == jobYAML.Name
+			})
+			if err != nil {
+				return nil, err
+			}
+			for _, pod := range pods {
+				podNames = append(podNames, pod.Name())
+			}
 		case "PersistentVolumeClaim":
 			var pvcYAML v1.PersistentVolumeClaim
 			if err := yaml.Unmarshal(document, &pvcYAML); err != nil {
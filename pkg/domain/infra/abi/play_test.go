@@ -279,3 +279,23 @@ items:
 		})
 	}
 }
+
+func TestSortKubeKinds(t *testing.T) {
+	documents := [][]byte{
+		[]byte("kind: Job\nmetadata:\n  name: job"),
+		[]byte("kind: Pod\nmetadata:\n  name: pod"),
+		[]byte("kind: Secret\nmetadata:\n  name: secret"),
+		[]byte("kind: Deployment\nmetadata:\n  name: deployment"),
+	}
+
+	sorted, err := sortKubeKinds(documents)
+	assert.NoError(t, err)
+
+	var kinds []string
+	for _, document := range sorted {
+		kind, err := getKubeKind(document)
+		assert.NoError(t, err)
+		kinds = append(kinds, kind)
+	}
+	assert.Equal(t, []string{"Secret", "Pod", "Deployment", "Job"}, kinds)
+}
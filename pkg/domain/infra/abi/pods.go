@@ -4,11 +4,14 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"os"
 	"strconv"
 	"strings"
 
 	"github.com/containers/podman/v4/libpod"
 	"github.com/containers/podman/v4/libpod/define"
+	"github.com/containers/podman/v4/pkg/checkpoint"
+	"github.com/containers/podman/v4/pkg/checkpoint/crutils"
 	"github.com/containers/podman/v4/pkg/domain/entities"
 	dfilters "github.com/containers/podman/v4/pkg/domain/filters"
 	"github.com/containers/podman/v4/pkg/signal"
@@ -187,6 +190,36 @@ func (ic *ContainerEngine) PodUnpause(ctx context.Context, namesOrIds []string,
 	return reports, nil
 }
 
+func (ic *ContainerEngine) PodUpdate(ctx context.Context, options *entities.PodUpdateOptions) (*entities.PodUpdateReport, error) {
+	updateOptions := &libpod.PodUpdateOptions{
+		RestartPolicy:  options.RestartPolicy,
+		RestartRetries: options.RestartRetries,
+		ExitPolicy:     options.ExitPolicy,
+	}
+	if options.Specgen != nil {
+		if err := specgen.WeightDevices(options.Specgen); err != nil {
+			return nil, err
+		}
+		if err := specgen.FinishThrottleDevices(options.Specgen); err != nil {
+			return nil, err
+		}
+		updateOptions.Resources = options.Specgen.ResourceLimits
+	}
+	pod, err := ic.Libpod.LookupPod(options.NameOrID)
+	if err != nil {
+		return nil, err
+	}
+	errs, err := pod.Update(ctx, updateOptions)
+	if err != nil && !errors.Is(err, define.ErrPodPartialFail) {
+		return nil, err
+	}
+	report := entities.PodUpdateReport{Id: pod.ID()}
+	for id, v := range errs {
+		report.Errs = append(report.Errs, fmt.Errorf("updating container %s: %w", id, v))
+	}
+	return &report, nil
+}
+
 func (ic *ContainerEngine) PodStop(ctx context.Context, namesOrIds []string, options entities.PodStopOptions) ([]*entities.PodStopReport, error) {
 	reports := []*entities.PodStopReport{}
 	pods, err := getPodsByContext(options.All, options.Latest, namesOrIds, ic.Libpod)
@@ -560,3 +593,110 @@ func (ic *ContainerEngine) PodInspect(ctx context.Context, nameOrIDs []string, o
 	}
 	return podReport, errs, nil
 }
+
+func (ic *ContainerEngine) PodCheckpoint(ctx context.Context, namesOrIds []string, options entities.PodCheckpointOptions) ([]*entities.PodCheckpointReport, error) {
+	pods, err := getPodsByContext(options.All, options.Latest, namesOrIds, ic.Libpod)
+	if err != nil {
+		return nil, err
+	}
+	if (options.Export != "" || options.CreateImage != "") && len(pods) > 1 {
+		return nil, errors.New("--export and --create-image can only be used with a single pod")
+	}
+
+	reports := make([]*entities.PodCheckpointReport, 0, len(pods))
+	for _, p := range pods {
+		ctrReports, err := checkpoint.CRCheckpointPod(ctx, ic.Libpod, p, options)
+		reports = append(reports, &entities.PodCheckpointReport{
+			Err:        err,
+			Id:         p.ID(),
+			Containers: ctrReports,
+		})
+	}
+	return reports, nil
+}
+
+func (ic *ContainerEngine) PodRestore(ctx context.Context, namesOrIds []string, options entities.PodRestoreOptions) ([]*entities.PodRestoreReport, error) {
+	if options.Import != "" {
+		dir, err := os.MkdirTemp("", "pod-checkpoint")
+		if err != nil {
+			return nil, err
+		}
+		defer func() {
+			if err := os.RemoveAll(dir); err != nil {
+				logrus.Errorf("Could not recursively remove %s: %q", dir, err)
+			}
+		}()
+		if err := crutils.CRImportPodCheckpoint(dir, options.Import); err != nil {
+			return nil, err
+		}
+		return []*entities.PodRestoreReport{ic.importPodCheckpoint(ctx, options, dir)}, nil
+	}
+
+	if options.All || options.Latest {
+		pods, err := getPodsByContext(options.All, options.Latest, nil, ic.Libpod)
+		if err != nil {
+			return nil, err
+		}
+		reports := make([]*entities.PodRestoreReport, 0, len(pods))
+		for _, p := range pods {
+			ctrReports, err := checkpoint.CRRestorePod(ctx, ic.Libpod, p, options)
+			// Only restore pods with checkpointed containers.
+			if options.All && ctrReports == nil && errors.Is(err, define.ErrCtrStateInvalid) {
+				continue
+			}
+			reports = append(reports, &entities.PodRestoreReport{
+				Err:        err,
+				Id:         p.ID(),
+				Containers: ctrReports,
+			})
+		}
+		return reports, nil
+	}
+
+	reports := make([]*entities.PodRestoreReport, 0, len(namesOrIds))
+	for _, nameOrID := range namesOrIds {
+		p, err := ic.Libpod.LookupPod(nameOrID)
+		if err == nil {
+			ctrReports, err := checkpoint.CRRestorePod(ctx, ic.Libpod, p, options)
+			reports = append(reports, &entities.PodRestoreReport{
+				Err:        err,
+				Id:         p.ID(),
+				RawInput:   nameOrID,
+				Containers: ctrReports,
+			})
+			continue
+		}
+		// If the pod was not found, check if this is a checkpoint image
+		img, _, err := ic.Libpod.LibimageRuntime().LookupImage(nameOrID, nil)
+		if err != nil {
+			return nil, fmt.Errorf("no such pod or image: %s", nameOrID)
+		}
+		report := func() *entities.PodRestoreReport {
+			mountPoint, err := img.Mount(ctx, nil, "")
+			if err != nil {
+				return &entities.PodRestoreReport{Err: err, RawInput: nameOrID}
+			}
+			defer func() {
+				if err := img.Unmount(true); err != nil {
+					logrus.Errorf("Failed to unmount image: %v", err)
+				}
+			}()
+			return ic.importPodCheckpoint(ctx, options, mountPoint)
+		}()
+		reports = append(reports, report)
+	}
+	return reports, nil
+}
+
+// importPodCheckpoint restores the pod checkpoint in the directory dir.
+func (ic *ContainerEngine) importPodCheckpoint(ctx context.Context, options entities.PodRestoreOptions, dir string) *entities.PodRestoreReport {
+	pod, ctrReports, err := checkpoint.CRImportPodCheckpoint(ctx, ic.Libpod, options, dir)
+	report := &entities.PodRestoreReport{
+		Err:        err,
+		Containers: ctrReports,
+	}
+	if pod != nil {
+		report.Id = pod.ID()
+	}
+	return report
+}
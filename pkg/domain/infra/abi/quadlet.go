@@ -0,0 +1,523 @@
+package abi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/containers/podman/v4/pkg/domain/entities"
+	"github.com/containers/podman/v4/pkg/rootless"
+	"github.com/containers/podman/v4/pkg/systemd"
+	"github.com/containers/podman/v4/pkg/systemd/parser"
+	"github.com/containers/podman/v4/pkg/systemd/quadlet"
+	"github.com/coreos/go-systemd/v22/dbus"
+	"github.com/sirupsen/logrus"
+)
+
+// quadletFile is a quadlet file found in the quadlet unit directories.
+type quadletFile struct {
+	name string
+	path string
+}
+
+// findQuadlets returns the quadlet files in the unit directories, in the
+// order of their precedence.  Unless all is set, files shadowed by a file of
+// the same name in a directory with a higher precedence are skipped, like
+// the generator does.
+func findQuadlets(dirs []string, all bool) ([]quadletFile, error) {
+	var quadlets []quadletFile
+	seen := make(map[string]bool)
+	for _, dir := range dirs {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				continue
+			}
+			return nil, err
+		}
+		for _, entry := range entries {
+			name := entry.Name()
+			if entry.IsDir() || !quadlet.IsExtSupported(name) || (seen[name] && !all) {
+				continue
+			}
+			seen[name] = true
+			quadlets = append(quadlets, quadletFile{name: name, path: filepath.Join(dir, name)})
+		}
+	}
+	return quadlets, nil
+}
+
+// lookupQuadlet returns the quadlet file with the given name used by the
+// generator.
+func lookupQuadlet(dirs []string, name string) (*quadletFile, error) {
+	quadlets, err := findQuadlets(dirs, false)
+	if err != nil {
+		return nil, err
+	}
+	for _, q := range quadlets {
+		if q.name == name {
+			return &q, nil
+		}
+	}
+	return nil, fmt.Errorf("quadlet %s is not installed", name)
+}
+
+// quadletUnitDirs returns the directories the generator reads quadlet files
+// from for the current user.
+func quadletUnitDirs() ([]string, error) {
+	return quadlet.GetUnitDirs(rootless.IsRootless())
+}
+
+func reloadSystemd(ctx context.Context) error {
+	conn, err := systemd.ConnectToDBUS()
+	if err != nil {
+		return fmt.Errorf("connecting to systemd: %w", err)
+	}
+	defer conn.Close()
+	if err := conn.ReloadContext(ctx); err != nil {
+		return fmt.Errorf("reloading systemd: %w", err)
+	}
+	return nil
+}
+
+// unitStates returns the active state of the given systemd units.
+func unitStates(ctx context.Context, conn *dbus.Conn, units []string) (map[string]string, error) {
+	statuses, err := conn.ListUnitsByNamesContext(ctx, units)
+	if err != nil {
+		return nil, err
+	}
+	states := make(map[string]string, len(statuses))
+	for _, status := range statuses {
+		states[status.Name] = status.ActiveState
+	}
+	return states, nil
+}
+
+func (ic *ContainerEngine) QuadletInstall(ctx context.Context, pathsOrURLs []string, options entities.QuadletInstallOptions) (*entities.QuadletInstallReport, error) {
+	installDir, err := quadlet.UserUnitDir(rootless.IsRootless())
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(installDir, 0o755); err != nil {
+		return nil, fmt.Errorf("creating quadlet directory %s: %w", installDir, err)
+	}
+
+	report := &entities.QuadletInstallReport{
+		InstalledQuadlets: make(map[string]string),
+		QuadletErrors:     make(map[string]error),
+	}
+	for _, source := range pathsOrURLs {
+		dest, err := installQuadlet(ctx, source, installDir, options.Replace)
+		if err != nil {
+			report.QuadletErrors[source] = err
+			continue
+		}
+		report.InstalledQuadlets[source] = dest
+	}
+
+	if options.ReloadSystemd && len(report.InstalledQuadlets) > 0 {
+		if err := reloadSystemd(ctx); err != nil {
+			return report, err
+		}
+	}
+	return report, nil
+}
+
+// installQuadlet installs a quadlet file, a directory holding quadlet files
+// along with the files they use, or a quadlet file downloaded from a URL into
+// the installation directory.  It returns the path of the installed file or
+// directory.
+func installQuadlet(ctx context.Context, source, installDir string, replace bool) (string, error) {
+	if strings.HasPrefix(source, "http://") || strings.HasPrefix(source, "https://") {
+		return downloadQuadlet(ctx, source, installDir, replace)
+	}
+	if strings.HasPrefix(source, "file://") {
+		u, err := url.Parse(source)
+		if err != nil {
+			return "", err
+		}
+		source = u.Path
+	}
+
+	info, err := os.Stat(source)
+	if err != nil {
+		return "", err
+	}
+	dest := filepath.Join(installDir, filepath.Base(source))
+	if err := checkQuadletDest(dest, replace); err != nil {
+		return "", err
+	}
+
+	if !info.IsDir() {
+		if !quadlet.IsExtSupported(source) {
+			return "", fmt.Errorf("%s is not a quadlet file", source)
+		}
+		return dest, copyQuadletFile(source, dest, info.Mode().Perm())
+	}
+
+	quadlets, err := findQuadlets([]string{source}, false)
+	if err != nil {
+		return "", err
+	}
+	if len(quadlets) == 0 {
+		return "", fmt.Errorf("directory %s does not contain any quadlet files", source)
+	}
+	if err := os.RemoveAll(dest); err != nil {
+		return "", err
+	}
+	// The directory is installed as a whole, so relative paths in the
+	// quadlet files, e.g. to a YAML file or a Containerfile, remain valid.
+	err = filepath.WalkDir(source, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(source, p)
+		if err != nil {
+			return err
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dest, rel)
+		if d.IsDir() {
+			return os.MkdirAll(target, info.Mode().Perm())
+		}
+		if !info.Mode().IsRegular() {
+			logrus.Debugf("Skipping %s while installing quadlet directory: not a regular file", p)
+			return nil
+		}
+		return copyQuadletFile(p, target, info.Mode().Perm())
+	})
+	if err != nil {
+		return "", fmt.Errorf("installing quadlet directory %s: %w", source, err)
+	}
+	return dest, nil
+}
+
+func checkQuadletDest(dest string, replace bool) error {
+	if _, err := os.Lstat(dest); err == nil {
+		if !replace {
+			return fmt.Errorf("%s is already installed, use --replace to replace it", dest)
+		}
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+func downloadQuadlet(ctx context.Context, source, installDir string, replace bool) (string, error) {
+	u, err := url.Parse(source)
+	if err != nil {
+		return "", err
+	}
+	name := path.Base(u.Path)
+	if !quadlet.IsExtSupported(name) {
+		return "", fmt.Errorf("%s is not a quadlet file", source)
+	}
+	dest := filepath.Join(installDir, name)
+	if err := checkQuadletDest(dest, replace); err != nil {
+		return "", err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, source, nil)
+	if err != nil {
+		return "", err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("downloading %s: %s", source, resp.Status)
+	}
+	return dest, writeQuadletFile(resp.Body, dest, 0o644)
+}
+
+func copyQuadletFile(source, dest string, perm fs.FileMode) error {
+	f, err := os.Open(source)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return writeQuadletFile(f, dest, perm)
+}
+
+// writeQuadletFile writes the file to a temporary file first, so the
+// generator never sees a partially written quadlet file.
+func writeQuadletFile(r io.Reader, dest string, perm fs.FileMode) error {
+	tmp, err := os.CreateTemp(filepath.Dir(dest), "."+filepath.Base(dest))
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := io.Copy(tmp, r); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Chmod(perm); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), dest)
+}
+
+func (ic *ContainerEngine) QuadletList(ctx context.Context, options entities.QuadletListOptions) ([]*entities.ListQuadlet, error) {
+	dirs, err := quadletUnitDirs()
+	if err != nil {
+		return nil, err
+	}
+	quadlets, err := findQuadlets(dirs, options.All)
+	if err != nil {
+		return nil, err
+	}
+
+	reports := make([]*entities.ListQuadlet, 0, len(quadlets))
+	units := make([]string, 0, len(quadlets))
+	for _, q := range quadlets {
+		unit := quadlet.ServiceName(q.name)
+		units = append(units, unit)
+		reports = append(reports, &entities.ListQuadlet{
+			Name:     q.name,
+			UnitName: unit,
+			Path:     q.path,
+			Status:   "unknown",
+		})
+	}
+	if len(reports) == 0 {
+		return reports, nil
+	}
+
+	// The state of the units is informational, so do not fail if systemd
+	// cannot be reached, e.g. in a container.
+	conn, err := systemd.ConnectToDBUS()
+	if err != nil {
+		logrus.Debugf("Connecting to systemd to get the state of quadlet units: %v", err)
+		return reports, nil
+	}
+	defer conn.Close()
+	states, err := unitStates(ctx, conn, units)
+	if err != nil {
+		logrus.Debugf("Getting the state of quadlet units: %v", err)
+		return reports, nil
+	}
+	for _, r := range reports {
+		if state, ok := states[r.UnitName]; ok {
+			r.Status = state
+		}
+	}
+	return reports, nil
+}
+
+func (ic *ContainerEngine) QuadletPrint(ctx context.Context, name string) (string, error) {
+	dirs, err := quadletUnitDirs()
+	if err != nil {
+		return "", err
+	}
+	q, err := lookupQuadlet(dirs, name)
+	if err != nil {
+		return "", err
+	}
+	data, err := os.ReadFile(q.path)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+func (ic *ContainerEngine) QuadletRemove(ctx context.Context, quadlets []string, options entities.QuadletRemoveOptions) (*entities.QuadletRemoveReport, error) {
+	dirs, err := quadletUnitDirs()
+	if err != nil {
+		return nil, err
+	}
+
+	report := &entities.QuadletRemoveReport{Errors: make(map[string]error)}
+	var (
+		conn    *dbus.Conn
+		connErr error
+	)
+	for _, name := range quadlets {
+		q, err := lookupQuadlet(dirs, name)
+		if err != nil {
+			if !options.Ignore {
+				report.Errors[name] = err
+			}
+			continue
+		}
+
+		unit := quadlet.ServiceName(q.name)
+		if conn == nil && connErr == nil {
+			conn, connErr = systemd.ConnectToDBUS()
+			if connErr != nil {
+				logrus.Warnf("Cannot connect to systemd, not checking the state of quadlet units: %v", connErr)
+			} else {
+				defer conn.Close()
+			}
+		}
+		if conn != nil {
+			if err := stopQuadletUnit(ctx, conn, name, unit, options.Force); err != nil {
+				report.Errors[name] = err
+				continue
+			}
+		}
+
+		if err := os.Remove(q.path); err != nil {
+			report.Errors[name] = err
+			continue
+		}
+		report.Removed = append(report.Removed, name)
+	}
+
+	if options.ReloadSystemd && len(report.Removed) > 0 {
+		if err := reloadSystemd(ctx); err != nil {
+			return report, err
+		}
+	}
+	return report, nil
+}
+
+// stopQuadletUnit stops the unit generated for a quadlet file if it is active
+// and force is set, otherwise an active unit is an error.
+func stopQuadletUnit(ctx context.Context, conn *dbus.Conn, name, unit string, force bool) error {
+	states, err := unitStates(ctx, conn, []string{unit})
+	if err != nil {
+		return fmt.Errorf("getting state of unit %s: %w", unit, err)
+	}
+	state := states[unit]
+	if state != "active" && state != "activating" && state != "reloading" {
+		return nil
+	}
+	if !force {
+		return fmt.Errorf("unit %s of quadlet %s is %s, stop it first or use --force", unit, name, state)
+	}
+	stopChan := make(chan string)
+	if _, err := conn.StopUnitContext(ctx, unit, "replace", stopChan); err != nil {
+		return fmt.Errorf("stopping unit %s: %w", unit, err)
+	}
+	if result := <-stopChan; result != "done" {
+		return fmt.Errorf("stopping unit %s: job %s", unit, result)
+	}
+	return nil
+}
+
+func (ic *ContainerEngine) QuadletValidate(ctx context.Context, quadlets []string) ([]*entities.QuadletValidateReport, error) {
+	dirs, err := quadletUnitDirs()
+	if err != nil {
+		return nil, err
+	}
+	installed, err := findQuadlets(dirs, false)
+	if err != nil {
+		return nil, err
+	}
+
+	// Quadlet files given by path take precedence over installed ones, so
+	// they can be validated before installing them.
+	files := make(map[string]quadletFile)
+	fromPath := make(map[string]bool)
+	var requested []string
+	for _, arg := range quadlets {
+		if !strings.Contains(arg, "/") {
+			requested = append(requested, arg)
+			continue
+		}
+		p, err := filepath.Abs(arg)
+		if err != nil {
+			return nil, err
+		}
+		info, err := os.Stat(p)
+		if err != nil {
+			return nil, err
+		}
+		var found []quadletFile
+		if info.IsDir() {
+			if found, err = findQuadlets([]string{p}, false); err != nil {
+				return nil, err
+			}
+		} else {
+			if !quadlet.IsExtSupported(p) {
+				return nil, fmt.Errorf("%s is not a quadlet file", arg)
+			}
+			found = []quadletFile{{name: filepath.Base(p), path: p}}
+		}
+		for _, q := range found {
+			files[q.name] = q
+			fromPath[q.name] = true
+			requested = append(requested, q.name)
+		}
+	}
+	for _, q := range installed {
+		if _, ok := files[q.name]; !ok {
+			files[q.name] = q
+		}
+	}
+	if len(quadlets) == 0 {
+		for name := range files {
+			requested = append(requested, name)
+		}
+	}
+
+	reports := make(map[string]*entities.QuadletValidateReport, len(files))
+	known := make(map[string]bool, len(files))
+	units := make([]*parser.UnitFile, 0, len(files))
+	for name, q := range files {
+		reports[name] = &entities.QuadletValidateReport{Name: name, Path: q.path}
+		known[name] = true
+		unit, err := parser.ParseUnitFile(q.path)
+		if err != nil {
+			reports[name].Errors = append(reports[name].Errors, err.Error())
+			continue
+		}
+		dropinDirs := dirs
+		if fromPath[name] {
+			dropinDirs = append([]string{filepath.Dir(q.path)}, dirs...)
+		}
+		if err := quadlet.LoadUnitDropins(unit, dropinDirs); err != nil {
+			reports[name].Errors = append(reports[name].Errors, err.Error())
+		}
+		units = append(units, unit)
+	}
+
+	// Convert all units like the generator does, as units depend on the
+	// names of the resources created by other units.
+	sort.Slice(units, func(i, j int) bool { return units[i].Filename < units[j].Filename })
+	quadlet.SortUnits(units)
+	podsInfoMap := quadlet.GeneratePodsInfoMap(units)
+	names := make(map[string]string)
+	quadlet.AddContainerNames(units, names)
+	for _, unit := range units {
+		r := reports[unit.Filename]
+		for _, ref := range quadlet.UnresolvedReferences(unit, known) {
+			r.Errors = append(r.Errors, fmt.Sprintf("referenced quadlet %s does not exist", ref))
+		}
+		if _, err := quadlet.ConvertUnit(unit, names, rootless.IsRootless(), podsInfoMap); err != nil {
+			r.Errors = append(r.Errors, err.Error())
+		}
+	}
+
+	sort.Strings(requested)
+	results := make([]*entities.QuadletValidateReport, 0, len(requested))
+	for i, name := range requested {
+		if i > 0 && requested[i-1] == name {
+			continue
+		}
+		r, ok := reports[name]
+		if !ok {
+			return nil, fmt.Errorf("quadlet %s is not installed", name)
+		}
+		results = append(results, r)
+	}
+	return results, nil
+}
@@ -9,10 +9,17 @@ import (
 	"strings"
 
 	"github.com/containers/common/pkg/secrets"
+	"github.com/containers/podman/v4/libpod/define"
+	"github.com/containers/podman/v4/libpod/events"
 	"github.com/containers/podman/v4/pkg/domain/entities"
 	"github.com/containers/podman/v4/pkg/domain/utils"
+	"github.com/sirupsen/logrus"
 )
 
+// maxSecretSize is the size limit for secret data enforced by the secrets
+// manager.
+const maxSecretSize = 512000
+
 func (ic *ContainerEngine) SecretCreate(ctx context.Context, name string, reader io.Reader, options entities.SecretCreateOptions) (*entities.SecretCreateReport, error) {
 	data, _ := io.ReadAll(reader)
 	secretsPath := ic.Libpod.GetSecretsStorageDir()
@@ -53,6 +60,7 @@ func (ic *ContainerEngine) SecretCreate(ctx context.Context, name string, reader
 	if err != nil {
 		return nil, err
 	}
+	ic.Libpod.NewSecretEvent(events.Create, secretID, name)
 
 	return &entities.SecretCreateReport{
 		ID: secretID,
@@ -165,10 +173,17 @@ func (ic *ContainerEngine) SecretRm(ctx context.Context, nameOrIDs []string, opt
 		}
 	}
 	for _, nameOrID := range toRemove {
+		var name string
+		if secr, err := manager.Lookup(nameOrID); err == nil {
+			name = secr.Name
+		}
 		deletedID, err := manager.Delete(nameOrID)
 		if options.Ignore && errors.Is(err, secrets.ErrNoSuchSecret) {
 			continue
 		}
+		if err == nil {
+			ic.Libpod.NewSecretEvent(events.Remove, deletedID, name)
+		}
 		reports = append(reports, &entities.SecretRmReport{Err: err, ID: deletedID})
 	}
 
@@ -188,3 +203,80 @@ func (ic *ContainerEngine) SecretExists(ctx context.Context, nameOrID string) (*
 
 	return &entities.BoolReport{Value: secret != nil}, nil
 }
+
+func (ic *ContainerEngine) SecretUpdate(ctx context.Context, nameOrID string, reader io.Reader, options entities.SecretUpdateOptions) (*entities.SecretUpdateReport, error) {
+	switch options.Propagate {
+	case "", entities.SecretPropagateNone, entities.SecretPropagateReinject, entities.SecretPropagateRestart:
+	default:
+		return nil, fmt.Errorf("invalid propagation mode %q, must be one of %q, %q or %q: %w", options.Propagate,
+			entities.SecretPropagateNone, entities.SecretPropagateReinject, entities.SecretPropagateRestart, define.ErrInvalidArg)
+	}
+
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) == 0 || len(data) >= maxSecretSize {
+		return nil, fmt.Errorf("secret data must be larger than 0 and less than %d bytes: %w", maxSecretSize, define.ErrInvalidArg)
+	}
+
+	manager, err := ic.Libpod.SecretsManager()
+	if err != nil {
+		return nil, err
+	}
+	secret, err := manager.Lookup(nameOrID)
+	if err != nil {
+		return nil, err
+	}
+	// The secrets manager allocates a new ID when replacing the secret, but
+	// containers refer to secrets by name and keep working.
+	storeOpts := secrets.StoreOptions{
+		DriverOpts: secret.DriverOptions,
+		Metadata:   secret.Metadata,
+		Labels:     secret.Labels,
+		Replace:    true,
+	}
+	secretID, err := manager.Store(secret.Name, data, secret.Driver, storeOpts)
+	if err != nil {
+		return nil, err
+	}
+	secret.ID = secretID
+	ic.Libpod.NewSecretEvent(events.Update, secret.ID, secret.Name)
+
+	report := &entities.SecretUpdateReport{ID: secret.ID}
+	if options.Propagate == "" || options.Propagate == entities.SecretPropagateNone {
+		return report, nil
+	}
+
+	ctrs, err := ic.Libpod.GetAllContainers()
+	if err != nil {
+		return nil, err
+	}
+	for _, ctr := range ctrs {
+		mounted, env := ctr.UsesSecret(secret.Name)
+		if !mounted && !env {
+			continue
+		}
+		if mounted {
+			if err := ctr.ReinjectSecret(secret.Name); err != nil {
+				return nil, fmt.Errorf("propagating secret %s to container %s: %w", secret.Name, ctr.ID(), err)
+			}
+		}
+		state, err := ctr.State()
+		if err != nil {
+			return nil, err
+		}
+		if state == define.ContainerStateRunning {
+			switch {
+			case options.Propagate == entities.SecretPropagateRestart:
+				if err := ctr.RestartWithTimeout(ctx, ctr.StopTimeout()); err != nil {
+					return nil, fmt.Errorf("restarting container %s: %w", ctr.ID(), err)
+				}
+			case env:
+				logrus.Warnf("Container %s uses secret %s as environment variable, the new data is only visible after a restart", ctr.ID(), secret.Name)
+			}
+		}
+		report.Containers = append(report.Containers, ctr.ID())
+	}
+	return report, nil
+}
@@ -9,6 +9,7 @@ import (
 	"os/exec"
 	"path/filepath"
 
+	"github.com/containers/podman/v4/libpod"
 	"github.com/containers/podman/v4/libpod/define"
 	"github.com/containers/podman/v4/pkg/domain/entities"
 	"github.com/containers/podman/v4/pkg/domain/entities/reports"
@@ -256,6 +257,14 @@ func (ic *ContainerEngine) SystemDf(ctx context.Context, options entities.System
 		if err != nil {
 			return nil, err
 		}
+		snapshots, err := v.Snapshots()
+		if err != nil {
+			return nil, err
+		}
+		var snapshotsSize int64
+		for _, snapshot := range snapshots {
+			snapshotsSize += snapshot.Size
+		}
 		if len(inUse) == 0 {
 			reclaimableSize = volSize
 		}
@@ -264,6 +273,8 @@ func (ic *ContainerEngine) SystemDf(ctx context.Context, options entities.System
 			Links:           len(inUse),
 			Size:            volSize,
 			ReclaimableSize: reclaimableSize,
+			Snapshots:       len(snapshots),
+			SnapshotsSize:   snapshotsSize,
 		}
 		dfVolumes = append(dfVolumes, &report)
 	}
@@ -284,8 +295,60 @@ func (ic *ContainerEngine) Renumber(ctx context.Context) error {
 	return ic.Libpod.RenumberLocks()
 }
 
-func (ic *ContainerEngine) Migrate(ctx context.Context, options entities.SystemMigrateOptions) error {
-	return ic.Libpod.Migrate(options.NewRuntime)
+func (ic *ContainerEngine) Migrate(ctx context.Context, options entities.SystemMigrateOptions) (*entities.SystemMigrateReport, error) {
+	// A dry run must not stop any container.
+	if !options.DryRun {
+		if err := ic.Libpod.Migrate(options.NewRuntime); err != nil {
+			return nil, err
+		}
+	}
+	if options.DatabaseBackend == "" {
+		return nil, nil
+	}
+
+	report, err := ic.Libpod.MigrateDatabase(options.DatabaseBackend, options.DryRun)
+	if err != nil {
+		return nil, err
+	}
+	return &entities.SystemMigrateReport{
+		From:         report.From,
+		To:           report.To,
+		Path:         report.Path,
+		Backup:       report.Backup,
+		DryRun:       report.DryRun,
+		Containers:   report.Containers,
+		Pods:         report.Pods,
+		Volumes:      report.Volumes,
+		ExecSessions: report.ExecSessions,
+		Networks:     report.Networks,
+		ExitCodes:    report.ExitCodes,
+	}, nil
+}
+
+func (ic *ContainerEngine) SystemCheck(ctx context.Context, options entities.SystemCheckOptions) (*entities.SystemCheckReport, error) {
+	report, err := ic.Libpod.SystemCheck(ctx, libpod.SystemCheckOptions{
+		Quick:                       options.Quick,
+		Repair:                      options.Repair,
+		RepairLossy:                 options.RepairLossy,
+		UnreferencedLayerMaximumAge: options.UnreferencedLayerMaximumAge,
+	})
+	if err != nil {
+		return nil, err
+	}
+	problems := make([]entities.SystemCheckProblem, 0, len(report.Problems))
+	for _, problem := range report.Problems {
+		p := entities.SystemCheckProblem{
+			Category:    problem.Category,
+			ID:          problem.ID,
+			Description: problem.Description,
+			Repaired:    problem.Repaired,
+		}
+		if problem.RepairError != nil {
+			p.RepairError = problem.RepairError.Error()
+		}
+		problems = append(problems, p)
+	}
+	return &entities.SystemCheckReport{Problems: problems}, nil
 }
 
 func (se SystemEngine) Shutdown(ctx context.Context) {
@@ -0,0 +1,1277 @@
+package abi
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/containers/common/libnetwork/types"
+	"github.com/containers/common/pkg/config"
+	"github.com/containers/podman/v4/libpod"
+	"github.com/containers/podman/v4/libpod/define"
+	"github.com/containers/podman/v4/pkg/domain/entities"
+	"github.com/containers/podman/v4/pkg/specgen"
+	"github.com/containers/podman/v4/pkg/specgen/generate"
+	"github.com/containers/podman/v4/pkg/specgenutil"
+	"github.com/containers/podman/v4/pkg/util"
+	"github.com/containers/podman/v4/utils"
+	"github.com/containers/podman/v4/version"
+	"github.com/containers/storage/pkg/archive"
+	"github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/secure-systems-lab/go-securesystemslib/encrypted"
+	"github.com/sirupsen/logrus"
+)
+
+// A backup is an uncompressed tar archive.  It starts with backup.json, which
+// lists its contents.  Every network, volume, secret, pod and container
+// follows as a JSON file in the directory of its kind.  The contents of
+// volumes come next, stored below volumes/NAME/, one volume after the other,
+// so that they can be streamed in both directions.  The images, if included,
+// are stored last in a single docker-archive.
+const (
+	backupVersion       = 1
+	backupIndexFile     = "backup.json"
+	backupImagesFile    = "images.tar"
+	backupNetworksDir   = "networks"
+	backupVolumesDir    = "volumes"
+	backupSecretsDir    = "secrets"
+	backupPodsDir       = "pods"
+	backupContainersDir = "containers"
+)
+
+// backupIndex is stored as backup.json and lists the contents of a backup.
+type backupIndex struct {
+	Version       int       `json:"version"`
+	Created       time.Time `json:"created"`
+	PodmanVersion string    `json:"podmanVersion"`
+	Networks      []string  `json:"networks,omitempty"`
+	Volumes       []string  `json:"volumes,omitempty"`
+	Secrets       []string  `json:"secrets,omitempty"`
+	Pods          []string  `json:"pods,omitempty"`
+	// Containers are sorted by creation time.
+	Containers []string `json:"containers,omitempty"`
+	// Images are the names, or IDs if unnamed, of the images of the
+	// containers.
+	Images []string `json:"images,omitempty"`
+	// ImagesIncluded is set if the images are stored in images.tar.
+	// Otherwise they are pulled on restore.
+	ImagesIncluded bool `json:"imagesIncluded,omitempty"`
+	// SecretsEncrypted is set if the data of the secrets is encrypted
+	// with a passphrase.
+	SecretsEncrypted bool `json:"secretsEncrypted,omitempty"`
+}
+
+// backupVolume describes a volume in a backup.
+type backupVolume struct {
+	Name    string            `json:"name"`
+	Driver  string            `json:"driver,omitempty"`
+	Labels  map[string]string `json:"labels,omitempty"`
+	Options map[string]string `json:"options,omitempty"`
+	// Data is set if the contents of the volume are stored in the
+	// backup.
+	Data bool `json:"data,omitempty"`
+}
+
+// backupSecret describes a secret in a backup.
+type backupSecret struct {
+	Name          string            `json:"name"`
+	Driver        string            `json:"driver"`
+	DriverOptions map[string]string `json:"driverOptions,omitempty"`
+	Labels        map[string]string `json:"labels,omitempty"`
+	Data          []byte            `json:"data"`
+}
+
+// backupJSONFile is a JSON file of a backup.
+type backupJSONFile struct {
+	name string
+	v    interface{}
+}
+
+// SystemBackup writes a backup of all networks, volumes including their
+// contents, secrets, pods and containers to w.  Containers and pods are stored
+// as specs to recreate them with, their state is not part of the backup.
+func (ic *ContainerEngine) SystemBackup(ctx context.Context, w io.Writer, options entities.SystemBackupOptions) (*entities.SystemBackupReport, error) {
+	index := backupIndex{
+		Version:          backupVersion,
+		Created:          time.Now(),
+		PodmanVersion:    version.Version.String(),
+		ImagesIncluded:   options.IncludeImages,
+		SecretsEncrypted: len(options.Passphrase) > 0,
+	}
+	// The JSON files are collected first, as they are written after the
+	// index.
+	var files []backupJSONFile
+
+	networks, err := ic.Libpod.Network().NetworkList()
+	if err != nil {
+		return nil, err
+	}
+	for _, network := range networks {
+		if network.Name == ic.Libpod.GetDefaultNetworkName() {
+			continue
+		}
+		// The ID and interface are assigned again on restore.
+		network.ID = ""
+		network.NetworkInterface = ""
+		network.Created = time.Time{}
+		files = append(files, backupJSONFile{backupFile(backupNetworksDir, network.Name+".json"), network})
+		index.Networks = append(index.Networks, network.Name)
+	}
+
+	volumes, err := ic.Libpod.GetAllVolumes()
+	if err != nil {
+		return nil, err
+	}
+	var dataVolumes []*libpod.Volume
+	for _, vol := range volumes {
+		record := backupVolumeRecord(vol)
+		files = append(files, backupJSONFile{backupFile(backupVolumesDir, vol.Name()+".json"), record})
+		index.Volumes = append(index.Volumes, vol.Name())
+		if record.Data {
+			dataVolumes = append(dataVolumes, vol)
+		}
+	}
+
+	secrets, err := ic.backupSecrets(options.Passphrase)
+	if err != nil {
+		return nil, err
+	}
+	for _, secret := range secrets {
+		files = append(files, backupJSONFile{backupFile(backupSecretsDir, secret.Name+".json"), secret})
+		index.Secrets = append(index.Secrets, secret.Name)
+	}
+
+	pods, err := ic.Libpod.GetAllPods()
+	if err != nil {
+		return nil, err
+	}
+	podNames := make(map[string]string, len(pods))
+	infraIDs := make(map[string]bool, len(pods))
+	for _, pod := range pods {
+		spec, err := ic.backupPodSpec(pod)
+		if err != nil {
+			return nil, fmt.Errorf("backing up pod %s: %w", pod.Name(), err)
+		}
+		files = append(files, backupJSONFile{backupFile(backupPodsDir, pod.Name()+".json"), spec})
+		index.Pods = append(index.Pods, pod.Name())
+		podNames[pod.ID()] = pod.Name()
+		if pod.HasInfraContainer() {
+			infraID, err := pod.InfraContainerID()
+			if err != nil {
+				return nil, err
+			}
+			infraIDs[infraID] = true
+		}
+	}
+
+	ctrs, err := ic.Libpod.GetAllContainers()
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(ctrs, func(i, j int) bool {
+		return ctrs[i].CreatedTime().Before(ctrs[j].CreatedTime())
+	})
+	images := make(map[string]bool)
+	for _, ctr := range ctrs {
+		// Infra containers are created with their pods and service
+		// containers by play kube.
+		if ctr.IsInfra() || ctr.IsService() {
+			continue
+		}
+		spec, err := ic.backupContainerSpec(ctr, podNames, infraIDs)
+		if err != nil {
+			return nil, fmt.Errorf("backing up container %s: %w", ctr.Name(), err)
+		}
+		files = append(files, backupJSONFile{backupFile(backupContainersDir, ctr.Name()+".json"), spec})
+		index.Containers = append(index.Containers, ctr.Name())
+		if spec.Image != "" && !images[spec.Image] {
+			images[spec.Image] = true
+			index.Images = append(index.Images, spec.Image)
+		}
+	}
+
+	tw := tar.NewWriter(w)
+	if err := writeBackupJSON(tw, backupIndexFile, index); err != nil {
+		return nil, err
+	}
+	for _, file := range files {
+		if err := writeBackupJSON(tw, file.name, file.v); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, vol := range dataVolumes {
+		if err := writeVolumeData(tw, vol); err != nil {
+			return nil, fmt.Errorf("backing up contents of volume %s: %w", vol.Name(), err)
+		}
+	}
+
+	if options.IncludeImages && len(index.Images) > 0 {
+		if err := ic.writeBackupImages(ctx, tw, index.Images); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return nil, err
+	}
+
+	return &entities.SystemBackupReport{
+		Containers: index.Containers,
+		Pods:       index.Pods,
+		Networks:   index.Networks,
+		Volumes:    index.Volumes,
+		Secrets:    index.Secrets,
+		Images:     index.Images,
+	}, nil
+}
+
+// backupVolumeRecord returns the description of the volume in the backup.
+// The contents of volumes backed by a device, including bind mounted host
+// directories, are not part of the backup.
+func backupVolumeRecord(vol *libpod.Volume) backupVolume {
+	record := backupVolume{
+		Name:    vol.Name(),
+		Driver:  vol.Driver(),
+		Labels:  vol.Labels(),
+		Options: vol.Options(),
+	}
+	_, hasDevice := record.Options["device"]
+	record.Data = !hasDevice && vol.Driver() != define.VolumeDriverImage
+	return record
+}
+
+// writeVolumeData streams the contents of the volume to the backup.  The
+// files are stored below volumes/NAME/.
+func writeVolumeData(tw *tar.Writer, vol *libpod.Volume) (retErr error) {
+	mountPoint, err := vol.Mount()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := vol.Unmount(); err != nil {
+			if retErr == nil {
+				retErr = err
+			} else {
+				logrus.Errorf("Unmounting volume %s: %v", vol.Name(), err)
+			}
+		}
+	}()
+
+	data, err := utils.TarWithChroot(mountPoint)
+	if err != nil {
+		return err
+	}
+	defer data.Close()
+
+	prefix := backupFile(backupVolumesDir, vol.Name()) + "/"
+	tr := tar.NewReader(data)
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		header.Name = prefix + header.Name
+		if header.Typeflag == tar.TypeLink {
+			header.Linkname = prefix + header.Linkname
+		}
+		// The longer names may require another format.
+		header.Format = tar.FormatUnknown
+		if err := tw.WriteHeader(header); err != nil {
+			return err
+		}
+		if _, err := io.Copy(tw, tr); err != nil {
+			return err
+		}
+	}
+}
+
+// backupTmpDir returns the directory to stage images in.  Like for copying
+// images, it must have room for them, so it defaults to /var/tmp.
+func (ic *ContainerEngine) backupTmpDir() (string, error) {
+	config, err := ic.Libpod.GetConfigNoCopy()
+	if err != nil {
+		return "", err
+	}
+	tmpDir, err := config.ImageCopyTmpDir()
+	if err != nil {
+		return "", err
+	}
+	if tmpDir == "" {
+		tmpDir = util.Tmpdir()
+	}
+	return tmpDir, nil
+}
+
+// writeBackupImages saves the images to a docker-archive and writes it to
+// the backup.
+func (ic *ContainerEngine) writeBackupImages(ctx context.Context, tw *tar.Writer, images []string) error {
+	parentDir, err := ic.backupTmpDir()
+	if err != nil {
+		return err
+	}
+	tmpDir, err := os.MkdirTemp(parentDir, "podman-backup")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmpDir)
+
+	imagesPath := filepath.Join(tmpDir, backupImagesFile)
+	if err := ic.Libpod.LibimageRuntime().Save(ctx, images, "docker-archive", imagesPath, nil); err != nil {
+		return fmt.Errorf("saving images: %w", err)
+	}
+	return writeBackupFileFrom(tw, backupImagesFile, imagesPath)
+}
+
+// backupSecrets returns all secrets including their data.  The data is
+// encrypted if a passphrase is given.
+func (ic *ContainerEngine) backupSecrets(passphrase []byte) ([]backupSecret, error) {
+	manager, err := ic.Libpod.SecretsManager()
+	if err != nil {
+		return nil, err
+	}
+	secrets, err := manager.List()
+	if err != nil {
+		return nil, err
+	}
+	defaultFilePath := filepath.Join(ic.Libpod.GetSecretsStorageDir(), "filedriver")
+	records := make([]backupSecret, 0, len(secrets))
+	for _, secret := range secrets {
+		_, data, err := manager.LookupSecretData(secret.ID)
+		if err != nil {
+			return nil, fmt.Errorf("backing up secret %s: %w", secret.Name, err)
+		}
+		if len(passphrase) > 0 {
+			data, err = encrypted.Encrypt(data, passphrase)
+			if err != nil {
+				return nil, fmt.Errorf("encrypting secret %s: %w", secret.Name, err)
+			}
+		}
+		record := backupSecret{
+			Name:          secret.Name,
+			Driver:        secret.Driver,
+			DriverOptions: make(map[string]string, len(secret.DriverOptions)),
+			Labels:        secret.Labels,
+			Data:          data,
+		}
+		for key, value := range secret.DriverOptions {
+			// The default location of the file driver is set
+			// again on restore.
+			if secret.Driver == "file" && key == "path" && value == defaultFilePath {
+				continue
+			}
+			record.DriverOptions[key] = value
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+// backupPodSpec returns the spec to recreate the pod with.  As for pod clone,
+// the matching options of the infra container are options of the pod.
+func (ic *ContainerEngine) backupPodSpec(pod *libpod.Pod) (*specgen.PodSpecGenerator, error) {
+	data, err := pod.Inspect()
+	if err != nil {
+		return nil, err
+	}
+
+	spec := specgen.NewPodSpecGenerator()
+	if pod.HasInfraContainer() {
+		infra, err := pod.InfraContainer()
+		if err != nil {
+			return nil, err
+		}
+		infraSpec := &specgen.SpecGenerator{}
+		if _, _, err := generate.ConfigToSpec(ic.Libpod, infraSpec, infra.ID()); err != nil {
+			return nil, err
+		}
+		matching, err := json.Marshal(infraSpec)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(matching, spec); err != nil {
+			return nil, err
+		}
+		if infra.Name() != pod.ID()[:12]+"-infra" {
+			spec.InfraName = infra.Name()
+		}
+		// The pause image is built locally, so it is only kept if
+		// another image was used.
+		if _, name := infra.Image(); !strings.HasPrefix(name, "localhost/podman-pause:") {
+			spec.InfraImage = name
+		}
+	}
+
+	spec.Name = pod.Name()
+	spec.Labels = pod.Labels()
+	spec.Hostname = pod.Hostname()
+	spec.CgroupParent = ""
+	spec.ServiceContainerID = ""
+	spec.NoInfra = !pod.HasInfraContainer()
+	spec.SharedNamespaces = data.SharedNamespaces
+	spec.ExitPolicy = data.ExitPolicy
+	spec.RestartPolicy = data.RestartPolicy
+	spec.PodCreateCommand = pod.CreateCommand()
+	return spec, nil
+}
+
+// backupContainerSpec returns the spec to recreate the container with.  It
+// references pods, images and the containers it depends on by name, as their
+// IDs change on restore.  Namespaces shared with the infra container of the
+// pod of the container are left to the pod.
+func (ic *ContainerEngine) backupContainerSpec(ctr *libpod.Container, podNames map[string]string, infraIDs map[string]bool) (*specgen.SpecGenerator, error) {
+	spec := &specgen.SpecGenerator{}
+	if _, _, err := generate.ConfigToSpec(ic.Libpod, spec, ctr.ID()); err != nil {
+		return nil, err
+	}
+	conf := ctr.Config()
+
+	spec.Name = ctr.Name()
+	spec.Pod = podNames[conf.Pod]
+	spec.Terminal = ctr.Terminal()
+	if id, name := ctr.Image(); id != "" {
+		if name == "" {
+			name = id
+		}
+		spec.Image = name
+		spec.RawImageName = name
+	}
+
+	ctrName := func(id string) (string, error) {
+		dep, err := ic.Libpod.LookupContainer(id)
+		if err != nil {
+			return "", err
+		}
+		return dep.Name(), nil
+	}
+
+	namespaces := []struct {
+		ctr string
+		ns  *specgen.Namespace
+	}{
+		{conf.PIDNsCtr, &spec.PidNS},
+		{conf.NetNsCtr, &spec.NetNS},
+		{conf.CgroupNsCtr, &spec.CgroupNS},
+		{conf.IPCNsCtr, &spec.IpcNS},
+		{conf.UTSNsCtr, &spec.UtsNS},
+		{conf.UserNsCtr, &spec.UserNS},
+	}
+	for _, namespace := range namespaces {
+		switch {
+		case namespace.ctr == "":
+			continue
+		case infraIDs[namespace.ctr]:
+			*namespace.ns = specgen.Namespace{NSMode: specgen.Default}
+		default:
+			name, err := ctrName(namespace.ctr)
+			if err != nil {
+				return nil, err
+			}
+			*namespace.ns = specgen.Namespace{NSMode: specgen.FromContainer, Value: name}
+		}
+	}
+	if conf.NetNsCtr != "" {
+		spec.Networks = nil
+		spec.NetworkOptions = nil
+		spec.PortMappings = nil
+		spec.Expose = nil
+	}
+
+	spec.DependencyContainers = nil
+	for _, id := range conf.Dependencies {
+		if infraIDs[id] {
+			continue
+		}
+		name, err := ctrName(id)
+		if err != nil {
+			return nil, err
+		}
+		if condition, ok := conf.DependencyConditions[id]; ok && condition != define.DependencyConditionStarted {
+			name += ":" + string(condition)
+		}
+		spec.DependencyContainers = append(spec.DependencyContainers, name)
+	}
+
+	return spec, nil
+}
+
+// backupFile returns the name of a file in a directory of a backup.
+func backupFile(dir, name string) string {
+	return dir + "/" + name
+}
+
+// writeBackupJSON writes v as a JSON file to the backup.
+func writeBackupJSON(tw *tar.Writer, name string, v interface{}) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeBackupFile(tw, name, bytes.NewReader(data), int64(len(data)))
+}
+
+// writeBackupFileFrom writes the file at the given path to the backup.
+func writeBackupFileFrom(tw *tar.Writer, name, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	return writeBackupFile(tw, name, f, info.Size())
+}
+
+// writeBackupFile writes a file with the given contents to the backup.
+func writeBackupFile(tw *tar.Writer, name string, r io.Reader, size int64) error {
+	header := &tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     name,
+		Mode:     0o600,
+		Size:     size,
+		ModTime:  time.Now(),
+	}
+	if err := tw.WriteHeader(header); err != nil {
+		return fmt.Errorf("writing %s to backup: %w", name, err)
+	}
+	if _, err := io.Copy(tw, r); err != nil {
+		return fmt.Errorf("writing %s to backup: %w", name, err)
+	}
+	return nil
+}
+
+// SystemRestore recreates the networks, volumes, secrets, pods and containers
+// of a backup written by SystemBackup.  Nothing is replaced: if anything of
+// the same name exists already, it must be renamed with the name mapping of
+// the options.  If restoring anything fails, everything restored before is
+// removed again.  The backup is read as a stream, only the images are staged
+// in a temporary file.
+func (ic *ContainerEngine) SystemRestore(ctx context.Context, r io.Reader, options entities.SystemRestoreOptions) (_ *entities.SystemRestoreReport, retErr error) {
+	decompressed, err := archive.DecompressStream(r)
+	if err != nil {
+		return nil, err
+	}
+	defer decompressed.Close()
+	tr := tar.NewReader(decompressed)
+
+	header, err := nextBackupFile(tr)
+	if err != nil {
+		return nil, err
+	}
+	if header == nil || header.Name != backupIndexFile {
+		return nil, fmt.Errorf("backup does not start with %s: %w", backupIndexFile, define.ErrInvalidArg)
+	}
+	index := backupIndex{}
+	if err := readBackupJSON(tr, header, &index); err != nil {
+		return nil, err
+	}
+	if index.Version != backupVersion {
+		return nil, fmt.Errorf("backup version %d is not supported, only version %d is: %w", index.Version, backupVersion, define.ErrInvalidArg)
+	}
+	if index.SecretsEncrypted && len(options.Passphrase) == 0 {
+		return nil, fmt.Errorf("the secrets of the backup are encrypted, a passphrase is required: %w", define.ErrInvalidArg)
+	}
+
+	// The JSON files come before the contents of volumes and the images.
+	files := make(map[string]*tar.Header)
+	data := make(map[string][]byte)
+	for {
+		header, err = nextBackupFile(tr)
+		if err != nil {
+			return nil, err
+		}
+		if header == nil || !strings.HasSuffix(header.Name, ".json") || strings.Count(header.Name, "/") != 1 {
+			break
+		}
+		if _, exists := files[header.Name]; exists {
+			return nil, fmt.Errorf("duplicate file %q in backup: %w", header.Name, define.ErrInvalidArg)
+		}
+		files[header.Name] = header
+		if data[header.Name], err = io.ReadAll(tr); err != nil {
+			return nil, fmt.Errorf("reading %s from backup: %w", header.Name, err)
+		}
+	}
+	readJSON := func(name string, v interface{}) error {
+		if _, exists := files[name]; !exists {
+			return fmt.Errorf("%s is missing in backup: %w", name, define.ErrInvalidArg)
+		}
+		if err := json.Unmarshal(data[name], v); err != nil {
+			return fmt.Errorf("reading %s from backup: %w", name, err)
+		}
+		return nil
+	}
+
+	m := newBackupMapping(options)
+
+	networks := make([]types.Network, 0, len(index.Networks))
+	for _, name := range index.Networks {
+		network := types.Network{}
+		if err := readJSON(backupFile(backupNetworksDir, name+".json"), &network); err != nil {
+			return nil, err
+		}
+		network.Name = m.name("network", network.Name)
+		networks = append(networks, network)
+	}
+	volumes := make([]backupVolume, 0, len(index.Volumes))
+	for _, name := range index.Volumes {
+		vol := backupVolume{}
+		if err := readJSON(backupFile(backupVolumesDir, name+".json"), &vol); err != nil {
+			return nil, err
+		}
+		m.mapVolume(&vol)
+		volumes = append(volumes, vol)
+	}
+	secrets := make([]backupSecret, 0, len(index.Secrets))
+	for _, name := range index.Secrets {
+		secret := backupSecret{}
+		if err := readJSON(backupFile(backupSecretsDir, name+".json"), &secret); err != nil {
+			return nil, err
+		}
+		if index.SecretsEncrypted {
+			secret.Data, err = encrypted.Decrypt(secret.Data, options.Passphrase)
+			if err != nil {
+				return nil, fmt.Errorf("decrypting secret %s: %w", secret.Name, err)
+			}
+		}
+		m.mapSecret(&secret)
+		secrets = append(secrets, secret)
+	}
+	pods := make([]*specgen.PodSpecGenerator, 0, len(index.Pods))
+	for _, name := range index.Pods {
+		spec := &specgen.PodSpecGenerator{}
+		if err := readJSON(backupFile(backupPodsDir, name+".json"), spec); err != nil {
+			return nil, err
+		}
+		m.mapPodSpec(spec)
+		pods = append(pods, spec)
+	}
+	ctrs := make([]*specgen.SpecGenerator, 0, len(index.Containers))
+	for _, name := range index.Containers {
+		spec := &specgen.SpecGenerator{}
+		if err := readJSON(backupFile(backupContainersDir, name+".json"), spec); err != nil {
+			return nil, err
+		}
+		m.mapContainerSpec(spec)
+		ctrs = append(ctrs, spec)
+	}
+	ctrs, err = sortBackupContainers(ctrs)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ic.checkRestoreConflicts(networks, volumes, secrets, pods, ctrs); err != nil {
+		return nil, err
+	}
+
+	// Everything restored is removed again if restoring anything fails.
+	var rollback []func() error
+	defer func() {
+		if retErr == nil {
+			return
+		}
+		for i := len(rollback) - 1; i >= 0; i-- {
+			if err := rollback[i](); err != nil {
+				logrus.Errorf("Rolling back restore: %v", err)
+			}
+		}
+	}()
+
+	report := &entities.SystemRestoreReport{Images: index.Images}
+	for _, network := range networks {
+		if _, err := ic.NetworkCreate(ctx, network, nil); err != nil {
+			return nil, fmt.Errorf("restoring network %s: %w", network.Name, err)
+		}
+		name := network.Name
+		rollback = append(rollback, func() error {
+			return ic.Libpod.Network().NetworkRemove(name)
+		})
+		report.Networks = append(report.Networks, name)
+	}
+
+	manager, err := ic.Libpod.SecretsManager()
+	if err != nil {
+		return nil, err
+	}
+	for _, secret := range secrets {
+		createOptions := entities.SecretCreateOptions{
+			Driver:     secret.Driver,
+			DriverOpts: secret.DriverOptions,
+			Labels:     secret.Labels,
+		}
+		if _, err := ic.SecretCreate(ctx, secret.Name, bytes.NewReader(secret.Data), createOptions); err != nil {
+			return nil, fmt.Errorf("restoring secret %s: %w", secret.Name, err)
+		}
+		name := secret.Name
+		rollback = append(rollback, func() error {
+			_, err := manager.Delete(name)
+			return err
+		})
+		report.Secrets = append(report.Secrets, name)
+	}
+
+	// The volumes are looked up by their names in the backup when their
+	// contents are read.
+	dataVolumes := make(map[string]*libpod.Volume)
+	for i, vol := range volumes {
+		createOptions := entities.VolumeCreateOptions{
+			Name:    vol.Name,
+			Driver:  vol.Driver,
+			Label:   vol.Labels,
+			Options: vol.Options,
+		}
+		if _, err := ic.VolumeCreate(ctx, createOptions); err != nil {
+			return nil, fmt.Errorf("restoring volume %s: %w", vol.Name, err)
+		}
+		created, err := ic.Libpod.LookupVolume(vol.Name)
+		if err != nil {
+			return nil, err
+		}
+		rollback = append(rollback, func() error {
+			return ic.Libpod.RemoveVolume(ctx, created, true, nil)
+		})
+		if vol.Data {
+			dataVolumes[index.Volumes[i]] = created
+		}
+		report.Volumes = append(report.Volumes, vol.Name)
+	}
+
+	imagesLoaded := false
+	for header != nil {
+		if header.Name == backupImagesFile {
+			if !index.ImagesIncluded || imagesLoaded {
+				return nil, fmt.Errorf("unexpected file %q in backup: %w", header.Name, define.ErrInvalidArg)
+			}
+			if err := ic.loadBackupImages(ctx, tr); err != nil {
+				return nil, err
+			}
+			imagesLoaded = true
+			if header, err = nextBackupFile(tr); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		name, _, ok := volumeDataPath(header.Name)
+		vol, exists := dataVolumes[name]
+		if !ok || !exists {
+			return nil, fmt.Errorf("unexpected file %q in backup: %w", header.Name, define.ErrInvalidArg)
+		}
+		// Every volume is read only once.
+		delete(dataVolumes, name)
+		if header, err = restoreVolumeFromBackup(tr, header, name, vol, m); err != nil {
+			return nil, fmt.Errorf("restoring contents of volume %s: %w", vol.Name(), err)
+		}
+	}
+
+	if !index.ImagesIncluded {
+		for _, name := range index.Images {
+			if _, err := ic.Libpod.LibimageRuntime().Pull(ctx, name, config.PullPolicyMissing, nil); err != nil {
+				return nil, fmt.Errorf("pulling image %s: %w", name, err)
+			}
+		}
+	} else if !imagesLoaded && len(index.Images) > 0 {
+		return nil, fmt.Errorf("%s is missing in backup: %w", backupImagesFile, define.ErrInvalidArg)
+	}
+
+	for _, spec := range pods {
+		pod, err := ic.restorePod(spec)
+		if err != nil {
+			return nil, fmt.Errorf("restoring pod %s: %w", spec.Name, err)
+		}
+		rollback = append(rollback, func() error {
+			_, err := ic.Libpod.RemovePod(ctx, pod, true, true, nil)
+			return err
+		})
+		report.Pods = append(report.Pods, pod.Name())
+	}
+
+	for _, spec := range ctrs {
+		name := spec.Name
+		if _, err := ic.ContainerCreate(ctx, spec); err != nil {
+			return nil, fmt.Errorf("restoring container %s: %w", name, err)
+		}
+		ctr, err := ic.Libpod.LookupContainer(name)
+		if err != nil {
+			return nil, err
+		}
+		rollback = append(rollback, func() error {
+			return ic.Libpod.RemoveContainer(ctx, ctr, true, false, nil)
+		})
+		report.Containers = append(report.Containers, name)
+	}
+
+	return report, nil
+}
+
+// nextBackupFile returns the header of the next file of the backup, or nil at
+// the end of the backup.
+func nextBackupFile(tr *tar.Reader) (*tar.Header, error) {
+	header, err := tr.Next()
+	if errors.Is(err, io.EOF) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading backup: %w", err)
+	}
+	if _, _, ok := volumeDataPath(header.Name); ok {
+		return header, nil
+	}
+	if header.Typeflag != tar.TypeReg || !isBackupFile(header.Name) {
+		return nil, fmt.Errorf("unexpected file %q in backup: %w", header.Name, define.ErrInvalidArg)
+	}
+	return header, nil
+}
+
+// isBackupFile returns whether name is the name of a file of a backup, apart
+// from the contents of volumes.
+func isBackupFile(name string) bool {
+	if name == backupIndexFile || name == backupImagesFile {
+		return true
+	}
+	dir, file, ok := strings.Cut(name, "/")
+	if !ok || file == "" || file != filepath.Base(file) || strings.HasPrefix(file, ".") {
+		return false
+	}
+	switch dir {
+	case backupNetworksDir, backupVolumesDir, backupSecretsDir, backupPodsDir, backupContainersDir:
+		return strings.HasSuffix(file, ".json")
+	}
+	return false
+}
+
+// volumeDataPath splits the name of a file of the contents of a volume into
+// the name of the volume and the path of the file in the volume.
+func volumeDataPath(name string) (string, string, bool) {
+	rest, ok := strings.CutPrefix(name, backupVolumesDir+"/")
+	if !ok {
+		return "", "", false
+	}
+	volume, path, ok := strings.Cut(rest, "/")
+	if !ok || volume == "" || volume == "." || volume == ".." || path == "" {
+		return "", "", false
+	}
+	return volume, path, true
+}
+
+// readBackupJSON reads the JSON file of the backup with the given header.
+func readBackupJSON(tr *tar.Reader, header *tar.Header, v interface{}) error {
+	data, err := io.ReadAll(tr)
+	if err != nil {
+		return fmt.Errorf("reading %s from backup: %w", header.Name, err)
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("reading %s from backup: %w", header.Name, err)
+	}
+	return nil
+}
+
+// loadBackupImages loads the images of the backup, which are staged in a
+// temporary file as a docker-archive cannot be read from a stream.
+func (ic *ContainerEngine) loadBackupImages(ctx context.Context, r io.Reader) error {
+	parentDir, err := ic.backupTmpDir()
+	if err != nil {
+		return err
+	}
+	tmpDir, err := os.MkdirTemp(parentDir, "podman-restore")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmpDir)
+
+	imagesPath := filepath.Join(tmpDir, backupImagesFile)
+	f, err := os.OpenFile(imagesPath, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(f, r)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("extracting %s from backup: %w", backupImagesFile, err)
+	}
+
+	if _, err := ic.Libpod.LibimageRuntime().Load(ctx, imagesPath, nil); err != nil {
+		return fmt.Errorf("loading images: %w", err)
+	}
+	return nil
+}
+
+// restoreVolumeFromBackup streams the contents of the volume, starting with
+// the file of the given header, from the backup into vol.  It returns the
+// header of the first file after them.
+func restoreVolumeFromBackup(tr *tar.Reader, header *tar.Header, name string, vol *libpod.Volume, m *backupMapping) (*tar.Header, error) {
+	pr, pw := io.Pipe()
+	errChan := make(chan error, 1)
+	go func() {
+		err := restoreVolumeData(vol, pr, m)
+		if err == nil {
+			// Consume the end of the stream so that the
+			// writer does not block.
+			_, err = io.Copy(io.Discard, pr)
+		}
+		pr.CloseWithError(err)
+		errChan <- err
+	}()
+
+	next, err := copyVolumeData(tr, header, name, pw)
+	pw.CloseWithError(err)
+	if restoreErr := <-errChan; restoreErr != nil {
+		return nil, restoreErr
+	}
+	return next, err
+}
+
+// copyVolumeData writes the contents of the volume with the given name,
+// starting with the file of the given header, from the backup to w as a tar
+// archive of its own.  It returns the header of the first file after them.
+func copyVolumeData(tr *tar.Reader, header *tar.Header, name string, w io.Writer) (*tar.Header, error) {
+	prefix := backupFile(backupVolumesDir, name) + "/"
+	tw := tar.NewWriter(w)
+	for header != nil {
+		volume, path, ok := volumeDataPath(header.Name)
+		if !ok || volume != name {
+			break
+		}
+		header.Name = path
+		if header.Typeflag == tar.TypeLink {
+			linkname, ok := strings.CutPrefix(header.Linkname, prefix)
+			if !ok {
+				return nil, fmt.Errorf("link %q in backup points outside of volume %s: %w", prefix+path, name, define.ErrInvalidArg)
+			}
+			header.Linkname = linkname
+		}
+		header.Format = tar.FormatUnknown
+		if err := tw.WriteHeader(header); err != nil {
+			return nil, err
+		}
+		if _, err := io.Copy(tw, tr); err != nil {
+			return nil, err
+		}
+
+		var err error
+		if header, err = nextBackupFile(tr); err != nil {
+			return nil, err
+		}
+	}
+	return header, tw.Close()
+}
+
+// checkRestoreConflicts returns an error if anything to restore exists already.
+func (ic *ContainerEngine) checkRestoreConflicts(networks []types.Network, volumes []backupVolume, secrets []backupSecret, pods []*specgen.PodSpecGenerator, ctrs []*specgen.SpecGenerator) error {
+	conflict := func(kind, name string) error {
+		return fmt.Errorf("%s %s already exists, restore it under another name with --map-name %s:%s=NEWNAME: %w", kind, name, kind, name, define.ErrInvalidArg)
+	}
+	for _, network := range networks {
+		if _, err := ic.Libpod.Network().NetworkInspect(network.Name); err == nil {
+			return conflict("network", network.Name)
+		}
+	}
+	for _, vol := range volumes {
+		if _, err := ic.Libpod.LookupVolume(vol.Name); err == nil {
+			return conflict("volume", vol.Name)
+		}
+	}
+	manager, err := ic.Libpod.SecretsManager()
+	if err != nil {
+		return err
+	}
+	for _, secret := range secrets {
+		if _, err := manager.Lookup(secret.Name); err == nil {
+			return conflict("secret", secret.Name)
+		}
+	}
+	for _, pod := range pods {
+		if _, err := ic.Libpod.LookupPod(pod.Name); err == nil {
+			return conflict("pod", pod.Name)
+		}
+	}
+	for _, ctr := range ctrs {
+		if _, err := ic.Libpod.LookupContainer(ctr.Name); err == nil {
+			return conflict("container", ctr.Name)
+		}
+	}
+	return nil
+}
+
+// restorePod creates a pod from its spec, the same way pods created through
+// the REST API are.
+func (ic *ContainerEngine) restorePod(spec *specgen.PodSpecGenerator) (*libpod.Pod, error) {
+	if !spec.NoInfra {
+		spec.InfraContainerSpec = &specgen.SpecGenerator{}
+		infraOptions := entities.NewInfraContainerCreateOptions()
+		infraOptions.Net = &entities.NetOptions{}
+		infraOptions.Devices = spec.Devices
+		infraOptions.SecurityOpt = spec.SecurityOpt
+		if spec.ShareParent == nil {
+			shareParent := true
+			spec.ShareParent = &shareParent
+		}
+		if err := specgenutil.FillOutSpecGen(spec.InfraContainerSpec, &infraOptions, []string{}); err != nil {
+			return nil, err
+		}
+		matching, err := json.Marshal(spec)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(matching, spec.InfraContainerSpec); err != nil {
+			return nil, err
+		}
+		spec.InfraContainerSpec.Name = spec.InfraName
+		spec.InfraContainerSpec.ConmonPidFile = spec.InfraConmonPidFile
+		spec.InfraContainerSpec.ContainerCreateCommand = spec.InfraCommand
+		spec.InfraContainerSpec.Image = spec.InfraImage
+		spec.InfraContainerSpec.RawImageName = spec.InfraImage
+	}
+	return generate.MakePod(&entities.PodSpec{PodSpecGen: *spec}, ic.Libpod)
+}
+
+// restoreVolumeData extracts the contents of a volume from the tar archive
+// read from r and maps the owners of the files.
+func restoreVolumeData(vol *libpod.Volume, r io.Reader, m *backupMapping) (retErr error) {
+	mountPoint, err := vol.Mount()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := vol.Unmount(); err != nil {
+			if retErr == nil {
+				retErr = err
+			} else {
+				logrus.Errorf("Unmounting volume %s: %v", vol.Name(), err)
+			}
+		}
+	}()
+
+	if err := archive.UntarUncompressed(r, mountPoint, nil); err != nil {
+		return err
+	}
+
+	if len(m.uids) == 0 && len(m.gids) == 0 {
+		return nil
+	}
+	return filepath.Walk(mountPoint, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		stat, ok := info.Sys().(*syscall.Stat_t)
+		if !ok {
+			return nil
+		}
+		uid, gid := m.uid(int(stat.Uid)), m.gid(int(stat.Gid))
+		if uid == int(stat.Uid) && gid == int(stat.Gid) {
+			return nil
+		}
+		return os.Lchown(path, uid, gid)
+	})
+}
+
+// sortBackupContainers sorts the specs of containers so that each container
+// comes after the containers it depends on, as they must be created first.
+// Otherwise, the order is kept.
+func sortBackupContainers(specs []*specgen.SpecGenerator) ([]*specgen.SpecGenerator, error) {
+	return util.SortByDependencies(specs,
+		func(spec *specgen.SpecGenerator) string { return spec.Name },
+		func(spec *specgen.SpecGenerator) ([]string, error) { return backupContainerDependencies(spec), nil },
+		// Dependencies not part of the backup must exist already.
+		func(*specgen.SpecGenerator, string) error { return nil })
+}
+
+// backupContainerDependencies returns the names of the containers the
+// container of the spec depends on.
+func backupContainerDependencies(spec *specgen.SpecGenerator) []string {
+	var deps []string
+	for _, ns := range specNamespaces(spec) {
+		if ns.NSMode == specgen.FromContainer {
+			deps = append(deps, ns.Value)
+		}
+	}
+	for _, dep := range spec.DependencyContainers {
+		name, _, _ := strings.Cut(dep, ":")
+		deps = append(deps, name)
+	}
+	return deps
+}
+
+// specNamespaces returns the namespaces of the spec which may be shared with
+// other containers.
+func specNamespaces(spec *specgen.SpecGenerator) []*specgen.Namespace {
+	return []*specgen.Namespace{&spec.PidNS, &spec.NetNS, &spec.CgroupNS, &spec.IpcNS, &spec.UtsNS, &spec.UserNS}
+}
+
+// backupMapping maps the names, host paths and file owners of a backup to
+// those on the restoring host.
+type backupMapping struct {
+	names map[string]string
+	// Cleaned paths.
+	paths map[string]string
+	uids  map[int]int
+	gids  map[int]int
+}
+
+func newBackupMapping(options entities.SystemRestoreOptions) *backupMapping {
+	m := &backupMapping{
+		names: options.NameMap,
+		paths: make(map[string]string, len(options.PathMap)),
+		uids:  options.UIDMap,
+		gids:  options.GIDMap,
+	}
+	for oldPath, newPath := range options.PathMap {
+		m.paths[filepath.Clean(oldPath)] = filepath.Clean(newPath)
+	}
+	return m
+}
+
+// name maps the name of something of the given kind.  A mapping for KIND:NAME
+// takes precedence over one for NAME.
+func (m *backupMapping) name(kind, name string) string {
+	if newName, ok := m.names[kind+":"+name]; ok {
+		return newName
+	}
+	if newName, ok := m.names[name]; ok {
+		return newName
+	}
+	return name
+}
+
+// path maps a host path.  The longest matching prefix wins.
+func (m *backupMapping) path(p string) string {
+	match := ""
+	for oldPath := range m.paths {
+		if (p == oldPath || strings.HasPrefix(p, oldPath+"/") || oldPath == "/") && len(oldPath) > len(match) {
+			match = oldPath
+		}
+	}
+	if match == "" {
+		return p
+	}
+	return filepath.Join(m.paths[match], strings.TrimPrefix(p, match))
+}
+
+func (m *backupMapping) uid(uid int) int {
+	if newUID, ok := m.uids[uid]; ok {
+		return newUID
+	}
+	return uid
+}
+
+func (m *backupMapping) gid(gid int) int {
+	if newGID, ok := m.gids[gid]; ok {
+		return newGID
+	}
+	return gid
+}
+
+// mapVolume maps the name of the volume, the path of the device of the volume
+// and the owner set by its options.
+func (m *backupMapping) mapVolume(vol *backupVolume) {
+	vol.Name = m.name("volume", vol.Name)
+	if device, ok := vol.Options["device"]; ok && filepath.IsAbs(device) {
+		vol.Options["device"] = m.path(device)
+	}
+	if o, ok := vol.Options["o"]; ok {
+		opts := strings.Split(o, ",")
+		for i, opt := range opts {
+			key, value, _ := strings.Cut(opt, "=")
+			id, err := strconv.Atoi(value)
+			if err != nil {
+				continue
+			}
+			switch key {
+			case "uid":
+				opts[i] = "uid=" + strconv.Itoa(m.uid(id))
+			case "gid":
+				opts[i] = "gid=" + strconv.Itoa(m.gid(id))
+			}
+		}
+		vol.Options["o"] = strings.Join(opts, ",")
+	}
+}
+
+// mapSecret maps the name of the secret and the path of the file driver.
+func (m *backupMapping) mapSecret(secret *backupSecret) {
+	secret.Name = m.name("secret", secret.Name)
+	if p, ok := secret.DriverOptions["path"]; ok && secret.Driver == "file" {
+		secret.DriverOptions["path"] = m.path(p)
+	}
+}
+
+// mapPodSpec maps the names of the pod and of the networks and volumes it
+// uses, and the host paths it mounts.
+func (m *backupMapping) mapPodSpec(spec *specgen.PodSpecGenerator) {
+	spec.Name = m.name("pod", spec.Name)
+	spec.Networks = m.mapNetworks(spec.Networks)
+	m.mapStorage(spec.Volumes, spec.Mounts, spec.OverlayVolumes)
+}
+
+// mapContainerSpec maps the names of the container and of the pod, containers,
+// networks, volumes and secrets it uses, and the host paths it mounts.
+func (m *backupMapping) mapContainerSpec(spec *specgen.SpecGenerator) {
+	spec.Name = m.name("container", spec.Name)
+	if spec.Pod != "" {
+		spec.Pod = m.name("pod", spec.Pod)
+	}
+	for _, ns := range specNamespaces(spec) {
+		if ns.NSMode == specgen.FromContainer {
+			ns.Value = m.name("container", ns.Value)
+		}
+	}
+	for i, dep := range spec.DependencyContainers {
+		name, condition, hasCondition := strings.Cut(dep, ":")
+		spec.DependencyContainers[i] = m.name("container", name)
+		if hasCondition {
+			spec.DependencyContainers[i] += ":" + condition
+		}
+	}
+	spec.Networks = m.mapNetworks(spec.Networks)
+	m.mapStorage(spec.Volumes, spec.Mounts, spec.OverlayVolumes)
+	for i := range spec.Secrets {
+		spec.Secrets[i].Source = m.name("secret", spec.Secrets[i].Source)
+	}
+	for env, secret := range spec.EnvSecrets {
+		spec.EnvSecrets[env] = m.name("secret", secret)
+	}
+	if spec.Rootfs != "" {
+		spec.Rootfs = m.path(spec.Rootfs)
+	}
+}
+
+func (m *backupMapping) mapNetworks(networks map[string]types.PerNetworkOptions) map[string]types.PerNetworkOptions {
+	if len(networks) == 0 {
+		return networks
+	}
+	mapped := make(map[string]types.PerNetworkOptions, len(networks))
+	for name, opts := range networks {
+		mapped[m.name("network", name)] = opts
+	}
+	return mapped
+}
+
+func (m *backupMapping) mapStorage(volumes []*specgen.NamedVolume, mounts []specs.Mount, overlays []*specgen.OverlayVolume) {
+	for _, vol := range volumes {
+		vol.Name = m.name("volume", vol.Name)
+	}
+	for i := range mounts {
+		if mounts[i].Type == define.TypeBind {
+			mounts[i].Source = m.path(mounts[i].Source)
+		}
+	}
+	for _, overlay := range overlays {
+		overlay.Source = m.path(overlay.Source)
+	}
+}
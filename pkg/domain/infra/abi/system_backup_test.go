@@ -0,0 +1,170 @@
+package abi
+
+import (
+	"archive/tar"
+	"bytes"
+	"testing"
+
+	"github.com/containers/common/libnetwork/types"
+	"github.com/containers/podman/v4/pkg/domain/entities"
+	"github.com/containers/podman/v4/pkg/specgen"
+	"github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBackupMapping(t *testing.T) {
+	m := newBackupMapping(entities.SystemRestoreOptions{
+		NameMap: map[string]string{
+			"web":           "web2",
+			"volume:web":    "webdata",
+			"network:front": "front2",
+		},
+		PathMap: map[string]string{
+			"/home/alice/":     "/home/bob",
+			"/home/alice/data": "/srv/data",
+		},
+		UIDMap: map[int]int{1000: 2000},
+		GIDMap: map[int]int{1000: 3000},
+	})
+
+	assert.Equal(t, "web2", m.name("container", "web"))
+	assert.Equal(t, "webdata", m.name("volume", "web"))
+	assert.Equal(t, "front2", m.name("network", "front"))
+	assert.Equal(t, "front", m.name("container", "front"))
+
+	assert.Equal(t, "/home/bob", m.path("/home/alice"))
+	assert.Equal(t, "/home/bob/src", m.path("/home/alice/src"))
+	assert.Equal(t, "/srv/data/db", m.path("/home/alice/data/db"))
+	assert.Equal(t, "/home/alice2", m.path("/home/alice2"))
+
+	vol := backupVolume{
+		Name:    "web",
+		Options: map[string]string{"device": "/home/alice/web", "o": "bind,uid=1000,gid=1000,mode=0755"},
+	}
+	m.mapVolume(&vol)
+	assert.Equal(t, "webdata", vol.Name)
+	assert.Equal(t, map[string]string{"device": "/home/bob/web", "o": "bind,uid=2000,gid=3000,mode=0755"}, vol.Options)
+
+	spec := &specgen.SpecGenerator{}
+	spec.Name = "web"
+	spec.Pod = "web"
+	spec.NetNS = specgen.Namespace{NSMode: specgen.FromContainer, Value: "web"}
+	spec.DependencyContainers = []string{"web:healthy", "db"}
+	spec.Networks = map[string]types.PerNetworkOptions{"front": {}}
+	spec.Volumes = []*specgen.NamedVolume{{Name: "web", Dest: "/data"}}
+	spec.Mounts = []specs.Mount{
+		{Type: "bind", Source: "/home/alice/conf", Destination: "/conf"},
+		{Type: "tmpfs", Source: "/home/alice", Destination: "/tmp"},
+	}
+	spec.Secrets = []specgen.Secret{{Source: "web"}}
+	spec.EnvSecrets = map[string]string{"TOKEN": "web"}
+	m.mapContainerSpec(spec)
+	assert.Equal(t, "web2", spec.Name)
+	assert.Equal(t, "web2", spec.Pod)
+	assert.Equal(t, "web2", spec.NetNS.Value)
+	assert.Equal(t, []string{"web2:healthy", "db"}, spec.DependencyContainers)
+	assert.Contains(t, spec.Networks, "front2")
+	assert.Equal(t, "webdata", spec.Volumes[0].Name)
+	assert.Equal(t, "/home/bob/conf", spec.Mounts[0].Source)
+	assert.Equal(t, "/home/alice", spec.Mounts[1].Source)
+	assert.Equal(t, "web2", spec.Secrets[0].Source)
+	assert.Equal(t, "web2", spec.EnvSecrets["TOKEN"])
+}
+
+func TestSortBackupContainers(t *testing.T) {
+	newSpec := func(name string) *specgen.SpecGenerator {
+		spec := &specgen.SpecGenerator{}
+		spec.Name = name
+		return spec
+	}
+	app, db, proxy := newSpec("app"), newSpec("db"), newSpec("proxy")
+	app.DependencyContainers = []string{"db:healthy", "external"}
+	proxy.NetNS = specgen.Namespace{NSMode: specgen.FromContainer, Value: "app"}
+
+	sorted, err := sortBackupContainers([]*specgen.SpecGenerator{proxy, app, db})
+	require.NoError(t, err)
+	assert.Equal(t, []*specgen.SpecGenerator{db, app, proxy}, sorted)
+
+	db.DependencyContainers = []string{"proxy"}
+	_, err = sortBackupContainers([]*specgen.SpecGenerator{proxy, app, db})
+	assert.Error(t, err)
+}
+
+func TestNextBackupFile(t *testing.T) {
+	writeArchive := func(names ...string) *tar.Reader {
+		buf := &bytes.Buffer{}
+		tw := tar.NewWriter(buf)
+		for _, name := range names {
+			require.NoError(t, writeBackupFile(tw, name, bytes.NewReader([]byte("{}")), 2))
+		}
+		require.NoError(t, tw.Close())
+		return tar.NewReader(buf)
+	}
+
+	tr := writeArchive(backupIndexFile, "containers/web.json", "volumes/data/file", backupImagesFile)
+	for _, name := range []string{backupIndexFile, "containers/web.json", "volumes/data/file", backupImagesFile} {
+		header, err := nextBackupFile(tr)
+		require.NoError(t, err)
+		require.NotNil(t, header)
+		assert.Equal(t, name, header.Name)
+	}
+	header, err := nextBackupFile(tr)
+	require.NoError(t, err)
+	assert.Nil(t, header)
+
+	for _, name := range []string{"../evil.json", "containers/../../evil.json", "containers/web.tar", "other/web.json", "containers/.json", "volumes/../evil", "volumes//file"} {
+		_, err := nextBackupFile(writeArchive(name))
+		assert.Error(t, err, name)
+	}
+}
+
+func TestCopyVolumeData(t *testing.T) {
+	buf := &bytes.Buffer{}
+	tw := tar.NewWriter(buf)
+	for _, header := range []*tar.Header{
+		{Typeflag: tar.TypeDir, Name: "volumes/data/dir/", Mode: 0o755},
+		{Typeflag: tar.TypeReg, Name: "volumes/data/dir/file", Mode: 0o644, Size: 4},
+		{Typeflag: tar.TypeLink, Name: "volumes/data/link", Linkname: "volumes/data/dir/file"},
+		{Typeflag: tar.TypeSymlink, Name: "volumes/data/symlink", Linkname: "/etc/passwd"},
+		{Typeflag: tar.TypeReg, Name: backupImagesFile, Mode: 0o600},
+	} {
+		require.NoError(t, tw.WriteHeader(header))
+		if header.Size > 0 {
+			_, err := tw.Write([]byte("data"))
+			require.NoError(t, err)
+		}
+	}
+	require.NoError(t, tw.Close())
+
+	tr := tar.NewReader(buf)
+	header, err := nextBackupFile(tr)
+	require.NoError(t, err)
+	out := &bytes.Buffer{}
+	next, err := copyVolumeData(tr, header, "data", out)
+	require.NoError(t, err)
+	require.NotNil(t, next)
+	assert.Equal(t, backupImagesFile, next.Name)
+
+	var names []string
+	volumeReader := tar.NewReader(out)
+	for {
+		header, err := volumeReader.Next()
+		if err != nil {
+			break
+		}
+		names = append(names, header.Name+" "+header.Linkname)
+	}
+	assert.Equal(t, []string{"dir/ ", "dir/file ", "link dir/file", "symlink /etc/passwd"}, names)
+
+	// Hard links must not point outside of the volume.
+	buf.Reset()
+	tw = tar.NewWriter(buf)
+	require.NoError(t, tw.WriteHeader(&tar.Header{Typeflag: tar.TypeLink, Name: "volumes/data/link", Linkname: "volumes/other/file"}))
+	require.NoError(t, tw.Close())
+	tr = tar.NewReader(buf)
+	header, err = nextBackupFile(tr)
+	require.NoError(t, err)
+	_, err = copyVolumeData(tr, header, "data", &bytes.Buffer{})
+	assert.Error(t, err)
+}
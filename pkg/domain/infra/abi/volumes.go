@@ -234,3 +234,81 @@ func (ic *ContainerEngine) VolumeReload(ctx context.Context) (*entities.VolumeRe
 	report := ic.Libpod.UpdateVolumePlugins(ctx)
 	return &entities.VolumeReloadReport{VolumeReload: *report}, nil
 }
+
+func (ic *ContainerEngine) VolumeSnapshotCreate(ctx context.Context, nameOrID string, opts entities.VolumeSnapshotCreateOptions) (*entities.VolumeSnapshotReport, error) {
+	vol, err := ic.Libpod.LookupVolume(nameOrID)
+	if err != nil {
+		return nil, err
+	}
+	snapshot, err := vol.CreateSnapshot(opts.Name, opts.Pause)
+	if err != nil {
+		return nil, err
+	}
+	return volumeSnapshotReport(vol, snapshot), nil
+}
+
+func (ic *ContainerEngine) VolumeSnapshotList(ctx context.Context, namesOrIds []string) ([]*entities.VolumeSnapshotReport, error) {
+	var (
+		err  error
+		vols []*libpod.Volume
+	)
+	if len(namesOrIds) == 0 {
+		vols, err = ic.Libpod.GetAllVolumes()
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		for _, name := range namesOrIds {
+			vol, err := ic.Libpod.LookupVolume(name)
+			if err != nil {
+				return nil, err
+			}
+			vols = append(vols, vol)
+		}
+	}
+
+	reports := []*entities.VolumeSnapshotReport{}
+	for _, vol := range vols {
+		snapshots, err := vol.Snapshots()
+		if err != nil {
+			return nil, err
+		}
+		for i := range snapshots {
+			reports = append(reports, volumeSnapshotReport(vol, &snapshots[i]))
+		}
+	}
+	return reports, nil
+}
+
+func (ic *ContainerEngine) VolumeSnapshotRestore(ctx context.Context, nameOrID, snapshot string) error {
+	vol, err := ic.Libpod.LookupVolume(nameOrID)
+	if err != nil {
+		return err
+	}
+	return vol.RestoreSnapshot(snapshot)
+}
+
+func (ic *ContainerEngine) VolumeSnapshotRm(ctx context.Context, nameOrID string, snapshots []string) ([]*entities.VolumeSnapshotRmReport, error) {
+	vol, err := ic.Libpod.LookupVolume(nameOrID)
+	if err != nil {
+		return nil, err
+	}
+	reports := make([]*entities.VolumeSnapshotRmReport, 0, len(snapshots))
+	for _, snapshot := range snapshots {
+		reports = append(reports, &entities.VolumeSnapshotRmReport{
+			Err:  vol.RemoveSnapshot(snapshot),
+			Name: snapshot,
+		})
+	}
+	return reports, nil
+}
+
+func volumeSnapshotReport(vol *libpod.Volume, snapshot *libpod.VolumeSnapshot) *entities.VolumeSnapshotReport {
+	return &entities.VolumeSnapshotReport{
+		Volume:           vol.Name(),
+		Name:             snapshot.Name,
+		CreatedAt:        snapshot.CreatedTime,
+		Size:             snapshot.Size,
+		PausedContainers: snapshot.PausedContainers,
+	}
+}
@@ -1116,3 +1116,49 @@ func (ic *ContainerEngine) ContainerUpdate(ctx context.Context, updateOptions *e
 	}
 	return containers.Update(ic.ClientCtx, updateOptions)
 }
+
+// ContainerScheduleList lists the containers started on a schedule.
+func (ic *ContainerEngine) ContainerScheduleList(ctx context.Context) ([]*entities.ContainerScheduleReport, error) {
+	ctrs, err := containers.List(ic.ClientCtx, new(containers.ListOptions).WithAll(true))
+	if err != nil {
+		return nil, err
+	}
+	reports := make([]*entities.ContainerScheduleReport, 0)
+	for _, ctr := range ctrs {
+		data, err := containers.Inspect(ic.ClientCtx, ctr.ID, nil)
+		if err != nil {
+			// The container may have been removed in the meantime.
+			if errModel, ok := err.(*errorhandling.ErrorModel); ok && errModel.ResponseCode == 404 {
+				continue
+			}
+			return nil, err
+		}
+		if data.Config.Schedule == "" {
+			continue
+		}
+		report := &entities.ContainerScheduleReport{
+			ID:       data.ID,
+			Name:     data.Name,
+			Schedule: data.Config.Schedule,
+			State:    data.State.Status,
+		}
+		if schedule := data.State.Schedule; schedule != nil {
+			report.LastRun = schedule.LastRun
+			report.LastExitCode = schedule.LastExitCode
+			report.NextRun = schedule.NextRun
+		}
+		reports = append(reports, report)
+	}
+	return reports, nil
+}
+
+// ContainerScheduleRun starts a container the way its schedule does.
+func (ic *ContainerEngine) ContainerScheduleRun(ctx context.Context, nameOrID string) error {
+	return errors.New("running scheduled containers is not supported on the remote client")
+}
+
+// ContainerRotateLog rotates the log file of a container if it reached its
+// maximum size.
+func (ic *ContainerEngine) ContainerRotateLog(ctx context.Context, nameOrID string) error {
+	return errors.New("rotating container logs is not supported on the remote client")
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/containers/podman/v4/libpod/define"
 	"github.com/containers/podman/v4/pkg/bindings/system"
 	"github.com/containers/podman/v4/pkg/domain/entities"
 )
@@ -22,25 +23,52 @@ func (ir *ImageEngine) FarmNodeDriver(ctx context.Context) string {
 	return remoteFarmImageBuilderDriver
 }
 
-func (ir *ImageEngine) fetchInfo(_ context.Context) (os, arch, variant string, nativePlatforms []string, err error) {
+func (ir *ImageEngine) fetchInfo(_ context.Context) (*define.Info, error) {
 	engineInfo, err := system.Info(ir.ClientCtx, &system.InfoOptions{})
 	if err != nil {
-		return "", "", "", nil, fmt.Errorf("retrieving host info from %q: %w", ir.NodeName, err)
+		return nil, fmt.Errorf("retrieving host info from %q: %w", ir.NodeName, err)
 	}
-	nativePlatform := engineInfo.Host.OS + "/" + engineInfo.Host.Arch
-	if engineInfo.Host.Variant != "" {
-		nativePlatform = nativePlatform + "/" + engineInfo.Host.Variant
-	}
-	return engineInfo.Host.OS, engineInfo.Host.Arch, engineInfo.Host.Variant, []string{nativePlatform}, nil
+	return engineInfo, nil
 }
 
-// FarmNodeInspect returns information about the remote engines in the farm
+// FarmNodeInspect returns information about the remote engines in the farm.
+// The platforms of the engine are only retrieved once, its load every time.
 func (ir *ImageEngine) FarmNodeInspect(ctx context.Context) (*entities.FarmInspectReport, error) {
+	var engineInfo *define.Info
 	ir.platforms.Do(func() {
-		ir.os, ir.arch, ir.variant, ir.nativePlatforms, ir.platformsErr = ir.fetchInfo(ctx)
+		engineInfo, ir.platformsErr = ir.fetchInfo(ctx)
+		if ir.platformsErr != nil {
+			return
+		}
+		if engineInfo.Host == nil {
+			ir.platformsErr = fmt.Errorf("retrieving host info from %q: no host info", ir.NodeName)
+			return
+		}
+		ir.os, ir.arch, ir.variant = engineInfo.Host.OS, engineInfo.Host.Arch, engineInfo.Host.Variant
+		nativePlatform := ir.os + "/" + ir.arch
+		if ir.variant != "" {
+			nativePlatform = nativePlatform + "/" + ir.variant
+		}
+		ir.nativePlatforms = []string{nativePlatform}
 	})
-	return &entities.FarmInspectReport{NativePlatforms: ir.nativePlatforms,
+	report := &entities.FarmInspectReport{NativePlatforms: ir.nativePlatforms,
 		OS:      ir.os,
 		Arch:    ir.arch,
-		Variant: ir.variant}, ir.platformsErr
+		Variant: ir.variant}
+	if ir.platformsErr != nil {
+		return report, ir.platformsErr
+	}
+	if engineInfo == nil {
+		var err error
+		if engineInfo, err = ir.fetchInfo(ctx); err != nil {
+			return report, err
+		}
+	}
+	if engineInfo.Host != nil {
+		report.CPUs = engineInfo.Host.CPUs
+	}
+	if engineInfo.Store != nil {
+		report.RunningContainers = engineInfo.Store.ContainerStore.Running
+	}
+	return report, nil
 }
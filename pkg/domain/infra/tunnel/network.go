@@ -14,6 +14,12 @@ import (
 
 func (ic *ContainerEngine) NetworkUpdate(ctx context.Context, netName string, opts entities.NetworkUpdateOptions) error {
 	options := new(network.UpdateOptions).WithAddDNSServers(opts.AddDNSServers).WithRemoveDNSServers(opts.RemoveDNSServers)
+	options.WithAddLabels(opts.AddLabels).WithRemoveLabels(opts.RemoveLabels)
+	options.WithAddOptions(opts.AddOptions).WithRemoveOptions(opts.RemoveOptions)
+	options.WithAddRoutes(opts.AddRoutes).WithRemoveRoutes(opts.RemoveRoutes)
+	options.WithAddSubnets(opts.AddSubnets)
+	options.Internal = opts.Internal
+	options.DisableDNS = opts.DisableDNS
 	return network.Update(ic.ClientCtx, netName, options)
 }
 
@@ -9,6 +9,7 @@ import (
 	"github.com/containers/podman/v4/pkg/bindings/pods"
 	"github.com/containers/podman/v4/pkg/domain/entities"
 	"github.com/containers/podman/v4/pkg/errorhandling"
+	"github.com/containers/podman/v4/pkg/specgen"
 	"github.com/containers/podman/v4/pkg/util"
 )
 
@@ -100,6 +101,23 @@ func (ic *ContainerEngine) PodUnpause(ctx context.Context, namesOrIds []string,
 	return reports, nil
 }
 
+func (ic *ContainerEngine) PodUpdate(ctx context.Context, opts *entities.PodUpdateOptions) (*entities.PodUpdateReport, error) {
+	options := new(pods.UpdateOptions)
+	if opts.Specgen != nil {
+		if err := specgen.WeightDevices(opts.Specgen); err != nil {
+			return nil, err
+		}
+		if err := specgen.FinishThrottleDevices(opts.Specgen); err != nil {
+			return nil, err
+		}
+		options.Resources = opts.Specgen.ResourceLimits
+	}
+	options.RestartPolicy = opts.RestartPolicy
+	options.RestartRetries = opts.RestartRetries
+	options.ExitPolicy = opts.ExitPolicy
+	return pods.Update(ic.ClientCtx, opts.NameOrID, options)
+}
+
 func (ic *ContainerEngine) PodStop(ctx context.Context, namesOrIds []string, opts entities.PodStopOptions) ([]*entities.PodStopReport, error) {
 	timeout := -1
 	foundPods, err := getPodsByContext(ic.ClientCtx, opts.All, namesOrIds)
@@ -250,3 +268,75 @@ func (ic *ContainerEngine) PodStats(ctx context.Context, namesOrIds []string, op
 	options := new(pods.StatsOptions).WithAll(opts.All)
 	return pods.Stats(ic.ClientCtx, namesOrIds, options)
 }
+
+func (ic *ContainerEngine) PodCheckpoint(ctx context.Context, namesOrIds []string, options entities.PodCheckpointOptions) ([]*entities.PodCheckpointReport, error) {
+	foundPods, err := getPodsByContext(ic.ClientCtx, options.All, namesOrIds)
+	if err != nil {
+		return nil, err
+	}
+	if (options.Export != "" || options.CreateImage != "") && len(foundPods) > 1 {
+		return nil, errors.New("--export and --create-image can only be used with a single pod")
+	}
+	opts := new(pods.CheckpointOptions)
+	opts.WithKeep(options.Keep).WithLeaveRunning(options.LeaveRunning).WithTCPEstablished(options.TCPEstablished)
+	opts.WithIgnoreRootfs(options.IgnoreRootFS).WithIgnoreVolumes(options.IgnoreVolumes)
+	opts.WithFileLocks(options.FileLocks).WithPrintStats(options.PrintStats)
+	if options.Export != "" {
+		opts.WithExport(options.Export)
+	}
+	if options.CreateImage != "" {
+		opts.WithCreateImage(options.CreateImage)
+	}
+
+	reports := make([]*entities.PodCheckpointReport, 0, len(foundPods))
+	for _, p := range foundPods {
+		report, err := pods.Checkpoint(ic.ClientCtx, p.Id, opts)
+		if err != nil {
+			report = &entities.PodCheckpointReport{Err: err}
+		}
+		report.Id = p.Id
+		reports = append(reports, report)
+	}
+	return reports, nil
+}
+
+func (ic *ContainerEngine) PodRestore(ctx context.Context, namesOrIds []string, options entities.PodRestoreOptions) ([]*entities.PodRestoreReport, error) {
+	opts := new(pods.RestoreOptions)
+	opts.WithKeep(options.Keep).WithTCPEstablished(options.TCPEstablished)
+	opts.WithIgnoreRootfs(options.IgnoreRootFS).WithIgnoreVolumes(options.IgnoreVolumes)
+	opts.WithIgnoreStaticIP(options.IgnoreStaticIP).WithIgnoreStaticMAC(options.IgnoreStaticMAC)
+	opts.WithFileLocks(options.FileLocks).WithPrintStats(options.PrintStats)
+	if options.Name != "" {
+		opts.WithName(options.Name)
+	}
+
+	if options.Import != "" {
+		opts.WithImportArchive(options.Import)
+		report, err := pods.Restore(ic.ClientCtx, "", opts)
+		if err != nil {
+			return nil, err
+		}
+		return []*entities.PodRestoreReport{report}, nil
+	}
+
+	if options.All {
+		foundPods, err := getPodsByContext(ic.ClientCtx, true, nil)
+		if err != nil {
+			return nil, err
+		}
+		namesOrIds = make([]string, 0, len(foundPods))
+		for _, p := range foundPods {
+			namesOrIds = append(namesOrIds, p.Id)
+		}
+	}
+	reports := make([]*entities.PodRestoreReport, 0, len(namesOrIds))
+	for _, nameOrID := range namesOrIds {
+		report, err := pods.Restore(ic.ClientCtx, nameOrID, opts)
+		if err != nil {
+			report = &entities.PodRestoreReport{Err: err}
+		}
+		report.RawInput = nameOrID
+		reports = append(reports, report)
+	}
+	return reports, nil
+}
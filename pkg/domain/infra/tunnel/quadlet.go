@@ -0,0 +1,30 @@
+package tunnel
+
+import (
+	"context"
+	"errors"
+
+	"github.com/containers/podman/v4/pkg/domain/entities"
+)
+
+var errQuadletRemote = errors.New("managing quadlets is not supported on the remote client")
+
+func (ic *ContainerEngine) QuadletInstall(ctx context.Context, pathsOrURLs []string, options entities.QuadletInstallOptions) (*entities.QuadletInstallReport, error) {
+	return nil, errQuadletRemote
+}
+
+func (ic *ContainerEngine) QuadletList(ctx context.Context, options entities.QuadletListOptions) ([]*entities.ListQuadlet, error) {
+	return nil, errQuadletRemote
+}
+
+func (ic *ContainerEngine) QuadletPrint(ctx context.Context, quadlet string) (string, error) {
+	return "", errQuadletRemote
+}
+
+func (ic *ContainerEngine) QuadletRemove(ctx context.Context, quadlets []string, options entities.QuadletRemoveOptions) (*entities.QuadletRemoveReport, error) {
+	return nil, errQuadletRemote
+}
+
+func (ic *ContainerEngine) QuadletValidate(ctx context.Context, quadlets []string) ([]*entities.QuadletValidateReport, error) {
+	return nil, errQuadletRemote
+}
@@ -3,6 +3,7 @@ package tunnel
 import (
 	"context"
 	"errors"
+	"io"
 
 	"github.com/containers/podman/v4/libpod/define"
 	"github.com/containers/podman/v4/pkg/bindings/system"
@@ -23,8 +24,8 @@ func (ic *ContainerEngine) SystemPrune(ctx context.Context, opts entities.System
 	return system.Prune(ic.ClientCtx, options)
 }
 
-func (ic *ContainerEngine) Migrate(ctx context.Context, options entities.SystemMigrateOptions) error {
-	return errors.New("runtime migration is not supported on remote clients")
+func (ic *ContainerEngine) Migrate(ctx context.Context, options entities.SystemMigrateOptions) (*entities.SystemMigrateReport, error) {
+	return nil, errors.New("runtime migration is not supported on remote clients")
 }
 
 func (ic *ContainerEngine) Renumber(ctx context.Context) error {
@@ -35,10 +36,22 @@ func (ic *ContainerEngine) Reset(ctx context.Context) error {
 	return errors.New("system reset is not supported on remote clients")
 }
 
+func (ic *ContainerEngine) SystemCheck(ctx context.Context, options entities.SystemCheckOptions) (*entities.SystemCheckReport, error) {
+	return nil, errors.New("system check is not supported on remote clients")
+}
+
 func (ic *ContainerEngine) SystemDf(ctx context.Context, options entities.SystemDfOptions) (*entities.SystemDfReport, error) {
 	return system.DiskUsage(ic.ClientCtx, nil)
 }
 
+func (ic *ContainerEngine) SystemBackup(ctx context.Context, w io.Writer, options entities.SystemBackupOptions) (*entities.SystemBackupReport, error) {
+	return nil, errors.New("system backup is not supported on remote clients")
+}
+
+func (ic *ContainerEngine) SystemRestore(ctx context.Context, r io.Reader, options entities.SystemRestoreOptions) (*entities.SystemRestoreReport, error) {
+	return nil, errors.New("system restore is not supported on remote clients")
+}
+
 func (ic *ContainerEngine) Unshare(ctx context.Context, args []string, options entities.SystemUnshareOptions) error {
 	return errors.New("unshare is not supported on remote clients")
 }
@@ -113,3 +113,47 @@ func (ic *ContainerEngine) VolumeUnmount(ctx context.Context, nameOrIDs []string
 func (ic *ContainerEngine) VolumeReload(ctx context.Context) (*entities.VolumeReloadReport, error) {
 	return nil, errors.New("volume reload is not supported for remote clients")
 }
+
+func (ic *ContainerEngine) VolumeSnapshotCreate(ctx context.Context, nameOrID string, opts entities.VolumeSnapshotCreateOptions) (*entities.VolumeSnapshotReport, error) {
+	options := new(volumes.SnapshotCreateOptions).WithPause(opts.Pause)
+	if opts.Name != "" {
+		options = options.WithName(opts.Name)
+	}
+	return volumes.CreateSnapshot(ic.ClientCtx, nameOrID, options)
+}
+
+func (ic *ContainerEngine) VolumeSnapshotList(ctx context.Context, namesOrIds []string) ([]*entities.VolumeSnapshotReport, error) {
+	if len(namesOrIds) == 0 {
+		vols, err := volumes.List(ic.ClientCtx, nil)
+		if err != nil {
+			return nil, err
+		}
+		for _, v := range vols {
+			namesOrIds = append(namesOrIds, v.Name)
+		}
+	}
+	reports := []*entities.VolumeSnapshotReport{}
+	for _, id := range namesOrIds {
+		snapshots, err := volumes.ListSnapshots(ic.ClientCtx, id, nil)
+		if err != nil {
+			return nil, err
+		}
+		reports = append(reports, snapshots...)
+	}
+	return reports, nil
+}
+
+func (ic *ContainerEngine) VolumeSnapshotRestore(ctx context.Context, nameOrID, snapshot string) error {
+	return volumes.RestoreSnapshot(ic.ClientCtx, nameOrID, snapshot, nil)
+}
+
+func (ic *ContainerEngine) VolumeSnapshotRm(ctx context.Context, nameOrID string, snapshots []string) ([]*entities.VolumeSnapshotRmReport, error) {
+	reports := make([]*entities.VolumeSnapshotRmReport, 0, len(snapshots))
+	for _, snapshot := range snapshots {
+		reports = append(reports, &entities.VolumeSnapshotRmReport{
+			Err:  volumes.RemoveSnapshot(ic.ClientCtx, nameOrID, snapshot, nil),
+			Name: snapshot,
+		})
+	}
+	return reports, nil
+}
@@ -27,11 +27,6 @@ type Farm struct {
 	builders    map[string]entities.ImageEngine // name -> builder
 }
 
-// Schedule is a description of where and how we'll do builds.
-type Schedule struct {
-	platformBuilders map[string]string // target->connection
-}
-
 func newFarmWithBuilders(_ context.Context, name string, destinations *map[string]config.Destination, localEngine entities.ImageEngine, buildLocal bool) (*Farm, error) {
 	farm := &Farm{
 		builders:    make(map[string]entities.ImageEngine),
@@ -229,92 +224,11 @@ func (f *Farm) EmulatedPlatforms(ctx context.Context) ([]string, error) {
 	return platforms, nil
 }
 
-// Schedule takes a list of platforms and returns a list of connections which
-// can be used to build for those platforms.  It always prefers native builders
-// over emulated builders, but will assign a builder which can use emulation
-// for a platform if no suitable native builder is available.
-//
-// If platforms is an empty list, all available native platforms will be
-// scheduled.
-//
-// TODO: add (Priority,Weight *int) a la RFC 2782 to destinations that we know
-// of, and factor those in when assigning builds to nodes in here.
-func (f *Farm) Schedule(ctx context.Context, platforms []string) (Schedule, error) {
-	var (
-		err       error
-		infoGroup multierror.Group
-		infoMutex sync.Mutex
-	)
-	// If we weren't given a list of target platforms, generate one.
-	if len(platforms) == 0 {
-		platforms, err = f.NativePlatforms(ctx)
-		if err != nil {
-			return Schedule{}, fmt.Errorf("reading list of available native platforms: %w", err)
-		}
-	}
-
-	platformBuilders := make(map[string]string)
-	native := make(map[string]string)
-	emulated := make(map[string]string)
-	var localPlatform string
-	// Make notes of which platforms we can build for natively, and which
-	// ones we can build for using emulation.
-	for name, engine := range f.builders {
-		name, engine := name, engine
-		infoGroup.Go(func() error {
-			inspect, err := engine.FarmNodeInspect(ctx)
-			if err != nil {
-				return err
-			}
-			infoMutex.Lock()
-			defer infoMutex.Unlock()
-			for _, n := range inspect.NativePlatforms {
-				if _, assigned := native[n]; !assigned {
-					native[n] = name
-				}
-				if name == entities.LocalFarmImageBuilderName {
-					localPlatform = n
-				}
-			}
-			for _, e := range inspect.EmulatedPlatforms {
-				if _, assigned := emulated[e]; !assigned {
-					emulated[e] = name
-				}
-			}
-			return nil
-		})
-	}
-	merr := infoGroup.Wait()
-	if merr != nil {
-		if err := merr.ErrorOrNil(); err != nil {
-			return Schedule{}, err
-		}
-	}
-	// Assign a build to the first node that could build it natively, and
-	// if there isn't one, the first one that can build it with the help of
-	// emulation, and if there aren't any, error out.
-	for _, platform := range platforms {
-		if builder, ok := native[platform]; ok {
-			platformBuilders[platform] = builder
-		} else if builder, ok := emulated[platform]; ok {
-			platformBuilders[platform] = builder
-		} else {
-			return Schedule{}, fmt.Errorf("no builder capable of building for platform %q available", platform)
-		}
-	}
-	// If local is set, prioritize building on local
-	if localPlatform != "" {
-		platformBuilders[localPlatform] = entities.LocalFarmImageBuilderName
-	}
-	schedule := Schedule{
-		platformBuilders: platformBuilders,
-	}
-	return schedule, nil
-}
-
-// Build runs a build using the specified targetplatform:service map.  If all
-// builds succeed, it copies the resulting images from the remote hosts to the
-// local service and builds a manifest list with the specified reference name.
+// Build runs a build using the specified targetplatform:service map.  If the
+// build for a platform fails, it is retried on the fallback nodes of the
+// platform.  If all builds succeed, it copies the resulting images from the
+// remote hosts to the local service and builds a manifest list with the
+// specified reference name.
 func (f *Farm) Build(ctx context.Context, schedule Schedule, options entities.BuildOptions, reference string, localEngine entities.ImageEngine) error {
 	switch options.OutputFormat {
 	default:
@@ -324,39 +238,12 @@ func (f *Farm) Build(ctx context.Context, schedule Schedule, options entities.Bu
 	case define.Dockerv2ImageManifest:
 	}
 
-	// Build the list of jobs.
-	var jobs sync.Map
-	type job struct {
-		platform string
-		os       string
-		arch     string
-		variant  string
-		builder  entities.ImageEngine
-	}
 	for platform, builderName := range schedule.platformBuilders { // prepare to build
-		builder, ok := f.builders[builderName]
-		if !ok {
-			return fmt.Errorf("unknown builder %q", builderName)
-		}
-		var rawOS, rawArch, rawVariant string
-		p := strings.Split(platform, "/")
-		if len(p) > 0 && p[0] != "" {
-			rawOS = p[0]
-		}
-		if len(p) > 1 {
-			rawArch = p[1]
-		}
-		if len(p) > 2 {
-			rawVariant = p[2]
+		for _, name := range append([]string{builderName}, schedule.fallbacks[platform]...) {
+			if _, ok := f.builders[name]; !ok {
+				return fmt.Errorf("unknown builder %q", name)
+			}
 		}
-		os, arch, variant := lplatform.Normalize(rawOS, rawArch, rawVariant)
-		jobs.Store(builderName, job{
-			platform: platform,
-			os:       os,
-			arch:     arch,
-			variant:  variant,
-			builder:  builder,
-		})
 	}
 
 	listBuilderOptions := listBuilderOptions{
@@ -377,63 +264,27 @@ func (f *Farm) Build(ctx context.Context, schedule Schedule, options entities.Bu
 		builder entities.ImageEngine
 	}
 	for platform, builder := range schedule.platformBuilders {
-		platform, builder := platform, builder
-		outReader, outWriter := io.Pipe()
-		errReader, errWriter := io.Pipe()
-		go func() {
-			defer outReader.Close()
-			reader := bufio.NewReader(outReader)
-			writer := options.Out
-			if writer == nil {
-				writer = os.Stdout
-			}
-			line, err := reader.ReadString('\n')
-			for err == nil {
-				line = strings.TrimSuffix(line, "\n")
-				fmt.Fprintf(writer, "[%s@%s] %s\n", platform, builder, line)
-				line, err = reader.ReadString('\n')
-			}
-		}()
-		go func() {
-			defer errReader.Close()
-			reader := bufio.NewReader(errReader)
-			writer := options.Err
-			if writer == nil {
-				writer = os.Stderr
-			}
-			line, err := reader.ReadString('\n')
-			for err == nil {
-				line = strings.TrimSuffix(line, "\n")
-				fmt.Fprintf(writer, "[%s@%s] %s\n", platform, builder, line)
-				line, err = reader.ReadString('\n')
-			}
-		}()
+		platform, builders := platform, append([]string{builder}, schedule.fallbacks[platform]...)
 		buildGroup.Go(func() error {
-			var j job
-			defer outWriter.Close()
-			defer errWriter.Close()
-			c, ok := jobs.Load(builder)
-			if !ok {
-				return fmt.Errorf("unknown connection for %q (shouldn't happen)", builder)
-			}
-			if j, ok = c.(job); !ok {
-				return fmt.Errorf("unexpected connection type for %q (shouldn't happen)", builder)
-			}
-			buildOptions := options
-			buildOptions.Platforms = []struct{ OS, Arch, Variant string }{{j.os, j.arch, j.variant}}
-			buildOptions.Out = outWriter
-			buildOptions.Err = errWriter
-			fmt.Printf("Starting build for %v at %q\n", buildOptions.Platforms, builder)
-			buildReport, err := j.builder.Build(ctx, options.ContainerFiles, buildOptions)
-			if err != nil {
-				return fmt.Errorf("building for %q on %q: %w", j.platform, builder, err)
+			var merr *multierror.Error
+			for i, builder := range builders {
+				buildReport, err := f.buildPlatform(ctx, platform, builder, options)
+				if err == nil {
+					buildResults.Store(platform, buildResult{
+						report:  *buildReport,
+						builder: f.builders[builder],
+					})
+					return nil
+				}
+				merr = multierror.Append(merr, err)
+				if ctx.Err() != nil {
+					break
+				}
+				if i+1 < len(builders) {
+					fmt.Printf("Build for %q on %q failed, retrying on %q: %v\n", platform, builder, builders[i+1], err)
+				}
 			}
-			fmt.Printf("finished build for %v at %q: built %s\n", buildOptions.Platforms, builder, buildReport.ID)
-			buildResults.Store(platform, buildResult{
-				report:  *buildReport,
-				builder: j.builder,
-			})
-			return nil
+			return merr.ErrorOrNil()
 		})
 	}
 	buildErrors := buildGroup.Wait()
@@ -460,6 +311,67 @@ func (f *Farm) Build(ctx context.Context, schedule Schedule, options entities.Bu
 	return nil
 }
 
+// buildPlatform builds the image for a platform on a node, prefixing the
+// output of the build with the platform and the node.
+func (f *Farm) buildPlatform(ctx context.Context, platform, builder string, options entities.BuildOptions) (*entities.BuildReport, error) {
+	engine, ok := f.builders[builder]
+	if !ok {
+		return nil, fmt.Errorf("unknown connection for %q (shouldn't happen)", builder)
+	}
+	var rawOS, rawArch, rawVariant string
+	p := strings.Split(platform, "/")
+	if len(p) > 0 && p[0] != "" {
+		rawOS = p[0]
+	}
+	if len(p) > 1 {
+		rawArch = p[1]
+	}
+	if len(p) > 2 {
+		rawVariant = p[2]
+	}
+	normOS, arch, variant := lplatform.Normalize(rawOS, rawArch, rawVariant)
+
+	outReader, outWriter := io.Pipe()
+	errReader, errWriter := io.Pipe()
+	var copyGroup sync.WaitGroup
+	prefixLines := func(reader io.ReadCloser, writer io.Writer) {
+		defer copyGroup.Done()
+		defer reader.Close()
+		bufReader := bufio.NewReader(reader)
+		line, err := bufReader.ReadString('\n')
+		for err == nil {
+			line = strings.TrimSuffix(line, "\n")
+			fmt.Fprintf(writer, "[%s@%s] %s\n", platform, builder, line)
+			line, err = bufReader.ReadString('\n')
+		}
+	}
+	outWriterDest, errWriterDest := options.Out, options.Err
+	if outWriterDest == nil {
+		outWriterDest = os.Stdout
+	}
+	if errWriterDest == nil {
+		errWriterDest = os.Stderr
+	}
+	copyGroup.Add(2)
+	go prefixLines(outReader, outWriterDest)
+	go prefixLines(errReader, errWriterDest)
+	defer copyGroup.Wait()
+	defer outWriter.Close()
+	defer errWriter.Close()
+
+	buildOptions := options
+	buildOptions.Platforms = []struct{ OS, Arch, Variant string }{{normOS, arch, variant}}
+	buildOptions.Out = outWriter
+	buildOptions.Err = errWriter
+	fmt.Printf("Starting build for %v at %q\n", buildOptions.Platforms, builder)
+	buildReport, err := engine.Build(ctx, options.ContainerFiles, buildOptions)
+	if err != nil {
+		return nil, fmt.Errorf("building for %q on %q: %w", platform, builder, err)
+	}
+	fmt.Printf("finished build for %v at %q: built %s\n", buildOptions.Platforms, builder, buildReport.ID)
+	return buildReport, nil
+}
+
 func getFarmDestinations(name string) (map[string]config.Destination, error) {
 	dest := make(map[string]config.Destination)
 	cfg, err := config.ReadCustomConfig()
@@ -0,0 +1,244 @@
+package farm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/containers/podman/v4/pkg/domain/entities"
+	"github.com/hashicorp/go-multierror"
+)
+
+// ScheduleOptions are the options for scheduling builds on the nodes of a farm.
+type ScheduleOptions struct {
+	// Weights of nodes, keyed by their name.  A node with a higher weight
+	// is assigned proportionally more builds.  Nodes default to a weight
+	// of 1.
+	Weights map[string]int
+	// Names of nodes which are not used for builds.
+	Exclude []string
+}
+
+// Schedule is a description of where and how we'll do builds.
+type Schedule struct {
+	platformBuilders map[string]string   // target->connection
+	fallbacks        map[string][]string // target->connections to retry a failed build on
+	native           map[string]bool     // target->built natively
+}
+
+// ScheduleEntry describes where a platform is built.
+type ScheduleEntry struct {
+	// Platform to build for.
+	Platform string
+	// Node the platform is built on.
+	Builder string
+	// Whether the node builds natively for the platform, or uses
+	// emulation.
+	Native bool
+	// Nodes the build is retried on, in order, if it fails.
+	Fallbacks []string
+}
+
+// Entries returns the entries of the schedule sorted by platform.
+func (s Schedule) Entries() []ScheduleEntry {
+	entries := make([]ScheduleEntry, 0, len(s.platformBuilders))
+	for platform, builder := range s.platformBuilders {
+		entries = append(entries, ScheduleEntry{
+			Platform:  platform,
+			Builder:   builder,
+			Native:    s.native[platform],
+			Fallbacks: s.fallbacks[platform],
+		})
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Platform < entries[j].Platform })
+	return entries
+}
+
+// nodeInfo describes the capabilities and the load of a farm node.
+type nodeInfo struct {
+	native   []string
+	emulated []string
+	cpus     int
+	running  int
+	weight   int
+}
+
+// cost returns the cost of assigning one more build to a node which has
+// already been assigned the given number of builds.  Each build and each
+// running container counts as one unit of load, which is divided by the
+// number of CPUs of the node and by its weight.
+func (n *nodeInfo) cost(assigned int) float64 {
+	cpus := n.cpus
+	if cpus < 1 {
+		cpus = 1
+	}
+	return float64(n.running+assigned+1) / float64(cpus*n.weight)
+}
+
+// Schedule takes a list of platforms and returns a list of connections which
+// can be used to build for those platforms.  It always prefers native builders
+// over emulated builders, but will assign a builder which can use emulation
+// for a platform if no suitable native builder is available.  Among the
+// suitable builders, the one with the lowest load, as reported by
+// FarmNodeInspect and scaled by its weight, is chosen.  The local builder is
+// always preferred for its native platform.  The other suitable builders are
+// recorded as fallbacks for the case the build fails.
+//
+// If platforms is an empty list, all available native platforms will be
+// scheduled.
+func (f *Farm) Schedule(ctx context.Context, platforms []string, options ScheduleOptions) (Schedule, error) {
+	var (
+		infoGroup multierror.Group
+		infoMutex sync.Mutex
+	)
+
+	excluded := make(map[string]bool, len(options.Exclude))
+	for _, name := range options.Exclude {
+		name = f.nodeName(name)
+		if _, ok := f.builders[name]; !ok {
+			return Schedule{}, fmt.Errorf("cannot exclude unknown farm node %q", name)
+		}
+		excluded[name] = true
+	}
+	weights := make(map[string]int, len(options.Weights))
+	for name, weight := range options.Weights {
+		name = f.nodeName(name)
+		if _, ok := f.builders[name]; !ok {
+			return Schedule{}, fmt.Errorf("cannot set weight of unknown farm node %q", name)
+		}
+		if weight < 1 {
+			return Schedule{}, fmt.Errorf("weight of farm node %q must be at least 1", name)
+		}
+		weights[name] = weight
+	}
+
+	// Make notes of which platforms each node can build for natively, and
+	// which ones using emulation, and of its load.
+	nodes := make(map[string]*nodeInfo)
+	for name, engine := range f.builders {
+		if excluded[name] {
+			continue
+		}
+		name, engine := name, engine
+		infoGroup.Go(func() error {
+			inspect, err := engine.FarmNodeInspect(ctx)
+			if err != nil {
+				return err
+			}
+			weight := 1
+			if w, ok := weights[name]; ok {
+				weight = w
+			}
+			infoMutex.Lock()
+			defer infoMutex.Unlock()
+			nodes[name] = &nodeInfo{
+				native:   inspect.NativePlatforms,
+				emulated: inspect.EmulatedPlatforms,
+				cpus:     inspect.CPUs,
+				running:  inspect.RunningContainers,
+				weight:   weight,
+			}
+			return nil
+		})
+	}
+	merr := infoGroup.Wait()
+	if merr != nil {
+		if err := merr.ErrorOrNil(); err != nil {
+			return Schedule{}, err
+		}
+	}
+	if len(nodes) == 0 {
+		return Schedule{}, errors.New("no farm nodes left to build on")
+	}
+
+	return scheduleBuilds(platforms, nodes)
+}
+
+// nodeName maps "local" to the name of the local builder, unless there is a
+// node of that name.
+func (f *Farm) nodeName(name string) string {
+	if _, ok := f.builders[name]; !ok && name == "local" {
+		return entities.LocalFarmImageBuilderName
+	}
+	return name
+}
+
+// scheduleBuilds assigns each platform to a node.
+func scheduleBuilds(platforms []string, nodes map[string]*nodeInfo) (Schedule, error) {
+	// If we weren't given a list of target platforms, build for all native
+	// platforms of the nodes.
+	if len(platforms) == 0 {
+		nativeMap := make(map[string]struct{})
+		for _, node := range nodes {
+			for _, platform := range node.native {
+				nativeMap[platform] = struct{}{}
+			}
+		}
+		for platform := range nativeMap {
+			platforms = append(platforms, platform)
+		}
+	}
+	platforms = append([]string{}, platforms...)
+	sort.Strings(platforms)
+
+	names := make([]string, 0, len(nodes))
+	for name := range nodes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	contains := func(list []string, s string) bool {
+		for _, e := range list {
+			if e == s {
+				return true
+			}
+		}
+		return false
+	}
+
+	schedule := Schedule{
+		platformBuilders: make(map[string]string),
+		fallbacks:        make(map[string][]string),
+		native:           make(map[string]bool),
+	}
+	assigned := make(map[string]int)
+	for _, platform := range platforms {
+		var native, emulated []string
+		for _, name := range names {
+			switch {
+			case contains(nodes[name].native, platform):
+				native = append(native, name)
+			case contains(nodes[name].emulated, platform):
+				emulated = append(emulated, name)
+			}
+		}
+		if len(native) == 0 && len(emulated) == 0 {
+			return Schedule{}, fmt.Errorf("no builder capable of building for platform %q available", platform)
+		}
+
+		byCost := func(candidates []string, preferLocal bool) {
+			sort.SliceStable(candidates, func(i, j int) bool {
+				a, b := candidates[i], candidates[j]
+				// Building natively on the local node saves
+				// copying the image.
+				if preferLocal && (a == entities.LocalFarmImageBuilderName || b == entities.LocalFarmImageBuilderName) {
+					return a == entities.LocalFarmImageBuilderName
+				}
+				return nodes[a].cost(assigned[a]) < nodes[b].cost(assigned[b])
+			})
+		}
+		byCost(native, true)
+		byCost(emulated, false)
+		candidates := make([]string, 0, len(native)+len(emulated))
+		candidates = append(candidates, native...)
+		candidates = append(candidates, emulated...)
+
+		builder := candidates[0]
+		assigned[builder]++
+		schedule.platformBuilders[platform] = builder
+		schedule.native[platform] = len(native) > 0
+		schedule.fallbacks[platform] = candidates[1:]
+	}
+	return schedule, nil
+}
@@ -0,0 +1,55 @@
+package farm
+
+import (
+	"testing"
+
+	"github.com/containers/podman/v4/pkg/domain/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScheduleBuilds(t *testing.T) {
+	local := entities.LocalFarmImageBuilderName
+	newNodes := func() map[string]*nodeInfo {
+		return map[string]*nodeInfo{
+			local:   {native: []string{"linux/amd64"}, emulated: []string{"linux/arm64"}, cpus: 2, running: 10, weight: 1},
+			"node1": {native: []string{"linux/amd64"}, cpus: 4, weight: 1},
+			"node2": {native: []string{"linux/arm64"}, cpus: 2, running: 3, weight: 1},
+			"node3": {native: []string{"linux/arm64"}, cpus: 2, weight: 1},
+		}
+	}
+
+	// The local node is preferred for its native platform despite its
+	// load, the least loaded native node is chosen for the other, and
+	// emulating nodes come last among the fallbacks.
+	schedule, err := scheduleBuilds([]string{"linux/arm64", "linux/amd64"}, newNodes())
+	assert.NoError(t, err)
+	assert.Equal(t, []ScheduleEntry{
+		{Platform: "linux/amd64", Builder: local, Native: true, Fallbacks: []string{"node1"}},
+		{Platform: "linux/arm64", Builder: "node3", Native: true, Fallbacks: []string{"node2", local}},
+	}, schedule.Entries())
+
+	// A higher weight outweighs the load.
+	nodes := newNodes()
+	nodes["node2"].weight = 4
+	schedule, err = scheduleBuilds([]string{"linux/arm64"}, nodes)
+	assert.NoError(t, err)
+	assert.Equal(t, "node2", schedule.Entries()[0].Builder)
+
+	// Emulation is used if no native node is left.
+	nodes = newNodes()
+	delete(nodes, "node2")
+	delete(nodes, "node3")
+	schedule, err = scheduleBuilds(nil, nodes)
+	assert.NoError(t, err)
+	assert.Equal(t, []ScheduleEntry{
+		{Platform: "linux/amd64", Builder: local, Native: true, Fallbacks: []string{"node1"}},
+	}, schedule.Entries())
+	schedule, err = scheduleBuilds([]string{"linux/arm64"}, nodes)
+	assert.NoError(t, err)
+	assert.Equal(t, []ScheduleEntry{
+		{Platform: "linux/arm64", Builder: local, Native: false, Fallbacks: []string{}},
+	}, schedule.Entries())
+
+	_, err = scheduleBuilds([]string{"linux/s390x"}, nodes)
+	assert.ErrorContains(t, err, `no builder capable of building for platform "linux/s390x" available`)
+}
@@ -148,6 +148,8 @@ func ListContainerBatch(rt *libpod.Runtime, ctr *libpod.Container, opts entities
 		networks                                []string
 		healthStatus                            string
 		restartCount                            uint
+		ready                                   bool
+		crashLoop                               bool
 	)
 
 	batchErr := ctr.Batch(func(c *libpod.Container) error {
@@ -201,6 +203,16 @@ func ListContainerBatch(rt *libpod.Runtime, ctr *libpod.Container, opts entities
 			return err
 		}
 
+		ready, err = c.Ready()
+		if err != nil {
+			return err
+		}
+
+		crashLoop, err = c.CrashLoop()
+		if err != nil {
+			return err
+		}
+
 		if !opts.Size && !opts.Namespace {
 			return nil
 		}
@@ -241,6 +253,7 @@ func ListContainerBatch(rt *libpod.Runtime, ctr *libpod.Container, opts entities
 		AutoRemove: ctr.AutoRemove(),
 		CIDFile:    conConfig.Spec.Annotations[define.InspectAnnotationCIDFile],
 		Command:    conConfig.Command,
+		CrashLoop:  crashLoop,
 		Created:    conConfig.CreatedTime,
 		ExitCode:   exitCode,
 		Exited:     exited,
@@ -256,6 +269,7 @@ func ListContainerBatch(rt *libpod.Runtime, ctr *libpod.Container, opts entities
 		Pid:        pid,
 		Pod:        conConfig.Pod,
 		Ports:      portMappings,
+		Ready:      ready,
 		Restarts:   restartCount,
 		Size:       size,
 		StartedAt:  startedTime.Unix(),
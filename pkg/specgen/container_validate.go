@@ -66,6 +66,10 @@ func (s *SpecGenerator) Validate() error {
 	if err := define.ValidateSdNotifyMode(s.ContainerBasicConfig.SdNotifyMode); err != nil {
 		return err
 	}
+	// A scheduled container must survive its runs
+	if len(s.ContainerBasicConfig.Schedule) > 0 && s.ContainerBasicConfig.Remove {
+		return exclusiveOptions("schedule", "rm")
+	}
 
 	//
 	// ContainerStorageConfig
@@ -319,9 +319,13 @@ func ConfigToSpec(rt *libpod.Runtime, specg *specgen.SpecGenerator, containerID
 
 	tmpSystemd := conf.Systemd
 	tmpMounts := conf.Mounts
+	tmpEnvSecrets := conf.EnvSecrets
 
 	conf.Systemd = nil
 	conf.Mounts = []string{}
+	// The config references the secrets themselves, while the spec
+	// references them by name, so they are converted below.
+	conf.EnvSecrets = nil
 
 	if specg == nil {
 		specg = &specgen.SpecGenerator{}
@@ -341,6 +345,24 @@ func ConfigToSpec(rt *libpod.Runtime, specg *specgen.SpecGenerator, containerID
 
 	conf.Systemd = tmpSystemd
 	conf.Mounts = tmpMounts
+	conf.EnvSecrets = tmpEnvSecrets
+
+	if len(conf.EnvSecrets) > 0 {
+		specg.EnvSecrets = make(map[string]string, len(conf.EnvSecrets))
+		for env, secret := range conf.EnvSecrets {
+			specg.EnvSecrets[env] = secret.Name
+		}
+	}
+	specg.Secrets = nil
+	for _, secret := range conf.Secrets {
+		specg.Secrets = append(specg.Secrets, specgen.Secret{
+			Source: secret.Name,
+			Target: secret.Target,
+			UID:    secret.UID,
+			GID:    secret.GID,
+			Mode:   secret.Mode,
+		})
+	}
 
 	if conf.Spec != nil {
 		if conf.Spec.Linux != nil && conf.Spec.Linux.Resources != nil {
@@ -398,6 +420,10 @@ func ConfigToSpec(rt *libpod.Runtime, specg *specgen.SpecGenerator, containerID
 						val = netMode[1]
 					}
 					specg.NetNS = specgen.Namespace{NSMode: specgen.Slirp, Value: val}
+				case conf.NetMode.IsNone():
+					specg.NetNS = specgen.Namespace{NSMode: specgen.NoNetwork}
+				case conf.NetMode.IsHost():
+					specg.NetNS = specgen.Namespace{NSMode: specgen.Host}
 				case conf.NetMode.IsPrivate():
 					specg.NetNS = specgen.Namespace{NSMode: specgen.Private}
 				case conf.NetMode.IsDefault():
@@ -532,6 +532,15 @@ func createContainerOptions(rt *libpod.Runtime, s *specgen.SpecGenerator, pod *l
 	if s.Timeout != 0 {
 		options = append(options, libpod.WithTimeout(s.Timeout))
 	}
+	if s.PostStartHook != nil {
+		options = append(options, libpod.WithPostStartHook(s.PostStartHook))
+	}
+	if s.PreStopHook != nil {
+		options = append(options, libpod.WithPreStopHook(s.PreStopHook))
+	}
+	if s.Schedule != "" {
+		options = append(options, libpod.WithSchedule(s.Schedule))
+	}
 	if s.LogConfiguration != nil {
 		if len(s.LogConfiguration.Path) > 0 {
 			options = append(options, libpod.WithLogPath(s.LogConfiguration.Path))
@@ -539,6 +548,12 @@ func createContainerOptions(rt *libpod.Runtime, s *specgen.SpecGenerator, pod *l
 		if s.LogConfiguration.Size > 0 {
 			options = append(options, libpod.WithMaxLogSize(s.LogConfiguration.Size))
 		}
+		if s.LogConfiguration.MaxFile > 0 {
+			options = append(options, libpod.WithLogMaxFile(s.LogConfiguration.MaxFile))
+		}
+		if s.LogConfiguration.Compress {
+			options = append(options, libpod.WithLogCompress())
+		}
 		if len(s.LogConfiguration.Options) > 0 && s.LogConfiguration.Options["tag"] != "" {
 			options = append(options, libpod.WithLogTag(s.LogConfiguration.Options["tag"]))
 		}
@@ -611,6 +626,15 @@ func createContainerOptions(rt *libpod.Runtime, s *specgen.SpecGenerator, pod *l
 	}
 	options = append(options, libpod.WithRestartRetries(retries), libpod.WithRestartPolicy(restartPolicy))
 
+	restartDelay, restartMaxDelay := define.DefaultRestartDelay, define.DefaultRestartMaxDelay
+	if s.RestartDelay != nil {
+		restartDelay = *s.RestartDelay
+	}
+	if s.RestartMaxDelay != nil {
+		restartMaxDelay = *s.RestartMaxDelay
+	}
+	options = append(options, libpod.WithRestartBackoff(restartDelay, restartMaxDelay))
+
 	healthCheckSet := false
 	if s.ContainerHealthCheckConfig.HealthConfig != nil {
 		options = append(options, libpod.WithHealthCheck(s.ContainerHealthCheckConfig.HealthConfig))
@@ -621,6 +645,12 @@ func createContainerOptions(rt *libpod.Runtime, s *specgen.SpecGenerator, pod *l
 		options = append(options, libpod.WithStartupHealthcheck(s.ContainerHealthCheckConfig.StartupHealthConfig))
 		healthCheckSet = true
 	}
+	if s.ContainerHealthCheckConfig.ReadinessHealthConfig != nil {
+		options = append(options, libpod.WithReadinessHealthcheck(s.ContainerHealthCheckConfig.ReadinessHealthConfig))
+		if s.ContainerHealthCheckConfig.ReadinessGate {
+			options = append(options, libpod.WithReadinessGate())
+		}
+	}
 
 	if s.ContainerHealthCheckConfig.HealthCheckOnFailureAction != define.HealthCheckOnFailureActionNone {
 		options = append(options, libpod.WithHealthCheckOnFailureAction(s.ContainerHealthCheckConfig.HealthCheckOnFailureAction))
@@ -658,14 +688,28 @@ func createContainerOptions(rt *libpod.Runtime, s *specgen.SpecGenerator, pod *l
 
 	if len(s.DependencyContainers) > 0 {
 		deps := make([]*libpod.Container, 0, len(s.DependencyContainers))
-		for _, ctr := range s.DependencyContainers {
+		conditions := make(map[string]define.DependencyCondition)
+		for _, dep := range s.DependencyContainers {
+			ctr, condition, err := define.ParseDependency(dep)
+			if err != nil {
+				return nil, err
+			}
 			depCtr, err := rt.LookupContainer(ctr)
 			if err != nil {
 				return nil, fmt.Errorf("%q is not a valid container, cannot be used as a dependency: %w", ctr, err)
 			}
+			if condition == define.DependencyConditionHealthy && !depCtr.HasHealthCheck() {
+				return nil, fmt.Errorf("dependency %s has no healthcheck, cannot wait for it to become healthy", ctr)
+			}
 			deps = append(deps, depCtr)
+			if condition != define.DependencyConditionStarted {
+				conditions[depCtr.ID()] = condition
+			}
 		}
 		options = append(options, libpod.WithDependencyCtrs(deps))
+		if len(conditions) > 0 || s.DependencyTimeout != 0 {
+			options = append(options, libpod.WithDependencyConditions(conditions, s.DependencyTimeout))
+		}
 	}
 	if s.PidFile != "" {
 		options = append(options, libpod.WithPidFile(s.PidFile))
@@ -10,7 +10,6 @@ import (
 	"math"
 	"net"
 	"os"
-	"regexp"
 	"runtime"
 	"strconv"
 	"strings"
@@ -179,6 +178,9 @@ type CtrSpecGenOptions struct {
 	PodSecurityContext *v1.PodSecurityContext
 	// TerminationGracePeriodSeconds is the grace period given to a container to stop before being forcefully killed
 	TerminationGracePeriodSeconds *int64
+	// ReadinessGate withholds the published ports of containers with a
+	// readinessProbe until they are ready
+	ReadinessGate bool
 }
 
 func ToSpecGen(ctx context.Context, opts *CtrSpecGenOptions) (*specgen.SpecGenerator, error) {
@@ -236,6 +238,18 @@ func ToSpecGen(ctx context.Context, opts *CtrSpecGenOptions) (*specgen.SpecGener
 				return nil, err
 			}
 			s.LogConfiguration.Size = logSize
+		case "max-file":
+			maxFile, err := strconv.ParseUint(val, 10, 32)
+			if err != nil || maxFile == 0 {
+				return nil, fmt.Errorf("invalid value %q for log option max-file, must be a positive integer", val)
+			}
+			s.LogConfiguration.MaxFile = uint(maxFile)
+		case "compress":
+			compress, err := strconv.ParseBool(val)
+			if err != nil {
+				return nil, fmt.Errorf("invalid value %q for log option compress: %w", val, err)
+			}
+			s.LogConfiguration.Compress = compress
 		default:
 			switch len(val) {
 			case 0:
@@ -262,6 +276,14 @@ func ToSpecGen(ctx context.Context, opts *CtrSpecGenOptions) (*specgen.SpecGener
 	if err != nil {
 		return nil, fmt.Errorf("failed to configure startupProbe: %w", err)
 	}
+	err = setupReadinessProbe(s, opts.Container, opts.ReadinessGate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure readinessProbe: %w", err)
+	}
+	err = setupLifecycleHooks(s, opts.Container)
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure lifecycle hooks: %w", err)
+	}
 
 	// Since we prefix the container name with pod name to work-around the uniqueness requirement,
 	// the seccomp profile should reference the actual container name from the YAML
@@ -429,6 +451,21 @@ func ToSpecGen(ctx context.Context, opts *CtrSpecGenOptions) (*specgen.SpecGener
 		s.Annotations[define.InspectAnnotationPublishAll] = publishAll
 	}
 
+	if requires, ok := opts.Annotations[define.RequiresAnnotation+"/"+opts.Container.Name]; ok {
+		deps, err := ParseRequires(requires)
+		if err != nil {
+			return nil, fmt.Errorf("annotation %s of container %s: %w", define.RequiresAnnotation, opts.Container.Name, err)
+		}
+		for _, dep := range deps {
+			// The containers of the pod are named after the pod.
+			dependency := fmt.Sprintf("%s-%s", opts.PodName, dep.Name)
+			if dep.Condition != define.DependencyConditionStarted {
+				dependency = fmt.Sprintf("%s:%s", dependency, dep.Condition)
+			}
+			s.DependencyContainers = append(s.DependencyContainers, dependency)
+		}
+	}
+
 	s.Annotations[define.KubeHealthCheckAnnotation] = "true"
 
 	// Environment Variables
@@ -509,7 +546,7 @@ func ToSpecGen(ctx context.Context, opts *CtrSpecGenOptions) (*specgen.SpecGener
 				SubPath: volume.SubPath,
 			}
 			s.Volumes = append(s.Volumes, &namedVolume)
-		case KubeVolumeTypeConfigMap:
+		case KubeVolumeTypeConfigMap, KubeVolumeTypeDownwardAPI, KubeVolumeTypeProjected:
 			cmVolume := specgen.NamedVolume{
 				Dest:    volume.MountPath,
 				Name:    volumeSource.Source,
@@ -645,12 +682,13 @@ func parseMountPath(mountPath string, readOnly bool, propagationMode *v1.MountPr
 }
 
 func probeToHealthConfig(probe *v1.Probe, containerPorts []v1.ContainerPort) (*manifest.Schema2HealthConfig, error) {
-	var commandString string
-	failureCmd := "exit 1"
 	probeHandler := probe.Handler
-	host := "localhost" // Kubernetes default is host IP, but with Podman currently we run inside the container
+	host := "localhost" // Kubernetes default is the pod IP, with Podman the probe is run inside the network namespace of the container
 
-	// configure healthcheck on the basis of Handler Actions.
+	// configure healthcheck on the basis of Handler Actions.  Network
+	// probes are run by Podman, so they work with images without any
+	// tooling.
+	var hcProbe *define.HealthProbe
 	switch {
 	case probeHandler.Exec != nil:
 		// `makeHealthCheck` function can accept a json array as the command.
@@ -658,25 +696,20 @@ func probeToHealthConfig(probe *v1.Probe, containerPorts []v1.ContainerPort) (*m
 		if err != nil {
 			return nil, err
 		}
-		commandString = string(cmd)
+		return makeHealthCheck(string(cmd), probe.PeriodSeconds, probe.FailureThreshold, probe.TimeoutSeconds, probe.InitialDelaySeconds)
 	case probeHandler.HTTPGet != nil:
-		// set defaults as in https://kubernetes.io/docs/tasks/configure-pod-container/configure-liveness-readiness-startup-probes/#http-probes
-		uriScheme := v1.URISchemeHTTP
-		if probeHandler.HTTPGet.Scheme != "" {
-			uriScheme = probeHandler.HTTPGet.Scheme
-		}
-		if probeHandler.HTTPGet.Host != "" {
-			host = probeHandler.HTTPGet.Host
+		url, err := httpGetActionURL(probeHandler.HTTPGet, containerPorts)
+		if err != nil {
+			return nil, err
 		}
-		path := "/"
-		if probeHandler.HTTPGet.Path != "" {
-			path = probeHandler.HTTPGet.Path
+		headers := make([]string, 0, len(probeHandler.HTTPGet.HTTPHeaders))
+		for _, header := range probeHandler.HTTPGet.HTTPHeaders {
+			headers = append(headers, header.Name+": "+header.Value)
 		}
-		portNum, err := getPortNumber(probeHandler.HTTPGet.Port, containerPorts)
+		hcProbe, err = define.NewHTTPGetHealthProbe(url, "", headers)
 		if err != nil {
 			return nil, err
 		}
-		commandString = fmt.Sprintf("curl -f %s://%s:%d%s || %s", uriScheme, host, portNum, path, failureCmd)
 	case probeHandler.TCPSocket != nil:
 		portNum, err := getPortNumber(probeHandler.TCPSocket.Port, containerPorts)
 		if err != nil {
@@ -685,9 +718,47 @@ func probeToHealthConfig(probe *v1.Probe, containerPorts []v1.ContainerPort) (*m
 		if probeHandler.TCPSocket.Host != "" {
 			host = probeHandler.TCPSocket.Host
 		}
-		commandString = fmt.Sprintf("nc -z -v %s %d || %s", host, portNum, failureCmd)
+		hcProbe, err = define.NewTCPHealthProbe(net.JoinHostPort(host, strconv.Itoa(portNum)))
+		if err != nil {
+			return nil, err
+		}
+	case probeHandler.GRPC != nil:
+		address := net.JoinHostPort(host, strconv.Itoa(int(probeHandler.GRPC.Port)))
+		if probeHandler.GRPC.Service != nil && *probeHandler.GRPC.Service != "" {
+			address += "/" + *probeHandler.GRPC.Service
+		}
+		var err error
+		hcProbe, err = define.NewGRPCHealthProbe(address)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, errors.New("probe without a handler")
 	}
-	return makeHealthCheck(commandString, probe.PeriodSeconds, probe.FailureThreshold, probe.TimeoutSeconds, probe.InitialDelaySeconds)
+	return makeHealthCheckFromTest(hcProbe.Test(), probe.PeriodSeconds, probe.FailureThreshold, probe.TimeoutSeconds, probe.InitialDelaySeconds)
+}
+
+// httpGetActionURL returns the URL an HTTP GET action of a probe or
+// lifecycle hook is sent to.
+func httpGetActionURL(action *v1.HTTPGetAction, containerPorts []v1.ContainerPort) (string, error) {
+	// set defaults as in https://kubernetes.io/docs/tasks/configure-pod-container/configure-liveness-readiness-startup-probes/#http-probes
+	uriScheme := v1.URISchemeHTTP
+	if action.Scheme != "" {
+		uriScheme = v1.URIScheme(strings.ToLower(string(action.Scheme)))
+	}
+	host := "localhost" // Kubernetes default is the pod IP, with Podman the request is sent inside the network namespace of the container
+	if action.Host != "" {
+		host = action.Host
+	}
+	path := action.Path
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+	portNum, err := getPortNumber(action.Port, containerPorts)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s://%s%s", uriScheme, net.JoinHostPort(host, strconv.Itoa(portNum)), path), nil
 }
 
 func getPortNumber(port intstr.IntOrString, containerPorts []v1.ContainerPort) (int, error) {
@@ -756,6 +827,66 @@ func setupStartupProbe(s *specgen.SpecGenerator, containerYAML v1.Container, res
 	return nil
 }
 
+func setupReadinessProbe(s *specgen.SpecGenerator, containerYAML v1.Container, readinessGate bool) error {
+	if containerYAML.ReadinessProbe == nil {
+		return nil
+	}
+	emptyHandler := v1.Handler{}
+	if containerYAML.ReadinessProbe.Handler != emptyHandler {
+		healthConfig, err := probeToHealthConfig(containerYAML.ReadinessProbe, containerYAML.Ports)
+		if err != nil {
+			return err
+		}
+		s.ReadinessHealthConfig = &define.ReadinessHealthCheck{
+			Schema2HealthConfig: *healthConfig,
+			Successes:           int(containerYAML.ReadinessProbe.SuccessThreshold),
+		}
+		s.ReadinessGate = readinessGate
+		return nil
+	}
+	return nil
+}
+
+func setupLifecycleHooks(s *specgen.SpecGenerator, containerYAML v1.Container) error {
+	if containerYAML.Lifecycle == nil {
+		return nil
+	}
+	var err error
+	s.PostStartHook, err = handlerToLifecycleHook(containerYAML.Lifecycle.PostStart, containerYAML.Ports)
+	if err != nil {
+		return fmt.Errorf("postStart: %w", err)
+	}
+	s.PreStopHook, err = handlerToLifecycleHook(containerYAML.Lifecycle.PreStop, containerYAML.Ports)
+	if err != nil {
+		return fmt.Errorf("preStop: %w", err)
+	}
+	return nil
+}
+
+func handlerToLifecycleHook(handler *v1.Handler, containerPorts []v1.ContainerPort) (*define.LifecycleHook, error) {
+	if handler == nil {
+		return nil, nil
+	}
+	switch {
+	case handler.Exec != nil:
+		if len(handler.Exec.Command) == 0 {
+			return nil, errors.New("exec handler without a command")
+		}
+		return &define.LifecycleHook{Exec: handler.Exec.Command}, nil
+	case handler.HTTPGet != nil:
+		url, err := httpGetActionURL(handler.HTTPGet, containerPorts)
+		if err != nil {
+			return nil, err
+		}
+		return &define.LifecycleHook{HTTPGet: url}, nil
+	case handler.TCPSocket != nil:
+		logrus.Warn("tcpSocket lifecycle hooks are not supported by Kubernetes and ignored")
+	case handler.GRPC != nil:
+		logrus.Warn("grpc lifecycle hooks are not supported by Kubernetes and ignored")
+	}
+	return nil, nil
+}
+
 func makeHealthCheck(inCmd string, interval int32, retries int32, timeout int32, startPeriod int32) (*manifest.Schema2HealthConfig, error) {
 	// Every healthcheck requires a command
 	if len(inCmd) == 0 {
@@ -777,8 +908,14 @@ func makeHealthCheck(inCmd string, interval int32, retries int32, timeout int32,
 			cmd = append([]string{define.HealthConfigTestCmd}, cmd...)
 		}
 	}
+	return makeHealthCheckFromTest(cmd, interval, retries, timeout, startPeriod)
+}
+
+// makeHealthCheckFromTest creates a healthcheck config for the specified test
+// and applies the Kubernetes defaults.
+func makeHealthCheckFromTest(test []string, interval int32, retries int32, timeout int32, startPeriod int32) (*manifest.Schema2HealthConfig, error) {
 	hc := manifest.Schema2HealthConfig{
-		Test: cmd,
+		Test: test,
 	}
 
 	if interval < 1 {
@@ -1013,51 +1150,43 @@ func envVarValue(env v1.EnvVar, opts *CtrSpecGenOptions) (*string, error) {
 }
 
 func envVarValueFieldRef(env v1.EnvVar, opts *CtrSpecGenOptions) (*string, error) {
-	fieldRef := env.ValueFrom.FieldRef
-
-	fieldPathLabelPattern := `^metadata.labels\['(.+)'\]$`
-	fieldPathLabelRegex := regexp.MustCompile(fieldPathLabelPattern)
-	fieldPathAnnotationPattern := `^metadata.annotations\['(.+)'\]$`
-	fieldPathAnnotationRegex := regexp.MustCompile(fieldPathAnnotationPattern)
-
-	fieldPath := fieldRef.FieldPath
-
-	if fieldPath == "metadata.name" {
-		return &opts.PodName, nil
-	}
-	if fieldPath == "metadata.uid" {
-		return &opts.PodID, nil
+	pod := DownwardAPIPod{
+		Name:        opts.PodName,
+		UID:         opts.PodID,
+		Labels:      opts.Labels,
+		Annotations: opts.Annotations,
 	}
-	fieldPathMatches := fieldPathLabelRegex.FindStringSubmatch(fieldPath)
-	if len(fieldPathMatches) == 2 { // 1 for entire regex and 1 for subexp
-		labelValue := opts.Labels[fieldPathMatches[1]] // not existent label is OK
-		return &labelValue, nil
-	}
-	fieldPathMatches = fieldPathAnnotationRegex.FindStringSubmatch(fieldPath)
-	if len(fieldPathMatches) == 2 { // 1 for entire regex and 1 for subexp
-		annotationValue := opts.Annotations[fieldPathMatches[1]] // not existent annotation is OK
-		return &annotationValue, nil
+	value, err := pod.fieldRefValue(env.ValueFrom.FieldRef.FieldPath)
+	if err != nil {
+		return nil, fmt.Errorf("can not set env %v. Reason: %w", env.Name, err)
 	}
-
-	return nil, fmt.Errorf(
-		"can not set env %v. Reason: fieldPath %v is either not valid or not supported",
-		env.Name, fieldPath,
-	)
+	return &value, nil
 }
 
 func envVarValueResourceFieldRef(env v1.EnvVar, opts *CtrSpecGenOptions) (*string, error) {
-	divisor := env.ValueFrom.ResourceFieldRef.Divisor
+	value, err := resourceFieldRefValue(env.ValueFrom.ResourceFieldRef, opts.Container)
+	if err != nil {
+		return nil, fmt.Errorf("can not set env %v. Reason: %w", env.Name, err)
+	}
+	return &value, nil
+}
+
+// resourceFieldRefValue returns the value of the container resource selected
+// by resourceFieldRef, used by both environment variables and downwardAPI
+// volumes.
+func resourceFieldRefValue(resourceFieldRef *v1.ResourceFieldSelector, container v1.Container) (string, error) {
+	divisor := resourceFieldRef.Divisor
 	if divisor.IsZero() { // divisor not set, use default
 		divisor.Set(1)
 	}
 
-	resources, err := getContainerResources(opts.Container)
+	resources, err := getContainerResources(container)
 	if err != nil {
-		return nil, err
+		return "", err
 	}
 
 	var value *resource.Quantity
-	resourceName := env.ValueFrom.ResourceFieldRef.Resource
+	resourceName := resourceFieldRef.Resource
 	var isValidDivisor bool
 
 	switch resourceName {
@@ -1074,24 +1203,16 @@ func envVarValueResourceFieldRef(env v1.EnvVar, opts *CtrSpecGenOptions) (*strin
 		value = resources.Requests.Cpu()
 		isValidDivisor = isCPUDivisor(divisor)
 	default:
-		return nil, fmt.Errorf(
-			"can not set env %v. Reason: resource %v is either not valid or not supported",
-			env.Name, resourceName,
-		)
+		return "", fmt.Errorf("resource %v is either not valid or not supported", resourceName)
 	}
 
 	if !isValidDivisor {
-		return nil, fmt.Errorf(
-			"can not set env %s. Reason: divisor value %s is not valid",
-			env.Name, divisor.String(),
-		)
+		return "", fmt.Errorf("divisor value %s is not valid", divisor.String())
 	}
 
 	// k8s rounds up the result to the nearest integer
 	intValue := int64(math.Ceil(value.AsApproximateFloat64() / divisor.AsApproximateFloat64()))
-	stringValue := strconv.FormatInt(intValue, 10)
-
-	return &stringValue, nil
+	return strconv.FormatInt(intValue, 10), nil
 }
 
 func isMemoryDivisor(divisor resource.Quantity) bool {
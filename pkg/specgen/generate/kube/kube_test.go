@@ -5,6 +5,7 @@ package kube
 import (
 	"testing"
 
+	"github.com/containers/podman/v4/libpod/define"
 	v1 "github.com/containers/podman/v4/pkg/k8s.io/api/core/v1"
 	"github.com/containers/podman/v4/pkg/k8s.io/apimachinery/pkg/util/intstr"
 	"github.com/stretchr/testify/assert"
@@ -98,3 +99,47 @@ func TestGetPortNumber(t *testing.T) {
 	assert.NoError(t, e)
 	assert.Equal(t, i, 6000)
 }
+
+func TestParseRequires(t *testing.T) {
+	deps, err := ParseRequires("db:healthy, cache,migrate:completed-successfully")
+	assert.NoError(t, err)
+	assert.Equal(t, []Dependency{
+		{Name: "db", Condition: define.DependencyConditionHealthy},
+		{Name: "cache", Condition: define.DependencyConditionStarted},
+		{Name: "migrate", Condition: define.DependencyConditionCompletedSuccessfully},
+	}, deps)
+
+	_, err = ParseRequires("db:ready")
+	assert.Error(t, err)
+}
+
+func TestSortContainersByRequires(t *testing.T) {
+	containers := []v1.Container{{Name: "web"}, {Name: "db"}, {Name: "migrate"}, {Name: "sidecar"}}
+	names := func(ctrs []v1.Container) []string {
+		n := make([]string, 0, len(ctrs))
+		for _, ctr := range ctrs {
+			n = append(n, ctr.Name)
+		}
+		return n
+	}
+
+	sorted, err := SortContainersByRequires(containers, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"web", "db", "migrate", "sidecar"}, names(sorted))
+
+	annotations := map[string]string{
+		define.RequiresAnnotation + "/web":     "migrate:completed-successfully,db:healthy",
+		define.RequiresAnnotation + "/migrate": "db",
+	}
+	sorted, err = SortContainersByRequires(containers, annotations)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"db", "migrate", "web", "sidecar"}, names(sorted))
+
+	annotations[define.RequiresAnnotation+"/db"] = "web"
+	_, err = SortContainersByRequires(containers, annotations)
+	assert.ErrorContains(t, err, "dependency cycle")
+
+	annotations = map[string]string{define.RequiresAnnotation + "/web": "nonexistent"}
+	_, err = SortContainersByRequires(containers, annotations)
+	assert.ErrorContains(t, err, "not a container of the pod")
+}
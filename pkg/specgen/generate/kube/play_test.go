@@ -9,6 +9,7 @@ import (
 	"testing"
 
 	"github.com/containers/common/pkg/secrets"
+	"github.com/containers/podman/v4/libpod/define"
 	v1 "github.com/containers/podman/v4/pkg/k8s.io/api/core/v1"
 	"github.com/containers/podman/v4/pkg/k8s.io/apimachinery/pkg/api/resource"
 	v12 "github.com/containers/podman/v4/pkg/k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -1274,12 +1275,75 @@ func TestHttpLivenessProbe(t *testing.T) {
 			err := setupLivenessProbe(&test.specGenerator, test.container, test.restartPolicy)
 			if err == nil {
 				assert.Equal(t, err == nil, test.succeed)
+				assert.Equal(t, define.HealthConfigTestHTTPGet, test.specGenerator.ContainerHealthCheckConfig.HealthConfig.Test[0])
 				assert.Contains(t, test.specGenerator.ContainerHealthCheckConfig.HealthConfig.Test, test.expectedURL)
 			}
 		})
 	}
 }
 
+func TestHTTPProbeHeaders(t *testing.T) {
+	s := specgen.SpecGenerator{}
+	container := v1.Container{
+		LivenessProbe: &v1.Probe{
+			Handler: v1.Handler{
+				HTTPGet: &v1.HTTPGetAction{
+					Scheme: v1.URISchemeHTTPS,
+					Port:   intstr.FromInt(8443),
+					Path:   "healthz",
+					HTTPHeaders: []v1.HTTPHeader{
+						{Name: "Host", Value: "example.com"},
+						{Name: "X-Probe", Value: "podman"},
+					},
+				},
+			},
+		},
+	}
+	err := setupLivenessProbe(&s, container, "never")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		define.HealthConfigTestHTTPGet,
+		"https://localhost:8443/healthz",
+		"header=Host: example.com",
+		"header=X-Probe: podman",
+	}, s.HealthConfig.Test)
+}
+
+func TestGRPCProbe(t *testing.T) {
+	service := "my.Service"
+	tests := []struct {
+		name         string
+		action       v1.GRPCAction
+		expectedTest []string
+	}{
+		{
+			"GRPCProbeServer",
+			v1.GRPCAction{Port: 9000},
+			[]string{define.HealthConfigTestGRPC, "localhost:9000"},
+		},
+		{
+			"GRPCProbeService",
+			v1.GRPCAction{Port: 9000, Service: &service},
+			[]string{define.HealthConfigTestGRPC, "localhost:9000", "service=my.Service"},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			s := specgen.SpecGenerator{}
+			container := v1.Container{
+				StartupProbe: &v1.Probe{
+					Handler: v1.Handler{GRPC: &test.action},
+				},
+			}
+			err := setupStartupProbe(&s, container, "never")
+			assert.NoError(t, err)
+			assert.Equal(t, test.expectedTest, s.StartupHealthConfig.Test)
+		})
+	}
+}
+
 func TestTCPLivenessProbe(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -1287,8 +1351,7 @@ func TestTCPLivenessProbe(t *testing.T) {
 		container     v1.Container
 		restartPolicy string
 		succeed       bool
-		expectedHost  string
-		expectedPort  string
+		expectedTest  []string
 	}{
 		{
 			"TCPLivenessProbeNormal",
@@ -1305,8 +1368,7 @@ func TestTCPLivenessProbe(t *testing.T) {
 			},
 			"always",
 			true,
-			"127.0.0.1",
-			"8080",
+			[]string{define.HealthConfigTestTCP, "127.0.0.1:8080"},
 		},
 		{
 			"TCPLivenessProbeHostUsesDefault",
@@ -1322,8 +1384,7 @@ func TestTCPLivenessProbe(t *testing.T) {
 			},
 			"always",
 			true,
-			"localhost",
-			"200",
+			[]string{define.HealthConfigTestTCP, "localhost:200"},
 		},
 		{
 			"TCPLivenessProbeUseNamedPort",
@@ -1345,8 +1406,7 @@ func TestTCPLivenessProbe(t *testing.T) {
 			},
 			"always",
 			true,
-			"myservice.domain.com",
-			"4000",
+			[]string{define.HealthConfigTestTCP, "myservice.domain.com:4000"},
 		},
 		{
 			"TCPLivenessProbeInvalidPortName",
@@ -1368,8 +1428,7 @@ func TestTCPLivenessProbe(t *testing.T) {
 			},
 			"always",
 			false,
-			"myservice.domain.com",
-			"4000",
+			[]string{define.HealthConfigTestTCP, "myservice.domain.com:4000"},
 		},
 	}
 
@@ -1379,8 +1438,381 @@ func TestTCPLivenessProbe(t *testing.T) {
 			err := setupLivenessProbe(&test.specGenerator, test.container, test.restartPolicy)
 			assert.Equal(t, err == nil, test.succeed)
 			if err == nil {
-				assert.Contains(t, test.specGenerator.ContainerHealthCheckConfig.HealthConfig.Test, test.expectedHost)
-				assert.Contains(t, test.specGenerator.ContainerHealthCheckConfig.HealthConfig.Test, test.expectedPort)
+				assert.Equal(t, test.expectedTest, test.specGenerator.ContainerHealthCheckConfig.HealthConfig.Test)
+			}
+		})
+	}
+}
+
+func TestReadinessProbe(t *testing.T) {
+	tests := []struct {
+		name              string
+		container         v1.Container
+		readinessGate     bool
+		succeed           bool
+		expectedSuccesses int
+		expectedRetries   int
+		expectedGate      bool
+	}{
+		{
+			"ReadinessProbeDefaults",
+			v1.Container{
+				ReadinessProbe: &v1.Probe{
+					Handler: v1.Handler{
+						TCPSocket: &v1.TCPSocketAction{
+							Port: intstr.FromInt(8080),
+						},
+					},
+				},
+			},
+			false,
+			true,
+			0,
+			3,
+			false,
+		},
+		{
+			"ReadinessProbeThresholdsAndGate",
+			v1.Container{
+				ReadinessProbe: &v1.Probe{
+					Handler: v1.Handler{
+						Exec: &v1.ExecAction{
+							Command: []string{"cat", "/ready"},
+						},
+					},
+					SuccessThreshold: 2,
+					FailureThreshold: 5,
+				},
+			},
+			true,
+			true,
+			2,
+			5,
+			true,
+		},
+		{
+			"ReadinessProbeInvalidPortName",
+			v1.Container{
+				ReadinessProbe: &v1.Probe{
+					Handler: v1.Handler{
+						HTTPGet: &v1.HTTPGetAction{
+							Port: intstr.FromString("unknown"),
+						},
+					},
+				},
+			},
+			true,
+			false,
+			0,
+			0,
+			false,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			s := specgen.SpecGenerator{}
+			err := setupReadinessProbe(&s, test.container, test.readinessGate)
+			assert.Equal(t, err == nil, test.succeed)
+			if err == nil {
+				// The readiness probe must not turn into a healthcheck.
+				assert.Nil(t, s.HealthConfig)
+				assert.Equal(t, test.expectedSuccesses, s.ReadinessHealthConfig.Successes)
+				assert.Equal(t, test.expectedRetries, s.ReadinessHealthConfig.Retries)
+				assert.Equal(t, test.expectedGate, s.ReadinessGate)
+			}
+		})
+	}
+}
+
+func TestLifecycleHooks(t *testing.T) {
+	tests := []struct {
+		name              string
+		container         v1.Container
+		succeed           bool
+		expectedPostStart *define.LifecycleHook
+		expectedPreStop   *define.LifecycleHook
+	}{
+		{
+			"NoLifecycle",
+			v1.Container{},
+			true,
+			nil,
+			nil,
+		},
+		{
+			"ExecAndHTTPGet",
+			v1.Container{
+				Ports: []v1.ContainerPort{{Name: "admin", ContainerPort: 9090}},
+				Lifecycle: &v1.Lifecycle{
+					PostStart: &v1.Handler{
+						Exec: &v1.ExecAction{
+							Command: []string{"/bin/register", "--sidecar"},
+						},
+					},
+					PreStop: &v1.Handler{
+						HTTPGet: &v1.HTTPGetAction{
+							Path:   "drain",
+							Port:   intstr.FromString("admin"),
+							Scheme: v1.URISchemeHTTPS,
+						},
+					},
+				},
+			},
+			true,
+			&define.LifecycleHook{Exec: []string{"/bin/register", "--sidecar"}},
+			&define.LifecycleHook{HTTPGet: "https://localhost:9090/drain"},
+		},
+		{
+			"HTTPGetDefaults",
+			v1.Container{
+				Lifecycle: &v1.Lifecycle{
+					PreStop: &v1.Handler{
+						HTTPGet: &v1.HTTPGetAction{
+							Port: intstr.FromInt(8080),
+						},
+					},
+				},
+			},
+			true,
+			nil,
+			&define.LifecycleHook{HTTPGet: "http://localhost:8080/"},
+		},
+		{
+			"HTTPGetInvalidPortName",
+			v1.Container{
+				Lifecycle: &v1.Lifecycle{
+					PostStart: &v1.Handler{
+						HTTPGet: &v1.HTTPGetAction{
+							Port: intstr.FromString("unknown"),
+						},
+					},
+				},
+			},
+			false,
+			nil,
+			nil,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			s := specgen.SpecGenerator{}
+			err := setupLifecycleHooks(&s, test.container)
+			assert.Equal(t, err == nil, test.succeed)
+			if err == nil {
+				assert.Equal(t, test.expectedPostStart, s.PostStartHook)
+				assert.Equal(t, test.expectedPreStop, s.PreStopHook)
+			}
+		})
+	}
+}
+
+func TestDownwardAPIVolumes(t *testing.T) {
+	pod := &DownwardAPIPod{
+		Name:        "mypod",
+		Namespace:   "default",
+		Labels:      map[string]string{"app": "web", "tier": "frontend"},
+		Annotations: map[string]string{"build": "42"},
+		Containers: []v1.Container{
+			{
+				Name: "ctr",
+				Resources: v1.ResourceRequirements{
+					Limits: v1.ResourceList{
+						v1.ResourceMemory: resource.MustParse("64Mi"),
+					},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name          string
+		volume        v1.DownwardAPIVolumeSource
+		errorMessage  string
+		expectedItems map[string][]byte
+	}{
+		{
+			"FieldRefs",
+			v1.DownwardAPIVolumeSource{
+				Items: []v1.DownwardAPIVolumeFile{
+					{Path: "name", FieldRef: &v1.ObjectFieldSelector{FieldPath: "metadata.name"}},
+					{Path: "namespace", FieldRef: &v1.ObjectFieldSelector{FieldPath: "metadata.namespace"}},
+					{Path: "labels", FieldRef: &v1.ObjectFieldSelector{FieldPath: "metadata.labels"}},
+					{Path: "meta/build", FieldRef: &v1.ObjectFieldSelector{FieldPath: "metadata.annotations['build']"}},
+				},
+			},
+			"",
+			map[string][]byte{
+				"name":       []byte("mypod"),
+				"namespace":  []byte("default"),
+				"labels":     []byte("app=\"web\"\ntier=\"frontend\""),
+				"meta/build": []byte("42"),
+			},
+		},
+		{
+			"ResourceFieldRef",
+			v1.DownwardAPIVolumeSource{
+				Items: []v1.DownwardAPIVolumeFile{
+					{Path: "mem", ResourceFieldRef: &v1.ResourceFieldSelector{
+						ContainerName: "ctr",
+						Resource:      "limits.memory",
+						Divisor:       resource.MustParse("1Mi"),
+					}},
+				},
+			},
+			"",
+			map[string][]byte{"mem": []byte("64")},
+		},
+		{
+			"UnknownContainer",
+			v1.DownwardAPIVolumeSource{
+				Items: []v1.DownwardAPIVolumeFile{
+					{Path: "mem", ResourceFieldRef: &v1.ResourceFieldSelector{
+						ContainerName: "missing",
+						Resource:      "limits.memory",
+					}},
+				},
+			},
+			`can not set downwardAPI item "mem". Reason: unknown container "missing" in resourceFieldRef`,
+			nil,
+		},
+		{
+			"UIDNotSupported",
+			v1.DownwardAPIVolumeSource{
+				Items: []v1.DownwardAPIVolumeFile{
+					{Path: "uid", FieldRef: &v1.ObjectFieldSelector{FieldPath: "metadata.uid"}},
+				},
+			},
+			`can not set downwardAPI item "uid". Reason: fieldPath metadata.uid is either not valid or not supported`,
+			nil,
+		},
+		{
+			"PathEscapesVolume",
+			v1.DownwardAPIVolumeSource{
+				Items: []v1.DownwardAPIVolumeFile{
+					{Path: "../name", FieldRef: &v1.ObjectFieldSelector{FieldPath: "metadata.name"}},
+				},
+			},
+			`invalid item path "../name": must not contain '..'`,
+			nil,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			result, err := VolumeFromDownwardAPI(&test.volume, pod, "podinfo")
+			if test.errorMessage == "" {
+				assert.NoError(t, err)
+				assert.Equal(t, KubeVolumeTypeDownwardAPI, result.Type)
+				assert.Equal(t, "mypod-podinfo", result.Source)
+				assert.Equal(t, test.expectedItems, result.Items)
+			} else {
+				assert.Error(t, err)
+				assert.Equal(t, test.errorMessage, err.Error())
+			}
+		})
+	}
+}
+
+func TestProjectedVolumes(t *testing.T) {
+	d := t.TempDir()
+	secretsManager := createSecrets(t, d)
+	yes := true
+	pod := &DownwardAPIPod{Name: "mypod", Namespace: "default"}
+
+	tests := []struct {
+		name          string
+		volume        v1.ProjectedVolumeSource
+		errorMessage  string
+		expectedItems map[string][]byte
+	}{
+		{
+			"MergedSources",
+			v1.ProjectedVolumeSource{
+				Sources: []v1.VolumeProjection{
+					{ConfigMap: &v1.ConfigMapProjection{
+						LocalObjectReference: v1.LocalObjectReference{Name: "foo"},
+						Items:                []v1.KeyToPath{{Key: "myvar", Path: "config/myvar"}},
+					}},
+					{Secret: &v1.SecretProjection{
+						LocalObjectReference: v1.LocalObjectReference{Name: "bar"},
+						Items:                []v1.KeyToPath{{Key: "myvar", Path: "secret/myvar"}},
+					}},
+					{DownwardAPI: &v1.DownwardAPIProjection{
+						Items: []v1.DownwardAPIVolumeFile{
+							{Path: "podname", FieldRef: &v1.ObjectFieldSelector{FieldPath: "metadata.name"}},
+						},
+					}},
+				},
+			},
+			"",
+			map[string][]byte{
+				"config/myvar": []byte("foo"),
+				"secret/myvar": []byte("bar"),
+				"podname":      []byte("mypod"),
+			},
+		},
+		{
+			"OptionalMissingSource",
+			v1.ProjectedVolumeSource{
+				Sources: []v1.VolumeProjection{
+					{ConfigMap: &v1.ConfigMapProjection{
+						LocalObjectReference: v1.LocalObjectReference{Name: "fizz"},
+						Optional:             &yes,
+					}},
+					{Secret: &v1.SecretProjection{
+						LocalObjectReference: v1.LocalObjectReference{Name: "foo"},
+					}},
+				},
+			},
+			"",
+			map[string][]byte{"myvar": []byte("foo")},
+		},
+		{
+			"MissingSource",
+			v1.ProjectedVolumeSource{
+				Sources: []v1.VolumeProjection{
+					{ConfigMap: &v1.ConfigMapProjection{
+						LocalObjectReference: v1.LocalObjectReference{Name: "fizz"},
+					}},
+				},
+			},
+			`no such ConfigMap "fizz"`,
+			nil,
+		},
+		{
+			"ConflictingPaths",
+			v1.ProjectedVolumeSource{
+				Sources: []v1.VolumeProjection{
+					{ConfigMap: &v1.ConfigMapProjection{
+						LocalObjectReference: v1.LocalObjectReference{Name: "foo"},
+					}},
+					{Secret: &v1.SecretProjection{
+						LocalObjectReference: v1.LocalObjectReference{Name: "bar"},
+					}},
+				},
+			},
+			`projected volume "all-in-one" is invalid: conflicting path "myvar"`,
+			nil,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			result, err := VolumeFromProjected(&test.volume, configMapList, secretsManager, pod, "all-in-one")
+			if test.errorMessage == "" {
+				assert.NoError(t, err)
+				assert.Equal(t, KubeVolumeTypeProjected, result.Type)
+				assert.Equal(t, "mypod-all-in-one", result.Source)
+				assert.Equal(t, test.expectedItems, result.Items)
+			} else {
+				assert.Error(t, err)
+				assert.Equal(t, test.errorMessage, err.Error())
 			}
 		})
 	}
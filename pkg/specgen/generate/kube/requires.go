@@ -0,0 +1,75 @@
+//go:build !remote
+
+package kube
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/containers/podman/v4/libpod/define"
+	v1 "github.com/containers/podman/v4/pkg/k8s.io/api/core/v1"
+	"github.com/containers/podman/v4/pkg/util"
+)
+
+// Dependency is a dependency of a container of a pod on another container
+// of the pod, as specified by the define.RequiresAnnotation annotation.
+type Dependency struct {
+	// Name of the container in the pod.
+	Name string
+	// Condition the container must meet.
+	Condition define.DependencyCondition
+}
+
+// ParseRequires parses the value of the define.RequiresAnnotation annotation of
+// a container.
+func ParseRequires(value string) ([]Dependency, error) {
+	var deps []Dependency
+	for _, dep := range strings.Split(value, ",") {
+		dep = strings.TrimSpace(dep)
+		if dep == "" {
+			continue
+		}
+		name, condition, err := define.ParseDependency(dep)
+		if err != nil {
+			return nil, err
+		}
+		deps = append(deps, Dependency{Name: name, Condition: condition})
+	}
+	return deps, nil
+}
+
+// SortContainersByRequires sorts the containers of a pod so that each
+// container comes after the containers it requires according to the
+// define.RequiresAnnotation annotations, as dependencies must be created
+// first.  Otherwise, the order of the containers is kept.
+func SortContainersByRequires(containers []v1.Container, annotations map[string]string) ([]v1.Container, error) {
+	names := make(map[string]bool, len(containers))
+	for _, ctr := range containers {
+		if names[ctr.Name] {
+			// Duplicate names are reported by the caller.
+			return containers, nil
+		}
+		names[ctr.Name] = true
+	}
+
+	return util.SortByDependencies(containers,
+		func(ctr v1.Container) string { return ctr.Name },
+		func(ctr v1.Container) ([]string, error) {
+			value, ok := annotations[define.RequiresAnnotation+"/"+ctr.Name]
+			if !ok {
+				return nil, nil
+			}
+			deps, err := ParseRequires(value)
+			if err != nil {
+				return nil, fmt.Errorf("annotation %s of container %s: %w", define.RequiresAnnotation, ctr.Name, err)
+			}
+			names := make([]string, 0, len(deps))
+			for _, dep := range deps {
+				names = append(names, dep.Name)
+			}
+			return names, nil
+		},
+		func(ctr v1.Container, dep string) error {
+			return fmt.Errorf("container %q requires container %q, which is not a container of the pod", ctr.Name, dep)
+		})
+}
@@ -6,6 +6,11 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
 
 	"github.com/containers/common/pkg/parse"
 	"github.com/containers/common/pkg/secrets"
@@ -13,6 +18,7 @@ import (
 	v1 "github.com/containers/podman/v4/pkg/k8s.io/api/core/v1"
 
 	"github.com/sirupsen/logrus"
+	"golang.org/x/exp/slices"
 	"sigs.k8s.io/yaml"
 )
 
@@ -34,6 +40,8 @@ const (
 	KubeVolumeTypeCharDevice
 	KubeVolumeTypeSecret
 	KubeVolumeTypeEmptyDir
+	KubeVolumeTypeDownwardAPI
+	KubeVolumeTypeProjected
 )
 
 //nolint:revive
@@ -55,6 +63,30 @@ type KubeVolume struct {
 	DefaultMode int32
 }
 
+// DownwardAPIPod holds the pod metadata exposed via the downward API.
+type DownwardAPIPod struct {
+	// Name of the pod
+	Name string
+	// UID of the pod. Only known when resolving environment variables as
+	// volumes are initialized before the pod is created.
+	UID string
+	// Namespace of the pod. Only set when resolving downwardAPI volumes.
+	Namespace string
+	// Labels of the pod
+	Labels map[string]string
+	// Annotations of the pod
+	Annotations map[string]string
+	// Containers of the pod, needed to resolve the resourceFieldRef items
+	// of downwardAPI volumes
+	Containers []v1.Container
+}
+
+// PodVolumeName returns the name of the podman volume backing a volume which
+// is specific to a pod such as downwardAPI and projected volumes.
+func PodVolumeName(podName, volName string) string {
+	return podName + "-" + volName
+}
+
 // Create a KubeVolume from an HostPathVolumeSource
 func VolumeFromHostPath(hostPath *v1.HostPathVolumeSource, mountLabel string) (*KubeVolume, error) {
 	if hostPath.Type != nil {
@@ -266,8 +298,186 @@ func VolumeFromEmptyDir(emptyDirVolumeSource *v1.EmptyDirVolumeSource, name stri
 	return &KubeVolume{Type: KubeVolumeTypeEmptyDir, Source: name}, nil
 }
 
+// VolumeFromDownwardAPI creates a new kube volume from a downwardAPI volume source.
+func VolumeFromDownwardAPI(downwardAPIVolumeSource *v1.DownwardAPIVolumeSource, pod *DownwardAPIPod, volName string) (*KubeVolume, error) {
+	kv := &KubeVolume{
+		Type:        KubeVolumeTypeDownwardAPI,
+		Source:      PodVolumeName(pod.Name, volName),
+		Items:       map[string][]byte{},
+		DefaultMode: v1.DownwardAPIVolumeSourceDefaultMode,
+	}
+	// Set the defaultMode if set in the kube yaml
+	validMode, err := isValidDefaultMode(downwardAPIVolumeSource.DefaultMode)
+	if err != nil {
+		return nil, fmt.Errorf("invalid DefaultMode for downwardAPI volume %q: %w", volName, err)
+	}
+	if validMode {
+		kv.DefaultMode = *downwardAPIVolumeSource.DefaultMode
+	}
+
+	if err := addDownwardAPIItems(kv.Items, downwardAPIVolumeSource.Items, pod); err != nil {
+		return nil, err
+	}
+	return kv, nil
+}
+
+// VolumeFromProjected creates a new kube volume from a projected volume
+// source by merging the items of all of its sources.
+func VolumeFromProjected(projectedVolumeSource *v1.ProjectedVolumeSource, configMaps []v1.ConfigMap, secretsManager *secrets.SecretsManager, pod *DownwardAPIPod, volName string) (*KubeVolume, error) {
+	kv := &KubeVolume{
+		Type:        KubeVolumeTypeProjected,
+		Source:      PodVolumeName(pod.Name, volName),
+		Items:       map[string][]byte{},
+		DefaultMode: v1.ProjectedVolumeSourceDefaultMode,
+	}
+	// Set the defaultMode if set in the kube yaml
+	validMode, err := isValidDefaultMode(projectedVolumeSource.DefaultMode)
+	if err != nil {
+		return nil, fmt.Errorf("invalid DefaultMode for projected volume %q: %w", volName, err)
+	}
+	if validMode {
+		kv.DefaultMode = *projectedVolumeSource.DefaultMode
+	}
+
+	for _, source := range projectedVolumeSource.Sources {
+		items := map[string][]byte{}
+		switch {
+		case source.ConfigMap != nil:
+			cmVolume, err := VolumeFromConfigMap(&v1.ConfigMapVolumeSource{
+				LocalObjectReference: source.ConfigMap.LocalObjectReference,
+				Items:                source.ConfigMap.Items,
+				Optional:             source.ConfigMap.Optional,
+			}, configMaps)
+			if err != nil {
+				return nil, err
+			}
+			items = cmVolume.Items
+		case source.Secret != nil:
+			secretVolume, err := VolumeFromSecret(&v1.SecretVolumeSource{
+				SecretName: source.Secret.Name,
+				Items:      source.Secret.Items,
+				Optional:   source.Secret.Optional,
+			}, secretsManager)
+			if err != nil {
+				return nil, err
+			}
+			items = secretVolume.Items
+		case source.DownwardAPI != nil:
+			if err := addDownwardAPIItems(items, source.DownwardAPI.Items, pod); err != nil {
+				return nil, err
+			}
+		case source.ServiceAccountToken != nil:
+			logrus.Warnf("serviceAccountToken sources of projected volume %q are not supported and ignored", volName)
+		}
+
+		for path, data := range items {
+			if err := validateVolumeItemPath(path); err != nil {
+				return nil, err
+			}
+			if _, ok := kv.Items[path]; ok {
+				return nil, fmt.Errorf("projected volume %q is invalid: conflicting path %q", volName, path)
+			}
+			kv.Items[path] = data
+		}
+	}
+	return kv, nil
+}
+
+// addDownwardAPIItems resolves the specified downwardAPI items and adds them
+// to items.
+func addDownwardAPIItems(items map[string][]byte, downwardAPIItems []v1.DownwardAPIVolumeFile, pod *DownwardAPIPod) error {
+	for _, item := range downwardAPIItems {
+		if err := validateVolumeItemPath(item.Path); err != nil {
+			return err
+		}
+
+		var value string
+		var err error
+		switch {
+		case item.FieldRef != nil:
+			value, err = pod.fieldRefValue(item.FieldRef.FieldPath)
+		case item.ResourceFieldRef != nil:
+			value, err = pod.resourceFieldRefValue(item.ResourceFieldRef)
+		default:
+			err = errors.New("either fieldRef or resourceFieldRef must be set")
+		}
+		if err != nil {
+			return fmt.Errorf("can not set downwardAPI item %q. Reason: %w", item.Path, err)
+		}
+		items[item.Path] = []byte(value)
+	}
+	return nil
+}
+
+// fieldRefValue returns the value of the pod field selected by fieldPath.
+func (p *DownwardAPIPod) fieldRefValue(fieldPath string) (string, error) {
+	fieldPathLabelPattern := `^metadata.labels\['(.+)'\]$`
+	fieldPathLabelRegex := regexp.MustCompile(fieldPathLabelPattern)
+	fieldPathAnnotationPattern := `^metadata.annotations\['(.+)'\]$`
+	fieldPathAnnotationRegex := regexp.MustCompile(fieldPathAnnotationPattern)
+
+	switch {
+	case fieldPath == "metadata.name":
+		return p.Name, nil
+	case fieldPath == "metadata.uid" && p.UID != "":
+		return p.UID, nil
+	case fieldPath == "metadata.namespace" && p.Namespace != "":
+		return p.Namespace, nil
+	case fieldPath == "metadata.labels":
+		return formatDownwardAPIMap(p.Labels), nil
+	case fieldPath == "metadata.annotations":
+		return formatDownwardAPIMap(p.Annotations), nil
+	}
+
+	fieldPathMatches := fieldPathLabelRegex.FindStringSubmatch(fieldPath)
+	if len(fieldPathMatches) == 2 { // 1 for entire regex and 1 for subexp
+		return p.Labels[fieldPathMatches[1]], nil // not existent label is OK
+	}
+	fieldPathMatches = fieldPathAnnotationRegex.FindStringSubmatch(fieldPath)
+	if len(fieldPathMatches) == 2 { // 1 for entire regex and 1 for subexp
+		return p.Annotations[fieldPathMatches[1]], nil // not existent annotation is OK
+	}
+
+	return "", fmt.Errorf("fieldPath %v is either not valid or not supported", fieldPath)
+}
+
+// resourceFieldRefValue returns the value of the container resource selected
+// by resourceFieldRef.
+func (p *DownwardAPIPod) resourceFieldRefValue(resourceFieldRef *v1.ResourceFieldSelector) (string, error) {
+	idx := slices.IndexFunc(p.Containers, func(c v1.Container) bool { return c.Name == resourceFieldRef.ContainerName })
+	if idx == -1 {
+		return "", fmt.Errorf("unknown container %q in resourceFieldRef", resourceFieldRef.ContainerName)
+	}
+	return resourceFieldRefValue(resourceFieldRef, p.Containers[idx])
+}
+
+// formatDownwardAPIMap formats labels and annotations the way Kubernetes
+// does: one key="value" pair per line, sorted by key.
+func formatDownwardAPIMap(m map[string]string) string {
+	lines := make([]string, 0, len(m))
+	for k, v := range m {
+		lines = append(lines, k+"="+strconv.Quote(v))
+	}
+	sort.Strings(lines)
+	return strings.Join(lines, "\n")
+}
+
+// validateVolumeItemPath makes sure the path of a volume item is relative
+// and does not escape the volume.
+func validateVolumeItemPath(path string) error {
+	if path == "" || filepath.IsAbs(path) {
+		return fmt.Errorf("invalid item path %q: must be a relative path", path)
+	}
+	for _, elem := range strings.Split(path, "/") {
+		if elem == ".." {
+			return fmt.Errorf("invalid item path %q: must not contain '..'", path)
+		}
+	}
+	return nil
+}
+
 // Create a KubeVolume from one of the supported VolumeSource
-func VolumeFromSource(volumeSource v1.VolumeSource, configMaps []v1.ConfigMap, secretsManager *secrets.SecretsManager, volName, mountLabel string) (*KubeVolume, error) {
+func VolumeFromSource(volumeSource v1.VolumeSource, configMaps []v1.ConfigMap, secretsManager *secrets.SecretsManager, pod *DownwardAPIPod, volName, mountLabel string) (*KubeVolume, error) {
 	switch {
 	case volumeSource.HostPath != nil:
 		return VolumeFromHostPath(volumeSource.HostPath, mountLabel)
@@ -279,17 +489,21 @@ func VolumeFromSource(volumeSource v1.VolumeSource, configMaps []v1.ConfigMap, s
 		return VolumeFromSecret(volumeSource.Secret, secretsManager)
 	case volumeSource.EmptyDir != nil:
 		return VolumeFromEmptyDir(volumeSource.EmptyDir, volName)
+	case volumeSource.DownwardAPI != nil:
+		return VolumeFromDownwardAPI(volumeSource.DownwardAPI, pod, volName)
+	case volumeSource.Projected != nil:
+		return VolumeFromProjected(volumeSource.Projected, configMaps, secretsManager, pod, volName)
 	default:
-		return nil, errors.New("HostPath, ConfigMap, EmptyDir, Secret, and PersistentVolumeClaim are currently the only supported VolumeSource")
+		return nil, errors.New("HostPath, ConfigMap, EmptyDir, Secret, PersistentVolumeClaim, DownwardAPI and Projected are currently the only supported VolumeSource")
 	}
 }
 
 // Create a map of volume name to KubeVolume
-func InitializeVolumes(specVolumes []v1.Volume, configMaps []v1.ConfigMap, secretsManager *secrets.SecretsManager, mountLabel string) (map[string]*KubeVolume, error) {
+func InitializeVolumes(specVolumes []v1.Volume, configMaps []v1.ConfigMap, secretsManager *secrets.SecretsManager, pod *DownwardAPIPod, mountLabel string) (map[string]*KubeVolume, error) {
 	volumes := make(map[string]*KubeVolume)
 
 	for _, specVolume := range specVolumes {
-		volume, err := VolumeFromSource(specVolume.VolumeSource, configMaps, secretsManager, specVolume.Name, mountLabel)
+		volume, err := VolumeFromSource(specVolume.VolumeSource, configMaps, secretsManager, pod, specVolume.Name, mountLabel)
 		if err != nil {
 			return nil, fmt.Errorf("failed to create volume %q: %w", specVolume.Name, err)
 		}
@@ -5,6 +5,7 @@ import (
 	"net"
 	"strings"
 	"syscall"
+	"time"
 
 	nettypes "github.com/containers/common/libnetwork/types"
 	"github.com/containers/image/v5/manifest"
@@ -26,6 +27,13 @@ type LogConfig struct {
 	// Size is the maximum size of the log file
 	// Optional.
 	Size int64 `json:"size,omitempty"`
+	// MaxFile is the number of log files kept when rotating the log.
+	// Only available if LogDriver is set to "json-file" or "k8s-file".
+	// Optional.
+	MaxFile uint `json:"max_file,omitempty"`
+	// Compress compresses rotated log files.
+	// Optional.
+	Compress bool `json:"compress,omitempty"`
 	// A set of options to accompany the log driver.
 	// Optional.
 	Options map[string]string `json:"options,omitempty"`
@@ -92,6 +100,17 @@ type ContainerBasicConfig struct {
 	// If 0 is used, signal will not be sent. Container can run indefinitely
 	// Optional.
 	Timeout uint `json:"timeout,omitempty"`
+	// PostStartHook is a lifecycle hook run after the container started.
+	// Optional.
+	PostStartHook *define.LifecycleHook `json:"post_start_hook,omitempty"`
+	// PreStopHook is a lifecycle hook run before the stop signal is sent to
+	// the container. It is bounded by the stop timeout.
+	// Optional.
+	PreStopHook *define.LifecycleHook `json:"pre_stop_hook,omitempty"`
+	// Schedule is a systemd calendar expression. A transient systemd timer
+	// starts the container whenever it elapses.
+	// Optional.
+	Schedule string `json:"schedule,omitempty"`
 	// LogConfiguration describes the logging for a container including
 	// driver, path, and options.
 	// Optional
@@ -120,6 +139,16 @@ type ContainerBasicConfig struct {
 	// Only available when RestartPolicy is set to "on-failure".
 	// Optional.
 	RestartRetries *uint `json:"restart_tries,omitempty"`
+	// RestartDelay is the delay before the second restart of the container
+	// by its restart policy, if it keeps crashing.  The delay doubles with
+	// every restart, up to RestartMaxDelay.  0 restarts the container
+	// immediately.
+	// Optional.  If not given, define.DefaultRestartDelay is used.
+	RestartDelay *time.Duration `json:"restart_delay,omitempty"`
+	// RestartMaxDelay is the maximum delay between restarts of the
+	// container by its restart policy.
+	// Optional.  If not given, define.DefaultRestartMaxDelay is used.
+	RestartMaxDelay *time.Duration `json:"restart_max_delay,omitempty"`
 	// OCIRuntime is the name of the OCI runtime that will be used to create
 	// the container.
 	// If not specified, the default will be used.
@@ -191,9 +220,17 @@ type ContainerBasicConfig struct {
 	Timezone string `json:"timezone,omitempty"`
 	// DependencyContainers is an array of containers this container
 	// depends on. Dependency containers must be started before this
-	// container. Dependencies can be specified by name or full/partial ID.
+	// container. Dependencies can be specified by name or full/partial ID,
+	// optionally followed by a colon and the condition the dependency must
+	// meet before this container is started: started (the default),
+	// healthy or completed-successfully.
 	// Optional.
 	DependencyContainers []string `json:"dependencyContainers,omitempty"`
+	// DependencyTimeout is the time in seconds to wait for the conditions
+	// of the dependency containers to be met.  If 0, the default timeout
+	// of 300 seconds is used.
+	// Optional.
+	DependencyTimeout uint `json:"dependencyTimeout,omitempty"`
 	// PidFile is the file that saves container process id.
 	// set tags as `json:"-"` for not supported remote
 	// Optional.
@@ -571,6 +608,14 @@ type ContainerHealthCheckConfig struct {
 	// Requires that HealthConfig be set.
 	// Optional.
 	StartupHealthConfig *define.StartupHealthCheck `json:"startupHealthConfig,omitempty"`
+	// Readiness healthcheck for a container.
+	// Optional.
+	ReadinessHealthConfig *define.ReadinessHealthCheck `json:"readinessHealthConfig,omitempty"`
+	// ReadinessGate withholds the published ports of the container until
+	// its readiness healthcheck passed.
+	// Requires that ReadinessHealthConfig be set.
+	// Optional.
+	ReadinessGate bool `json:"readinessGate,omitempty"`
 }
 
 // SpecGenerator creates an OCI spec and Libpod configuration options to create
@@ -346,11 +346,30 @@ func FillOutSpecGen(s *specgen.SpecGenerator, c *entities.ContainerCreateOptions
 		}
 	}
 
-	if len(c.HealthCmd) > 0 {
+	hcProbe, err := makeHealthProbeFromCli(c)
+	if err != nil {
+		return err
+	}
+	if len(c.HealthCmd) > 0 || hcProbe != nil {
 		if c.NoHealthCheck {
+			if hcProbe != nil {
+				return errors.New("cannot specify both --no-healthcheck and a healthcheck probe")
+			}
 			return errors.New("cannot specify both --no-healthcheck and --health-cmd")
 		}
-		s.HealthConfig, err = makeHealthCheckFromCli(c.HealthCmd, c.HealthInterval, c.HealthRetries, c.HealthTimeout, c.HealthStartPeriod, false)
+		var hcTest []string
+		if hcProbe != nil {
+			if len(c.HealthCmd) > 0 {
+				return errors.New("cannot specify both --health-cmd and a healthcheck probe")
+			}
+			hcTest = hcProbe.Test()
+		} else {
+			hcTest, err = makeHealthCheckTestFromCli(c.HealthCmd)
+			if err != nil {
+				return err
+			}
+		}
+		s.HealthConfig, err = makeHealthCheckFromCli(hcTest, c.HealthInterval, c.HealthRetries, c.HealthTimeout, c.HealthStartPeriod, false)
 		if err != nil {
 			return err
 		}
@@ -373,7 +392,11 @@ func FillOutSpecGen(s *specgen.SpecGenerator, c *entities.ContainerCreateOptions
 		// The hardcoded "1s" will be discarded, as the startup
 		// healthcheck does not have a period. So just hardcode
 		// something that parses correctly.
-		tmpHcConfig, err := makeHealthCheckFromCli(c.StartupHCCmd, c.StartupHCInterval, c.StartupHCRetries, c.StartupHCTimeout, "1s", true)
+		startupTest, err := makeHealthCheckTestFromCli(c.StartupHCCmd)
+		if err != nil {
+			return err
+		}
+		tmpHcConfig, err := makeHealthCheckFromCli(startupTest, c.StartupHCInterval, c.StartupHCRetries, c.StartupHCTimeout, "1s", true)
 		if err != nil {
 			return err
 		}
@@ -663,6 +686,9 @@ func FillOutSpecGen(s *specgen.SpecGenerator, c *entities.ContainerCreateOptions
 	if len(s.DependencyContainers) == 0 || len(c.Requires) != 0 {
 		s.DependencyContainers = c.Requires
 	}
+	if s.DependencyTimeout == 0 || c.RequiresTimeout != 0 {
+		s.DependencyTimeout = c.RequiresTimeout
+	}
 
 	// Only add ReadWrite tmpfs mounts iff the container is
 	// being run ReadOnly and ReadWriteTmpFS is not disabled,
@@ -831,6 +857,18 @@ func FillOutSpecGen(s *specgen.SpecGenerator, c *entities.ContainerCreateOptions
 				return err
 			}
 			s.LogConfiguration.Size = logSize
+		case "max-file":
+			maxFile, err := strconv.ParseUint(val, 10, 32)
+			if err != nil || maxFile == 0 {
+				return fmt.Errorf("invalid value %q for log option max-file, must be a positive integer", val)
+			}
+			s.LogConfiguration.MaxFile = uint(maxFile)
+		case "compress":
+			compress, err := strconv.ParseBool(val)
+			if err != nil {
+				return fmt.Errorf("invalid value %q for log option compress: %w", val, err)
+			}
+			s.LogConfiguration.Compress = compress
 		default:
 			logOpts[key] = val
 		}
@@ -864,6 +902,20 @@ func FillOutSpecGen(s *specgen.SpecGenerator, c *entities.ContainerCreateOptions
 		s.RestartPolicy = policy
 		s.RestartRetries = &retries
 	}
+	if c.RestartDelay != "" {
+		delay, err := time.ParseDuration(c.RestartDelay)
+		if err != nil {
+			return fmt.Errorf("invalid restart delay: %w", err)
+		}
+		s.RestartDelay = &delay
+	}
+	if c.RestartMaxDelay != "" {
+		maxDelay, err := time.ParseDuration(c.RestartMaxDelay)
+		if err != nil {
+			return fmt.Errorf("invalid maximum restart delay: %w", err)
+		}
+		s.RestartMaxDelay = &maxDelay
+	}
 
 	if len(s.Secrets) == 0 || len(c.Secrets) != 0 {
 		s.Secrets, s.EnvSecrets, err = parseSecrets(c.Secrets)
@@ -886,6 +938,23 @@ func FillOutSpecGen(s *specgen.SpecGenerator, c *entities.ContainerCreateOptions
 	if s.Timeout == 0 || c.Timeout != 0 {
 		s.Timeout = c.Timeout
 	}
+	if len(s.Schedule) == 0 || len(c.Schedule) != 0 {
+		s.Schedule = c.Schedule
+	}
+	if c.HookPostStart != "" {
+		hook, err := define.ParseLifecycleHook(c.HookPostStart)
+		if err != nil {
+			return err
+		}
+		s.PostStartHook = hook
+	}
+	if c.HookPreStop != "" {
+		hook, err := define.ParseLifecycleHook(c.HookPreStop)
+		if err != nil {
+			return err
+		}
+		s.PreStopHook = hook
+	}
 	if len(s.Timezone) == 0 || len(c.Timezone) != 0 {
 		s.Timezone = c.Timezone
 	}
@@ -932,7 +1001,48 @@ func FillOutSpecGen(s *specgen.SpecGenerator, c *entities.ContainerCreateOptions
 	return nil
 }
 
-func makeHealthCheckFromCli(inCmd, interval string, retries uint, timeout, startPeriod string, isStartup bool) (*manifest.Schema2HealthConfig, error) {
+// makeHealthProbeFromCli returns the healthcheck probe specified by the
+// --health-http-get, --health-tcp and --health-grpc options or nil if none
+// is set.
+func makeHealthProbeFromCli(c *entities.ContainerCreateOptions) (*define.HealthProbe, error) {
+	var probes []*define.HealthProbe
+	if c.HealthHTTPGet != "" {
+		probe, err := define.NewHTTPGetHealthProbe(c.HealthHTTPGet, c.HealthHTTPStatus, c.HealthHTTPHeader)
+		if err != nil {
+			return nil, err
+		}
+		probes = append(probes, probe)
+	} else if c.HealthHTTPStatus != "" || len(c.HealthHTTPHeader) > 0 {
+		return nil, errors.New("--health-http-status and --health-http-header require --health-http-get")
+	}
+	if c.HealthTCP != "" {
+		probe, err := define.NewTCPHealthProbe(c.HealthTCP)
+		if err != nil {
+			return nil, err
+		}
+		probes = append(probes, probe)
+	}
+	if c.HealthGRPC != "" {
+		probe, err := define.NewGRPCHealthProbe(c.HealthGRPC)
+		if err != nil {
+			return nil, err
+		}
+		probes = append(probes, probe)
+	}
+
+	switch len(probes) {
+	case 0:
+		return nil, nil
+	case 1:
+		return probes[0], nil
+	default:
+		return nil, errors.New("--health-http-get, --health-tcp and --health-grpc are mutually exclusive")
+	}
+}
+
+// makeHealthCheckTestFromCli converts a healthcheck command into the test of
+// a healthcheck config.
+func makeHealthCheckTestFromCli(inCmd string) ([]string, error) {
 	cmdArr := []string{}
 	isArr := true
 	err := json.Unmarshal([]byte(inCmd), &cmdArr) // array unmarshalling
@@ -964,10 +1074,13 @@ func makeHealthCheckFromCli(inCmd, interval string, retries uint, timeout, start
 	if strings.ToUpper(cmdArr[0]) == define.HealthConfigTestNone { // if specified to remove healtcheck
 		cmdArr = []string{define.HealthConfigTestNone}
 	}
+	return cmdArr, nil
+}
 
+func makeHealthCheckFromCli(test []string, interval string, retries uint, timeout, startPeriod string, isStartup bool) (*manifest.Schema2HealthConfig, error) {
 	// healthcheck is by default an array, so we simply pass the user input
 	hc := manifest.Schema2HealthConfig{
-		Test: cmdArr,
+		Test: test,
 	}
 
 	if interval == "disable" {
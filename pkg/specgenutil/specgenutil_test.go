@@ -8,6 +8,7 @@ import (
 	"testing"
 
 	"github.com/containers/common/pkg/machine"
+	"github.com/containers/podman/v4/libpod/define"
 	"github.com/containers/podman/v4/pkg/domain/entities"
 	"github.com/containers/podman/v4/pkg/specgen"
 	"github.com/stretchr/testify/assert"
@@ -216,3 +217,93 @@ func TestGenRlimits(t *testing.T) {
 	_, err = GenRlimits([]string{"nofile=bar:buzz"})
 	assert.Error(t, err, "err is not nil")
 }
+
+func TestMakeHealthProbeFromCli(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     entities.ContainerCreateOptions
+		expected []string
+		succeed  bool
+	}{
+		{
+			name:     "no probe",
+			opts:     entities.ContainerCreateOptions{},
+			expected: nil,
+			succeed:  true,
+		},
+		{
+			name: "http get",
+			opts: entities.ContainerCreateOptions{
+				HealthHTTPGet:    "http://localhost:8080/healthz",
+				HealthHTTPStatus: "200,204-206",
+				HealthHTTPHeader: []string{"X-Probe: podman"},
+			},
+			expected: []string{define.HealthConfigTestHTTPGet, "http://localhost:8080/healthz", "status=200,204-206", "header=X-Probe: podman"},
+			succeed:  true,
+		},
+		{
+			name:     "tcp port only",
+			opts:     entities.ContainerCreateOptions{HealthTCP: "5432"},
+			expected: []string{define.HealthConfigTestTCP, "localhost:5432"},
+			succeed:  true,
+		},
+		{
+			name:     "grpc with service",
+			opts:     entities.ContainerCreateOptions{HealthGRPC: "127.0.0.1:9000/my.Service"},
+			expected: []string{define.HealthConfigTestGRPC, "127.0.0.1:9000", "service=my.Service"},
+			succeed:  true,
+		},
+		{
+			name:    "relative url",
+			opts:    entities.ContainerCreateOptions{HealthHTTPGet: "/healthz"},
+			succeed: false,
+		},
+		{
+			name:    "invalid status",
+			opts:    entities.ContainerCreateOptions{HealthHTTPGet: "http://localhost/", HealthHTTPStatus: "2xx"},
+			succeed: false,
+		},
+		{
+			name:    "invalid header",
+			opts:    entities.ContainerCreateOptions{HealthHTTPGet: "http://localhost/", HealthHTTPHeader: []string{"novalue"}},
+			succeed: false,
+		},
+		{
+			name:    "status without url",
+			opts:    entities.ContainerCreateOptions{HealthHTTPStatus: "200"},
+			succeed: false,
+		},
+		{
+			name:    "invalid port",
+			opts:    entities.ContainerCreateOptions{HealthTCP: "localhost:http"},
+			succeed: false,
+		},
+		{
+			name:    "multiple probes",
+			opts:    entities.ContainerCreateOptions{HealthTCP: "80", HealthGRPC: "9000"},
+			succeed: false,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			probe, err := makeHealthProbeFromCli(&tt.opts)
+			if !tt.succeed {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			if tt.expected == nil {
+				assert.Nil(t, probe)
+				return
+			}
+			assert.Equal(t, tt.expected, probe.Test())
+
+			// The test must round-trip through the healthcheck config.
+			parsed, err := define.ParseHealthProbe(probe.Test())
+			assert.NoError(t, err)
+			assert.Equal(t, probe, parsed)
+		})
+	}
+}
@@ -25,15 +25,18 @@ const (
 	UnitDirDistro = "/usr/share/containers/systemd"
 
 	// Names of commonly used systemd/quadlet group names
+	BuildGroup      = "Build"
 	ContainerGroup  = "Container"
 	InstallGroup    = "Install"
 	KubeGroup       = "Kube"
 	NetworkGroup    = "Network"
 	PodGroup        = "Pod"
 	ServiceGroup    = "Service"
+	TimerGroup      = "Timer"
 	UnitGroup       = "Unit"
 	VolumeGroup     = "Volume"
 	ImageGroup      = "Image"
+	XBuildGroup     = "X-Build"
 	XContainerGroup = "X-Container"
 	XKubeGroup      = "X-Kube"
 	XNetworkGroup   = "X-Network"
@@ -56,6 +59,7 @@ const (
 	KeyArch                  = "Arch"
 	KeyAuthFile              = "AuthFile"
 	KeyAutoUpdate            = "AutoUpdate"
+	KeyBuildArg              = "BuildArg"
 	KeyCertDir               = "CertDir"
 	KeyConfigMap             = "ConfigMap"
 	KeyContainerName         = "ContainerName"
@@ -77,11 +81,17 @@ const (
 	KeyExec                  = "Exec"
 	KeyExitCodePropagation   = "ExitCodePropagation"
 	KeyExposeHostPort        = "ExposeHostPort"
+	KeyFile                  = "File"
+	KeyForceRM               = "ForceRM"
 	KeyGateway               = "Gateway"
 	KeyGIDMap                = "GIDMap"
 	KeyGlobalArgs            = "GlobalArgs"
 	KeyGroup                 = "Group"
 	KeyHealthCmd             = "HealthCmd"
+	KeyHealthGRPC            = "HealthGRPC"
+	KeyHealthHTTPGet         = "HealthHTTPGet"
+	KeyHealthHTTPHeader      = "HealthHTTPHeader"
+	KeyHealthHTTPStatus      = "HealthHTTPStatus"
 	KeyHealthInterval        = "HealthInterval"
 	KeyHealthOnFailure       = "HealthOnFailure"
 	KeyHealthRetries         = "HealthRetries"
@@ -91,6 +101,7 @@ const (
 	KeyHealthStartupRetries  = "HealthStartupRetries"
 	KeyHealthStartupSuccess  = "HealthStartupSuccess"
 	KeyHealthStartupTimeout  = "HealthStartupTimeout"
+	KeyHealthTCP             = "HealthTCP"
 	KeyHealthTimeout         = "HealthTimeout"
 	KeyHostName              = "HostName"
 	KeyImage                 = "Image"
@@ -104,6 +115,7 @@ const (
 	KeyKubeDownForce         = "KubeDownForce"
 	KeyLabel                 = "Label"
 	KeyLogDriver             = "LogDriver"
+	KeyLogOpt                = "LogOpt"
 	KeyMask                  = "Mask"
 	KeyMount                 = "Mount"
 	KeyNetwork               = "Network"
@@ -124,8 +136,11 @@ const (
 	KeyRemapUid              = "RemapUid"     //nolint:stylecheck // deprecated
 	KeyRemapUidSize          = "RemapUidSize" //nolint:stylecheck // deprecated
 	KeyRemapUsers            = "RemapUsers"   // deprecated
+	KeyRequires              = "Requires"
+	KeyRequiresTimeout       = "RequiresTimeout"
 	KeyRootfs                = "Rootfs"
 	KeyRunInit               = "RunInit"
+	KeySchedule              = "Schedule"
 	KeySeccompProfile        = "SeccompProfile"
 	KeySecret                = "Secret"
 	KeySecurityLabelDisable  = "SecurityLabelDisable"
@@ -140,6 +155,7 @@ const (
 	KeySubnet                = "Subnet"
 	KeySubUIDMap             = "SubUIDMap"
 	KeySysctl                = "Sysctl"
+	KeyTarget                = "Target"
 	KeyTimezone              = "Timezone"
 	KeyTLSVerify             = "TLSVerify"
 	KeyTmpfs                 = "Tmpfs"
@@ -187,6 +203,10 @@ var (
 		KeyGlobalArgs:            true,
 		KeyGroup:                 true,
 		KeyHealthCmd:             true,
+		KeyHealthGRPC:            true,
+		KeyHealthHTTPGet:         true,
+		KeyHealthHTTPHeader:      true,
+		KeyHealthHTTPStatus:      true,
 		KeyHealthInterval:        true,
 		KeyHealthOnFailure:       true,
 		KeyHealthRetries:         true,
@@ -196,6 +216,7 @@ var (
 		KeyHealthStartupRetries:  true,
 		KeyHealthStartupSuccess:  true,
 		KeyHealthStartupTimeout:  true,
+		KeyHealthTCP:             true,
 		KeyHealthTimeout:         true,
 		KeyHostName:              true,
 		KeyIP6:                   true,
@@ -203,6 +224,7 @@ var (
 		KeyImage:                 true,
 		KeyLabel:                 true,
 		KeyLogDriver:             true,
+		KeyLogOpt:                true,
 		KeyMask:                  true,
 		KeyMount:                 true,
 		KeyNetwork:               true,
@@ -219,8 +241,11 @@ var (
 		KeyRemapUid:              true,
 		KeyRemapUidSize:          true,
 		KeyRemapUsers:            true,
+		KeyRequires:              true,
+		KeyRequiresTimeout:       true,
 		KeyRootfs:                true,
 		KeyRunInit:               true,
+		KeySchedule:              true,
 		KeySeccompProfile:        true,
 		KeySecret:                true,
 		KeySecurityLabelDisable:  true,
@@ -290,6 +315,7 @@ var (
 		KeyGlobalArgs:           true,
 		KeyKubeDownForce:        true,
 		KeyLogDriver:            true,
+		KeyLogOpt:               true,
 		KeyNetwork:              true,
 		KeyPodmanArgs:           true,
 		KeyPublishPort:          true,
@@ -320,6 +346,29 @@ var (
 		KeyVariant:              true,
 	}
 
+	// Supported keys in "Build" group
+	supportedBuildKeys = map[string]bool{
+		KeyAnnotation:           true,
+		KeyArch:                 true,
+		KeyAuthFile:             true,
+		KeyBuildArg:             true,
+		KeyContainersConfModule: true,
+		KeyEnvironment:          true,
+		KeyFile:                 true,
+		KeyForceRM:              true,
+		KeyGlobalArgs:           true,
+		KeyImageTag:             true,
+		KeyLabel:                true,
+		KeyNetwork:              true,
+		KeyPodmanArgs:           true,
+		KeyPull:                 true,
+		KeySecret:               true,
+		KeySetWorkingDirectory:  true,
+		KeyTarget:               true,
+		KeyTLSVerify:            true,
+		KeyVariant:              true,
+	}
+
 	supportedPodKeys = map[string]bool{
 		KeyContainersConfModule: true,
 		KeyGlobalArgs:           true,
@@ -400,6 +449,26 @@ func usernsOpts(kind string, opts []string) string {
 	return res.String()
 }
 
+// ConvertContainerSchedule returns the systemd timer starting the service
+// generated for a quadlet container file on the calendar of its Schedule key.
+// nil is returned if the container file has no schedule.
+func ConvertContainerSchedule(container *parser.UnitFile, service *parser.UnitFile) *parser.UnitFile {
+	schedule, ok := container.Lookup(ContainerGroup, KeySchedule)
+	if !ok || len(schedule) == 0 {
+		return nil
+	}
+
+	timer := parser.NewUnitFile()
+	timer.Filename = replaceExtension(container.Filename, ".timer", "", "")
+	if container.Path != "" {
+		timer.Add(UnitGroup, "SourcePath", container.Path)
+	}
+	timer.Add(TimerGroup, "OnCalendar", schedule)
+	timer.Add(TimerGroup, "Unit", service.Filename)
+	timer.Add(InstallGroup, "WantedBy", "timers.target")
+	return timer
+}
+
 // Convert a quadlet container file (unit file with a Container group) to a systemd
 // service file (unit file with Service group) based on the options in the
 // Container group.
@@ -492,6 +561,7 @@ func ConvertContainer(container *parser.UnitFile, names map[string]string, isUse
 	)
 
 	handleLogDriver(container, ContainerGroup, podman)
+	handleLogOpt(container, ContainerGroup, podman)
 
 	// We delegate groups to the runtime
 	service.Add(ServiceGroup, "Delegate", "yes")
@@ -504,6 +574,8 @@ func ConvertContainer(container *parser.UnitFile, names map[string]string, isUse
 
 	addNetworks(container, ContainerGroup, service, names, podman)
 
+	addRequires(container, service, names, podman)
+
 	// Run with a pid1 init to reap zombies by default (as most apps don't do that)
 	runInit, ok := container.LookupBoolean(ContainerGroup, KeyRunInit)
 	if ok {
@@ -1105,6 +1177,7 @@ func ConvertKube(kube *parser.UnitFile, names map[string]string, isUser bool) (*
 	}
 
 	handleLogDriver(kube, KubeGroup, execStart)
+	handleLogOpt(kube, KubeGroup, execStart)
 
 	if err := handleUserMappings(kube, KubeGroup, execStart, isUser, false); err != nil {
 		return nil, err
@@ -1156,8 +1229,7 @@ func ConvertKube(kube *parser.UnitFile, names map[string]string, isUser bool) (*
 	execStop.add(yamlPath)
 	service.AddCmdline(ServiceGroup, "ExecStopPost", execStop.Args)
 
-	err = handleSetWorkingDirectory(kube, service)
-	if err != nil {
+	if _, err := handleSetWorkingDirectory(kube, service, KubeGroup); err != nil {
 		return nil, err
 	}
 
@@ -1234,6 +1306,115 @@ func ConvertImage(image *parser.UnitFile) (*parser.UnitFile, string, error) {
 	return service, imageName, nil
 }
 
+func ConvertBuild(build *parser.UnitFile, names map[string]string) (*parser.UnitFile, string, error) {
+	service := build.Dup()
+	service.Filename = replaceExtension(build.Filename, ".service", "", "-build")
+
+	if build.Path != "" {
+		service.Add(UnitGroup, "SourcePath", build.Path)
+	}
+
+	if err := checkForUnknownKeys(build, BuildGroup, supportedBuildKeys); err != nil {
+		return nil, "", err
+	}
+
+	imageTags := build.LookupAll(BuildGroup, KeyImageTag)
+	if len(imageTags) == 0 || len(imageTags[0]) == 0 {
+		return nil, "", fmt.Errorf("no ImageTag key specified")
+	}
+
+	// The build context is either set explicitly, or it is the directory
+	// of the Containerfile
+	buildContext, err := handleSetWorkingDirectory(build, service, BuildGroup)
+	if err != nil {
+		return nil, "", err
+	}
+	file, _ := build.Lookup(BuildGroup, KeyFile)
+	if len(file) > 0 && !isURL(file) {
+		if file, err = getAbsolutePath(build, file); err != nil {
+			return nil, "", err
+		}
+	}
+	if len(buildContext) == 0 {
+		if workingDir, ok := build.Lookup(ServiceGroup, ServiceKeyWorkingDirectory); ok && len(workingDir) > 0 {
+			buildContext = workingDir
+		} else if len(file) > 0 && !isURL(file) {
+			buildContext = filepath.Dir(file)
+		} else {
+			return nil, "", fmt.Errorf("neither SetWorkingDirectory nor a local File key specified")
+		}
+	}
+
+	/* Rename old Build group to X-Build so that systemd ignores it */
+	service.RenameGroup(BuildGroup, XBuildGroup)
+
+	// Need the containers filesystem mounted to start podman
+	service.Add(UnitGroup, "RequiresMountsFor", "%t/containers")
+
+	podman := createBasePodmanCommand(build, BuildGroup)
+
+	podman.add("build")
+
+	for _, imageTag := range imageTags {
+		podman.addf("--tag=%s", imageTag)
+	}
+
+	if len(file) > 0 {
+		podman.addf("--file=%s", file)
+	}
+
+	stringKeys := map[string]string{
+		KeyArch:     "--arch",
+		KeyAuthFile: "--authfile",
+		KeyPull:     "--pull",
+		KeyTarget:   "--target",
+		KeyVariant:  "--variant",
+	}
+
+	boolKeys := map[string]string{
+		KeyForceRM:   "--force-rm",
+		KeyTLSVerify: "--tls-verify",
+	}
+
+	for key, flag := range stringKeys {
+		lookupAndAddString(build, BuildGroup, key, flag, podman)
+	}
+
+	for key, flag := range boolKeys {
+		lookupAndAddBoolean(build, BuildGroup, key, flag, podman)
+	}
+
+	for _, buildArg := range build.LookupAllArgs(BuildGroup, KeyBuildArg) {
+		podman.add("--build-arg", buildArg)
+	}
+
+	for _, secret := range build.LookupAllArgs(BuildGroup, KeySecret) {
+		podman.add("--secret", secret)
+	}
+
+	podman.addEnv(build.LookupAllKeyVal(BuildGroup, KeyEnvironment))
+	podman.addLabels(build.LookupAllKeyVal(BuildGroup, KeyLabel))
+	podman.addAnnotations(build.LookupAllKeyVal(BuildGroup, KeyAnnotation))
+
+	addNetworks(build, BuildGroup, service, names, podman)
+
+	handlePodmanArgs(build, BuildGroup, podman)
+
+	// The build context has to be the last argument
+	podman.add(buildContext)
+
+	service.AddCmdline(ServiceGroup, "ExecStart", podman.Args)
+
+	service.Setv(ServiceGroup,
+		"Type", "oneshot",
+		"RemainAfterExit", "yes",
+
+		// The default syslog identifier is the exec basename (podman) which isn't very useful here
+		"SyslogIdentifier", "%N")
+
+	return service, imageTags[0], nil
+}
+
 func GetPodServiceName(podUnit *parser.UnitFile) string {
 	return replaceExtension(podUnit.Filename, "", "", "-pod")
 }
@@ -1495,6 +1676,38 @@ func addNetworks(quadletUnitFile *parser.UnitFile, groupName string, serviceUnit
 	}
 }
 
+// addRequires adds the containers the container requires.  Quadlet .container
+// files are resolved to the names of their containers, and their services are
+// started before the service of the container.
+func addRequires(container, service *parser.UnitFile, names map[string]string, podman *PodmanCmdline) {
+	for _, requires := range container.LookupAll(ContainerGroup, KeyRequires) {
+		if len(requires) == 0 {
+			continue
+		}
+		name, condition, found := strings.Cut(requires, ":")
+		if strings.HasSuffix(name, ".container") {
+			serviceName := replaceExtension(name, ".service", "", "")
+			service.Add(UnitGroup, "Requires", serviceName)
+			service.Add(UnitGroup, "After", serviceName)
+
+			containerName := names[name]
+			if containerName == "" {
+				containerName = replaceExtension(name, "", "systemd-", "")
+			}
+			name = containerName
+		}
+		if found {
+			podman.addf("--requires=%s:%s", name, condition)
+		} else {
+			podman.addf("--requires=%s", name)
+		}
+	}
+
+	if timeout, ok := container.Lookup(ContainerGroup, KeyRequiresTimeout); ok && len(timeout) > 0 {
+		podman.addf("--requires-timeout=%s", timeout)
+	}
+}
+
 // Systemd Specifiers start with % with the exception of %%
 func startsWithSystemdSpecifier(filePath string) bool {
 	if len(filePath) == 0 || filePath[0] != '%' {
@@ -1595,6 +1808,12 @@ func handleLogDriver(unitFile *parser.UnitFile, groupName string, podman *Podman
 	}
 }
 
+func handleLogOpt(unitFile *parser.UnitFile, groupName string, podman *PodmanCmdline) {
+	for _, logOpt := range unitFile.LookupAll(groupName, KeyLogOpt) {
+		podman.add("--log-opt", logOpt)
+	}
+}
+
 func handleStorageSource(quadletUnitFile, serviceUnitFile *parser.UnitFile, source string, names map[string]string) (string, error) {
 	if source[0] == '.' {
 		var err error
@@ -1628,6 +1847,9 @@ func handleStorageSource(quadletUnitFile, serviceUnitFile *parser.UnitFile, sour
 func handleHealth(unitFile *parser.UnitFile, groupName string, podman *PodmanCmdline) {
 	keyArgMap := [][2]string{
 		{KeyHealthCmd, "cmd"},
+		{KeyHealthGRPC, "grpc"},
+		{KeyHealthHTTPGet, "http-get"},
+		{KeyHealthHTTPStatus, "http-status"},
 		{KeyHealthInterval, "interval"},
 		{KeyHealthOnFailure, "on-failure"},
 		{KeyHealthRetries, "retries"},
@@ -1638,6 +1860,7 @@ func handleHealth(unitFile *parser.UnitFile, groupName string, podman *PodmanCmd
 		{KeyHealthStartupRetries, "startup-retries"},
 		{KeyHealthStartupSuccess, "startup-success"},
 		{KeyHealthStartupTimeout, "startup-timeout"},
+		{KeyHealthTCP, "tcp"},
 	}
 
 	for _, keyArg := range keyArgMap {
@@ -1647,6 +1870,10 @@ func handleHealth(unitFile *parser.UnitFile, groupName string, podman *PodmanCmd
 			podman.addf("%s", val)
 		}
 	}
+
+	for _, header := range unitFile.LookupAll(groupName, KeyHealthHTTPHeader) {
+		podman.add("--health-http-header", header)
+	}
 }
 
 func handlePodmanArgs(unitFile *parser.UnitFile, groupName string, podman *PodmanCmdline) {
@@ -1656,39 +1883,63 @@ func handlePodmanArgs(unitFile *parser.UnitFile, groupName string, podman *Podma
 	}
 }
 
-func handleSetWorkingDirectory(kube, serviceUnitFile *parser.UnitFile) error {
-	// If WorkingDirectory is already set in the Service section do not change it
-	workingDir, ok := kube.Lookup(ServiceGroup, ServiceKeyWorkingDirectory)
-	if ok && len(workingDir) > 0 {
-		return nil
-	}
-
-	setWorkingDirectory, ok := kube.Lookup(KubeGroup, KeySetWorkingDirectory)
+// handleSetWorkingDirectory sets the WorkingDirectory of the service as
+// requested by the SetWorkingDirectory key and returns the directory.  For
+// builds, the key may also specify the build context directly, in which case
+// the WorkingDirectory is not changed.
+func handleSetWorkingDirectory(quadletUnitFile, serviceUnitFile *parser.UnitFile, quadletGroup string) (string, error) {
+	setWorkingDirectory, ok := quadletUnitFile.Lookup(quadletGroup, KeySetWorkingDirectory)
 	if !ok || len(setWorkingDirectory) == 0 {
-		return nil
+		return "", nil
 	}
 
 	var relativeToFile string
-	switch strings.ToLower(setWorkingDirectory) {
-	case "yaml":
-		relativeToFile, ok = kube.Lookup(KubeGroup, KeyYaml)
+	switch {
+	case quadletGroup == KubeGroup && strings.EqualFold(setWorkingDirectory, "yaml"):
+		relativeToFile, ok = quadletUnitFile.Lookup(KubeGroup, KeyYaml)
 		if !ok {
-			return fmt.Errorf("no Yaml key specified")
+			return "", fmt.Errorf("no Yaml key specified")
+		}
+	case quadletGroup == BuildGroup && strings.EqualFold(setWorkingDirectory, "file"):
+		relativeToFile, ok = quadletUnitFile.Lookup(BuildGroup, KeyFile)
+		if !ok || isURL(relativeToFile) {
+			return "", fmt.Errorf("no local File key specified")
 		}
-	case "unit":
-		relativeToFile = kube.Path
+	case strings.EqualFold(setWorkingDirectory, "unit"):
+		relativeToFile = quadletUnitFile.Path
+	case quadletGroup == BuildGroup:
+		if isURL(setWorkingDirectory) {
+			return setWorkingDirectory, nil
+		}
+		return getAbsolutePath(quadletUnitFile, setWorkingDirectory)
 	default:
-		return fmt.Errorf("unsupported value for %s: %s ", ServiceKeyWorkingDirectory, setWorkingDirectory)
+		return "", fmt.Errorf("unsupported value for %s: %s ", ServiceKeyWorkingDirectory, setWorkingDirectory)
 	}
 
-	fileInWorkingDir, err := getAbsolutePath(kube, relativeToFile)
+	fileInWorkingDir, err := getAbsolutePath(quadletUnitFile, relativeToFile)
 	if err != nil {
-		return err
+		return "", err
 	}
+	workingDir := filepath.Dir(fileInWorkingDir)
 
-	serviceUnitFile.Add(ServiceGroup, ServiceKeyWorkingDirectory, filepath.Dir(fileInWorkingDir))
+	// If WorkingDirectory is already set in the Service section do not change it
+	if dir, ok := quadletUnitFile.Lookup(ServiceGroup, ServiceKeyWorkingDirectory); ok && len(dir) > 0 {
+		return dir, nil
+	}
 
-	return nil
+	serviceUnitFile.Add(ServiceGroup, ServiceKeyWorkingDirectory, workingDir)
+
+	return workingDir, nil
+}
+
+// isURL returns true if the build context or Containerfile is remote
+func isURL(path string) bool {
+	for _, prefix := range []string{"http://", "https://", "git://", "github.com/"} {
+		if strings.HasPrefix(path, prefix) {
+			return true
+		}
+	}
+	return false
 }
 
 func lookupAndAddString(unit *parser.UnitFile, group, key, flag string, podman *PodmanCmdline) {
@@ -1706,20 +1957,24 @@ func lookupAndAddBoolean(unit *parser.UnitFile, group, key, flag string, podman
 }
 
 func handleImageSource(quadletImageName string, serviceUnitFile *parser.UnitFile, names map[string]string) (string, error) {
-	if strings.HasSuffix(quadletImageName, ".image") {
+	for _, extension := range []string{".image", ".build"} {
+		if !strings.HasSuffix(quadletImageName, extension) {
+			continue
+		}
+
 		// since there is no default name conversion, the actual image name must exist in the names map
 		imageName, ok := names[quadletImageName]
 		if !ok {
-			return "", fmt.Errorf("requested Quadlet image %s was not found", imageName)
+			return "", fmt.Errorf("requested Quadlet image %s was not found", quadletImageName)
 		}
 
-		// the systemd unit name is $name-image.service
-		imageServiceName := replaceExtension(quadletImageName, ".service", "", "-image")
+		// the systemd unit name is $name-image.service or $name-build.service
+		imageServiceName := replaceExtension(quadletImageName, ".service", "", "-"+strings.TrimPrefix(extension, "."))
 
 		serviceUnitFile.Add(UnitGroup, "Requires", imageServiceName)
 		serviceUnitFile.Add(UnitGroup, "After", imageServiceName)
 
-		quadletImageName = imageName
+		return imageName, nil
 	}
 
 	return quadletImageName, nil
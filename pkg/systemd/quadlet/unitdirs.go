@@ -0,0 +1,129 @@
+package quadlet
+
+import (
+	"fmt"
+	"os"
+	"os/user"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/containers/storage/pkg/regexp"
+)
+
+const (
+	SystemUserDirLevel = 5
+)
+
+var numericDirRegex = regexp.Delayed(`^[0-9]*$`)
+
+// GetUnitDirs returns the directories where we read quadlet files from.
+// For system generators these are in /usr/share/containers/systemd (for distro files)
+// and /etc/containers/systemd (for sysadmin files).
+// For user generators these can live in /etc/containers/systemd/users, /etc/containers/systemd/users/$UID, and $XDG_CONFIG_HOME/containers/systemd
+func GetUnitDirs(rootless bool) ([]string, error) {
+	// Allow overriding source dir, this is mainly for the CI tests
+	unitDirsEnv := os.Getenv("QUADLET_UNIT_DIRS")
+	dirs := make([]string, 0)
+
+	if len(unitDirsEnv) > 0 {
+		for _, eachUnitDir := range strings.Split(unitDirsEnv, ":") {
+			if !filepath.IsAbs(eachUnitDir) {
+				return nil, fmt.Errorf("%s not a valid file path", eachUnitDir)
+			}
+			dirs = appendSubPaths(dirs, eachUnitDir, false, nil)
+		}
+		return dirs, nil
+	}
+
+	if rootless {
+		configDir, err := os.UserConfigDir()
+		if err != nil {
+			return nil, err
+		}
+		dirs = appendSubPaths(dirs, path.Join(configDir, "containers/systemd"), false, nil)
+		u, err := user.Current()
+		if err == nil {
+			dirs = appendSubPaths(dirs, filepath.Join(UnitDirAdmin, "users"), true, nonNumericFilter)
+			dirs = appendSubPaths(dirs, filepath.Join(UnitDirAdmin, "users", u.Uid), true, userLevelFilter)
+		} else {
+			fmt.Fprintf(os.Stderr, "Warning: %v", err)
+		}
+		return append(dirs, filepath.Join(UnitDirAdmin, "users")), nil
+	}
+
+	dirs = appendSubPaths(dirs, UnitDirAdmin, false, userLevelFilter)
+	return appendSubPaths(dirs, UnitDirDistro, false, nil), nil
+}
+
+// UserUnitDir returns the directory holding the quadlet files of the user,
+// for rootless users, or of the sysadmin.  If QUADLET_UNIT_DIRS is set, its
+// first directory is used.
+func UserUnitDir(rootless bool) (string, error) {
+	if unitDirsEnv := os.Getenv("QUADLET_UNIT_DIRS"); len(unitDirsEnv) > 0 {
+		dir, _, _ := strings.Cut(unitDirsEnv, ":")
+		if !filepath.IsAbs(dir) {
+			return "", fmt.Errorf("%s not a valid file path", dir)
+		}
+		return dir, nil
+	}
+	if !rootless {
+		return UnitDirAdmin, nil
+	}
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return path.Join(configDir, "containers/systemd"), nil
+}
+
+func appendSubPaths(dirs []string, path string, isUserFlag bool, filterPtr func(string, bool) bool) []string {
+	resolvedPath, err := filepath.EvalSymlinks(path)
+	if err != nil {
+		// Despite the failure add the path to the list for logging purposes
+		// This is the equivalent of adding the path when info==nil below
+		dirs = append(dirs, path)
+		return dirs
+	}
+
+	// Errors walking the sub directories are ignored, the directories
+	// walked so far are still used
+	_ = filepath.WalkDir(resolvedPath, func(_path string, info os.DirEntry, err error) error {
+		if info == nil || info.IsDir() {
+			if filterPtr == nil || filterPtr(_path, isUserFlag) {
+				dirs = append(dirs, _path)
+			}
+		}
+		return err
+	})
+	return dirs
+}
+
+func nonNumericFilter(_path string, isUserFlag bool) bool {
+	// when running in rootless, recursive walk directories that are non numeric
+	// ignore sub dirs under the `users` directory which correspond to a user id
+	if strings.Contains(_path, filepath.Join(UnitDirAdmin, "users")) {
+		listDirUserPathLevels := strings.Split(_path, string(os.PathSeparator))
+		if len(listDirUserPathLevels) > SystemUserDirLevel {
+			if !(numericDirRegex.MatchString(listDirUserPathLevels[SystemUserDirLevel])) {
+				return true
+			}
+		}
+	} else {
+		return true
+	}
+	return false
+}
+
+func userLevelFilter(_path string, isUserFlag bool) bool {
+	// if quadlet generator is run rootless, do not recurse other user sub dirs
+	// if quadlet generator is run as root, ignore users sub dirs
+	if strings.Contains(_path, filepath.Join(UnitDirAdmin, "users")) {
+		if isUserFlag {
+			return true
+		}
+	} else {
+		return true
+	}
+	return false
+}
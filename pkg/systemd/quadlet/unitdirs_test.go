@@ -0,0 +1,73 @@
+package quadlet
+
+import (
+	"os"
+	"os/user"
+	"path"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnitDirs(t *testing.T) {
+	rootDirs := []string{}
+	rootDirs = appendSubPaths(rootDirs, UnitDirAdmin, false, userLevelFilter)
+	rootDirs = appendSubPaths(rootDirs, UnitDirDistro, false, userLevelFilter)
+	unitDirs, err := GetUnitDirs(false)
+	assert.Nil(t, err)
+	assert.Equal(t, unitDirs, rootDirs, "rootful unit dirs should match")
+
+	configDir, err := os.UserConfigDir()
+	assert.Nil(t, err)
+	u, err := user.Current()
+	assert.Nil(t, err)
+
+	rootlessDirs := []string{}
+
+	rootlessDirs = appendSubPaths(rootlessDirs, path.Join(configDir, "containers/systemd"), false, nil)
+	rootlessDirs = appendSubPaths(rootlessDirs, filepath.Join(UnitDirAdmin, "users"), true, nonNumericFilter)
+	rootlessDirs = appendSubPaths(rootlessDirs, filepath.Join(UnitDirAdmin, "users", u.Uid), true, userLevelFilter)
+	rootlessDirs = append(rootlessDirs, filepath.Join(UnitDirAdmin, "users"))
+
+	unitDirs, err = GetUnitDirs(true)
+	assert.Nil(t, err)
+	assert.Equal(t, unitDirs, rootlessDirs, "rootless unit dirs should match")
+
+	name, err := os.MkdirTemp("", "dir")
+	assert.Nil(t, err)
+	// remove the temporary directory at the end of the program
+	defer os.RemoveAll(name)
+
+	t.Setenv("QUADLET_UNIT_DIRS", name)
+	unitDirs, err = GetUnitDirs(false)
+	assert.Nil(t, err)
+	assert.Equal(t, unitDirs, []string{name}, "rootful should use environment variable")
+
+	unitDirs, err = GetUnitDirs(true)
+	assert.Nil(t, err)
+	assert.Equal(t, unitDirs, []string{name}, "rootless should use environment variable")
+
+	symLinkTestBaseDir, err := os.MkdirTemp("", "podman-symlinktest")
+	assert.Nil(t, err)
+	// remove the temporary directory at the end of the program
+	defer os.RemoveAll(symLinkTestBaseDir)
+
+	actualDir := filepath.Join(symLinkTestBaseDir, "actual")
+	err = os.Mkdir(actualDir, 0755)
+	assert.Nil(t, err)
+	innerDir := filepath.Join(actualDir, "inner")
+	err = os.Mkdir(innerDir, 0755)
+	assert.Nil(t, err)
+	symlink := filepath.Join(symLinkTestBaseDir, "symlink")
+	err = os.Symlink(actualDir, symlink)
+	assert.Nil(t, err)
+	t.Setenv("QUADLET_UNIT_DIRS", actualDir)
+	unitDirs, err = GetUnitDirs(true)
+	assert.Nil(t, err)
+	assert.Equal(t, unitDirs, []string{actualDir, innerDir}, "directory resolution should follow symlink")
+
+	t.Setenv("QUADLET_UNIT_DIRS", "relative/dir")
+	_, err = GetUnitDirs(false)
+	assert.Error(t, err, "relative paths should be rejected")
+}
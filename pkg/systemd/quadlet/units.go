@@ -0,0 +1,240 @@
+package quadlet
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/containers/podman/v4/pkg/systemd/parser"
+)
+
+// Key: Extension
+// Value: Processing order for resource naming dependencies
+var SupportedExtensions = map[string]int{
+	".container": 5,
+	".volume":    4,
+	".kube":      5,
+	".network":   2,
+	".image":     1,
+	".build":     3,
+	".pod":       6,
+}
+
+// IsExtSupported returns true if the file has the extension of a quadlet file.
+func IsExtSupported(filename string) bool {
+	ext := filepath.Ext(filename)
+	_, ok := SupportedExtensions[ext]
+	return ok
+}
+
+// ServiceName returns the name of the service generated for the quadlet file.
+func ServiceName(filename string) string {
+	ext := filepath.Ext(filename)
+	switch ext {
+	case ".container", ".kube":
+		return replaceExtension(filename, ".service", "", "")
+	default:
+		return replaceExtension(filename, ".service", "", "-"+strings.TrimPrefix(ext, "."))
+	}
+}
+
+// LoadUnitDropins merges the *.conf drop-in files found in the $unit.d
+// directories of the source paths into the unit.
+func LoadUnitDropins(unit *parser.UnitFile, sourcePaths []string) error {
+	var prevError error
+	reportError := func(err error) {
+		if prevError != nil {
+			err = fmt.Errorf("%s\n%s", prevError, err)
+		}
+		prevError = err
+	}
+
+	var dropinPaths = make(map[string]string)
+	for _, sourcePath := range sourcePaths {
+		dropinDir := path.Join(sourcePath, unit.Filename+".d")
+
+		dropinFiles, err := os.ReadDir(dropinDir)
+		if err != nil {
+			if !errors.Is(err, os.ErrNotExist) {
+				reportError(fmt.Errorf("error reading directory %q, %w", dropinDir, err))
+			}
+
+			continue
+		}
+
+		for _, dropinFile := range dropinFiles {
+			dropinName := dropinFile.Name()
+			if filepath.Ext(dropinName) != ".conf" {
+				continue // Only *.conf supported
+			}
+
+			if _, ok := dropinPaths[dropinName]; ok {
+				continue // We already saw this name
+			}
+
+			dropinPaths[dropinName] = path.Join(dropinDir, dropinName)
+		}
+	}
+
+	dropinFiles := make([]string, len(dropinPaths))
+	i := 0
+	for k := range dropinPaths {
+		dropinFiles[i] = k
+		i++
+	}
+
+	// Merge in alpha-numerical order
+	sort.Strings(dropinFiles)
+
+	for _, dropinFile := range dropinFiles {
+		dropinPath := dropinPaths[dropinFile]
+
+		if f, err := parser.ParseUnitFile(dropinPath); err != nil {
+			reportError(fmt.Errorf("error loading %q, %w", dropinPath, err))
+		} else {
+			unit.Merge(f)
+		}
+	}
+
+	return prevError
+}
+
+// SortUnits sorts unit files according to potential inter-dependencies, with
+// Image, Network, Build and Volume units taking precedence over all others.
+func SortUnits(units []*parser.UnitFile) {
+	sort.SliceStable(units, func(i, j int) bool {
+		getOrder := func(i int) int {
+			ext := filepath.Ext(units[i].Filename)
+			order, ok := SupportedExtensions[ext]
+			if !ok {
+				return 0
+			}
+			return order
+		}
+		return getOrder(i) < getOrder(j)
+	})
+}
+
+// GeneratePodsInfoMap generates the PodsInfoMap to allow containers to link
+// to their pods and add themselves to the pod's containers list.
+func GeneratePodsInfoMap(units []*parser.UnitFile) map[string]*PodInfo {
+	podsInfoMap := make(map[string]*PodInfo)
+	for _, unit := range units {
+		if !strings.HasSuffix(unit.Filename, ".pod") {
+			continue
+		}
+
+		serviceName := GetPodServiceName(unit)
+		podsInfoMap[unit.Filename] = &PodInfo{
+			ServiceName: serviceName,
+			Containers:  make([]string, 0),
+		}
+	}
+
+	return podsInfoMap
+}
+
+// ConvertUnit converts a quadlet file into the systemd units generated for
+// it.  The names of the resources created by the unit are added to names, so
+// units must be converted in the order of SortUnits.
+func ConvertUnit(unit *parser.UnitFile, names map[string]string, isUser bool, podsInfoMap map[string]*PodInfo) ([]*parser.UnitFile, error) {
+	var service *parser.UnitFile
+	var name string
+	var err error
+
+	switch filepath.Ext(unit.Filename) {
+	case ".container":
+		service, err = ConvertContainer(unit, names, isUser, podsInfoMap)
+	case ".volume":
+		service, name, err = ConvertVolume(unit, unit.Filename, names)
+	case ".kube":
+		service, err = ConvertKube(unit, names, isUser)
+	case ".network":
+		service, name, err = ConvertNetwork(unit, unit.Filename)
+	case ".image":
+		service, name, err = ConvertImage(unit)
+	case ".build":
+		service, name, err = ConvertBuild(unit, names)
+	case ".pod":
+		service, err = ConvertPod(unit, unit.Filename, podsInfoMap, names)
+	default:
+		return nil, fmt.Errorf("unsupported file type %q", unit.Filename)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if name != "" {
+		names[unit.Filename] = name
+	}
+
+	generated := []*parser.UnitFile{service}
+	// A scheduled container is started by a timer
+	if strings.HasSuffix(unit.Filename, ".container") {
+		if timer := ConvertContainerSchedule(unit, service); timer != nil {
+			generated = append(generated, timer)
+		}
+	}
+	return generated, nil
+}
+
+// AddContainerNames adds the names of the containers created by the .container
+// units to names, so containers can require other containers regardless of
+// the order the units are converted in.
+func AddContainerNames(units []*parser.UnitFile, names map[string]string) {
+	for _, unit := range units {
+		if !strings.HasSuffix(unit.Filename, ".container") {
+			continue
+		}
+		containerName, ok := unit.Lookup(ContainerGroup, KeyContainerName)
+		if !ok || len(containerName) == 0 {
+			continue
+		}
+		// %N refers to the unit the name is used in.
+		base := strings.TrimSuffix(unit.Filename, ".container")
+		names[unit.Filename] = strings.ReplaceAll(containerName, "%N", base)
+	}
+}
+
+// UnresolvedReferences returns the .network, .volume and .container quadlet
+// files referenced by the unit which are not in units.  Such references are
+// not an error for the generator, but the generated service requires a unit
+// which does not exist.
+func UnresolvedReferences(unit *parser.UnitFile, units map[string]bool) []string {
+	var missing []string
+	check := func(ref string) {
+		if (strings.HasSuffix(ref, ".network") || strings.HasSuffix(ref, ".volume") || strings.HasSuffix(ref, ".container")) && !units[ref] {
+			missing = append(missing, ref)
+		}
+	}
+
+	for _, group := range []string{BuildGroup, ContainerGroup, KubeGroup, PodGroup} {
+		for _, network := range unit.LookupAll(group, KeyNetwork) {
+			ref, _, _ := strings.Cut(network, ":")
+			check(ref)
+		}
+	}
+	for _, group := range []string{ContainerGroup, PodGroup} {
+		for _, volume := range unit.LookupAll(group, KeyVolume) {
+			ref, _, _ := strings.Cut(volume, ":")
+			check(ref)
+		}
+	}
+	for _, requires := range unit.LookupAll(ContainerGroup, KeyRequires) {
+		ref, _, _ := strings.Cut(requires, ":")
+		check(ref)
+	}
+	for _, mount := range unit.LookupAll(ContainerGroup, KeyMount) {
+		for _, param := range strings.Split(mount, ",") {
+			key, value, found := strings.Cut(param, "=")
+			if found && (key == "source" || key == "src") {
+				check(value)
+			}
+		}
+	}
+	return missing
+}
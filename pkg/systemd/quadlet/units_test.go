@@ -0,0 +1,45 @@
+package quadlet
+
+import (
+	"testing"
+
+	"github.com/containers/podman/v4/pkg/systemd/parser"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServiceName(t *testing.T) {
+	tests := []struct {
+		input string
+		res   string
+	}{
+		{"foo.container", "foo.service"},
+		{"foo.kube", "foo.service"},
+		{"foo.volume", "foo-volume.service"},
+		{"foo.network", "foo-network.service"},
+		{"foo.image", "foo-image.service"},
+		{"foo.build", "foo-build.service"},
+		{"foo.pod", "foo-pod.service"},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.res, ServiceName(test.input), "%q", test.input)
+	}
+}
+
+func TestUnresolvedReferences(t *testing.T) {
+	unit := parser.NewUnitFile()
+	unit.Filename = "test.container"
+	unit.Add(ContainerGroup, KeyNetwork, "net1.network")
+	unit.Add(ContainerGroup, KeyNetwork, "net2.network:ip=10.88.0.10")
+	unit.Add(ContainerGroup, KeyNetwork, "host")
+	unit.Add(ContainerGroup, KeyVolume, "vol1.volume:/data")
+	unit.Add(ContainerGroup, KeyVolume, "/srv:/srv")
+	unit.Add(ContainerGroup, KeyMount, "type=volume,source=vol2.volume,destination=/data2")
+
+	known := map[string]bool{"net1.network": true, "vol1.volume": true}
+	assert.Equal(t, []string{"net2.network", "vol2.volume"}, UnresolvedReferences(unit, known))
+
+	known["net2.network"] = true
+	known["vol2.volume"] = true
+	assert.Empty(t, UnresolvedReferences(unit, known))
+}
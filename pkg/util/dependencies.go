@@ -0,0 +1,63 @@
+package util
+
+import (
+	"fmt"
+
+	"github.com/containers/podman/v4/libpod/define"
+)
+
+// SortByDependencies sorts the items so that each item comes after the items
+// it depends on.  Otherwise, the order of the items is kept.  key returns the
+// unique key of an item and deps the keys of the items it depends on.  For a
+// dependency which is not one of the items, missing is called: it returns
+// the error to fail with, or nil to ignore the dependency.
+func SortByDependencies[T any](items []T, key func(T) string, deps func(T) ([]string, error), missing func(item T, dep string) error) ([]T, error) {
+	byKey := make(map[string]T, len(items))
+	for _, item := range items {
+		byKey[key(item)] = item
+	}
+
+	sorted := make([]T, 0, len(items))
+	// An item is visiting while the items it depends on are added, which
+	// detects cycles.
+	visiting := make(map[string]bool)
+	visited := make(map[string]bool)
+	var visit func(item T) error
+	visit = func(item T) error {
+		k := key(item)
+		if visited[k] {
+			return nil
+		}
+		if visiting[k] {
+			return fmt.Errorf("dependency cycle at %s: %w", k, define.ErrInvalidArg)
+		}
+		visiting[k] = true
+		itemDeps, err := deps(item)
+		if err != nil {
+			return err
+		}
+		for _, dep := range itemDeps {
+			depItem, ok := byKey[dep]
+			if !ok {
+				if err := missing(item, dep); err != nil {
+					return err
+				}
+				continue
+			}
+			if err := visit(depItem); err != nil {
+				return err
+			}
+		}
+		visiting[k] = false
+		visited[k] = true
+		sorted = append(sorted, item)
+		return nil
+	}
+
+	for _, item := range items {
+		if err := visit(item); err != nil {
+			return nil, err
+		}
+	}
+	return sorted, nil
+}
@@ -573,3 +573,27 @@ func TestConvertMappings(t *testing.T) {
 		assert.Equal(t, start[i].Size, convertedBack[i].Size)
 	}
 }
+
+func TestSortByDependencies(t *testing.T) {
+	deps := map[string][]string{
+		"app":   {"db", "cache"},
+		"db":    {"vol"},
+		"cache": {"external"},
+	}
+	key := func(s string) string { return s }
+	depsOf := func(s string) ([]string, error) { return deps[s], nil }
+	ignore := func(string, string) error { return nil }
+
+	sorted, err := SortByDependencies([]string{"app", "cache", "vol", "db", "other"}, key, depsOf, ignore)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"vol", "db", "cache", "app", "other"}, sorted)
+
+	_, err = SortByDependencies([]string{"app", "cache", "db"}, key, depsOf, func(item, dep string) error {
+		return fmt.Errorf("%s misses %s", item, dep)
+	})
+	assert.EqualError(t, err, "db misses vol")
+
+	deps["vol"] = []string{"app"}
+	_, err = SortByDependencies([]string{"app", "cache", "vol", "db"}, key, depsOf, ignore)
+	assert.ErrorContains(t, err, "dependency cycle")
+}
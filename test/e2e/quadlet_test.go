@@ -39,6 +39,8 @@ func loadQuadletTestcase(path string) *quadletTestcase {
 		service += "-network"
 	case ".image":
 		service += "-image"
+	case ".build":
+		service += "-build"
 	case ".pod":
 		service += "-pod"
 	}
@@ -766,6 +768,7 @@ BOGUS=foo
 		Entry("escapes.container", "escapes.container", 0, ""),
 		Entry("exec.container", "exec.container", 0, ""),
 		Entry("health.container", "health.container", 0, ""),
+		Entry("health-probe.container", "health-probe.container", 0, ""),
 		Entry("hostname.container", "hostname.container", 0, ""),
 		Entry("idmapping.container", "idmapping.container", 0, ""),
 		Entry("idmapping-with-remap.container", "idmapping-with-remap.container", 1, "converting \"idmapping-with-remap.container\": deprecated Remap keys are set along with explicit mapping keys"),
@@ -775,6 +778,7 @@ BOGUS=foo
 		Entry("label.container", "label.container", 0, ""),
 		Entry("line-continuation-whitespace.container", "line-continuation-whitespace.container", 0, ""),
 		Entry("logdriver.container", "logdriver.container", 0, ""),
+		Entry("logopt.container", "logopt.container", 0, ""),
 		Entry("mask.container", "mask.container", 0, ""),
 		Entry("mount.container", "mount.container", 0, ""),
 		Entry("name.container", "name.container", 0, ""),
@@ -794,6 +798,7 @@ BOGUS=foo
 		Entry("pull.container", "pull.container", 0, ""),
 		Entry("readonly.container", "readonly.container", 0, ""),
 		Entry("readonly-tmpfs.container", "readonly-tmpfs.container", 0, ""),
+		Entry("requires.container", "requires.container", 0, ""),
 		Entry("readonly-notmpfs.container", "readonly-notmpfs.container", 0, ""),
 		Entry("readwrite-notmpfs.container", "readwrite-notmpfs.container", 0, ""),
 		Entry("volatiletmp-readwrite.container", "volatiletmp-readwrite.container", 0, ""),
@@ -804,6 +809,7 @@ BOGUS=foo
 		Entry("remap-keep-id2.container", "remap-keep-id2.container", 0, ""),
 		Entry("remap-manual.container", "remap-manual.container", 0, ""),
 		Entry("rootfs.container", "rootfs.container", 0, ""),
+		Entry("schedule.container", "schedule.container", 0, ""),
 		Entry("seccomp.container", "seccomp.container", 0, ""),
 		Entry("secrets.container", "secrets.container", 0, ""),
 		Entry("selinux.container", "selinux.container", 0, ""),
@@ -843,6 +849,7 @@ BOGUS=foo
 		Entry("Kube - ConfigMap", "configmap.kube", 0, ""),
 		Entry("Kube - Exit Code Propagation", "exit_code_propagation.kube", 0, ""),
 		Entry("Kube - Logdriver", "logdriver.kube", 0, ""),
+		Entry("Kube - Logopt", "logopt.kube", 0, ""),
 		Entry("Kube - Network", "network.kube", 0, ""),
 		Entry("Kube - PodmanArgs", "podmanargs.kube", 0, ""),
 		Entry("Kube - Publish IPv4 ports", "ports.kube", 0, ""),
@@ -900,6 +907,15 @@ BOGUS=foo
 		Entry("Image - global args", "globalargs.image", 0, ""),
 		Entry("Image - Containers Conf Modules", "containersconfmodule.image", 0, ""),
 
+		Entry("Build - Basic", "basic.build", 0, ""),
+		Entry("Build - No ImageTag", "no-imagetag.build", 1, "converting \"no-imagetag.build\": no ImageTag key specified"),
+		Entry("Build - No build context", "no-context.build", 1, "converting \"no-context.build\": neither SetWorkingDirectory nor a local File key specified"),
+		Entry("Build - Options", "options.build", 0, ""),
+		Entry("Build - Working Directory unit", "workingdir-unit.build", 0, ""),
+		Entry("Build - Working Directory file", "workingdir-file.build", 0, ""),
+		Entry("Build - Remote context", "url.build", 0, ""),
+		Entry("Build - global args", "globalargs.build", 0, ""),
+
 		Entry("basic.pod", "basic.pod", 0, ""),
 		Entry("name.pod", "name.pod", 0, ""),
 		Entry("network.pod", "network.pod", 0, ""),
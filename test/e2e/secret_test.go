@@ -436,4 +436,92 @@ var _ = Describe("Podman secret", func() {
 		exists.WaitWithDefaultTimeout()
 		Expect(exists).Should(Exit(1))
 	})
+
+	It("podman secret update", func() {
+		secretFilePath := filepath.Join(podmanTest.TempDir, "secret")
+		err := os.WriteFile(secretFilePath, []byte("mysecret"), 0755)
+		Expect(err).ToNot(HaveOccurred())
+
+		session := podmanTest.Podman([]string{"secret", "create", "--label", "foo=bar", "a", secretFilePath})
+		session.WaitWithDefaultTimeout()
+		secrID := session.OutputToString()
+		Expect(session).Should(ExitCleanly())
+
+		err = os.WriteFile(secretFilePath, []byte("newsecret"), 0755)
+		Expect(err).ToNot(HaveOccurred())
+
+		update := podmanTest.Podman([]string{"secret", "update", "a", secretFilePath})
+		update.WaitWithDefaultTimeout()
+		Expect(update).Should(ExitCleanly())
+		newID := update.OutputToString()
+		Expect(newID).ToNot(Equal(secrID))
+
+		inspect := podmanTest.Podman([]string{"secret", "inspect", "--showsecret", "--format", "{{.ID}} {{.Spec.Labels}} {{.SecretData}}", "a"})
+		inspect.WaitWithDefaultTimeout()
+		Expect(inspect).Should(ExitCleanly())
+		Expect(inspect.OutputToString()).To(Equal(newID + " map[foo:bar] newsecret"))
+
+		update = podmanTest.Podman([]string{"secret", "update", "--propagate", "bogus", "a", secretFilePath})
+		update.WaitWithDefaultTimeout()
+		Expect(update).Should(ExitWithError())
+		Expect(update.ErrorToString()).To(ContainSubstring("invalid propagation mode \"bogus\""))
+
+		update = podmanTest.Podman([]string{"secret", "update", "non-existent-secret", secretFilePath})
+		update.WaitWithDefaultTimeout()
+		Expect(update).Should(ExitWithError())
+		Expect(update.ErrorToString()).To(ContainSubstring("no such secret"))
+
+		events := podmanTest.Podman([]string{"events", "--stream=false", "--filter", "type=secret", "--filter", "secret=a"})
+		events.WaitWithDefaultTimeout()
+		Expect(events).Should(ExitCleanly())
+		Expect(events.OutputToString()).To(ContainSubstring("secret create"))
+		Expect(events.OutputToString()).To(ContainSubstring("secret update"))
+	})
+
+	It("podman secret update --propagate", func() {
+		secretFilePath := filepath.Join(podmanTest.TempDir, "secret")
+		err := os.WriteFile(secretFilePath, []byte("mysecret"), 0755)
+		Expect(err).ToNot(HaveOccurred())
+
+		session := podmanTest.Podman([]string{"secret", "create", "a", secretFilePath})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+
+		session = podmanTest.Podman([]string{"run", "-d", "--name", "mounted", "--secret", "a", ALPINE, "top"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+
+		session = podmanTest.Podman([]string{"run", "-d", "--name", "env", "--secret", "a,type=env", ALPINE, "top"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+
+		err = os.WriteFile(secretFilePath, []byte("reinjected"), 0755)
+		Expect(err).ToNot(HaveOccurred())
+
+		update := podmanTest.Podman([]string{"secret", "update", "--propagate", "reinject", "a", secretFilePath})
+		update.WaitWithDefaultTimeout()
+		Expect(update).Should(Exit(0))
+
+		session = podmanTest.Podman([]string{"exec", "mounted", "cat", "/run/secrets/a"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+		Expect(session.OutputToString()).To(Equal("reinjected"))
+
+		session = podmanTest.Podman([]string{"exec", "env", "printenv", "a"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+		Expect(session.OutputToString()).To(Equal("mysecret"))
+
+		err = os.WriteFile(secretFilePath, []byte("restarted"), 0755)
+		Expect(err).ToNot(HaveOccurred())
+
+		update = podmanTest.Podman([]string{"secret", "update", "--propagate", "restart", "a", secretFilePath})
+		update.WaitWithDefaultTimeout()
+		Expect(update).Should(ExitCleanly())
+
+		session = podmanTest.Podman([]string{"exec", "env", "printenv", "a"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+		Expect(session.OutputToString()).To(Equal("restarted"))
+	})
 })
@@ -85,6 +85,28 @@ load helpers.bash
     run_podman image prune -f
 }
 
+@test "farm - build --dry-run prints the schedule" {
+    run_podman info --format '{{.Host.Arch}}'
+    ARCH=$output
+
+    run_podman farm build --dry-run --node-weight test-node=2 -t $REGISTRY/test-image-dry-run $FARM_TMPDIR
+    assert "${lines[0]}" =~ "PLATFORM +BUILDER +NATIVE +FALLBACKS" "dry-run header"
+    assert "$output" =~ "linux/$ARCH +\(local\) +true +test-node" "local builder preferred, farm node as fallback"
+
+    run_podman farm build --dry-run --exclude-node local -t $REGISTRY/test-image-dry-run $FARM_TMPDIR
+    assert "$output" =~ "linux/$ARCH +test-node +true" "local builder excluded"
+    assert "$output" !~ "\(local\)" "local builder excluded"
+
+    run_podman 125 farm build --dry-run --exclude-node nonexistent -t $REGISTRY/test-image-dry-run $FARM_TMPDIR
+    assert "$output" =~ "cannot exclude unknown farm node \"nonexistent\"" "excluding unknown node"
+
+    run_podman 125 farm build --dry-run --node-weight test-node=0 -t $REGISTRY/test-image-dry-run $FARM_TMPDIR
+    assert "$output" =~ "weight of farm node \"test-node\" must be at least 1" "invalid weight"
+
+    # Nothing was built
+    run_podman 1 manifest exists test-image-dry-run
+}
+
 # Test out podman-remote
 
 @test "farm - build on farm node only (podman-remote)" {
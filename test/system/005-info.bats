@@ -271,4 +271,194 @@ EOF
     run_podman $safe_opts system reset --force
 }
 
+@test "podman system migrate --database-backend" {
+    skip_if_remote "DB migration only works for local Podman"
+
+    safe_opts=$(podman_isolation_opts ${PODMAN_TMPDIR})
+    containersConf=$PODMAN_TMPDIR/containers.conf
+    cat >$containersConf <<EOF
+[engine]
+database_backend = "boltdb"
+EOF
+    mkdir $PODMAN_TMPDIR/rootfs
+
+    # Set up a BoltDB database with a volume, a pod and two containers.
+    CONTAINERS_CONF=$containersConf CI_DESIRED_DATABASE=boltdb run_podman $safe_opts volume create migratevol
+    CONTAINERS_CONF=$containersConf run_podman $safe_opts pod create --infra=false --name migratepod
+    CONTAINERS_CONF=$containersConf run_podman $safe_opts create --name migratectr1 \
+        --pod migratepod -v migratevol:/vol --rootfs $PODMAN_TMPDIR/rootfs true
+    CONTAINERS_CONF=$containersConf run_podman $safe_opts create --name migratectr2 \
+        --requires migratectr1 --pod migratepod --rootfs $PODMAN_TMPDIR/rootfs true
+
+    CONTAINERS_CONF=$containersConf run_podman $safe_opts system migrate --database-backend sqlite --dry-run
+    assert "$output" =~ "Would migrate 2 containers, 1 pods, 1 volumes, .* from boltdb to sqlite database " \
+           "dry run reports what would be copied"
+    CONTAINERS_CONF=$containersConf run_podman $safe_opts info --format '{{.Host.DatabaseBackend}}'
+    is "$output" "boltdb" "dry run does not switch the database backend"
+
+    CONTAINERS_CONF=$containersConf run_podman $safe_opts system migrate --database-backend sqlite
+    assert "$output" =~ "Migrated 2 containers, 1 pods, 1 volumes, .* from boltdb to sqlite database " \
+           "migration to sqlite"
+    assert "$(< $containersConf)" =~ 'database_backend = "sqlite"' "containers.conf is switched to sqlite"
+
+    CONTAINERS_CONF=$containersConf run_podman $safe_opts info --format '{{.Host.DatabaseBackend}}'
+    is "$output" "sqlite" "database backend after migration"
+    CONTAINERS_CONF=$containersConf run_podman $safe_opts ps -a --sort names --format '{{.Names}}:{{.PodName}}'
+    is "$output" "migratectr1:migratepod
+migratectr2:migratepod" "containers after migration"
+    CONTAINERS_CONF=$containersConf run_podman $safe_opts volume ls --format '{{.Name}}'
+    is "$output" "migratevol" "volumes after migration"
+    CONTAINERS_CONF=$containersConf run_podman 2 $safe_opts volume rm migratevol
+    assert "$output" =~ "volume is being used" "volume is still in use by migratectr1"
+
+    CONTAINERS_CONF=$containersConf run_podman 125 $safe_opts system migrate --database-backend sqlite
+    is "$output" "database backend is already sqlite: invalid argument" "migration to the current backend"
+
+    # Roll back to the BoltDB database.
+    CONTAINERS_CONF=$containersConf run_podman $safe_opts system migrate --database-backend boltdb
+    assert "$output" =~ "Migrated 2 containers, 1 pods, 1 volumes, .* from sqlite to boltdb database " \
+           "migration back to boltdb"
+    assert "$output" =~ "The existing boltdb database was moved to .*/bolt_state.db.bak" \
+           "the stale boltdb database is kept"
+    CONTAINERS_CONF=$containersConf run_podman $safe_opts info --format '{{.Host.DatabaseBackend}}'
+    is "$output" "boltdb" "database backend after rollback"
+    CONTAINERS_CONF=$containersConf run_podman $safe_opts ps -a --format '{{.Names}}'
+    assert "${#lines[@]}" -eq 2 "containers after rollback"
+
+    CONTAINERS_CONF=$containersConf run_podman $safe_opts system reset --force
+}
+
+@test "podman system backup and restore" {
+    skip_if_remote "system backup only works for local Podman"
+
+    src_opts="$(podman_isolation_opts ${PODMAN_TMPDIR}/src) --network-config-dir ${PODMAN_TMPDIR}/src/networks"
+    dst_opts="$(podman_isolation_opts ${PODMAN_TMPDIR}/dst) --network-config-dir ${PODMAN_TMPDIR}/dst/networks"
+    mkdir -p ${PODMAN_TMPDIR}/src/networks ${PODMAN_TMPDIR}/dst/networks ${PODMAN_TMPDIR}/rootfs
+
+    echo "hello" > $PODMAN_TMPDIR/file
+    tar -C $PODMAN_TMPDIR -cf $PODMAN_TMPDIR/data.tar file
+    echo -n "secretdata" > $PODMAN_TMPDIR/secret
+    echo "passphrase" > $PODMAN_TMPDIR/passphrase
+
+    run_podman $src_opts network create backupnet
+    run_podman $src_opts volume create backupvol
+    run_podman $src_opts volume import backupvol $PODMAN_TMPDIR/data.tar
+    run_podman $src_opts secret create backupsecret $PODMAN_TMPDIR/secret
+    run_podman $src_opts pod create --infra=false --name backuppod
+    run_podman $src_opts create --name backupctr1 --pod backuppod --network none \
+        -v backupvol:/vol --secret backupsecret --rootfs $PODMAN_TMPDIR/rootfs true
+    run_podman $src_opts create --name backupctr2 --pod backuppod --network none \
+        --requires backupctr1 --rootfs $PODMAN_TMPDIR/rootfs true
+    run_podman $src_opts create --name backupctr3 --network backupnet \
+        --rootfs $PODMAN_TMPDIR/rootfs true
+
+    run_podman $src_opts system backup --passphrase-file $PODMAN_TMPDIR/passphrase $PODMAN_TMPDIR/backup.tar
+    is "$output" "Backed up 3 containers, 1 pods, 1 networks, 1 volumes, 1 secrets and 0 images to $PODMAN_TMPDIR/backup.tar"
+    run tar -xOf $PODMAN_TMPDIR/backup.tar secrets/backupsecret.json
+    assert "$status" -eq 0 "secret is part of the backup"
+    assert "$output" !~ "$(echo -n secretdata | base64)" "secret data is encrypted"
+
+    run_podman 125 $dst_opts system restore $PODMAN_TMPDIR/backup.tar
+    is "$output" "Error: the secrets of the backup are encrypted, a passphrase is required: invalid argument"
+
+    run_podman $dst_opts system restore --passphrase-file $PODMAN_TMPDIR/passphrase $PODMAN_TMPDIR/backup.tar
+    is "$output" "Restored 3 containers, 1 pods, 1 networks, 1 volumes, 1 secrets and 0 images"
+    run_podman $dst_opts ps -a --sort names --format '{{.Names}}:{{.PodName}}:{{.Networks}}'
+    is "$output" "backupctr1:backuppod:
+backupctr2:backuppod:
+backupctr3::backupnet" "restored containers"
+    run_podman $dst_opts volume inspect --format '{{.Mountpoint}}' backupvol
+    is "$(< $output/file)" "hello" "contents of the restored volume"
+    run_podman $dst_opts secret inspect --showsecret --format '{{.SecretData}}' backupsecret
+    is "$output" "secretdata" "data of the restored secret"
+
+    # Nothing is replaced.
+    run_podman 125 $dst_opts system restore --passphrase-file $PODMAN_TMPDIR/passphrase $PODMAN_TMPDIR/backup.tar
+    is "$output" "Error: network backupnet already exists, restore it under another name with --map-name network:backupnet=NEWNAME: invalid argument"
+
+    run_podman $dst_opts system restore --passphrase-file $PODMAN_TMPDIR/passphrase \
+        --map-name backupnet=net2 --map-name backupvol=vol2 --map-name backupsecret=secret2 \
+        --map-name pod:backuppod=pod2 --map-name backupctr1=ctr1 --map-name backupctr2=ctr2 \
+        --map-name container:backupctr3=ctr3 - < $PODMAN_TMPDIR/backup.tar
+    is "$output" "Restored 3 containers, 1 pods, 1 networks, 1 volumes, 1 secrets and 0 images"
+    run_podman $dst_opts ps -a --filter name=ctr --sort names --format '{{.Names}}:{{.PodName}}:{{.Networks}}'
+    is "$output" "backupctr1:backuppod:
+backupctr2:backuppod:
+backupctr3::backupnet
+ctr1:pod2:
+ctr2:pod2:
+ctr3::net2" "containers restored under other names"
+    run_podman $dst_opts container inspect --format '{{range .Mounts}}{{.Name}}{{end}}' ctr1
+    is "$output" "vol2" "renamed volume of the renamed container"
+
+    run_podman $src_opts system reset --force
+    run_podman $dst_opts system reset --force
+}
+
+@test "podman system check" {
+    skip_if_remote "system check only works for local Podman"
+
+    opts="$(podman_isolation_opts ${PODMAN_TMPDIR}/check)"
+    mkdir -p ${PODMAN_TMPDIR}/rootfs
+
+    run_podman $opts system check
+    is "$output" "No problems found"
+
+    run_podman 125 $opts system check --force
+    is "$output" "Error: --force requires --repair"
+
+    # Lose the mountpoint of a volume...
+    run_podman $opts volume create checkvol
+    run_podman $opts volume inspect --format '{{.Mountpoint}}' checkvol
+    mountpoint="$output"
+    rm -rf "$mountpoint"
+
+    # ...and leave the directory of an exec session behind.
+    run_podman $opts create --name checkctr --rootfs $PODMAN_TMPDIR/rootfs true
+    run_podman $opts inspect --format '{{.StaticDir}}' checkctr
+    execdir="$output/$(echo $RANDOM | sha256sum | cut -d' ' -f1)"
+    mkdir -p $execdir/exit
+
+    run_podman 125 $opts system check
+    assert "$output" =~ "Volume problems:"
+    assert "$output" =~ "checkvol: mountpoint $mountpoint is missing"
+    assert "$output" =~ "Exec session problems:"
+    assert "$output" =~ "directory $execdir of container .* belongs to no exec session"
+    assert "$output" =~ "2 problems found, 0 repaired"
+    assert "$output" =~ "Error: 2 problems found, use --repair to repair them"
+
+    run_podman $opts system check --repair
+    assert "$output" =~ "checkvol: .* \(repaired\)"
+    assert "$output" =~ "2 problems found, 2 repaired"
+    assert "$mountpoint" != "" "volume has a mountpoint"
+    test -d "$mountpoint" || die "mountpoint $mountpoint was not recreated"
+    test ! -e "$execdir" || die "exec session directory $execdir was not removed"
+
+    run_podman $opts system check
+    is "$output" "No problems found"
+
+    # A running container whose storage is lost must not be evicted.
+    run_podman save -o $PODMAN_TMPDIR/image.tar $IMAGE
+    run_podman $opts load -i $PODMAN_TMPDIR/image.tar
+    run_podman $opts run -d $IMAGE top
+    cid="$output"
+    ctrjson=$(echo ${PODMAN_TMPDIR}/check/root/*-containers/containers.json)
+    jq --arg id "$cid" 'map(select(.id != $id))' $ctrjson > $PODMAN_TMPDIR/containers.json
+    mv $PODMAN_TMPDIR/containers.json $ctrjson
+
+    run_podman 125 $opts system check --repair
+    assert "$output" =~ "$cid: storage of the container is missing, not evicting it as it is running"
+    assert "$output" =~ "1 problems found, 0 repaired"
+    run_podman $opts inspect --format '{{.State.Running}}' $cid
+    is "$output" "true" "container is still running"
+
+    # Once stopped, it is evicted.
+    run_podman '?' $opts stop -t 0 $cid
+    run_podman $opts system check --repair
+    assert "$output" =~ "$cid: storage of the container is missing \(repaired\)"
+    run_podman 1 $opts container exists $cid
+
+    run_podman $opts system reset --force
+}
+
 # vim: filetype=sh
@@ -1431,4 +1431,89 @@ search               | $IMAGE           |
     is "$output" "Error.*: $expect" "podman emits useful diagnostic when no entrypoint is set"
 }
 
+@test "podman run --requires with conditions" {
+    dep=dep$(random_string)
+    run_podman create --name $dep $IMAGE true
+
+    run_podman 125 run --rm --requires $dep:bogus $IMAGE true
+    is "$output" "Error: invalid condition \"bogus\" of dependency $dep: must be one of started, healthy or completed-successfully: invalid argument" \
+       "unknown condition"
+
+    run_podman 125 run --rm --requires $dep:healthy $IMAGE true
+    is "$output" "Error: dependency $dep has no healthcheck, cannot wait for it to become healthy" \
+       "healthy condition without healthcheck"
+
+    # The dependency is started, and must have exited successfully
+    run_podman run --rm --requires $dep:completed-successfully $IMAGE echo hello
+    is "$output" "hello" "container started after dependency completed"
+    run_podman inspect --format '{{.State.Status}}:{{.State.ExitCode}}' $dep
+    is "$output" "exited:0" "dependency state"
+
+    fail=fail$(random_string)
+    run_podman create --name $fail $IMAGE false
+    run_podman 125 run --rm --requires $fail:completed-successfully $IMAGE true
+    is "$output" "Error: .*dependency $fail of container .* cannot meet condition \"completed-successfully\": container exited with code 1" \
+       "dependency exited with non-zero exit code"
+
+    run_podman rm $dep $fail
+
+    # Waiting for a condition does not lock the waiting container.
+    unhealthy=unhealthy$(random_string)
+    waiter=waiter$(random_string)
+    run_podman run -d --name $unhealthy --health-cmd false --health-retries 100 \
+               --health-interval 1h $IMAGE top
+    run_podman create --name $waiter --requires $unhealthy:healthy --requires-timeout 60 $IMAGE true
+    $PODMAN start $waiter &>/dev/null &
+    startpid=$!
+    sleep 2
+    PODMAN_TIMEOUT=10 run_podman inspect --format '{{.State.Status}}' $waiter
+    is "$output" "created" "container waiting for its dependency can be inspected"
+    PODMAN_TIMEOUT=10 run_podman rm -f -t 0 $waiter
+    kill $startpid
+    wait $startpid || true
+
+    run_podman rm -f -t 0 $unhealthy
+}
+
+@test "podman run --restart with backoff" {
+    run_podman 125 create --restart=always --restart-delay=2s --restart-max-delay=1s $IMAGE false
+    is "$output" "Error: .*maximum restart delay 1s must not be shorter than the restart delay 2s: invalid argument" \
+       "maximum delay shorter than delay"
+
+    ctr=c-$(random_string)
+    run_podman run -d --restart=always --restart-delay=10s --restart-max-delay=20s --name=$ctr $IMAGE false
+
+    run_podman inspect --format '{{.HostConfig.RestartPolicy.Delay}} {{.HostConfig.RestartPolicy.MaxDelay}}' $ctr
+    is "$output" "10s 20s" "restart delays in inspect"
+
+    # The first restart is immediate, the second one is delayed
+    local crashloop=
+    for i in {1..20}; do
+        run_podman inspect --format '{{.State.CrashLoop}}' $ctr
+        if [[ "$output" == "true" ]]; then
+            crashloop=1
+            break
+        fi
+        sleep 0.5
+    done
+    test -n "$crashloop" || die "container $ctr never entered the crash loop"
+
+    run_podman inspect --format '{{.State.Restarting}} {{.RestartCount}}' $ctr
+    is "$output" "true 1" "container waits for its second restart"
+
+    run_podman ps -a --filter name=$ctr --format '{{.Status}}'
+    assert "$output" =~ "^Exited \(1\) .* \(crashloop\)$" "crash loop shown in ps"
+
+    run_podman events --since=1m --stream=false --filter container=$ctr --filter event=crashloop \
+               --format '{{.Status}}'
+    assert "$output" =~ "crashloop" "crashloop event"
+
+    # Stopping the container ends the crash loop
+    run_podman stop -t0 $ctr
+    run_podman inspect --format '{{.State.CrashLoop}} {{.RestartCount}}' $ctr
+    is "$output" "false 1" "container stopped instead of being restarted"
+
+    run_podman rm -f -t0 $ctr
+}
+
 # vim: filetype=sh
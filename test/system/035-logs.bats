@@ -436,4 +436,58 @@ EOF
     run_podman rm $cname
 }
 
+@test "podman logs - rotated k8s-file" {
+    skip_if_remote "log rotation is not supported remotely"
+
+    run_podman 125 create --log-opt max-size=1k --log-opt max-file=2 --log-opt compress=true $IMAGE true
+    is "$output" "Error: .*compress requires max-file to be greater than 2" \
+       "compress with a single rotated file"
+
+    cname="rotate_container"
+    run_podman run -d --name $cname --log-driver k8s-file \
+               --log-opt max-size=1k --log-opt max-file=3 --log-opt compress=true \
+               $IMAGE sh -c 'i=0; while [ ! -e /stop ]; do i=$((i+1)); echo line$i; sleep 0.01; done; echo done'
+
+    run_podman inspect --format '{{.HostConfig.LogConfig.MaxFile}} {{.HostConfig.LogConfig.Compress}}' $cname
+    is "$output" "3 true" "log rotation settings in inspect"
+
+    run_podman inspect --format '{{.HostConfig.LogConfig.Path}}' $cname
+    logpath="$output"
+
+    # Rotate twice while the container keeps writing, each time once the
+    # log exceeds max-size.
+    for n in 1 2; do
+        for i in {1..50}; do
+            if [[ $(stat -c %s $logpath 2>/dev/null || echo 0) -ge 1024 ]]; then
+                break
+            fi
+            sleep 0.1
+        done
+        run_podman container rotate-log $cname
+    done
+
+    # The newest rotated file is only compressed by the next rotation
+    test -e "$logpath.1" || die "rotated log file $logpath.1 does not exist"
+    test -e "$logpath.2.gz" || die "rotated log file $logpath.2.gz does not exist"
+    test -e "$logpath.2" && die "rotated log file $logpath.2 is not compressed"
+
+    run_podman exec $cname touch /stop
+    run_podman wait $cname
+
+    # No lines are lost across the rotations
+    run_podman logs $cname
+    assert "${lines[-1]}" = "done" "last line"
+    local count=$((${#lines[*]} - 1))
+    assert "$count" -gt 50 "lines written across the rotations"
+    for i in $(seq 1 $count); do
+        assert "${lines[$((i - 1))]}" = "line$i" "line $i is read from the log files"
+    done
+
+    run_podman logs --tail 2 $cname
+    assert "$output" = "line$count
+done" "--tail reads the log files"
+
+    run_podman rm -f -t0 $cname
+}
+
 # vim: filetype=sh
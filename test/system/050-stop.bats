@@ -233,4 +233,40 @@ load helpers
     # Check the OCI runtime directory has removed.
     is "$(ls $OCIDir | grep $cid)" "" "The OCI runtime directory should have been removed"
 }
+
+@test "podman stop - lifecycle hooks" {
+    local hookdir=$PODMAN_TMPDIR/hooks
+    mkdir -p $hookdir
+
+    run_podman run -d --name hooks -v $hookdir:/hooks:Z \
+               --hook-poststart "echo started > /hooks/poststart" \
+               --hook-prestop '["/bin/sh", "-c", "echo stopping > /hooks/prestop"]' \
+               $IMAGE sleep infinity
+    local cid="$output"
+
+    # The post-start hook completes before run returns
+    is "$(< $hookdir/poststart)" "started" "post-start hook ran"
+    test ! -e $hookdir/prestop || die "pre-stop hook ran before stop"
+
+    run_podman inspect --format '{{.Config.PreStopHook.Exec}}' $cid
+    is "$output" "\[/bin/sh -c echo stopping > /hooks/prestop\]" "inspect shows pre-stop hook"
+
+    run_podman stop -t 5 $cid
+    is "$(< $hookdir/prestop)" "stopping" "pre-stop hook ran"
+    run_podman rm $cid
+
+    # A failing hook is recorded as an event but does not stop the container
+    run_podman run -d --hook-poststart "exit 42" $IMAGE sleep infinity
+    cid="$output"
+    run_podman events --since=1m --stream=false --filter container=$cid --filter event=hook_failed \
+               --format '{{.Status}} {{index .Attributes "hook"}}'
+    is "$output" "hook_failed poststart" "failing post-start hook emits event"
+    run_podman container inspect --format '{{.State.Status}}' $cid
+    is "$output" "running" "container keeps running after failed post-start hook"
+    run_podman rm -f -t0 $cid
+
+    run_podman 125 create --hook-prestop "http://" $IMAGE
+    is "$output" ".*lifecycle hook URL \"http://\" has no host.*" "invalid hook URL"
+}
+
 # vim: filetype=sh
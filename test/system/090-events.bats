@@ -400,3 +400,34 @@ EOF
     run_podman events --since=1m --stream=false --filter volume=${vname:0:5}
     assert "$output" = "$notrunc_results"
 }
+
+@test "events - exec event sink" {
+    skip_if_remote "setting CONTAINERS_CONF_OVERRIDE event sinks does not affect remote client"
+
+    local vname=v$(random_string 10)
+    local sinkfile=$PODMAN_TMPDIR/sink.json
+    containersConf=$PODMAN_TMPDIR/containers.conf
+    cat >$containersConf <<EOF
+[engine.event_sinks.test]
+type="exec"
+command=["sh", "-c", "cat >> $sinkfile"]
+filters=["type=volume", "event=remove"]
+EOF
+
+    CONTAINERS_CONF_OVERRIDE=$containersConf run_podman volume create $vname
+    CONTAINERS_CONF_OVERRIDE=$containersConf run_podman volume rm $vname
+
+    # Only the remove event matches the filters, and it must have been
+    # delivered by the time podman exits.
+    run cat $sinkfile
+    assert "${#lines[@]}" = 1 "Number of delivered events"
+    assert "${lines[0]}" =~ "\"Name\":\"$vname\",\"Status\":\"remove\",.*\"Type\":\"volume\"" "delivered event"
+
+    # An invalid sink is rejected
+    cat >$containersConf <<EOF
+[engine.event_sinks.test]
+type="mail"
+EOF
+    CONTAINERS_CONF_OVERRIDE=$containersConf run_podman 125 volume ls
+    assert "$output" =~ "event sink \"test\": unknown type \"mail\"" "invalid sink type"
+}
@@ -602,5 +602,64 @@ EOF
 
     run_podman volume rm $volume_name --force
 }
+@test "podman volume snapshot" {
+    local volname="snapvol_$(random_string 10)"
+    run_podman volume create $volname
+    run_podman run --rm -v $volname:/data $IMAGE sh -c "echo before >/data/file"
+
+    run_podman volume snapshot create --name snap1 $volname
+    is "$output" "snap1" "snapshot create emits the snapshot name"
+    run_podman 125 volume snapshot create --name snap1 $volname
+    is "$output" "Error: snapshot snap1 of volume $volname: snapshot already exists" "duplicate snapshot name"
+
+    # A name is generated if none is given
+    run_podman volume snapshot create $volname
+    generated="$output"
+    assert "$generated" =~ "^[0-9]{8}T[0-9]{6}Z$" "generated snapshot name"
+
+    run_podman volume snapshot ls --format '{{.Volume}} {{.Name}}' $volname
+    is "$output" "$volname snap1
+$volname $generated" "snapshot ls"
+
+    run_podman system df -v --format '{{range .Volumes}}{{.VolumeName}} {{.Snapshots}}{{"\n"}}{{end}}'
+    assert "$output" =~ "$volname 2" "system df counts the snapshots"
+
+    run_podman run --rm -v $volname:/data $IMAGE sh -c "echo after >/data/file; touch /data/new"
+
+    # Restoring is refused while a container is using the volume
+    run_podman run -d -v $volname:/data $IMAGE top
+    cid="$output"
+    run_podman 125 volume snapshot restore $volname snap1
+    assert "$output" =~ "volume is being used" "restore refused while running"
+    run_podman rm -t 0 -f $cid
+
+    run_podman volume snapshot restore $volname snap1
+    run_podman run --rm -v $volname:/data $IMAGE ls /data
+    is "$output" "file" "files created after the snapshot are removed"
+    run_podman run --rm -v $volname:/data $IMAGE cat /data/file
+    is "$output" "before" "contents restored from the snapshot"
+
+    # Pausing a running container while creating the snapshot
+    # (rootless pause requires cgroups v2)
+    if ! is_rootless || is_cgroupsv2; then
+        run_podman run -d -v $volname:/data $IMAGE top
+        cid="$output"
+        run_podman volume snapshot create --pause --name paused $volname
+        run_podman volume snapshot ls --format '{{.PausedContainers}}' $volname
+        assert "$output" =~ "$cid" "paused container is recorded"
+        run_podman inspect --format '{{.State.Status}}' $cid
+        is "$output" "running" "container is unpaused after the snapshot"
+        run_podman rm -t 0 -f $cid
+        run_podman volume snapshot rm $volname paused
+    fi
+
+    run_podman 125 volume snapshot rm $volname snap1 bogus
+    is "$output" "snap1
+Error: snapshot bogus of volume $volname: no such snapshot" "snapshot rm"
+    run_podman volume snapshot ls -q $volname
+    is "$output" "$generated" "remaining snapshot"
+
+    run_podman volume rm $volname
+}
 
 # vim: filetype=sh
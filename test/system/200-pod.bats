@@ -609,6 +609,43 @@ io.max          | $lomajmin rbps=1048576 wbps=1048576 riops=max wiops=max
     run_podman pod rm -f -a
 }
 
+@test "podman pod update" {
+    podname=pod$(random_string)
+
+    run_podman pod create --name $podname --exit-policy=continue
+    run_podman create --pod $podname $IMAGE top
+    cid="$output"
+
+    run_podman pod update --restart=on-failure:3 --exit-policy=stop $podname
+    run_podman pod inspect --format '{{.RestartPolicy}} {{.ExitPolicy}}' $podname
+    is "$output" "on-failure stop" "pod restart and exit policy are updated"
+    run_podman container inspect --format '{{.HostConfig.RestartPolicy.Name}} {{.HostConfig.RestartPolicy.MaximumRetryCount}}' $cid
+    is "$output" "on-failure 3" "container restart policy is updated"
+
+    run_podman 125 pod update --restart=bogus $podname
+    is "$output" "Error: \"bogus\" is not a valid restart policy: invalid argument" "invalid restart policy"
+
+    run_podman 125 pod update $podname
+    is "$output" "Error: no changes specified for the pod"
+
+    if ! is_rootless && is_cgroupsv2; then
+        run_podman pod start $podname
+        run_podman pod update --memory=64m --cpus=1 $podname
+
+        run_podman pod inspect --format '{{.CgroupPath}} {{.MemoryLimit}}' $podname
+        local cgroup_path
+        read cgroup_path memory_limit <<<"$output"
+        is "$memory_limit" "67108864" "pod memory limit is persisted"
+        is "$(< /sys/fs/cgroup/$cgroup_path/memory.max)" "67108864" "pod cgroup memory.max"
+        is "$(< /sys/fs/cgroup/$cgroup_path/cpu.max)" "100000 100000" "pod cgroup cpu.max"
+
+        run_podman exec $cid cat /sys/fs/cgroup/memory.max
+        is "$output" "67108864" "container memory.max"
+    fi
+
+    run_podman pod rm -f -t0 $podname
+}
+
 # Helper used by pod ps --filter test. Creates one pod or container
 # with a UNIQUE two-character CID prefix.
 function thingy_with_unique_id() {
@@ -257,4 +257,79 @@ Log[-1].Output   | \"Uh-oh on stdout!\\\nUh-oh on stderr!\"
     done
 }
 
+@test "podman healthcheck - http, tcp and grpc probes" {
+    ctr="c-h-probe-$(random_string 6)"
+
+    # The probes are run from the host, so the healthcheck works even though
+    # httpd is the only thing running in the container.
+    run_podman run -d --name $ctr            \
+               --health-http-get http://localhost:80/index.txt \
+               --health-http-status 200      \
+               --health-http-header "X-Probe: podman" \
+               --health-interval disable     \
+               --health-retries 1            \
+               -w /var/www                   \
+               $IMAGE sh -c "echo ok > index.txt; exec /bin/busybox-extras httpd -f -p 80"
+
+    run_podman inspect $ctr --format "{{.Config.Healthcheck.Test}}"
+    is "$output" "\[HTTP-GET http://localhost:80/index.txt status=200 header=X-Probe: podman\]" "healthcheck test"
+
+    # httpd may need a moment to start listening
+    for i in $(seq 1 10); do
+        run_podman '?' healthcheck run $ctr
+        if [[ $status -eq 0 ]]; then
+            break
+        fi
+        sleep 0.5
+    done
+    assert "$status" -eq 0 "http probe passed"
+    run_podman inspect $ctr --format "{{json .State.Healthcheck}}"
+    is "$(jq -r '.Status' <<<"$output")" "healthy" "health status"
+    is "$(jq -r '.Log[-1].Output' <<<"$output")" "GET http://localhost:80/index.txt: 200 OK" "http probe output"
+
+    # A 404 is not in the list of healthy status codes
+    run_podman exec $ctr rm /var/www/index.txt
+    run_podman 1 healthcheck run $ctr
+    is "$output" "unhealthy" "http probe failed"
+
+    run_podman rm -f -t0 $ctr
+
+    # TCP probe against a closed and an open port
+    run_podman run -d --name $ctr --health-tcp 80 --health-interval disable \
+               --health-retries 1 $IMAGE /bin/busybox-extras httpd -f -p 80
+    run_podman inspect $ctr --format "{{.Config.Healthcheck.Test}}"
+    is "$output" "\[TCP localhost:80\]" "healthcheck test"
+    for i in $(seq 1 10); do
+        run_podman '?' healthcheck run $ctr
+        if [[ $status -eq 0 ]]; then
+            break
+        fi
+        sleep 0.5
+    done
+    assert "$status" -eq 0 "tcp probe passed"
+    run_podman rm -f -t0 $ctr
+
+    run_podman run -d --name $ctr --health-tcp 127.0.0.1:81 --health-interval disable \
+               --health-retries 1 $IMAGE top
+    run_podman 1 healthcheck run $ctr
+    is "$output" "unhealthy" "tcp probe against closed port"
+
+    # There is no gRPC server in the test image, make sure the probe fails
+    # cleanly.
+    run_podman rm -f -t0 $ctr
+    run_podman run -d --name $ctr --health-grpc 9000/my.Service --health-interval disable \
+               --health-timeout 2s --health-retries 1 $IMAGE top
+    run_podman inspect $ctr --format "{{.Config.Healthcheck.Test}}"
+    is "$output" "\[GRPC localhost:9000 service=my.Service\]" "healthcheck test"
+    run_podman 1 healthcheck run $ctr
+    is "$output" "unhealthy" "grpc probe without server"
+
+    run_podman 125 run --rm --health-cmd true --health-tcp 80 $IMAGE true
+    is "$output" "Error: cannot specify both --health-cmd and a healthcheck probe"
+    run_podman 125 run --rm --health-http-get /healthz $IMAGE true
+    is "$output" "Error: invalid argument: healthcheck URL \"/healthz\" must be an absolute http or https URL"
+
+    run_podman rm -f -t0 $ctr
+}
+
 # vim: filetype=sh
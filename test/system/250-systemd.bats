@@ -379,6 +379,31 @@ LISTEN_FDNAMES=listen_fdnames" | sort)
     run_podman rmi -f $img
 }
 
+@test "podman create --schedule" {
+    cname=c_$(random_string)
+    run_podman 125 create --rm --schedule "*-*-* 03:00" $IMAGE true
+    is "$output" "Error: .*schedule and rm are mutually exclusive options" \
+       "--schedule and --rm cannot be combined"
+
+    run_podman create --name $cname --schedule "*-*-* 03:00" $IMAGE true
+    cid="$output"
+
+    run systemctl is-active ${cid}-schedule.timer
+    assert "$output" = "active" "schedule timer of the container is active"
+
+    run_podman container schedule ls --format "{{.Name}} {{.Schedule}} {{.LastRun}}"
+    assert "$output" =~ "$cname \*-\*-\* 03:00 never" "container schedule ls"
+
+    run_podman container schedule run $cname
+    run_podman wait $cname
+    run_podman inspect $cname --format "{{.Config.Schedule}} {{.State.Schedule.LastExitCode}}"
+    assert "$output" = "*-*-* 03:00 0" "schedule and exit code of the last run"
+
+    run_podman rm $cname
+    run systemctl is-active ${cid}-schedule.timer
+    assert "$output" != "active" "schedule timer is removed with the container"
+}
+
 @test "podman-kube@.service template" {
     install_kube_template
     # Create the YAMl file
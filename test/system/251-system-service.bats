@@ -102,3 +102,40 @@ function teardown() {
     run_podman --url $URL rm $cname
     systemctl stop $SERVICE_NAME
 }
+
+@test "podman system service --metrics" {
+    skip_if_remote "podman system service unavailable over remote"
+
+    port=$(random_free_port)
+    URL=tcp://127.0.0.1:$port
+
+    systemd-run --unit=$SERVICE_NAME $PODMAN system service $URL --time=0 --metrics
+    wait_for_port 127.0.0.1 $port
+
+    cname=c-$(random_string)
+    run_podman run -d --name $cname --label app=metrics $IMAGE top
+    cid="$output"
+
+    # Make an API request, so that its duration is recorded
+    run_podman --url $URL ps
+
+    run curl -s --fail http://127.0.0.1:$port/metrics
+    assert "$status" -eq 0 "GET /metrics"
+    assert "$output" =~ "# TYPE podman_container_state gauge" "container state metric"
+    assert "$output" =~ "podman_container_state\{id=\"$cid\",name=\"$cname\",pod=\"\",label_app=\"metrics\"\} 3" \
+           "running container with its labels"
+    assert "$output" =~ "podman_container_memory_usage_bytes\{id=\"$cid\"" "stats of running container"
+    assert "$output" =~ "podman_image_size_bytes\{" "image disk usage"
+    assert "$output" =~ "podman_api_request_duration_seconds_count\{method=\"GET\",path=\"[^\"]*/libpod/containers/json\",code=\"200\"\} [1-9]" \
+           "request duration of podman ps"
+
+    run_podman rm -f -t0 $cname
+    systemctl stop $SERVICE_NAME
+
+    # Without --metrics there is no metrics endpoint
+    systemd-run --unit=$SERVICE_NAME $PODMAN system service $URL --time=0
+    wait_for_port 127.0.0.1 $port
+    run curl -s -o /dev/null -w "%{http_code}" http://127.0.0.1:$port/metrics
+    assert "$output" = "404" "metrics are opt-in"
+    systemctl stop $SERVICE_NAME
+}
@@ -1409,6 +1409,73 @@ EOF
     run_podman rmi --ignore $(pause_image)
 }
 
+@test "quadlet - build" {
+    local quadlet_tmpdir=$PODMAN_TMPDIR/quadlets
+    local image_for_test=localhost/quadlet_build_test:$(random_string)
+    local site=site_$(random_string)
+
+    # Use the same directory for all quadlet files to make sure later steps access previous ones
+    mkdir $quadlet_tmpdir
+
+    cat > $quadlet_tmpdir/Containerfile <<EOF
+FROM $IMAGE
+ARG SITE
+RUN echo \$SITE > /site
+EOF
+
+    local quadlet_build_unit=build_test_$(random_string).build
+    local quadlet_build_file=$PODMAN_TMPDIR/$quadlet_build_unit
+    cat > $quadlet_build_file <<EOF
+[Build]
+ImageTag=$image_for_test
+File=Containerfile
+SetWorkingDirectory=unit
+BuildArg=SITE=$site
+Label=org.test.Key=$site
+Pull=never
+EOF
+
+    local quadlet_container_unit=build_test_$(random_string).container
+    local quadlet_container_file=$PODMAN_TMPDIR/$quadlet_container_unit
+    cat > $quadlet_container_file <<EOF
+[Container]
+Image=$quadlet_build_unit
+Exec=sh -c "echo STARTED CONTAINER; echo "READY=1" | socat -u STDIN unix-sendto:\$NOTIFY_SOCKET; sleep inf"
+EOF
+
+    # Have quadlet create the systemd unit file for the build unit
+    run_quadlet "$quadlet_build_file" "$quadlet_tmpdir"
+    # Save the build service name since the variable will be overwritten
+    local build_service=$QUADLET_SERVICE_NAME
+
+    # Have quadlet create the systemd unit file for the container unit
+    run_quadlet "$quadlet_container_file" "$quadlet_tmpdir"
+    local container_service=$QUADLET_SERVICE_NAME
+
+    run grep -E "^(Requires|After)=.*$build_service" $UNIT_DIR/$container_service
+    assert "$status" -eq 0 "container service depends on the build service"
+
+    # Image should not exist
+    run_podman 1 image exists ${image_for_test}
+
+    service_setup $container_service
+
+    # Build system unit should be active
+    run systemctl show --property=ActiveState "$build_service"
+    assert "$output" = "ActiveState=active" \
+           "quadlet - build: build service should be active via dependency but is not"
+
+    # Image should exist and be built with the arguments of the unit
+    run_podman image inspect --format '{{index .Labels "org.test.Key"}}' $image_for_test
+    assert "$output" = "$site" "quadlet - build: image label"
+
+    run_podman exec $QUADLET_CONTAINER_NAME cat /site
+    assert "$output" = "$site" "quadlet - build: build argument"
+
+    service_cleanup $container_service failed
+    run_podman image rm --ignore $image_for_test
+}
+
 @test "quadlet - pod simple" {
     local quadlet_tmpdir=$PODMAN_TMPDIR/quadlets
 
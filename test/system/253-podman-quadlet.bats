@@ -0,0 +1,124 @@
+#!/usr/bin/env bats   -*- bats -*-
+#
+# Tests for podman quadlet
+#
+
+load helpers
+
+function setup() {
+    skip_if_remote "podman quadlet is not supported over remote"
+
+    basic_setup
+
+    # Keep the quadlet files of the tests away from the real unit
+    # directories, and from systemd.
+    export QUADLET_UNIT_DIRS=$PODMAN_TMPDIR/quadlets
+    mkdir -p $QUADLET_UNIT_DIRS
+}
+
+function teardown() {
+    unset QUADLET_UNIT_DIRS
+
+    basic_teardown
+}
+
+@test "podman quadlet install, list, print, rm" {
+    local srcdir=$PODMAN_TMPDIR/src
+    mkdir -p $srcdir/app
+    cat > $srcdir/web.container <<EOF
+[Container]
+Image=$IMAGE
+EOF
+    cat > $srcdir/app/app.kube <<EOF
+[Kube]
+Yaml=app.yaml
+EOF
+    echo "kind: Pod" > $srcdir/app/app.yaml
+
+    run_podman quadlet install --reload-systemd=false $srcdir/web.container $srcdir/app
+    assert "${lines[0]}" = "$QUADLET_UNIT_DIRS/web.container" "installed file"
+    assert "${lines[1]}" = "$QUADLET_UNIT_DIRS/app" "installed directory"
+    assert "$(< $QUADLET_UNIT_DIRS/app/app.yaml)" = "kind: Pod" "non-quadlet files of a directory are installed"
+
+    run_podman 125 quadlet install --reload-systemd=false $srcdir/web.container
+    assert "$output" =~ "already installed, use --replace" "installing again"
+    echo "Label=foo=bar" >> $srcdir/web.container
+    run_podman quadlet install --reload-systemd=false --replace $srcdir/web.container
+
+    run_podman 125 quadlet install --reload-systemd=false $srcdir/app/app.yaml
+    assert "$output" =~ "is not a quadlet file" "installing a file which is not a quadlet"
+
+    run_podman quadlet list --format "{{.Name}}:{{.UnitName}}:{{.Path}}"
+    assert "$output" =~ "web.container:web.service:$QUADLET_UNIT_DIRS/web.container" "list container"
+    assert "$output" =~ "app.kube:app.service:$QUADLET_UNIT_DIRS/app/app.kube" "list kube in sub directory"
+
+    run_podman quadlet list --noheading
+    assert "${#lines[@]}" = 2 "list without headers"
+
+    run_podman quadlet print web.container
+    assert "$output" = "$(< $srcdir/web.container)" "print"
+
+    run_podman 125 quadlet print nonexistent.container
+    assert "$output" =~ "quadlet nonexistent.container is not installed" "print nonexistent"
+
+    run_podman quadlet rm --reload-systemd=false web.container app.kube
+    assert "$output" =~ "web.container" "rm"
+    test ! -e $QUADLET_UNIT_DIRS/web.container || die "web.container still exists"
+    test ! -e $QUADLET_UNIT_DIRS/app/app.kube || die "app.kube still exists"
+
+    run_podman 125 quadlet rm --reload-systemd=false web.container
+    assert "$output" =~ "quadlet web.container is not installed" "rm nonexistent"
+    run_podman quadlet rm --reload-systemd=false --ignore web.container
+
+    run_podman quadlet list --noheading
+    assert "$output" = "" "no quadlets left"
+}
+
+@test "podman quadlet validate" {
+    cat > $QUADLET_UNIT_DIRS/good.container <<EOF
+[Container]
+Image=$IMAGE
+Network=good.network
+EOF
+    cat > $QUADLET_UNIT_DIRS/good.network <<EOF
+[Network]
+EOF
+    cat > $QUADLET_UNIT_DIRS/bad.container <<EOF
+[Container]
+Image=$IMAGE
+NoSuchKey=1
+EOF
+    cat > $QUADLET_UNIT_DIRS/ref.container <<EOF
+[Container]
+Image=$IMAGE
+Network=missing.network
+Volume=missing.volume:/data
+Pod=missing.pod
+EOF
+
+    run_podman quadlet validate good.container good.network
+    assert "$output" = "good.container: OK
+good.network: OK" "valid quadlets"
+
+    run_podman 125 quadlet validate
+    assert "$output" =~ "bad.container: unsupported key 'NoSuchKey' in group 'Container'" "unknown key"
+    assert "$output" =~ "ref.container: referenced quadlet missing.network does not exist" "missing network"
+    assert "$output" =~ "ref.container: referenced quadlet missing.volume does not exist" "missing volume"
+    assert "$output" =~ "ref.container: quadlet pod unit missing.pod does not exist" "missing pod"
+    assert "$output" =~ "2 of 4 Quadlet files are invalid" "summary"
+
+    # A quadlet file given by path resolves the references of installed ones
+    local srcdir=$PODMAN_TMPDIR/src
+    mkdir -p $srcdir
+    cat > $srcdir/missing.network <<EOF
+[Network]
+EOF
+    run_podman 125 quadlet validate $srcdir/missing.network ref.container
+    assert "$output" =~ "missing.network: OK" "quadlet given by path"
+    assert "$output" !~ "missing.network does not exist" "reference resolved by quadlet given by path"
+
+    run_podman 125 quadlet validate nonexistent.container
+    assert "$output" =~ "quadlet nonexistent.container is not installed" "validate nonexistent"
+}
+
+# vim: filetype=sh
@@ -688,4 +688,75 @@ EOF
     run_podman rmi $image_on_local_registry
 }
 
+@test "podman auto-update - canary rollout" {
+    local image=quay.io/libpod/localtest:latest
+
+    generate_service localtest local
+    local cname1=$cname
+    generate_service localtest local "" "" noTag
+    local cname2=$cname
+    _wait_service_ready container-$cname1.service
+    _wait_service_ready container-$cname2.service
+
+    # A new image whose healthcheck always fails: the canary must be rolled
+    # back and the rollout halted before updating the other unit.
+    dockerfile=$PODMAN_TMPDIR/Dockerfile
+    cat >$dockerfile <<EOF
+FROM $IMAGE
+HEALTHCHECK --interval=1s --retries=1 CMD false
+RUN touch /unhealthy
+EOF
+    run_podman build --format docker -t $image -f $dockerfile
+
+    run_podman 125 auto-update --rollout=canary --soak-time=15s --format "{{.Unit}},{{.Updated}}"
+    assert "$output" =~ "container-c_local_.*.service,rolled back" "canary has been rolled back"
+    assert "$output" =~ "container-c_local_.*.service,halted" "rollout has been halted"
+    assert "$output" =~ "is unhealthy after the update" "canary failed its healthcheck"
+
+    for cname in $cname1 $cname2; do
+        _wait_service_ready container-$cname.service
+        run_podman inspect --format "{{.Image}}" $cname
+        is "$output" "$ori_image" "$cname still uses the old image"
+    done
+
+    # A healthy image gets rolled out to both units.
+    cat >$dockerfile <<EOF
+FROM $IMAGE
+HEALTHCHECK --interval=1s CMD true
+RUN touch /healthy
+EOF
+    run_podman build --format docker -t $image -f $dockerfile
+
+    run_podman auto-update --rollout=canary --soak-time=15s --format "{{.Unit}},{{.Updated}}"
+    is "$output" ".*container-$cname1.service,true.*" "$cname1 has been updated"
+    is "$output" ".*container-$cname2.service,true.*" "$cname2 has been updated"
+    _confirm_update $cname1 $ori_image
+    _confirm_update $cname2 $ori_image
+
+    run_podman 125 auto-update --rollout=bogus
+    is "$output" "Error: invalid rollout mode \"bogus\".*"
+}
+
+@test "podman auto-update - maintenance window" {
+    # A window which surely does not include the current time.
+    local window="$(date -d '+2 hours' +%H):00-$(date -d '+3 hours' +%H):00"
+    generate_service localtest local "" "--label io.containers.autoupdate.window=$window"
+    _wait_service_ready container-$cname.service
+
+    run_podman commit --change CMD=/bin/bash $cname quay.io/libpod/localtest:latest
+
+    run_podman auto-update --format "{{.Unit}},{{.Updated}}"
+    is "$output" "container-$cname.service,skipped" "unit outside of its maintenance window is skipped"
+    run_podman inspect --format "{{.Image}}" $cname
+    is "$output" "$ori_image" "Image ID should not change"
+}
+
+@test "podman auto-update - semver policy requires a constraint" {
+    run_podman 125 create --label io.containers.autoupdate=semver $IMAGE
+    is "$output" "Error: the semver auto-update policy requires the io.containers.autoupdate.semver label"
+
+    run_podman 125 create --label io.containers.autoupdate=semver --label io.containers.autoupdate.semver=bogus $IMAGE
+    is "$output" "Error: invalid semver constraint \"bogus\".*"
+}
+
 # vim: filetype=sh
@@ -47,4 +47,63 @@ SOCKET_FILE="$UNIT_DIR/$SERVICE_NAME.socket"
     is "$output" ".*CORS Headers were set to ..\*...*" "debug log confirms CORS headers set"
 }
 
+@test "podman system service - tcp with mutual TLS" {
+    skip_if_remote "system service tests are meaningless over remote"
+    if ! type -p openssl &>/dev/null; then
+        skip "no openssl"
+    fi
+
+    certdir=${PODMAN_TMPDIR}/certs
+    mkdir -p $certdir
+    openssl req -x509 -newkey rsa:2048 -nodes -days 1 -subj "/CN=ca" \
+            -keyout $certdir/ca-key.pem -out $certdir/ca.pem &>/dev/null
+    for who in server client; do
+        openssl req -newkey rsa:2048 -nodes -subj "/CN=$SERVICE_TCP_HOST" \
+                -keyout $certdir/$who-key.pem -out $certdir/$who.csr &>/dev/null
+        openssl x509 -req -days 1 -in $certdir/$who.csr \
+                -CA $certdir/ca.pem -CAkey $certdir/ca-key.pem -CAcreateserial \
+                -extfile <(echo "subjectAltName=DNS:$SERVICE_TCP_HOST") \
+                -out $certdir/$who.pem &>/dev/null
+    done
+
+    PORT=$(random_free_port 63000-64999)
+    log=${PODMAN_TMPDIR}/system-service.log
+    $PODMAN system service --tls-cert=$certdir/server.pem --tls-key=$certdir/server-key.pem \
+            --tls-client-ca=$certdir/ca.pem tcp:$SERVICE_TCP_HOST:$PORT -t 20 2> $log &
+    podman_pid="$!"
+    sleep 5s
+
+    # No client certificate: handshake must be rejected
+    run curl -s --max-time 10 --cacert $certdir/ca.pem https://$SERVICE_TCP_HOST:$PORT/_ping
+    assert "$status" -ne 0 "curl without client certificate must fail"
+
+    run curl -s --max-time 10 --cacert $certdir/ca.pem \
+        --cert $certdir/client.pem --key $certdir/client-key.pem \
+        https://$SERVICE_TCP_HOST:$PORT/_ping
+    is "$output" "OK" "ping with client certificate"
+
+    run_podman --remote --url "tcp+tls://$SERVICE_TCP_HOST:$PORT?tls-ca=$certdir/ca.pem&tls-cert=$certdir/client.pem&tls-key=$certdir/client-key.pem" \
+               version --format '{{.Server.Version}}'
+    assert "$output" != "" "podman --url tcp+tls:// talks to the service"
+
+    kill $podman_pid
+    wait $podman_pid || true
+
+    # Clients are authenticated, so there is no need to warn
+    assert "$(< $log)" !~ "Using the Podman API service with TCP sockets" \
+           "podman does not warn about mutual TLS server on TCP"
+}
+
+@test "podman system service - TLS option validation" {
+    skip_if_remote "system service tests are meaningless over remote"
+    run_podman 125 system service --tls-cert=/dev/null tcp:localhost:1234
+    is "$output" "Error: --tls-cert and --tls-key must be used together"
+
+    run_podman 125 system service --tls-client-ca=/dev/null tcp:localhost:1234
+    is "$output" "Error: --tls-client-ca requires --tls-cert and --tls-key"
+
+    run_podman 125 system service --tls-cert=/dev/null --tls-key=/dev/null unix://$PODMAN_TMPDIR/tls.sock
+    is "$output" "Error: TLS is only supported for tcp endpoints, not \"unix\""
+}
+
 # vim: filetype=sh
@@ -922,4 +922,62 @@ EOF
     assert "$output" = $hostname "/etc/hostname with --uts=host --net=host must be equal to 'uname -n'"
 }
 
+@test "podman network update" {
+    if ! is_netavark; then
+        skip "updating networks in place is only supported with netavark"
+    fi
+
+    local netname=testnet-$(random_string 10)
+    local mysubnet=$(random_rfc1918_subnet)
+    local routesubnet=$(random_rfc1918_subnet)
+
+    run_podman network create --subnet $mysubnet.0/24 --label a=1 --label b=2 $netname
+    run_podman network inspect --format '{{.ID}}' $netname
+    local netid="$output"
+
+    run_podman run -d --network $netname:ip=$mysubnet.5 --name c-$netname $IMAGE top
+    local cid="$output"
+
+    # Changing labels and options recreates the network
+    run_podman network update --label-add c=3 --label-rm a --opt-add mtu=1400 $netname
+    run_podman network inspect --format '{{.ID}} {{.Labels}} {{.Options.mtu}}' $netname
+    assert "$output" =~ "map\[b:2 c:3\] 1400" "labels and options updated"
+    assert "$output" !~ "^$netid " "network recreated with a new ID"
+    run_podman inspect --format "{{(index .NetworkSettings.Networks \"$netname\").IPAddress}}" $cid
+    is "$output" "$mysubnet.5" "static IP kept after labels and options update"
+
+    # The network cannot be recreated while a container is paused
+    if ! is_rootless || is_cgroupsv2; then
+        run_podman pause $cid
+        run_podman 125 network update --internal $netname
+        is "$output" "Error: container $cid connected to network $netname is paused, .*" "paused container"
+        run_podman unpause $cid
+    fi
+
+    run_podman network update --route-add $routesubnet.0/24,$mysubnet.2 --internal $netname
+
+    run_podman network inspect --format '{{.Labels}} {{.Internal}}' $netname
+    is "$output" "map\[b:2 c:3\] true" "labels and internal after update"
+    run_podman network inspect --format '{{range .Routes}}{{.Destination}} {{.Gateway}}{{end}}' $netname
+    is "$output" "$routesubnet.0/24 $mysubnet.2" "routes after update"
+    run_podman network inspect --format '{{.ID}}' $netname
+    assert "$output" != "$netid" "network is recreated with a new ID"
+
+    # The running container is reconnected and keeps its static IP
+    run_podman inspect --format "{{(index .NetworkSettings.Networks \"$netname\").IPAddress}}" $cid
+    is "$output" "$mysubnet.5" "container reconnected with its static ip"
+    run_podman exec $cid ip addr show eth0
+    assert "$output" =~ "$mysubnet.5" "container has ip after update"
+
+    run_podman network update --route-rm $routesubnet.0/24 --internal=false $netname
+    run_podman network inspect --format '{{len .Routes}} {{.Internal}}' $netname
+    is "$output" "0 false" "routes and internal after second update"
+
+    run_podman 125 network update --route-rm bogus $netname
+    is "$output" "Error: .*bogus.*" "invalid route destination"
+
+    run_podman rm -t 0 -f $cid
+    run_podman network rm $netname
+}
+
 # vim: filetype=sh
@@ -415,4 +415,75 @@ function teardown() {
     run_podman rm -t 0 -f $ctrID
 }
 
+@test "podman pod checkpoint/restore" {
+    local podname=p_$(random_string 10)
+    run_podman pod create --name $podname
+    local podid="$output"
+    run_podman run -d --pod $podname --name ${podname}-top $IMAGE top
+    local cid1="$output"
+    run_podman run -d --pod $podname $IMAGE sh -c 'while :;do cat /proc/uptime; sleep 0.1;done'
+    local cid2="$output"
+
+    run_podman pod checkpoint $podname
+    is "$output" "$podid" "pod checkpoint prints the pod ID"
+
+    for cid in $cid1 $cid2; do
+        run_podman container inspect \
+                   --format '{{.State.Status}}:{{.State.Checkpointed}}' $cid
+        is "$output" "exited:true" "container $cid of the pod is checkpointed"
+    done
+
+    run_podman pod restore $podname
+    is "$output" "$podid" "pod restore prints the pod ID"
+    for cid in $cid1 $cid2; do
+        run_podman container inspect \
+                   --format '{{.State.Status}}:{{.State.Checkpointed}}' $cid
+        is "$output" "running:false" "container $cid of the pod is restored"
+    done
+
+    run_podman 125 pod restore $podname
+    is "$output" "Error: pod $podname has no checkpointed containers to restore: .*" \
+       "restoring a pod without checkpointed containers"
+
+    run_podman pod rm -t 0 -f $podname
+}
+
+@test "podman pod checkpoint - refuses state held by the infra container" {
+    local podname=p_$(random_string 10)
+    run_podman pod create --name $podname --share pid,ipc,net,uts
+    run_podman run -d --pod $podname $IMAGE top
+
+    run_podman 125 pod checkpoint $podname
+    is "$output" "Error: pod $podname shares the PID namespace of its infra container, which cannot be checkpointed: .*" \
+       "checkpointing a pod sharing the PID namespace"
+
+    run_podman pod rm -t 0 -f $podname
+}
+
+@test "podman pod checkpoint --export, restore --import with new name" {
+    local podname=p_$(random_string 10)
+    local host_port=$(random_free_port)
+    run_podman pod create --name $podname -p $host_port:80
+    run_podman run -d --pod $podname --name ${podname}-web $IMAGE \
+               sh -c "echo $podname >/cname; /bin/busybox-extras httpd -p 80 -h /; echo READY; top"
+    local cid="$output"
+    wait_for_ready $cid
+
+    run_podman pod checkpoint --export=$PODMAN_TMPDIR/pod.tar.zst $podname
+    run_podman pod rm -t 0 -f $podname
+
+    local newname=p_$(random_string 10)
+    run_podman pod restore --import=$PODMAN_TMPDIR/pod.tar.zst --name $newname
+    run_podman pod inspect --format '{{.Name}}:{{.NumContainers}}' $newname
+    is "$output" "$newname:2" "pod restored with new name"
+    run_podman container inspect --format '{{.State.Status}}' ${newname}-web
+    is "$output" "running" "container renamed along with the pod"
+
+    # Port mappings of the pod are restored
+    run curl --max-time 3 -s http://127.0.0.1:$host_port/cname
+    is "$output" "$podname" "port mapping of the restored pod"
+
+    run_podman pod rm -t 0 -f $newname
+}
+
 # vim: filetype=sh
@@ -940,6 +940,94 @@ spec:
     run_podman rm -a
 }
 
+@test "podman kube play - readinessProbe" {
+    fname="$PODMAN_TMPDIR/play_kube_readiness_$(random_string 6).yaml"
+    echo "
+apiVersion: v1
+kind: Pod
+metadata:
+  labels:
+  name: readiness-exec
+spec:
+  containers:
+  - name: readiness
+    image: $IMAGE
+    args:
+    - /bin/sh
+    - -c
+    - sleep 100
+    readinessProbe:
+      exec:
+        command:
+        - cat
+        - /tmp/ready
+      periodSeconds: 1
+" > $fname
+
+    run_podman kube play $fname
+    ctrName="readiness-exec-readiness"
+
+    # The readiness probe is not a healthcheck
+    run_podman inspect $ctrName --format "{{.Config.Healthcheck}}"
+    is "$output" "<nil>" "readinessProbe does not define a healthcheck"
+
+    run_podman 1 healthcheck run --readiness $ctrName
+    is "$output" "unready" "readiness check fails without /tmp/ready"
+    run_podman ps --filter name=$ctrName --format "{{.Ready}}"
+    is "$output" "false" "podman ps shows container as not ready"
+
+    run_podman exec $ctrName touch /tmp/ready
+    run_podman healthcheck run --readiness $ctrName
+    run_podman inspect $ctrName --format "{{.State.Ready}}"
+    is "$output" "true" "podman inspect shows container as ready"
+    run_podman ps --filter name=$ctrName --format "{{.Ready}}"
+    is "$output" "true" "podman ps shows container as ready"
+
+    run_podman events --filter container=$ctrName --filter event=readiness_status \
+               --stream=false --format "{{.ReadinessStatus}}"
+    assert "$output" =~ "unready.*ready" "readiness_status events"
+
+    run_podman kube down $fname
+}
+
+@test "podman kube play --readiness-gate waits for readiness" {
+    fname="$PODMAN_TMPDIR/play_kube_readiness_gate_$(random_string 6).yaml"
+    echo "
+apiVersion: v1
+kind: Pod
+metadata:
+  labels:
+  name: readiness-gate
+spec:
+  containers:
+  - name: readiness
+    image: $IMAGE
+    args:
+    - /bin/sh
+    - -c
+    - sleep 6 && touch /tmp/ready && sleep 100
+    readinessProbe:
+      exec:
+        command:
+        - cat
+        - /tmp/ready
+      periodSeconds: 1
+      timeoutSeconds: 1
+      failureThreshold: 1
+" > $fname
+
+    # The wait is not bounded by the probe settings, which fail the probe
+    # long before the container becomes ready.
+    t0=$SECONDS
+    run_podman kube play --readiness-gate $fname
+    assert $((SECONDS - t0)) -ge 6 "kube play returns once the container is ready"
+
+    run_podman inspect readiness-gate-readiness --format "{{.State.Ready}}"
+    is "$output" "true" "container is ready once kube play returned"
+
+    run_podman kube down $fname
+}
+
 @test "podman play --build private registry" {
     skip_if_remote "--build is not supported in context remote"
 
@@ -982,3 +1070,163 @@ _EOF
     run_podman pod rm -t 0 -f test_pod
     run_podman rmi -f userimage:latest $from_image
 }
+
+@test "podman kube play - job" {
+    fname=$PODMAN_TMPDIR/job.yaml
+    marker=$PODMAN_TMPDIR/marker
+    echo "
+apiVersion: batch/v1
+kind: Job
+metadata:
+  name: testjob
+spec:
+  completions: 2
+  parallelism: 1
+  backoffLimit: 1
+  template:
+    spec:
+      restartPolicy: Never
+      containers:
+      - name: worker
+        image: $IMAGE
+        command:
+        - sh
+        - -c
+        - |
+          test -e /marker/ran && exit 0
+          touch /marker/ran
+          exit 1
+        volumeMounts:
+        - name: marker
+          mountPath: /marker
+      volumes:
+      - name: marker
+        hostPath:
+          path: $marker
+          type: DirectoryOrCreate
+" > $fname
+
+    # Without --wait, the first pods must complete the job
+    run_podman 125 kube play $fname
+    assert "$output" =~ "job testjob: 2 completions with a parallelism of 1 require --wait"
+
+    # The first attempt fails, the replacement and the second completion succeed
+    run_podman kube play --wait $fname
+    assert "$output" =~ "Job:
+testjob
+Succeeded: 2
+Failed: 1" "job report"
+    assert "$output" =~ "Cleaning up containers, pods, and volumes" "--wait removes the job pods"
+    run_podman pod ps --filter label=batch.kubernetes.io/job-name=testjob --format '{{.Name}}'
+    is "$output" "" "no pods of the job are left"
+
+    # A detached job is started and left running
+    rm -f $marker/ran
+    sed -i -e 's/completions: 2/completions: 1/' $fname
+    run_podman kube play $fname
+    assert "$output" =~ "Job:
+testjob" "job report"
+    run_podman pod ps --filter label=batch.kubernetes.io/job-name=testjob --format '{{.Name}}'
+    is "$output" "testjob-pod-0" "one pod for the job"
+    run_podman kube down $fname
+    assert "$output" =~ "Pods removed:" "kube down removes the job pods"
+
+    # Indexed jobs are rejected
+    sed -i -e 's/parallelism: 1/parallelism: 1\n  completionMode: Indexed/' $fname
+    run_podman 125 kube play $fname
+    assert "$output" =~ "job testjob: completionMode \"Indexed\" is not supported"
+    sed -i -e '/completionMode: Indexed/d' $fname
+
+    # A job which never succeeds exceeds its backoff limit
+    sed -i -e 's/backoffLimit: 1/backoffLimit: 0/' -e 's/exit 0/exit 2/' $fname
+    sed -i -e 's/completions: 1/completions: 2/' $fname
+    run_podman 125 kube play --wait $fname
+    assert "$output" =~ "job testjob failed: BackoffLimitExceeded"
+    run_podman kube down $fname
+    run_podman rmi $(pause_image)
+}
+
+@test "podman kube play - downwardAPI and projected volumes" {
+    configmap_file=$PODMAN_TMPDIR/configmap.yaml
+    echo "
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: settings
+data:
+  mode: production
+" > $configmap_file
+
+    fname=$PODMAN_TMPDIR/downward.yaml
+    echo "
+apiVersion: v1
+kind: Pod
+metadata:
+  labels:
+    app: downward
+  annotations:
+    build: \"42\"
+  name: downward
+spec:
+  restartPolicy: Never
+  containers:
+  - name: ctr
+    image: $IMAGE
+    command:
+    - sleep
+    - infinity
+    resources:
+      limits:
+        memory: 64Mi
+    volumeMounts:
+    - name: podinfo
+      mountPath: /podinfo
+    - name: all-in-one
+      mountPath: /projected
+  volumes:
+  - name: podinfo
+    downwardAPI:
+      items:
+      - path: name
+        fieldRef:
+          fieldPath: metadata.name
+      - path: labels
+        fieldRef:
+          fieldPath: metadata.labels
+      - path: mem
+        resourceFieldRef:
+          containerName: ctr
+          resource: limits.memory
+          divisor: 1Mi
+  - name: all-in-one
+    projected:
+      sources:
+      - configMap:
+          name: settings
+          items:
+          - key: mode
+            path: config/mode
+      - downwardAPI:
+          items:
+          - path: build
+            fieldRef:
+              fieldPath: metadata.annotations['build']
+" > $fname
+
+    run_podman kube play --configmap=$configmap_file $fname
+    run_podman exec downward-ctr sh -c 'for f in name labels mem; do cat /podinfo/$f; echo; done'
+    is "$output" "downward
+app=\"downward\"
+64" "downwardAPI volume"
+    run_podman exec downward-ctr sh -c 'cat /projected/config/mode; echo; cat /projected/build'
+    is "$output" "production
+42" "projected volume"
+
+    run_podman volume exists downward-podinfo
+    run_podman volume exists downward-all-in-one
+
+    run_podman kube down --force $fname
+    run_podman 1 volume exists downward-podinfo
+    run_podman 1 volume exists downward-all-in-one
+    run_podman rmi $(pause_image)
+}
@@ -94,6 +94,8 @@ quadlet_to_service_name() {
         suffix="-network"
     elif [ "$extension" == "image" ]; then
         suffix="-image"
+    elif [ "$extension" == "build" ]; then
+        suffix="-build"
     elif [ "$extension" == "pod" ]; then
         suffix="-pod"
     fi